@@ -0,0 +1,411 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Identifies the aggregate function names accepted by KEEP expressions
+var aggregateFunctionRegexp = regexp.MustCompile(`^[A-Za-z_]+$`)
+
+// ListAggExpr is the LISTAGG aggregate, which concatenates the values of a
+// column within each group.
+//
+//	db.Model(&User{}).
+//		Select("?, ?", clause.Column{Name: "age"}, oracle.ListAgg("name", ", ").WithinGroup("name").As("names")).
+//		Group("age").Scan(&results)
+//	// SELECT "age", LISTAGG("name", ', ') WITHIN GROUP (ORDER BY "name") AS "names" FROM "users" GROUP BY "age"
+type ListAggExpr struct {
+	Column             interface{}
+	Separator          string
+	Distinct           bool
+	OrderBy            []interface{}
+	OnOverflowTruncate bool
+	TruncateIndicator  string
+	WithCount          bool
+	Alias              string
+}
+
+// ListAgg returns a LISTAGG aggregate of the given column, which is either a
+// column name or an expression
+func ListAgg(column interface{}, separator string) ListAggExpr {
+	return ListAggExpr{Column: column, Separator: separator}
+}
+
+// WithDistinct removes duplicate values from the list
+func (l ListAggExpr) WithDistinct() ListAggExpr {
+	l.Distinct = true
+	return l
+}
+
+// WithinGroup orders the values of the list. Each column is either a column
+// name, sorted in ascending order, or a clause.OrderByColumn.
+func (l ListAggExpr) WithinGroup(columns ...interface{}) ListAggExpr {
+	l.OrderBy = columns
+	return l
+}
+
+// Truncate truncates the list instead of raising ORA-01489 when it exceeds
+// the maximum length of VARCHAR2. The indicator is appended to the truncated
+// list, followed by the number of truncated values when withCount is true.
+func (l ListAggExpr) Truncate(indicator string, withCount bool) ListAggExpr {
+	l.OnOverflowTruncate = true
+	l.TruncateIndicator = indicator
+	l.WithCount = withCount
+	return l
+}
+
+// As sets the column alias of the aggregate
+func (l ListAggExpr) As(alias string) ListAggExpr {
+	l.Alias = alias
+	return l
+}
+
+// Build writes the LISTAGG expression
+func (l ListAggExpr) Build(builder clause.Builder) {
+	builder.WriteString("LISTAGG(")
+	if l.Distinct {
+		builder.WriteString("DISTINCT ")
+	}
+	writeOperand(builder, l.Column)
+	builder.WriteString(", ")
+	writeStringLiteral(builder, l.Separator)
+
+	if l.OnOverflowTruncate {
+		builder.WriteString(" ON OVERFLOW TRUNCATE")
+		if l.TruncateIndicator != "" {
+			builder.WriteByte(' ')
+			writeStringLiteral(builder, l.TruncateIndicator)
+		}
+		if l.WithCount {
+			builder.WriteString(" WITH COUNT")
+		} else {
+			builder.WriteString(" WITHOUT COUNT")
+		}
+	}
+	builder.WriteByte(')')
+
+	if len(l.OrderBy) > 0 {
+		builder.WriteString(" WITHIN GROUP (ORDER BY ")
+		writeOrderByColumns(builder, l.OrderBy)
+		builder.WriteByte(')')
+	}
+
+	writeAlias(builder, l.Alias)
+}
+
+// KeepExpr is an aggregate restricted to the rows that rank first or last
+// in the given order, using the KEEP (DENSE_RANK FIRST|LAST ...) syntax.
+//
+//	oracle.KeepFirst("MAX", "salary", "hired_at")
+//	// MAX("salary") KEEP (DENSE_RANK FIRST ORDER BY "hired_at")
+type KeepExpr struct {
+	Function string
+	Column   interface{}
+	Last     bool
+	OrderBy  []interface{}
+	Alias    string
+}
+
+// KeepFirst returns the aggregate of the rows that rank first in the given order
+func KeepFirst(function string, column interface{}, orderBy ...interface{}) KeepExpr {
+	return KeepExpr{Function: function, Column: column, OrderBy: orderBy}
+}
+
+// KeepLast returns the aggregate of the rows that rank last in the given order
+func KeepLast(function string, column interface{}, orderBy ...interface{}) KeepExpr {
+	return KeepExpr{Function: function, Column: column, Last: true, OrderBy: orderBy}
+}
+
+// As sets the column alias of the aggregate
+func (k KeepExpr) As(alias string) KeepExpr {
+	k.Alias = alias
+	return k
+}
+
+// Build writes the KEEP expression
+func (k KeepExpr) Build(builder clause.Builder) {
+	if !aggregateFunctionRegexp.MatchString(k.Function) {
+		builder.AddError(fmt.Errorf("invalid aggregate function %q", k.Function))
+		return
+	}
+	if len(k.OrderBy) == 0 {
+		builder.AddError(fmt.Errorf("KEEP requires at least one ORDER BY column"))
+		return
+	}
+
+	builder.WriteString(strings.ToUpper(k.Function))
+	builder.WriteByte('(')
+	writeOperand(builder, k.Column)
+	builder.WriteString(") KEEP (DENSE_RANK ")
+	if k.Last {
+		builder.WriteString("LAST")
+	} else {
+		builder.WriteString("FIRST")
+	}
+	builder.WriteString(" ORDER BY ")
+	writeOrderByColumns(builder, k.OrderBy)
+	builder.WriteByte(')')
+
+	writeAlias(builder, k.Alias)
+}
+
+// ApproxCountDistinctExpr is the APPROX_COUNT_DISTINCT aggregate, a faster
+// approximation of COUNT(DISTINCT ...) for large data sets
+type ApproxCountDistinctExpr struct {
+	Column interface{}
+	Alias  string
+}
+
+// ApproxCountDistinct returns the approximate number of distinct values of the column
+func ApproxCountDistinct(column interface{}) ApproxCountDistinctExpr {
+	return ApproxCountDistinctExpr{Column: column}
+}
+
+// As sets the column alias of the aggregate
+func (a ApproxCountDistinctExpr) As(alias string) ApproxCountDistinctExpr {
+	a.Alias = alias
+	return a
+}
+
+// Build writes the APPROX_COUNT_DISTINCT expression
+func (a ApproxCountDistinctExpr) Build(builder clause.Builder) {
+	builder.WriteString("APPROX_COUNT_DISTINCT(")
+	writeOperand(builder, a.Column)
+	builder.WriteByte(')')
+
+	writeAlias(builder, a.Alias)
+}
+
+// ClauseGroupingSets is the name of the clause holding the ROLLUP, CUBE and
+// GROUPING SETS extensions of the GROUP BY clause
+const ClauseGroupingSets = "GROUPING SETS"
+
+// GroupingSetsExpr is a ROLLUP, CUBE or GROUPING SETS extension of the GROUP
+// BY clause. Pass it to Clauses, it is written after the columns given to
+// Group and before any HAVING condition. The column names are quoted by the
+// statement, so they follow Config.SkipQuoteIdentifiers.
+type GroupingSetsExpr struct {
+	Function string
+	Columns  []string
+	Sets     [][]string
+}
+
+// Rollup returns a ROLLUP grouping of the columns. The result also contains
+// subtotal rows for each prefix of the columns and a grand total row, unless
+// Group adds columns to every grouping.
+//
+//	db.Model(&Sale{}).Select("region, product, SUM(amount) AS total").
+//		Clauses(oracle.Rollup("region", "product")).Scan(&results)
+//	// ... GROUP BY ROLLUP("region","product")
+//
+//	db.Model(&Sale{}).Select("region, product, SUM(amount) AS total").
+//		Group("region").Clauses(oracle.Rollup("product")).Scan(&results)
+//	// ... GROUP BY "region",ROLLUP("product")
+func Rollup(columns ...string) GroupingSetsExpr {
+	return GroupingSetsExpr{Function: "ROLLUP", Columns: columns}
+}
+
+// Cube returns a CUBE grouping of the columns. The result contains subtotal
+// rows for every combination of the columns.
+func Cube(columns ...string) GroupingSetsExpr {
+	return GroupingSetsExpr{Function: "CUBE", Columns: columns}
+}
+
+// GroupingSets returns a GROUPING SETS grouping, where each set is a list of
+// columns. An empty set groups all rows.
+//
+//	oracle.GroupingSets([]string{"region", "product"}, []string{"region"}, nil)
+//	// GROUPING SETS(("region","product"),("region"),())
+func GroupingSets(sets ...[]string) GroupingSetsExpr {
+	return GroupingSetsExpr{Function: "GROUPING SETS", Sets: sets}
+}
+
+// Name returns the name of the clause
+func (g GroupingSetsExpr) Name() string {
+	return ClauseGroupingSets
+}
+
+// Build writes the grouping extension
+func (g GroupingSetsExpr) Build(builder clause.Builder) {
+	builder.WriteString(g.Function)
+	builder.WriteByte('(')
+	if g.Sets == nil {
+		writeGroupingColumns(builder, g.Columns)
+	} else {
+		for idx, set := range g.Sets {
+			if idx > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteByte('(')
+			writeGroupingColumns(builder, set)
+			builder.WriteByte(')')
+		}
+	}
+	builder.WriteByte(')')
+}
+
+// MergeClause adds the grouping to those of a previous grouping clause
+func (g GroupingSetsExpr) MergeClause(c *clause.Clause) {
+	groupings, _ := c.Expression.(groupingSets)
+	c.Expression = append(groupings, g)
+}
+
+// ModifyStatement adds the grouping clause, and an empty GROUP BY clause
+// when Group was not called, since only the GROUP BY clause is built
+func (g GroupingSetsExpr) ModifyStatement(stmt *gorm.Statement) {
+	c := stmt.Clauses[ClauseGroupingSets]
+	c.Name = ClauseGroupingSets
+	g.MergeClause(&c)
+	stmt.Clauses[ClauseGroupingSets] = c
+
+	if _, ok := stmt.Clauses[ClauseGroupBy]; !ok {
+		stmt.Clauses[ClauseGroupBy] = clause.Clause{Expression: clause.GroupBy{}}
+	}
+}
+
+// groupingSets are the groupings added to a statement, written separated
+// by commas
+type groupingSets []GroupingSetsExpr
+
+func (g groupingSets) Build(builder clause.Builder) {
+	for idx, grouping := range g {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		grouping.Build(builder)
+	}
+}
+
+// writeGroupingColumns writes the column names quoted by the statement,
+// resolving field names and table qualifiers like Group does
+func writeGroupingColumns(builder clause.Builder, columns []string) {
+	for idx, name := range columns {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		column := clause.Column{Name: name}
+		if stmt, ok := builder.(*gorm.Statement); ok {
+			column = resolveColumn(stmt, column)
+		}
+		builder.WriteQuoted(column)
+	}
+}
+
+// GroupingExpr is the GROUPING function, which returns 1 in the subtotal rows
+// produced by Rollup, Cube and GroupingSets where the column is aggregated,
+// and 0 otherwise.
+type GroupingExpr struct {
+	Column interface{}
+	Alias  string
+}
+
+// Grouping returns the GROUPING function of the column
+func Grouping(column interface{}) GroupingExpr {
+	return GroupingExpr{Column: column}
+}
+
+// As sets the column alias of the expression
+func (g GroupingExpr) As(alias string) GroupingExpr {
+	g.Alias = alias
+	return g
+}
+
+// Build writes the GROUPING expression
+func (g GroupingExpr) Build(builder clause.Builder) {
+	builder.WriteString("GROUPING(")
+	writeOperand(builder, g.Column)
+	builder.WriteByte(')')
+
+	writeAlias(builder, g.Alias)
+}
+
+// writeOperand writes a column name as a quoted column, or builds the given
+// expression. Any other value is added as a bind variable.
+func writeOperand(builder clause.Builder, operand interface{}) {
+	switch v := operand.(type) {
+	case string:
+		builder.WriteQuoted(clause.Column{Name: v})
+	case clause.Column:
+		builder.WriteQuoted(v)
+	case clause.Expression:
+		v.Build(builder)
+	default:
+		builder.AddVar(builder, v)
+	}
+}
+
+// writeOrderByColumns writes the columns of an ORDER BY list. Each column is
+// either a column name, sorted in ascending order, or a clause.OrderByColumn.
+func writeOrderByColumns(builder clause.Builder, columns []interface{}) {
+	for idx, column := range columns {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		if orderBy, ok := column.(clause.OrderByColumn); ok {
+			builder.WriteQuoted(orderBy.Column)
+			if orderBy.Desc {
+				builder.WriteString(" DESC")
+			}
+		} else {
+			writeOperand(builder, column)
+		}
+	}
+}
+
+// writeStringLiteral writes the value as an escaped SQL string literal.
+// Used where Oracle requires constants rather than bind variables.
+func writeStringLiteral(builder clause.Builder, value string) {
+	builder.WriteByte('\'')
+	builder.WriteString(strings.ReplaceAll(value, "'", "''"))
+	builder.WriteByte('\'')
+}
+
+func writeAlias(builder clause.Builder, alias string) {
+	if alias != "" {
+		builder.WriteString(" AS ")
+		builder.WriteQuoted(clause.Column{Name: alias})
+	}
+}
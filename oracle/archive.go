@@ -0,0 +1,176 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// archiveStateColumn is the hidden column that ROW ARCHIVAL adds to a table
+const archiveStateColumn = "ORA_ARCHIVE_STATE"
+
+// ArchiveState maps the ORA_ARCHIVE_STATE column of tables with in-database
+// archiving. A model with an ArchiveState field opts into ROW ARCHIVAL: the
+// migrator creates its table with the clause rather than creating the
+// column, and Delete archives rows instead of deleting them. Archived rows
+// are hidden from the session until WithArchivedRows makes them visible.
+//
+//	type Order struct {
+//		ID    uint
+//		State oracle.ArchiveState `gorm:"column:ORA_ARCHIVE_STATE"`
+//	}
+//
+// The field must map to the ORA_ARCHIVE_STATE column. As the column is
+// hidden, it is only scanned when it is selected explicitly.
+type ArchiveState string
+
+const (
+	// ArchiveStateActive is the state of rows that are not archived
+	ArchiveStateActive ArchiveState = "0"
+	// ArchiveStateArchived is the state Delete sets to archive rows
+	ArchiveStateArchived ArchiveState = "1"
+)
+
+// Archived reports whether the state is that of an archived row, which is
+// any state other than "0"
+func (s ArchiveState) Archived() bool {
+	return s != "" && s != ArchiveStateActive
+}
+
+// Scan implements the sql.Scanner interface
+func (s *ArchiveState) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = ""
+	case string:
+		*s = ArchiveState(v)
+	case []byte:
+		*s = ArchiveState(v)
+	default:
+		return fmt.Errorf("unsupported archive state %T", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The zero value inserts
+// rows as active.
+func (s ArchiveState) Value() (driver.Value, error) {
+	if s == "" {
+		return string(ArchiveStateActive), nil
+	}
+	return string(s), nil
+}
+
+// DeleteClauses makes Delete archive rows, unless it is Unscoped
+func (ArchiveState) DeleteClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{archiveDeleteClause{Field: f}}
+}
+
+// archiveDeleteClause turns a DELETE into an UPDATE archiving the rows
+type archiveDeleteClause struct {
+	Field *schema.Field
+}
+
+func (archiveDeleteClause) Name() string {
+	return ""
+}
+
+func (archiveDeleteClause) Build(clause.Builder) {
+}
+
+func (archiveDeleteClause) MergeClause(*clause.Clause) {
+}
+
+func (c archiveDeleteClause) ModifyStatement(stmt *gorm.Statement) {
+	if stmt.SQL.Len() > 0 || stmt.Unscoped {
+		return
+	}
+
+	stmt.AddClause(clause.Set{{Column: clause.Column{Name: c.Field.DBName}, Value: ArchiveStateArchived}})
+	stmt.SetColumn(c.Field.DBName, ArchiveStateArchived, true)
+
+	// The Delete callback has already added the primary key conditions
+	stmt.AddClauseIfNotExists(clause.Update{})
+	stmt.Build(stmt.DB.Callback().Update().Clauses...)
+}
+
+// isArchiveStateField reports whether the field is an ArchiveState
+func isArchiveStateField(field *schema.Field) bool {
+	fieldType := field.FieldType
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType == reflect.TypeOf(ArchiveState(""))
+}
+
+// archiveStateField returns the ArchiveState field of the schema, if any,
+// and fails when it doesn't map to the ORA_ARCHIVE_STATE column
+func archiveStateField(s *schema.Schema) (*schema.Field, error) {
+	for _, field := range s.Fields {
+		if field.DBName != "" && isArchiveStateField(field) {
+			if !strings.EqualFold(field.DBName, archiveStateColumn) {
+				return nil, fmt.Errorf("field %s of %s must map to the %s column", field.Name, s.Name, archiveStateColumn)
+			}
+			return field, nil
+		}
+	}
+	return nil, nil
+}
+
+// WithArchivedRows runs fc on a connection whose session sees archived rows
+// as well as active ones, with ROW ARCHIVAL VISIBILITY = ALL. Visibility is
+// restored to ACTIVE before the connection is returned to the pool.
+func WithArchivedRows(db *gorm.DB, fc func(tx *gorm.DB) error) error {
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("ALTER SESSION SET ROW ARCHIVAL VISIBILITY = ALL").Error; err != nil {
+			return err
+		}
+
+		err := fc(conn)
+		return errors.Join(err, conn.Exec("ALTER SESSION SET ROW ARCHIVAL VISIBILITY = ACTIVE").Error)
+	})
+}
@@ -0,0 +1,238 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RowIDRange is a range of the ROWIDs of a table, both bounds included. It
+// is a condition restricting a query to the rows stored in the range, which
+// Oracle reads with a ROWID range scan instead of a full table scan:
+//
+//	db.Where(r).Find(&orders)
+//	// SELECT * FROM "orders" WHERE "orders".ROWID BETWEEN CHARTOROWID(:1) AND CHARTOROWID(:2)
+type RowIDRange struct {
+	Start string
+	End   string
+}
+
+// Build writes the ROWID range condition
+func (r RowIDRange) Build(builder clause.Builder) {
+	builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+	builder.WriteString(".ROWID BETWEEN CHARTOROWID(")
+	builder.AddVar(builder, r.Start)
+	builder.WriteString(") AND CHARTOROWID(")
+	builder.AddVar(builder, r.End)
+	builder.WriteByte(')')
+}
+
+// rowIDRangesSQL groups the extents of a table and of its partitions, in
+// ROWID order, into groups of about the same number of blocks, and returns
+// the first and last ROWID a group can hold, like the ROWID chunks of
+// DBMS_PARALLEL_EXECUTE
+const rowIDRangesSQL = `SELECT
+	ROWIDTOCHAR(DBMS_ROWID.ROWID_CREATE(1,
+		MIN(DATA_OBJECT_ID) KEEP (DENSE_RANK FIRST ORDER BY DATA_OBJECT_ID, RELATIVE_FNO, BLOCK_ID),
+		MIN(RELATIVE_FNO) KEEP (DENSE_RANK FIRST ORDER BY DATA_OBJECT_ID, RELATIVE_FNO, BLOCK_ID),
+		MIN(BLOCK_ID) KEEP (DENSE_RANK FIRST ORDER BY DATA_OBJECT_ID, RELATIVE_FNO, BLOCK_ID), 0)) AS "start",
+	ROWIDTOCHAR(DBMS_ROWID.ROWID_CREATE(1,
+		MAX(DATA_OBJECT_ID) KEEP (DENSE_RANK LAST ORDER BY DATA_OBJECT_ID, RELATIVE_FNO, BLOCK_ID),
+		MAX(RELATIVE_FNO) KEEP (DENSE_RANK LAST ORDER BY DATA_OBJECT_ID, RELATIVE_FNO, BLOCK_ID),
+		MAX(BLOCK_ID + BLOCKS - 1) KEEP (DENSE_RANK LAST ORDER BY DATA_OBJECT_ID, RELATIVE_FNO, BLOCK_ID), 32767)) AS "end"
+FROM (
+	SELECT o.DATA_OBJECT_ID, e.RELATIVE_FNO, e.BLOCK_ID, e.BLOCKS,
+		TRUNC((SUM(e.BLOCKS) OVER (ORDER BY o.DATA_OBJECT_ID, e.RELATIVE_FNO, e.BLOCK_ID) - 0.5) / (SUM(e.BLOCKS) OVER () / ?)) AS GRP
+	FROM USER_EXTENTS e
+	JOIN USER_OBJECTS o ON o.OBJECT_NAME = e.SEGMENT_NAME AND o.OBJECT_TYPE = e.SEGMENT_TYPE
+		AND NVL(o.SUBOBJECT_NAME, ' ') = NVL(e.PARTITION_NAME, ' ')
+	WHERE e.SEGMENT_NAME = ? AND e.SEGMENT_TYPE IN ('TABLE', 'TABLE PARTITION', 'TABLE SUBPARTITION')
+)
+GROUP BY GRP
+ORDER BY GRP`
+
+// RowIDRanges splits the table of the model of db into up to n ranges of
+// ROWIDs that do not overlap and cover about the same number of blocks, from
+// the extents the table is stored in. The rows of the table are not read, so
+// splitting a large table is cheap, but rows are not evenly spread across
+// the ranges when the blocks of the table are not evenly filled. A table
+// without storage yet has no ranges.
+//
+//	ranges, err := oracle.RowIDRanges(db.Model(&Order{}), 16)
+//
+// The extents are read from USER_EXTENTS, so the table must belong to the
+// user. Ranges stay valid as long as the rows do not move, which only
+// happens when row movement is enabled for the table, for instance by a
+// shrink or an update of the partition key.
+func RowIDRanges(db *gorm.DB, n int) ([]RowIDRange, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid number of ROWID ranges %d", n)
+	}
+	table, err := rowIDRangeTable(db, db.Statement.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	var ranges []RowIDRange
+	rows, err := db.Session(&gorm.Session{NewDB: true}).Raw(rowIDRangesSQL, n, table).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var r RowIDRange
+		if err := rows.Scan(&r.Start, &r.End); err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, rows.Err()
+}
+
+// rowIDRangeTable returns the name of the table of the statement, or of the
+// table of model
+func rowIDRangeTable(db *gorm.DB, model interface{}) (string, error) {
+	if db.Statement.Table != "" {
+		return db.Statement.Table, nil
+	}
+	if model == nil {
+		return "", errors.New("ROWID ranges require a model or a table")
+	}
+	stmt := &gorm.Statement{DB: db, Context: db.Statement.Context}
+	if err := stmt.Parse(model); err != nil {
+		return "", err
+	}
+	return stmt.Table, nil
+}
+
+// FindInRowIDRanges is a FindInBatches that splits the table into up to n
+// ROWID ranges with RowIDRanges and finds the records matching db in each
+// range on its own connection, with up to workers ranges at a time. The
+// ranges do not overlap, so that every row is found once, and no ORDER BY
+// is needed to page through the table.
+//
+// dest is a pointer to a slice of models that gives the type of the
+// records; each range is found into a new slice of that type, which fc
+// receives as a pointer together with the number of the batch, from 1 in
+// ROWID order. fc is called concurrently and batches complete in any order.
+//
+//	err := oracle.FindInRowIDRanges(db.Where(`"status" = ?`, "open"), &[]Order{}, 32, 8,
+//		func(tx *gorm.DB, batch int, records interface{}) error {
+//			return export(*records.(*[]Order))
+//		})
+//
+// The first error stops the remaining ranges and is returned. Each range is
+// read at its own point in time; run it within WithSnapshot for a
+// consistent read of the whole table.
+func FindInRowIDRanges(db *gorm.DB, dest interface{}, n, workers int, fc func(tx *gorm.DB, batch int, records interface{}) error) error {
+	destType := reflect.TypeOf(dest)
+	if destType == nil || destType.Kind() != reflect.Ptr || destType.Elem().Kind() != reflect.Slice {
+		return errors.New("ROWID range destination must be a pointer to a slice")
+	}
+	if workers <= 0 {
+		return fmt.Errorf("invalid number of ROWID range workers %d", workers)
+	}
+
+	model := db.Statement.Model
+	if model == nil {
+		model = dest
+	}
+	table, err := rowIDRangeTable(db, model)
+	if err != nil {
+		return err
+	}
+	ranges, err := RowIDRanges(db.Session(&gorm.Session{NewDB: true}).Table(table), n)
+	if err != nil || len(ranges) == 0 {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(db.Statement.Context)
+	defer cancel()
+	session := db.Session(&gorm.Session{Context: ctx})
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	batches := make(chan int)
+	for i := 0; i < min(workers, len(ranges)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				records := reflect.New(destType.Elem()).Interface()
+				tx := session.Where(ranges[batch-1]).Find(records)
+				err := tx.Error
+				if err == nil {
+					err = fc(tx, batch, records)
+				}
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for batch := 1; batch <= len(ranges); batch++ {
+		select {
+		case batches <- batch:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(batches)
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return firstErr
+}
@@ -0,0 +1,255 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// skipSQLLiteral returns the index after the string literal, quoted
+// identifier or comment starting at sql[i], or i if there is none there.
+// It recognizes string literals with doubled quotes as escapes, N'...',
+// q'[...]' and nq'[...]' literals, "..." identifiers, -- line comments and
+// /* */ block comments.
+func skipSQLLiteral(sql string, i int) int {
+	if i >= len(sql) {
+		return i
+	}
+
+	// A literal prefix cannot continue an identifier, as in tabq'
+	prefixed := i == 0 || !isIdentifierChar(sql[i-1])
+	j := i
+	if prefixed && (sql[j] == 'n' || sql[j] == 'N') && j+1 < len(sql) &&
+		(sql[j+1] == '\'' || sql[j+1] == 'q' || sql[j+1] == 'Q') {
+		j++
+	}
+
+	switch {
+	case prefixed && (sql[j] == 'q' || sql[j] == 'Q') && j+2 < len(sql) && sql[j+1] == '\'':
+		closing := sql[j+2]
+		switch closing {
+		case '[':
+			closing = ']'
+		case '{':
+			closing = '}'
+		case '(':
+			closing = ')'
+		case '<':
+			closing = '>'
+		}
+		if end := strings.Index(sql[j+3:], string(closing)+"'"); end >= 0 {
+			return j + 3 + end + 2
+		}
+		return len(sql)
+	case sql[j] == '\'':
+		for k := j + 1; k < len(sql); k++ {
+			if sql[k] == '\'' {
+				if k+1 < len(sql) && sql[k+1] == '\'' {
+					k++
+					continue
+				}
+				return k + 1
+			}
+		}
+		return len(sql)
+	case j != i:
+		return i
+	case sql[i] == '"':
+		if end := strings.IndexByte(sql[i+1:], '"'); end >= 0 {
+			return i + 1 + end + 1
+		}
+		return len(sql)
+	case sql[i] == '-' && i+1 < len(sql) && sql[i+1] == '-':
+		if end := strings.IndexByte(sql[i:], '\n'); end >= 0 {
+			return i + end
+		}
+		return len(sql)
+	case sql[i] == '/' && i+1 < len(sql) && sql[i+1] == '*':
+		if end := strings.Index(sql[i+2:], "*/"); end >= 0 {
+			return i + 2 + end + 2
+		}
+		return len(sql)
+	}
+	return i
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '$' || c == '#' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// namedBinds returns the names of the :name bind placeholders in the SQL,
+// ignoring literals, comments and positional :1 placeholders. The names are
+// mapped from their uppercase form, since Oracle resolves them regardless
+// of case.
+func namedBinds(sql string) map[string]string {
+	names := map[string]string{}
+	for i := 0; i < len(sql); {
+		if next := skipSQLLiteral(sql, i); next > i {
+			i = next
+			continue
+		}
+		if sql[i] == ':' && i+1 < len(sql) && isIdentifierChar(sql[i+1]) && (sql[i+1] < '0' || sql[i+1] > '9') {
+			j := i + 1
+			for j < len(sql) && isIdentifierChar(sql[j]) {
+				j++
+			}
+			name := sql[i+1 : j]
+			names[strings.ToUpper(name)] = name
+			i = j
+			continue
+		}
+		i++
+	}
+	return names
+}
+
+// NamedBindHandler binds the values of maps and structs passed to Raw and
+// Exec to the Oracle :name placeholders of the statement, so that
+//
+//	db.Raw(`SELECT * FROM "users" WHERE "name" = :name OR "nickname" = :name`,
+//		map[string]interface{}{"name": "jinzhu"})
+//
+// binds the value once, by name, like sql.Named does. Map keys and struct
+// field names are matched regardless of case. Values that are not used by
+// the statement are left out.
+func NamedBindHandler(db *gorm.DB) {
+	if db.Error != nil || db.Statement == nil || db.Statement.SQL.Len() == 0 || len(db.Statement.Vars) == 0 {
+		return
+	}
+
+	var names map[string]string
+	vars := make([]interface{}, 0, len(db.Statement.Vars))
+	for _, v := range db.Statement.Vars {
+		values, ok := namedValues(v)
+		if !ok {
+			vars = append(vars, v)
+			continue
+		}
+		if names == nil {
+			names = namedBinds(db.Statement.SQL.String())
+		}
+		if len(names) == 0 {
+			vars = append(vars, v)
+			continue
+		}
+		for key, value := range values {
+			if name, ok := names[strings.ToUpper(key)]; ok {
+				vars = append(vars, sql.Named(name, value))
+				// Bind each name once, even if several arguments provide it
+				delete(names, strings.ToUpper(key))
+			}
+		}
+	}
+	db.Statement.Vars = vars
+}
+
+// namedValues returns the values of a map or struct argument by name
+func namedValues(v interface{}) (map[string]interface{}, bool) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		return value, true
+	case driver.Valuer, time.Time, *time.Time:
+		return nil, false
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, false
+	}
+	// Arguments of database/sql and the driver, such as sql.Out and
+	// godror.Lob, are passed on as they are
+	switch rv.Type().PkgPath() {
+	case "database/sql", "github.com/godror/godror":
+		return nil, false
+	}
+
+	values := map[string]interface{}{}
+	var appendFields func(reflect.Value)
+	appendFields = func(rv reflect.Value) {
+		rv = reflect.Indirect(rv)
+		if rv.Kind() != reflect.Struct {
+			return
+		}
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			if !ast.IsExported(field.Name) {
+				continue
+			}
+			if field.Anonymous {
+				appendFields(rv.Field(i))
+				continue
+			}
+			values[field.Name] = rv.Field(i).Interface()
+		}
+	}
+	appendFields(rv)
+	return values, true
+}
+
+// writeBindVar binds the last var of the statement and writes its
+// placeholder: national strings are converted with UNISTR, see NString, and
+// the wall clocks of DATE and TIMESTAMP columns with TO_TIMESTAMP, see
+// DateLocation
+func writeBindVar(writer interface{ WriteString(string) (int, error) }, stmt *gorm.Statement) {
+	last := len(stmt.Vars) - 1
+	placeholder := ":" + strconv.Itoa(last+1)
+	switch value := stmt.Vars[last].(type) {
+	case wallClock:
+		stmt.Vars[last] = string(value)
+		placeholder = "TO_TIMESTAMP(" + placeholder + ", '" + wallClockMask + "')"
+	case wallClocks:
+		stmt.Vars[last] = []string(value)
+		placeholder = "TO_TIMESTAMP(" + placeholder + ", '" + wallClockMask + "')"
+	default:
+		bind, national := nationalBind(stmt.DB, value)
+		stmt.Vars[last] = bind
+		if national {
+			placeholder = "UNISTR(" + placeholder + ")"
+		}
+	}
+	writer.WriteString(placeholder)
+}
@@ -0,0 +1,130 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// schemaColumns are the column lists of a model that the PL/SQL blocks of
+// bulk creates, updates, merges and deletes declare and return
+type schemaColumns struct {
+	// returnable are the columns of readable fields, leaving out fields
+	// tagged `->:false` and the ROWID pseudo-column, so that generated
+	// columns tagged `->` are returned and write-only fields are not
+	returnable []string
+}
+
+// schemaColumnsCache holds the schemaColumns of each parsed schema. GORM
+// parses a model once, so the lists are built on first use and shared by
+// every later statement. They must not be modified.
+var schemaColumnsCache sync.Map
+
+// cachedSchemaColumns returns the column lists of the schema
+func cachedSchemaColumns(s *schema.Schema) *schemaColumns {
+	if v, ok := schemaColumnsCache.Load(s); ok {
+		return v.(*schemaColumns)
+	}
+	columns := &schemaColumns{
+		returnable: filterFields(s, func(f *schema.Field) bool { return f.Readable && !isRowIDField(f) && !isLongField(f) }),
+	}
+	v, _ := schemaColumnsCache.LoadOrStore(s, columns)
+	return v.(*schemaColumns)
+}
+
+// quoteCacheKey identifies a quoted identifier, which depends on whether the
+// dialector quotes identifiers
+type quoteCacheKey struct {
+	identifier string
+	skipQuote  bool
+}
+
+// quoteCache holds the quoted names of the columns and tables written by the
+// PL/SQL builders, which quote the same names for every row of a batch
+var quoteCache sync.Map
+
+// recordDeclCache holds the record and collection type declarations written
+// by writeTableRecordCollectionDecl, by table and columns
+var recordDeclCache sync.Map
+
+// skipQuoteIdentifiers returns whether the dialector of the database writes
+// identifiers unquoted, and whether the dialector is this one
+func skipQuoteIdentifiers(db *gorm.DB) (skip bool, ok bool) {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		return d.Config != nil && d.SkipQuoteIdentifiers, true
+	case Dialector:
+		return d.Config != nil && d.SkipQuoteIdentifiers, true
+	}
+	return false, false
+}
+
+// quoteTo writes the quoted identifier of a column or table. The quoted name
+// is cached, as the PL/SQL builders quote the same columns for every row.
+// Only schema columns and tables should be passed, so that the cache stays
+// bounded by the models of the application.
+func quoteTo(db *gorm.DB, builder *strings.Builder, identifier string) {
+	skip, ok := skipQuoteIdentifiers(db)
+	if !ok {
+		db.QuoteTo(builder, identifier)
+		return
+	}
+	key := quoteCacheKey{identifier: identifier, skipQuote: skip}
+	if v, ok := quoteCache.Load(key); ok {
+		builder.WriteString(v.(string))
+		return
+	}
+	var quoted strings.Builder
+	db.QuoteTo(&quoted, identifier)
+	quoteCache.Store(key, quoted.String())
+	builder.WriteString(quoted.String())
+}
+
+// columnFields returns the fields of the columns, or nil for columns without
+// one, so that builders look them up once rather than for every row
+func columnFields(s *schema.Schema, columns []string) []*schema.Field {
+	fields := make([]*schema.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = findFieldByDBName(s, column)
+	}
+	return fields
+}
@@ -56,6 +56,12 @@ const (
 	ClauseOnConflict = "ON CONFLICT"
 	ClauseValues     = "VALUES"
 	ClauseReturning  = "RETURNING"
+	ClauseFrom       = "FROM"
+	ClauseSelect     = "SELECT"
+	ClauseFor        = "FOR"
+	ClauseWhere      = "WHERE"
+	ClauseOrderBy    = "ORDER BY"
+	ClauseGroupBy    = "GROUP BY"
 )
 
 // Returns the clause builders that are used to generate clauses for Oracle DB
@@ -68,6 +74,12 @@ func OracleClauseBuilders() map[string]clause.ClauseBuilder {
 		ClauseOnConflict: OnConflictClauseBuilder,
 		ClauseValues:     ValuesClauseBuilder,
 		ClauseReturning:  ReturningClauseBuilder,
+		ClauseFrom:       FromClauseBuilder,
+		ClauseSelect:     SelectClauseBuilder,
+		ClauseFor:        LockingClauseBuilder,
+		ClauseWhere:      WhereClauseBuilder,
+		ClauseOrderBy:    OrderByClauseBuilder,
+		ClauseGroupBy:    GroupByClauseBuilder,
 	}
 }
 
@@ -75,7 +87,9 @@ func OracleClauseBuilders() map[string]clause.ClauseBuilder {
 func InsertClauseBuilder(c clause.Clause, builder clause.Builder) {
 
 	if insert, ok := c.Expression.(clause.Insert); ok {
-		builder.WriteString("INSERT INTO ")
+		builder.WriteString("INSERT ")
+		writeParallelHint(builder)
+		builder.WriteString("INTO ")
 
 		// If the table name is empty in the clause, get it from the statement
 		if insert.Table.Name == "" {
@@ -85,6 +99,10 @@ func InsertClauseBuilder(c clause.Clause, builder clause.Builder) {
 		} else {
 			builder.WriteQuoted(insert.Table)
 		}
+
+		if stmt, ok := builder.(*gorm.Statement); ok {
+			writePartitionExtension(stmt, builder)
+		}
 	}
 	// Modifier field is intentionally ignored for Oracle
 }
@@ -93,6 +111,7 @@ func InsertClauseBuilder(c clause.Clause, builder clause.Builder) {
 func UpdateClauseBuilder(c clause.Clause, builder clause.Builder) {
 	if update, ok := c.Expression.(clause.Update); ok {
 		builder.WriteString("UPDATE ")
+		writeParallelHint(builder)
 
 		// If the table name is empty in the clause, get it from the statement
 		if update.Table.Name == "" {
@@ -102,6 +121,10 @@ func UpdateClauseBuilder(c clause.Clause, builder clause.Builder) {
 		} else {
 			builder.WriteQuoted(update.Table)
 		}
+
+		if stmt, ok := builder.(*gorm.Statement); ok {
+			writePartitionExtension(stmt, builder)
+		}
 	}
 	// Modifier field is intentionally ignored for Oracle
 }
@@ -110,6 +133,12 @@ func UpdateClauseBuilder(c clause.Clause, builder clause.Builder) {
 func DeleteClauseBuilder(c clause.Clause, builder clause.Builder) {
 	if _, ok := c.Expression.(clause.Delete); ok {
 		builder.WriteString("DELETE")
+		if stmt, ok := builder.(*gorm.Statement); ok {
+			if p, ok := parallelHint(stmt); ok {
+				builder.WriteByte(' ')
+				p.Build(builder)
+			}
+		}
 	}
 	// Modifier field is intentionally ignored for Oracle
 }
@@ -120,12 +149,12 @@ func ReturningClauseBuilder(c clause.Clause, builder clause.Builder) {
 		// Handle the case where RETURNING clause is empty - populate it with all columns
 		if len(returning.Columns) == 0 {
 			if stmt, ok := builder.(*gorm.Statement); ok && stmt.Schema != nil {
-				// Auto-populate with all columns
+				// Auto-populate with the columns of all readable fields,
+				// each once even when an embedded struct field is shadowed
+				// by a field of the same name
 				var returningColumns []clause.Column
-				for _, field := range stmt.Schema.Fields {
-					if field.DBName != "" {
-						returningColumns = append(returningColumns, clause.Column{Name: field.DBName})
-					}
+				for _, dbName := range getReturnableFields(stmt.Schema) {
+					returningColumns = append(returningColumns, clause.Column{Name: dbName})
 				}
 
 				if len(returningColumns) > 0 {
@@ -148,7 +177,12 @@ func ReturningClauseBuilder(c clause.Clause, builder clause.Builder) {
 				if idx > 0 {
 					builder.WriteByte(',')
 				}
-				builder.WriteQuoted(column)
+				if column.Raw {
+					// An expression such as ROWID, whose alias only names the value
+					builder.WriteString(column.Name)
+				} else {
+					builder.WriteQuoted(column)
+				}
 			}
 
 			// Handle the INTO part here
@@ -164,7 +198,7 @@ func ReturningClauseBuilder(c clause.Clause, builder clause.Builder) {
 					// Find the field by column name and create appropriate destination
 					var dest interface{}
 					if stmt.Schema != nil {
-						if field := findFieldByDBName(stmt.Schema, column.Name); field != nil {
+						if field := findFieldByDBName(stmt.Schema, returningKey(column)); field != nil {
 							dest = createTypedDestination(field)
 						} else {
 							dest = new(string) // Default to string for unknown fields
@@ -191,6 +225,149 @@ func LimitClauseBuilder(c clause.Clause, builder clause.Builder) {
 	}
 }
 
+// WhereClauseBuilder builds the WHERE clause. The WHERE clause of an UPDATE
+// or DELETE with a LIMIT selects the ROWIDs of the rows to change, as Oracle
+// does not support LIMIT on DML.
+func WhereClauseBuilder(c clause.Clause, builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok && isDMLStatement(stmt) {
+		if limit, ok := limitedRows(stmt); ok {
+			var writeConditions func()
+			if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+				writeConditions = func() { where.Build(builder) }
+			}
+			builder.WriteString("WHERE ")
+			writeLimitedRowIDs(builder, stmt, limit, writeConditions)
+			return
+		}
+	}
+	c.Build(builder)
+}
+
+// OrderByClauseBuilder builds the ORDER BY clause. The columns of
+// clause.OrderByColumn, such as those of oracle.Asc and oracle.Desc, are
+// resolved to the column names of the model and quoted by GORM.
+func OrderByClauseBuilder(c clause.Clause, builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		if orderBy, ok := c.Expression.(clause.OrderBy); ok && len(orderBy.Columns) > 0 {
+			columns := make([]clause.OrderByColumn, len(orderBy.Columns))
+			for i, column := range orderBy.Columns {
+				column.Column = resolveColumn(stmt, column.Column)
+				columns[i] = column
+			}
+			orderBy.Columns = columns
+			c.Expression = orderBy
+		}
+	}
+	c.Build(builder)
+}
+
+// GroupByClauseBuilder builds the GROUP BY clause, resolving its columns
+// like OrderByClauseBuilder
+func GroupByClauseBuilder(c clause.Clause, builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		if groupBy, ok := c.Expression.(clause.GroupBy); ok {
+			if len(groupBy.Columns) > 0 {
+				columns := make([]clause.Column, len(groupBy.Columns))
+				for i, column := range groupBy.Columns {
+					columns[i] = resolveColumn(stmt, column)
+				}
+				groupBy.Columns = columns
+				c.Expression = groupBy
+			}
+
+			if groupings, ok := stmt.Clauses[ClauseGroupingSets]; ok && groupings.Expression != nil {
+				writeGroupingSets(builder, groupBy, groupings.Expression)
+				return
+			}
+		}
+	}
+	c.Build(builder)
+}
+
+// writeGroupingSets writes a GROUP BY clause with the ROLLUP, CUBE and
+// GROUPING SETS extensions following the grouped columns
+func writeGroupingSets(builder clause.Builder, groupBy clause.GroupBy, groupings clause.Expression) {
+	builder.WriteString("GROUP BY ")
+	for _, column := range groupBy.Columns {
+		builder.WriteQuoted(column)
+		builder.WriteByte(',')
+	}
+	groupings.Build(builder)
+
+	if len(groupBy.Having) > 0 {
+		builder.WriteString(" HAVING ")
+		clause.Where{Exprs: groupBy.Having}.Build(builder)
+	}
+}
+
+// isDMLStatement reports whether the statement is an UPDATE or DELETE
+func isDMLStatement(stmt *gorm.Statement) bool {
+	_, isUpdate := stmt.Clauses["UPDATE"]
+	_, isDelete := stmt.Clauses["DELETE"]
+	return isUpdate || isDelete
+}
+
+// FromClauseBuilder builds the FROM clause. It behaves like GORM's default
+// builder, except that table modifiers such as SAMPLE are written directly
+// after the primary table reference, which is where Oracle expects them. A
+// flashback clause is also written after the tables joined by association.
+func FromClauseBuilder(c clause.Clause, builder clause.Builder) {
+	from, ok := c.Expression.(clause.From)
+	if !ok {
+		c.Build(builder)
+		return
+	}
+
+	builder.WriteString("FROM ")
+	if len(from.Tables) > 0 {
+		for idx, table := range from.Tables {
+			if idx > 0 {
+				builder.WriteByte(',')
+				builder.WriteQuoted(table)
+			} else {
+				writeTableReference(builder, table)
+			}
+		}
+	} else {
+		writeTableReference(builder, clause.Table{Name: clause.CurrentTable})
+	}
+
+	for _, join := range from.Joins {
+		builder.WriteByte(' ')
+		writeJoin(builder, join)
+	}
+}
+
+// SelectClauseBuilder builds the SELECT clause, adding the PARALLEL hint
+// and the total count column when the statement has those clauses, and the
+// ROWID pseudo-column for the models that map it
+func SelectClauseBuilder(c clause.Clause, builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		if p, ok := parallelHint(stmt); ok && c.AfterNameExpression == nil {
+			c.AfterNameExpression = p
+		}
+		if _, ok := totalCount(stmt); ok {
+			c = writeTotalCountColumn(c, builder)
+		}
+		c = selectRowID(c, stmt)
+	}
+	c.Build(builder)
+}
+
+// LockingClauseBuilder builds the FOR UPDATE clause. Oracle's FOR UPDATE OF
+// takes columns rather than tables, so the table of a clause.Locking is
+// replaced with its primary key column.
+func LockingClauseBuilder(c clause.Clause, builder clause.Builder) {
+	if locking, ok := c.Expression.(clause.Locking); ok && locking.Table.Name != "" {
+		if stmt, ok := builder.(*gorm.Statement); ok {
+			if column, ok := lockingColumn(stmt, locking.Table); ok {
+				c.Expression = Locking{Strength: locking.Strength, Columns: []clause.Column{column}, Options: locking.Options}
+			}
+		}
+	}
+	c.Build(builder)
+}
+
 // ValuesClauseBuilder builds the VALUES clause of an INSERT statement
 func ValuesClauseBuilder(c clause.Clause, builder clause.Builder) {
 	if values, ok := c.Expression.(clause.Values); ok {
@@ -457,10 +634,24 @@ func buildMergeInClause(stmt *gorm.Statement, onConflict clause.OnConflict, valu
 	}
 	stmt.WriteByte(')')
 
-	// WHEN MATCHED THEN UPDATE SET (if provided)
-	if len(onConflict.DoUpdates) > 0 {
+	// WHEN MATCHED THEN UPDATE SET (if provided), without the conflict
+	// columns, which Oracle does not allow to update (ORA-38104)
+	updates := make(clause.Set, 0, len(onConflict.DoUpdates))
+	for _, assignment := range onConflict.DoUpdates {
+		isConflictColumn := false
+		for _, column := range conflictColumns {
+			if strings.EqualFold(assignment.Column.Name, column.Name) {
+				isConflictColumn = true
+				break
+			}
+		}
+		if !isConflictColumn {
+			updates = append(updates, assignment)
+		}
+	}
+	if len(updates) > 0 {
 		stmt.WriteString(" WHEN MATCHED THEN UPDATE SET ")
-		onConflict.DoUpdates.Build(stmt)
+		updates.Build(stmt)
 	}
 
 	// WHEN NOT MATCHED THEN INSERT
@@ -63,6 +63,8 @@ const (
 	TimestampWithTimeZone schema.DataType = "timestamp with time zone"
 )
 
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
 // Helper function to get Oracle array type for a field
 func getOracleArrayType(values []any) string {
 	arrayType := "TABLE OF VARCHAR2(4000)"
@@ -76,7 +78,8 @@ func getOracleArrayType(values []any) string {
 		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
 			arrayType = "TABLE OF NUMBER"
 		case time.Time:
-			arrayType = "TABLE OF TIMESTAMP WITH TIME ZONE"
+			// Keep every fractional digit, PL/SQL defaults to 6
+			arrayType = "TABLE OF TIMESTAMP(9) WITH TIME ZONE"
 		case godror.Lob:
 			if v.IsClob {
 				return "TABLE OF CLOB"
@@ -93,21 +96,78 @@ func getOracleArrayType(values []any) string {
 			if len(v) > 4000 {
 				return "TABLE OF CLOB"
 			}
+		case NString:
+			arrayType = "TABLE OF NVARCHAR2(4000)"
+		case wallClock:
+			arrayType = "TABLE OF TIMESTAMP(9)"
 		}
 	}
 	return arrayType
 }
 
+// columnArrayType returns the type of the PL/SQL array holding the values of
+// a column in a bulk create. Maps carry no field types, and their values may
+// be nil or of different Go types for the same column, so the arrays of
+// creates from maps take the type of the table column, which the block
+// resolves from the live column metadata when it is compiled. LOB and JSON
+// values keep the arrays of their Go types.
+func columnArrayType(db *gorm.DB, column string, values []any) string {
+	arrayType := getOracleArrayType(values)
+	stmt := db.Statement
+	if !isMapCreate(stmt) || strings.HasSuffix(arrayType, "LOB") {
+		return arrayType
+	}
+	if stmt.Schema != nil {
+		if field := findFieldByDBName(stmt.Schema, column); field != nil && isJSONField(field) {
+			return arrayType
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("TABLE OF ")
+	quoteTo(db, &b, stmt.Table)
+	b.WriteString(".")
+	quoteTo(db, &b, column)
+	b.WriteString("%TYPE")
+	return b.String()
+}
+
+// isMapCreate reports whether the statement creates rows from a map or a
+// slice of maps
+func isMapCreate(stmt *gorm.Statement) bool {
+	rv := stmt.ReflectValue
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		if rv.Len() == 0 {
+			return false
+		}
+		rv = reflect.Indirect(rv.Index(0))
+		if rv.Kind() == reflect.Interface {
+			rv = reflect.Indirect(rv.Elem())
+		}
+	}
+	return rv.Kind() == reflect.Map
+}
+
 // Helper to check if a variable is an OUT parameter
 func isOutParam(v interface{}) bool {
 	_, ok := v.(sql.Out)
 	return ok
 }
 
-// Find field by database column name
+// Find field by database column name. Fields promoted from embedded structs
+// (including those renamed by embeddedPrefix) are resolved through
+// FieldsByDBName so that a column shadowed by an outer field maps to the same
+// field gorm itself would use. Column names echoed back by the database may
+// differ in case, so fall back to a case-insensitive match.
 func findFieldByDBName(schema *schema.Schema, dbName string) *schema.Field {
+	if field, ok := schema.FieldsByDBName[dbName]; ok {
+		return field
+	}
 	for _, field := range schema.Fields {
-		if field.DBName == dbName {
+		if field.DBName != "" && strings.EqualFold(field.DBName, dbName) {
+			if primary, ok := schema.FieldsByDBName[field.DBName]; ok {
+				return primary
+			}
 			return field
 		}
 	}
@@ -131,6 +191,11 @@ func createTypedDestination(f *schema.Field) interface{} {
 	// database.
 	if f.Serializer != nil {
 		dt := strings.ToLower(string(f.DataType))
+		// Types with a precision, such as timestamp(9), are read as times
+		// rather than strings formatted by the session
+		if strings.HasPrefix(dt, "timestamp") || strings.HasPrefix(dt, "date") {
+			dt = string(schema.Time)
+		}
 		switch schema.DataType(dt) {
 		case schema.Bool:
 			return new(bool)
@@ -184,6 +249,12 @@ func createTypedDestination(f *schema.Field) interface{} {
 		return new(sql.NullBool)
 	}
 
+	// Named time types, such as datatypes.Date, are read as times rather
+	// than strings formatted by the session
+	if ft.Kind() == reflect.Struct && ft.ConvertibleTo(reflect.TypeOf(time.Time{})) {
+		return new(sql.NullTime)
+	}
+
 	switch ft.Kind() {
 	case reflect.String:
 		return new(string)
@@ -219,6 +290,15 @@ func convertValue(val interface{}) interface{} {
 	rv := reflect.ValueOf(val)
 
 	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		// A Value method with a pointer receiver is lost by dereferencing,
+		// which would bind the struct itself
+		if valuer, ok := val.(driver.Valuer); ok && !rv.Elem().Type().Implements(valuerType) {
+			unwrappedValue, err := valuer.Value()
+			if err != nil {
+				return val
+			}
+			return convertValue(unwrappedValue)
+		}
 		rv = rv.Elem()
 		val = rv.Interface()
 	}
@@ -282,6 +362,23 @@ func convertValue(val interface{}) interface{} {
 		// If we get a clause.Expr, convert it to nil; it should be handled elsewhere
 		return nil
 	default:
+		// Named booleans and numbers without a Value method, which the
+		// PL/SQL arrays would otherwise declare and bind as strings
+		if !rv.IsValid() || rv.Type().PkgPath() == "" {
+			return val
+		}
+		switch rv.Kind() {
+		case reflect.Bool:
+			return convertValue(rv.Bool())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return rv.Uint()
+		case reflect.Float32:
+			return float32(rv.Float())
+		case reflect.Float64:
+			return rv.Float()
+		}
 		return val
 	}
 }
@@ -410,6 +507,13 @@ func convertFromOracleToField(value interface{}, field *schema.Field) interface{
 			converted = sql.NullBool{}
 		}
 	default:
+		if nullTime, ok := value.(sql.NullTime); ok && targetType.Kind() == reflect.Struct && reflect.TypeOf(time.Time{}).ConvertibleTo(targetType) {
+			if !nullTime.Valid {
+				return nil
+			}
+			converted = reflect.ValueOf(nullTime.Time).Convert(targetType).Interface()
+			break
+		}
 		// primitives and everything else
 		converted = convertPrimitiveType(value, targetType)
 	}
@@ -549,6 +653,10 @@ func convertPrimitiveType(value interface{}, targetType reflect.Type) interface{
 	case reflect.Float64:
 		return value
 	case reflect.String:
+		// Named string types, such as NString
+		if v, ok := value.(string); ok && targetType.Kind() == reflect.String {
+			return reflect.ValueOf(v).Convert(targetType).Interface()
+		}
 		return value
 	default:
 		return value
@@ -635,23 +743,58 @@ func QuoteIdentifier(identifier string) string {
 //   - dbNames: The slice containing the column names.
 //   - table: The table name
 func writeTableRecordCollectionDecl(db *gorm.DB, plsqlBuilder *strings.Builder, dbNames []string, table string) {
+	skip, cacheable := skipQuoteIdentifiers(db)
+	key := quoteCacheKey{identifier: table + "\x00" + strings.Join(dbNames, "\x00"), skipQuote: skip}
+	if cacheable {
+		if v, ok := recordDeclCache.Load(key); ok {
+			plsqlBuilder.WriteString(v.(string))
+			return
+		}
+	}
+
 	// Declare a record where each element has the same structure as a row from the given table
-	plsqlBuilder.WriteString("  TYPE t_record IS RECORD (\n")
+	var decl strings.Builder
+	decl.WriteString("  TYPE t_record IS RECORD (\n")
 	for i, field := range dbNames {
 		if i > 0 {
-			plsqlBuilder.WriteString(",\n")
-		}
-		plsqlBuilder.WriteString("    ")
-		db.QuoteTo(plsqlBuilder, field)
-		plsqlBuilder.WriteString(" ")
-		db.QuoteTo(plsqlBuilder, table)
-		plsqlBuilder.WriteString(".")
-		db.QuoteTo(plsqlBuilder, field)
-		plsqlBuilder.WriteString("%TYPE")
-	}
-	plsqlBuilder.WriteString("\n")
-	plsqlBuilder.WriteString("  );\n")
-	plsqlBuilder.WriteString("  TYPE t_records IS TABLE OF t_record;\n")
+			decl.WriteString(",\n")
+		}
+		decl.WriteString("    ")
+		quoteTo(db, &decl, field)
+		decl.WriteString(" ")
+		quoteTo(db, &decl, table)
+		decl.WriteString(".")
+		quoteTo(db, &decl, field)
+		decl.WriteString("%TYPE")
+	}
+	decl.WriteString("\n")
+	decl.WriteString("  );\n")
+	decl.WriteString("  TYPE t_records IS TABLE OF t_record;\n")
+
+	if cacheable {
+		recordDeclCache.Store(key, decl.String())
+	}
+	plsqlBuilder.WriteString(decl.String())
+}
+
+// plsqlClauseBuilder adapts the strings.Builder used to generate PL/SQL blocks
+// to clause.Builder, so that clause expressions can be written into the block
+// while their bind variables are added to the statement.
+type plsqlClauseBuilder struct {
+	*strings.Builder
+	stmt *gorm.Statement
+}
+
+func (b plsqlClauseBuilder) WriteQuoted(field interface{}) {
+	b.stmt.QuoteTo(b.Builder, field)
+}
+
+func (b plsqlClauseBuilder) AddVar(writer clause.Writer, vars ...interface{}) {
+	b.stmt.AddVar(writer, vars...)
+}
+
+func (b plsqlClauseBuilder) AddError(err error) error {
+	return b.stmt.AddError(err)
 }
 
 // Helper function to check if a value represents NULL
@@ -708,6 +851,12 @@ func isZeroFor(t reflect.Type, v interface{}) bool {
 func filterFields(s *schema.Schema, predicate func(f *schema.Field) bool) []string {
 	var fields []string
 	for _, f := range s.Fields {
+		// Skip fields shadowed by another field with the same column name
+		// (e.g. an embedded struct field overridden by the outer struct), so
+		// each column appears once and in the same order as the OUT binds.
+		if s.FieldsByDBName[f.DBName] != f {
+			continue
+		}
 		if slices.Contains(s.DBNames, f.DBName) {
 			if predicate(f) {
 				fields = append(fields, f.DBName)
@@ -717,20 +866,20 @@ func filterFields(s *schema.Schema, predicate func(f *schema.Field) bool) []stri
 	return fields
 }
 
-func getCreatableFields(s *schema.Schema) []string {
-	return filterFields(s, func(f *schema.Field) bool {
-		return f.Creatable
-	})
-}
-
-func getUpdatableFields(s *schema.Schema) []string {
-	return filterFields(s, func(f *schema.Field) bool {
-		return f.Updatable
-	})
+func getReturnableFields(s *schema.Schema) []string {
+	return cachedSchemaColumns(s).returnable
 }
 
-func getMergableFields(s *schema.Schema) []string {
-	return filterFields(s, func(f *schema.Field) bool {
-		return f.Creatable && f.Updatable
-	})
+// unpreparedConnPool returns the pool underneath GORM's prepared statement
+// cache. The PL/SQL blocks of bulk operations change with the number of rows,
+// so caching them would keep a prepared statement, and its open cursor, for
+// every batch size until the session runs out of cursors (ORA-01000).
+func unpreparedConnPool(pool gorm.ConnPool) gorm.ConnPool {
+	switch p := pool.(type) {
+	case *gorm.PreparedStmtDB:
+		return p.ConnPool
+	case *gorm.PreparedStmtTX:
+		return p.Tx
+	}
+	return pool
 }
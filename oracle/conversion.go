@@ -0,0 +1,259 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// ConversionWarningHandler logs a warning for each condition of the WHERE
+// clause that compares a character column of the model with a number bind,
+// or a number column with a string bind. Oracle converts the character side
+// to a number: the column itself when the column is VARCHAR2, which keeps its
+// indexes from being used and fails with ORA-01722 on rows that are not
+// numbers, or the bind when the column is NUMBER.
+//
+// It is meant for development, and registered by the ConversionWarnings
+// option after the statements are built.
+func ConversionWarningHandler(db *gorm.DB) {
+	stmt := db.Statement
+	if stmt.Schema == nil || stmt.SQL.Len() == 0 {
+		return
+	}
+
+	skipQuote := false
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		skipQuote = d.Config != nil && d.SkipQuoteIdentifiers
+	case Dialector:
+		skipQuote = d.Config != nil && d.SkipQuoteIdentifiers
+	}
+
+	// The fields by the names Oracle resolves their columns to
+	fields := map[string]*schema.Field{}
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" || field.DataType == "" {
+			continue
+		}
+		name := field.DBName
+		if skipQuote {
+			name = strings.ToUpper(name)
+		}
+		fields[QuoteIdentifier(name)] = field
+	}
+	table := QuoteIdentifier(stmt.Table)
+	if skipQuote {
+		table = QuoteIdentifier(strings.ToUpper(stmt.Table))
+	}
+
+	tokens := whereTokens(sqlTokens(stmt.SQL.String()))
+	for idx := range tokens {
+		if !isColumnRef(tokens, idx) {
+			continue
+		}
+		if idx >= 2 && tokens[idx-1].text == "." && columnRefName(tokens[idx-2]) != table {
+			// A column of another table
+			continue
+		}
+		field, ok := fields[columnRefName(tokens[idx])]
+		if !ok {
+			continue
+		}
+		columnType := db.Dialector.DataTypeOf(field)
+		columnKind := conversionKindOfType(columnType)
+		if columnKind == 0 {
+			continue
+		}
+
+		for _, bind := range comparedBinds(tokens, idx) {
+			value, ok := bindValue(stmt.Vars, bind)
+			if !ok {
+				continue
+			}
+			bindKind := conversionKindOfValue(value)
+			if bindKind == 0 || bindKind == columnKind {
+				continue
+			}
+			if columnKind == 's' {
+				db.Logger.Warn(stmt.Context, "implicit conversion: %s.%s %s is compared to the %T bind :%s, Oracle converts the column with TO_NUMBER, which keeps its indexes from being used",
+					table, columnRefName(tokens[idx]), columnType, value, bind)
+			} else {
+				db.Logger.Warn(stmt.Context, "implicit conversion: %s.%s %s is compared to the %T bind :%s, Oracle converts the bind with TO_NUMBER",
+					table, columnRefName(tokens[idx]), columnType, value, bind)
+			}
+		}
+	}
+}
+
+// conversionKindOfType returns 's' for character column types, 'n' for
+// number column types and 0 otherwise
+func conversionKindOfType(columnType string) byte {
+	columnType = strings.ToUpper(columnType)
+	switch {
+	case strings.HasPrefix(columnType, "VARCHAR"), strings.HasPrefix(columnType, "NVARCHAR2"),
+		strings.HasPrefix(columnType, "CHAR"), strings.HasPrefix(columnType, "NCHAR"):
+		return 's'
+	case strings.HasPrefix(columnType, "NUMBER"), strings.HasPrefix(columnType, "INTEGER"),
+		strings.HasPrefix(columnType, "FLOAT"), strings.HasPrefix(columnType, "BINARY_"):
+		return 'n'
+	}
+	return 0
+}
+
+// conversionKindOfValue returns 's' for string binds, 'n' for number binds
+// and 0 otherwise
+func conversionKindOfValue(value interface{}) byte {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return 0
+		}
+		value = v
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return 's'
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return 'n'
+	}
+	return 0
+}
+
+// comparedBinds returns the names of the binds the column token at idx is
+// compared to, with a comparison operator on either side or an IN list
+func comparedBinds(tokens []sqlToken, idx int) []string {
+	if idx+1 < len(tokens) {
+		if n := comparisonLength(tokens[idx+1:]); n > 0 {
+			if bind := bindAt(tokens, idx+1+n); bind != "" {
+				return []string{bind}
+			}
+		}
+	}
+	for n := 1; n <= 2 && idx-n >= 2; n++ {
+		if comparisonLength(tokens[idx-n:idx]) == n {
+			if bind := bindAt(tokens, idx-n-2); bind != "" {
+				return []string{bind}
+			}
+		}
+	}
+
+	next := idx + 1
+	if next < len(tokens) && tokens[next].kind == tokenWord && strings.EqualFold(tokens[next].text, "NOT") {
+		next++
+	}
+	if next+1 >= len(tokens) || tokens[next].kind != tokenWord || !strings.EqualFold(tokens[next].text, "IN") || tokens[next+1].text != "(" {
+		return nil
+	}
+	var binds []string
+	for next += 2; next < len(tokens); next += 3 {
+		bind := bindAt(tokens, next)
+		if bind == "" || next+2 >= len(tokens) {
+			return nil
+		}
+		binds = append(binds, bind)
+		if tokens[next+2].text == ")" {
+			return binds
+		}
+		if tokens[next+2].text != "," {
+			return nil
+		}
+	}
+	return nil
+}
+
+// comparisonLength returns the number of tokens of the comparison operator
+// the tokens start with, or 0
+func comparisonLength(tokens []sqlToken) int {
+	if len(tokens) == 0 || tokens[0].kind != tokenPunct {
+		return 0
+	}
+	switch tokens[0].text {
+	case "=":
+		return 1
+	case "<", ">", "!", "^":
+		if len(tokens) > 1 && tokens[1].kind == tokenPunct && (tokens[1].text == "=" || tokens[0].text == "<" && tokens[1].text == ">") {
+			return 2
+		}
+		if tokens[0].text == "<" || tokens[0].text == ">" {
+			return 1
+		}
+	}
+	return 0
+}
+
+// bindAt returns the name of the bind placeholder at idx, such as 1 for :1,
+// or an empty string
+func bindAt(tokens []sqlToken, idx int) string {
+	if idx < 0 || idx+1 >= len(tokens) || tokens[idx].text != ":" || tokens[idx+1].kind != tokenWord {
+		return ""
+	}
+	return tokens[idx+1].text
+}
+
+// bindValue returns the var of a positional or named bind
+func bindValue(vars []interface{}, bind string) (interface{}, bool) {
+	if position, err := strconv.Atoi(bind); err == nil {
+		if position < 1 || position > len(vars) {
+			return nil, false
+		}
+		if arg, ok := vars[position-1].(sql.NamedArg); ok {
+			return arg.Value, true
+		}
+		return vars[position-1], true
+	}
+	for _, v := range vars {
+		if arg, ok := v.(sql.NamedArg); ok && strings.EqualFold(arg.Name, bind) {
+			return arg.Value, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,455 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+)
+
+// DefaultCopyBatchSize is the number of rows CopyIn inserts per statement
+// when CopyOptions has no BatchSize
+const DefaultCopyBatchSize = 1000
+
+// ErrCopyMaxErrors is returned by CopyIn when the database rejects more
+// rows than CopyOptions.MaxErrors allows
+var ErrCopyMaxErrors = errors.New("copy rejected more rows than allowed")
+
+// CopySource is the source of the rows of CopyIn, read like sql.Rows
+type CopySource interface {
+	// Next advances to the next row, and returns false after the last row
+	// or on error
+	Next() bool
+	// Values returns the values of the current row, one per column
+	Values() ([]interface{}, error)
+	// Err returns the error that stopped Next, if any
+	Err() error
+}
+
+// CopyFromRows returns a CopySource reading the rows of a slice
+func CopyFromRows(rows [][]interface{}) CopySource {
+	return &copyFromRows{rows: rows, idx: -1}
+}
+
+type copyFromRows struct {
+	rows [][]interface{}
+	idx  int
+}
+
+func (c *copyFromRows) Next() bool {
+	c.idx++
+	return c.idx < len(c.rows)
+}
+
+func (c *copyFromRows) Values() ([]interface{}, error) {
+	return c.rows[c.idx], nil
+}
+
+func (c *copyFromRows) Err() error {
+	return nil
+}
+
+// CopyOptions tune CopyIn
+type CopyOptions struct {
+	// BatchSize is the number of rows inserted per statement,
+	// DefaultCopyBatchSize if zero
+	BatchSize int
+	// CommitRows commits the inserted rows whenever that many more rows have
+	// been read, rounded up to whole batches. When zero, the rows are
+	// committed once all of them are inserted.
+	CommitRows int
+	// MaxErrors is the number of rows the database may reject, for instance
+	// for violating a constraint, before the copy fails. The other rows of
+	// the batch of a rejected row are inserted. When zero, the first rejected
+	// row fails the copy; when negative, there is no limit.
+	MaxErrors int
+	// DirectPath inserts the rows with the APPEND_VALUES hint, above the
+	// high-water mark of the table and without going through the buffer
+	// cache. Oracle locks the table and does not allow it to be modified
+	// again in the transaction, so every batch is committed, and a rejected
+	// row fails the copy.
+	DirectPath bool
+}
+
+// CopyRowError is a row of CopyIn that the database rejected
+type CopyRowError struct {
+	// Row is the number of the row in the source, from 1
+	Row    int64
+	Values []interface{}
+	Err    error
+}
+
+// Error returns the error of the row with its number
+func (e CopyRowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// Unwrap returns the database error of the row
+func (e CopyRowError) Unwrap() error {
+	return e.Err
+}
+
+// CopyResult is the outcome of CopyIn
+type CopyResult struct {
+	// Rows is the number of rows inserted. When the copy fails, the rows
+	// inserted since the last commit are rolled back and not counted.
+	Rows int64
+	// Rejected are the rows the database rejected
+	Rejected []CopyRowError
+}
+
+// CopyIn inserts the rows of source into the columns of table, for loads
+// too large to go through Create. Rows are inserted in batches whose values
+// are bound as one array per column, so that the database runs a single
+// INSERT per batch, and are committed every CommitRows rows:
+//
+//	result, err := oracle.CopyIn(db.WithContext(ctx), "events", []string{"name", "created_at"}, source, &oracle.CopyOptions{
+//		BatchSize:  5000,
+//		CommitRows: 100000,
+//		MaxErrors:  100,
+//	})
+//	// INSERT INTO "events" ("name","created_at") VALUES (:1,:2)
+//
+// The rows the database rejects are collected in the result, up to
+// MaxErrors. Canceling the context of db stops the copy and rolls back the
+// rows inserted since the last commit.
+//
+// When db is in a transaction, the rows are inserted in it and neither
+// CommitRows nor DirectPath can be set. Batches whose values cannot be bound
+// as arrays, because a column mixes Go types, or drivers without ArrayDML
+// insert the rows one at a time.
+func CopyIn(db *gorm.DB, table string, columns []string, source CopySource, opts ...*CopyOptions) (CopyResult, error) {
+	var options CopyOptions
+	if len(opts) > 0 && opts[0] != nil {
+		options = *opts[0]
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultCopyBatchSize
+	}
+	if len(columns) == 0 {
+		return CopyResult{}, errors.New("copy requires at least one column")
+	}
+	_, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter)
+	if inTransaction && (options.CommitRows > 0 || options.DirectPath) {
+		return CopyResult{}, errors.New("copy cannot commit in the transaction of the session")
+	}
+
+	c := &copier{db: db, options: options, table: table, columns: columns, inTransaction: inTransaction}
+	err := c.copy(source)
+	return c.result, err
+}
+
+// copyInsertSQL returns the INSERT of a row into the columns of the table,
+// with the placeholders of national binds wrapped in UNISTR
+func copyInsertSQL(db *gorm.DB, table string, columns []string, directPath bool, national []bool) string {
+	var sql strings.Builder
+	sql.WriteString("INSERT ")
+	if directPath {
+		sql.WriteString("/*+ APPEND_VALUES */ ")
+	}
+	sql.WriteString("INTO ")
+	db.Dialector.QuoteTo(&sql, table)
+	sql.WriteString(" (")
+	for i, column := range columns {
+		if i > 0 {
+			sql.WriteByte(',')
+		}
+		db.Dialector.QuoteTo(&sql, column)
+	}
+	sql.WriteString(") VALUES (")
+	for i := range columns {
+		if i > 0 {
+			sql.WriteByte(',')
+		}
+		if national[i] {
+			sql.WriteString("UNISTR(:" + strconv.Itoa(i+1) + ")")
+		} else {
+			sql.WriteString(":" + strconv.Itoa(i+1))
+		}
+	}
+	sql.WriteByte(')')
+	return sql.String()
+}
+
+// copier runs a CopyIn
+type copier struct {
+	db            *gorm.DB
+	options       CopyOptions
+	table         string
+	columns       []string
+	inTransaction bool
+
+	tx      *gorm.DB
+	pending int64
+	result  CopyResult
+}
+
+// copy reads the source in batches, inserts them and commits them
+func (c *copier) copy(source CopySource) error {
+	if err := c.begin(); err != nil {
+		return err
+	}
+
+	var (
+		read        int64
+		uncommitted int
+		batch       = make([][]interface{}, 0, c.options.BatchSize)
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := c.insert(batch, read-int64(len(batch)))
+		uncommitted += len(batch)
+		batch = batch[:0]
+		if err != nil {
+			return err
+		}
+		if c.options.DirectPath || c.options.CommitRows > 0 && uncommitted >= c.options.CommitRows {
+			uncommitted = 0
+			if err := c.commit(); err != nil {
+				return err
+			}
+			return c.begin()
+		}
+		return nil
+	}
+
+	err := func() error {
+		for source.Next() {
+			values, err := source.Values()
+			if err != nil {
+				return err
+			}
+			if len(values) != len(c.columns) {
+				return fmt.Errorf("copy row %d has %d values for %d columns", read+1, len(values), len(c.columns))
+			}
+			read++
+			batch = append(batch, append([]interface{}(nil), values...))
+			if len(batch) == c.options.BatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := source.Err(); err != nil {
+			return err
+		}
+		return flush()
+	}()
+	if err != nil {
+		return errors.Join(err, c.rollback())
+	}
+	return c.commit()
+}
+
+// begin starts the transaction the next rows are inserted in
+func (c *copier) begin() error {
+	if c.inTransaction {
+		c.tx = c.db
+		return nil
+	}
+	c.tx = c.db.Session(&gorm.Session{NewDB: true}).Begin()
+	return c.tx.Error
+}
+
+// commit commits the rows inserted since the last commit, which are no
+// longer counted when the commit fails
+func (c *copier) commit() error {
+	if !c.inTransaction {
+		tx := c.tx
+		c.tx = nil
+		if err := tx.Commit().Error; err != nil {
+			c.result.Rows -= c.pending
+			c.pending = 0
+			return err
+		}
+	}
+	c.pending = 0
+	return nil
+}
+
+// rollback rolls back the rows inserted since the last commit, which are
+// then no longer counted
+func (c *copier) rollback() error {
+	c.result.Rows -= c.pending
+	c.pending = 0
+	if c.inTransaction || c.tx == nil {
+		return nil
+	}
+	if err := c.tx.Rollback().Error; err != nil && !errors.Is(err, gorm.ErrInvalidTransaction) {
+		return err
+	}
+	return nil
+}
+
+// insert inserts a batch of rows, the first of which is the row after
+// offset in the source
+func (c *copier) insert(batch [][]interface{}, offset int64) error {
+	if arrays, ok := c.arrays(batch); ok {
+		return c.insertArrays(batch, arrays, offset)
+	}
+	for i, row := range batch {
+		values := make([]interface{}, len(row))
+		for col, value := range row {
+			values[col] = convertValue(value)
+		}
+		_, err := c.exec(c.insertSQL(false, values), 1, values)
+		if err == nil {
+			c.inserted(1)
+			continue
+		}
+		if err := c.reject(batch, offset, i, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// arrays returns the values of each column of the batch as an array, when
+// the driver supports array DML and the values of each column are of one
+// type
+func (c *copier) arrays(batch [][]interface{}) ([]interface{}, bool) {
+	if !driverFeatures(c.db).ArrayDML {
+		return nil, false
+	}
+	arrays := make([]interface{}, len(c.columns))
+	values := make([]interface{}, len(batch))
+	for col := range arrays {
+		for row := range batch {
+			values[row] = convertValue(batch[row][col])
+		}
+		array, ok := insertArray(values)
+		if !ok {
+			return nil, false
+		}
+		arrays[col] = array
+	}
+	return arrays, true
+}
+
+// insertArrays inserts a batch with one INSERT binding the arrays of its
+// columns. When rows may be rejected, the driver inserts the other rows and
+// reports the rejected ones.
+func (c *copier) insertArrays(batch [][]interface{}, arrays []interface{}, offset int64) error {
+	partial := c.options.MaxErrors != 0 && !c.options.DirectPath
+	sql := c.insertSQL(c.options.DirectPath, arrays)
+	if partial {
+		arrays = append(arrays, godror.PartialBatch())
+	}
+	affected, err := c.exec(sql, len(batch), arrays)
+	if err == nil {
+		c.inserted(affected)
+		return nil
+	}
+
+	var batchErrors *godror.BatchErrors
+	if partial && errors.As(err, &batchErrors) {
+		c.inserted(int64(len(batchErrors.Affected)))
+		for _, oraErr := range batchErrors.Errs {
+			if err := c.reject(batch, offset, oraErr.Offset(), oraErr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("copy rows %d to %d: %w", offset+1, offset+int64(len(batch)), err)
+}
+
+// insertSQL returns the INSERT of the values, replacing the national binds
+// among them with their escaped form
+func (c *copier) insertSQL(directPath bool, values []interface{}) string {
+	national := make([]bool, len(values))
+	for i, value := range values {
+		values[i], national[i] = nationalBind(c.db, value)
+	}
+	return copyInsertSQL(c.db, c.table, c.columns, directPath, national)
+}
+
+// exec executes the INSERT of a number of rows and traces it
+func (c *copier) exec(sql string, rows int, args []interface{}) (int64, error) {
+	ctx := c.tx.Statement.Context
+	begin := time.Now()
+	result, err := c.tx.Statement.ConnPool.ExecContext(ctx, sql, args...)
+	var affected int64
+	if err == nil {
+		affected, _ = result.RowsAffected()
+	}
+	c.db.Logger.Trace(ctx, begin, func() (string, int64) {
+		if rows > 1 {
+			return fmt.Sprintf("%s /* %d rows */", sql, rows), affected
+		}
+		return sql, affected
+	}, err)
+	return affected, err
+}
+
+// inserted counts rows that were inserted and are not committed yet
+func (c *copier) inserted(rows int64) {
+	c.result.Rows += rows
+	c.pending += rows
+}
+
+// reject records the row at index idx of the batch as rejected with err,
+// and returns the error failing the copy when it must stop
+func (c *copier) reject(batch [][]interface{}, offset int64, idx int, err error) error {
+	if ctxErr := c.tx.Statement.Context.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if errors.Is(err, driver.ErrBadConn) {
+		return err
+	}
+	rowErr := CopyRowError{Row: offset + int64(idx) + 1, Values: batch[idx], Err: err}
+	c.result.Rejected = append(c.result.Rejected, rowErr)
+	if c.options.MaxErrors == 0 {
+		return rowErr
+	}
+	if c.options.MaxErrors > 0 && len(c.result.Rejected) > c.options.MaxErrors {
+		return fmt.Errorf("%w: %w", ErrCopyMaxErrors, rowErr)
+	}
+	return nil
+}
@@ -41,9 +41,12 @@ package oracle
 import (
 	"bytes"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/godror/godror"
 	"gorm.io/gorm"
@@ -60,18 +63,76 @@ type plsqlBindVariableMap struct {
 	variableMap map[string][]any
 }
 
+const ClauseInsertOnly = "INSERT_ONLY"
+
+// InsertOnlyMode makes Create skip the RETURNING clause and OUT binds it
+// adds for fields with default database values, such as auto-increment
+// primary keys. Those fields keep their zero values after the insert, and
+// batches are inserted with plain INSERT statements rather than PL/SQL
+// blocks, which suits ingestion that does not need the generated values.
+//
+//	db.Clauses(oracle.InsertOnly()).CreateInBatches(&events, 1000)
+//
+// Batches with LOB values still use PL/SQL so that every row binds the
+// same type. A RETURNING clause added explicitly is kept.
+type InsertOnlyMode struct {
+	// Disable turns insert-only mode off for a statement when
+	// Config.InsertOnly turns it on for every statement
+	Disable bool
+}
+
+// InsertOnly returns a clause enabling insert-only mode for a statement
+func InsertOnly() InsertOnlyMode {
+	return InsertOnlyMode{}
+}
+
+// Name returns the name of the clause
+func (InsertOnlyMode) Name() string {
+	return ClauseInsertOnly
+}
+
+// Build writes nothing, the clause only changes how Create builds the INSERT
+func (InsertOnlyMode) Build(clause.Builder) {}
+
+// MergeClause replaces any previous INSERT_ONLY clause
+func (m InsertOnlyMode) MergeClause(c *clause.Clause) {
+	c.Expression = m
+}
+
+// insertOnly reports whether Create should skip returning default values,
+// either because the statement has an INSERT_ONLY clause or because the
+// dialector enables insert-only mode
+func insertOnly(db *gorm.DB) bool {
+	if c, ok := db.Statement.Clauses[ClauseInsertOnly]; ok {
+		if m, ok := c.Expression.(InsertOnlyMode); ok {
+			return !m.Disable
+		}
+	}
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		return d.InsertOnly
+	case Dialector:
+		return d.InsertOnly
+	}
+	return false
+}
+
 // Create overrides GORM's create callback for Oracle.
 //
 // Behavior:
 //   - If the schema has fields with default DB values and only one row is
 //     being inserted, it builds an INSERT ... RETURNING statement.
-//   - If no RETURNING is needed, it emits a standard INSERT.
+//   - If no RETURNING is needed, it emits a standard INSERT. Multiple rows
+//     are bound as one array per column and inserted with array DML, unless
+//     a value is an expression or a column mixes types.
 //   - If multiple rows require RETURNING, it builds a PL/SQL block using
 //     FORALL and BULK COLLECT; if an ON CONFLICT clause is present and
 //     resolvable, it emits a MERGE.
 //   - For that last case, it validates Dest (non-nil, non-empty slice with
 //     no nil elements), normalizes bind variables for Oracle, and populates
 //     destinations from OUT parameters.
+//   - In insert-only mode (see InsertOnlyMode), default values are not
+//     returned and a standard INSERT is emitted.
 //
 // Register with:
 //
@@ -96,19 +157,31 @@ func Create(db *gorm.DB) {
 		}
 	}
 
+	skipReturning := insertOnly(db)
+
 	// SkipDefaultTransaction is here to distinguish the usage of DB.ToSQL
-	if stmtSchema != nil && len(stmtSchema.FieldsWithDefaultDBValue) > 0 && (!db.DryRun || (db.DryRun && db.SkipDefaultTransaction)) {
-		if _, ok := stmt.Clauses["RETURNING"]; !ok {
-			fromColumns := make([]clause.Column, 0, len(stmtSchema.FieldsWithDefaultDBValue))
-			for _, field := range stmtSchema.FieldsWithDefaultDBValue {
+	defaultFields := returnedDefaultFields(stmtSchema)
+	returnsAlways := false
+	if !skipReturning && stmtSchema != nil && (!db.DryRun || (db.DryRun && db.SkipDefaultTransaction)) {
+		if _, ok := stmt.Clauses["RETURNING"]; !ok && len(defaultFields) > 0 {
+			fromColumns := make([]clause.Column, 0, len(defaultFields))
+			for _, field := range defaultFields {
 				fromColumns = append(fromColumns, clause.Column{Name: field.DBName})
 			}
 			stmt.AddClause(clause.Returning{Columns: fromColumns})
 		}
+		returnsAlways = addReturningAlways(stmt)
 	}
 
 	if stmt.SQL.Len() == 0 {
 		createValues := callbacks.ConvertToCreateValues(stmt)
+		omitUpsertColumns(db)
+		nationalCreateValues(db, createValues)
+		if err := zeroTimeCreateValues(db, createValues); err != nil {
+			db.AddError(err)
+			return
+		}
+		wallClockCreateValues(db, createValues)
 
 		// Early validation for invalid data
 		if len(createValues.Values) == 0 {
@@ -139,23 +212,53 @@ func Create(db *gorm.DB) {
 		// Check if we need RETURNING clause for fields with default values
 		_, hasReturningClause := db.Statement.Clauses["RETURNING"]
 		hasReturningInDryRun := db.DryRun && hasReturningClause
-		needsReturning := stmtSchema != nil && len(stmtSchema.FieldsWithDefaultDBValue) > 0 && (!db.DryRun || hasReturningInDryRun)
+		needsReturning := stmtSchema != nil && (len(defaultFields) > 0 || returnsAlways) && (!db.DryRun || hasReturningInDryRun)
+		if skipReturning {
+			needsReturning = hasReturningClause
+		}
+
+		// Count the rows an upsert will update before running it
+		countMerge := countsMerge(stmt) && !db.DryRun
+		var matchedRows int64
+		if countMerge {
+			var err error
+			if matchedRows, err = countMatchedRows(db, createValues); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
 
 		// Pre-emptively map PL/SQL bind variables to check for LOBs
 		// If we have LOBs, we need to use PL/SQL for bulk inserts to ensure
 		// all values for a particular column are identically typed.
 		plsqlBindMap := mapPLSQLBindValues(createValues)
 
-		if (needsReturning || len(plsqlBindMap.lobColumns) > 0) && len(createValues.Values) > 1 {
+		// Upserts of rows with and without keys fill in the missing keys
+		// in PL/SQL before merging
+		_, hasMixedKeys := mixedKeys(stmt, createValues)
+		hasMixedKeys = hasMixedKeys && stmt.Clauses["ON CONFLICT"].Expression != nil
+
+		if (needsReturning || len(plsqlBindMap.lobColumns) > 0 || hasMixedKeys) && len(createValues.Values) > 1 {
 			// Multiple rows with RETURNING - use PL/SQL
+			if features := driverFeatures(db); !features.PLSQLArrays || (len(plsqlBindMap.lobColumns) > 0 && !features.LOBStreaming) {
+				db.AddError(fmt.Errorf("bulk create with RETURNING or LOB columns: %w", ErrUnsupportedByDriver))
+				return
+			}
 			buildBulkInsertPLSQL(db, createValues, plsqlBindMap)
 		} else if needsReturning {
 			// Single row with RETURNING - use regular SQL with RETURNING
 			buildSingleInsertSQL(db, createValues)
+		} else if arrayVars, ok := insertArrayValues(db, createValues); ok {
+			// Multiple rows without RETURNING - bind one array per column
+			buildArrayInsertSQL(db, createValues, arrayVars)
 		} else {
 			// No RETURNING needed - use standard INSERT
 			buildStandardInsertSQL(db, createValues)
 		}
+
+		if countMerge && db.Error == nil {
+			recordMergeResult(db, len(createValues.Values), matchedRows)
+		}
 	}
 }
 
@@ -250,7 +353,10 @@ func buildBulkInsertPLSQL(db *gorm.DB, createValues clause.Values, bindMap plsql
 	stmt := db.Statement
 	schema := stmt.Schema
 
-	if schema == nil {
+	// Maps created with Table have no schema, and only need PL/SQL to bind
+	// their LOB values, without returning any column
+	_, hasOnConflict := stmt.Clauses["ON CONFLICT"]
+	if schema == nil && (!isMapCreate(stmt) || hasOnConflict) {
 		db.AddError(fmt.Errorf("schema required for bulk insert with returning"))
 		return
 	}
@@ -276,8 +382,7 @@ func buildBulkInsertPLSQL(db *gorm.DB, createValues clause.Values, bindMap plsql
 	}
 
 	// Check if we have OnConflict clause
-	onConflictClause, hasOnConflict := stmt.Clauses["ON CONFLICT"]
-	if hasOnConflict {
+	if onConflictClause, ok := stmt.Clauses["ON CONFLICT"]; ok {
 		onConflict, ok := onConflictClause.Expression.(clause.OnConflict)
 		if !ok {
 			db.AddError(fmt.Errorf("invalid OnConflict clause"))
@@ -336,38 +441,41 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 		valuesColumnMap[strings.ToUpper(column.Name)] = true
 	}
 
-	// Filter conflict columns to remove non unique columns and columns not a part of the INSERT
-	var filteredConflictColumns []clause.Column
-	for _, conflictCol := range conflictColumns {
-		field := stmt.Schema.LookUpField(conflictCol.Name)
-		if valuesColumnMap[strings.ToUpper(conflictCol.Name)] && fieldCanConflict(field, sch) {
-			filteredConflictColumns = append(filteredConflictColumns, conflictCol)
-		}
-	}
+	// Match on a unique key made of conflict columns that are part of the INSERT
+	conflictColumns = conflictKey(sch, conflictColumns, valuesColumnMap)
 
 	// Check if we have any usable conflict columns
-	if len(filteredConflictColumns) == 0 {
+	if len(conflictColumns) == 0 {
 		buildBulkInsertOnlyPLSQL(db, createValues, bindMap)
 		return
 	}
 
-	// Use filtered conflict columns from here on
-	conflictColumns = filteredConflictColumns
+	// Return the requested columns of the merged rows
+	allColumns := plsqlReturningColumns(stmt, getReturnableFields(sch))
+	fields := columnFields(sch, allColumns)
 
 	var plsqlBuilder strings.Builder
 
 	// Start PL/SQL block
 	plsqlBuilder.WriteString("DECLARE\n")
-	writeTableRecordCollectionDecl(db, &plsqlBuilder, getCreatableFields(stmt.Schema), stmt.Table)
+	writeTableRecordCollectionDecl(db, &plsqlBuilder, allColumns, stmt.Table)
 	plsqlBuilder.WriteString("  l_affected_records t_records;\n")
 
 	// Create array types and variables for each column
 	for i, column := range createValues.Columns {
-		arrayType := getOracleArrayType(bindMap.variableMap[column.Name])
+		arrayType := columnArrayType(db, column.Name, bindMap.variableMap[column.Name])
 		plsqlBuilder.WriteString(fmt.Sprintf("  TYPE t_col_%d_array IS %s;\n", i, arrayType))
 		plsqlBuilder.WriteString(fmt.Sprintf("  l_col_%d_array t_col_%d_array;\n", i, i))
 	}
 
+	// Batches mixing rows with and without keys, as Save of new and loaded
+	// records gives, draw the missing keys from the identity sequence
+	keyField, mixed := mixedKeys(stmt, createValues)
+	if mixed {
+		// The quoted name of the sequence, with its owner
+		plsqlBuilder.WriteString("  l_identity_seq VARCHAR2(261);\n")
+	}
+
 	plsqlBuilder.WriteString("BEGIN\n")
 
 	// Initialize arrays with values
@@ -377,12 +485,16 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 			if j > 0 {
 				plsqlBuilder.WriteString(", ")
 			}
-			plsqlBuilder.WriteString(fmt.Sprintf(":%d", len(stmt.Vars)+1))
 			stmt.Vars = append(stmt.Vars, value)
+			writeBindVar(&plsqlBuilder, stmt)
 		}
 		plsqlBuilder.WriteString(");\n")
 	}
 
+	if mixed {
+		writeIdentityFill(db, &plsqlBuilder, createValues, keyField)
+	}
+
 	// FORALL with MERGE and RETURNING BULK COLLECT INTO
 	plsqlBuilder.WriteString(fmt.Sprintf("  FORALL i IN 1..%d\n", len(createValues.Values)))
 	plsqlBuilder.WriteString("    MERGE INTO ")
@@ -413,35 +525,40 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 	}
 	plsqlBuilder.WriteString(")\n")
 
-	// WHEN MATCHED THEN UPDATE (if DoUpdates specified)
-	if len(onConflict.DoUpdates) > 0 {
-		plsqlBuilder.WriteString("    WHEN MATCHED THEN UPDATE SET ")
-
-		// Build update assignments
-		updateCount := 0
-		for _, column := range createValues.Columns {
-			// Skip conflict columns in updates
-			isConflictColumn := false
-			for _, conflictCol := range conflictColumns {
-				if strings.EqualFold(column.Name, conflictCol.Name) {
-					isConflictColumn = true
-					break
-				}
+	// WHEN MATCHED THEN UPDATE (if DoUpdates specified), skipping conflict
+	// columns, which the ON clause references and Oracle does not allow to
+	// update, and columns the source rows do not have
+	var mergeUpdates clause.Set
+	for _, assignment := range onConflict.DoUpdates {
+		if column, ok := assignment.Value.(clause.Column); ok && column.Table == "excluded" &&
+			!valuesColumnMap[strings.ToUpper(column.Name)] {
+			continue
+		}
+		isConflictColumn := false
+		for _, conflictCol := range conflictColumns {
+			if strings.EqualFold(assignment.Column.Name, conflictCol.Name) {
+				isConflictColumn = true
+				break
 			}
+		}
+		if !isConflictColumn {
+			mergeUpdates = append(mergeUpdates, assignment)
+		}
+	}
 
-			if !isConflictColumn {
-				if updateCount > 0 {
-					plsqlBuilder.WriteString(", ")
-				}
-				plsqlBuilder.WriteString("t.")
-				db.QuoteTo(&plsqlBuilder, column.Name)
-				plsqlBuilder.WriteString(" = s.")
-				db.QuoteTo(&plsqlBuilder, column.Name)
-				updateCount++
+	if len(mergeUpdates) > 0 {
+		plsqlBuilder.WriteString("    WHEN MATCHED THEN UPDATE SET ")
+		for idx, assignment := range mergeUpdates {
+			if idx > 0 {
+				plsqlBuilder.WriteString(", ")
 			}
+			plsqlBuilder.WriteString("t.")
+			db.QuoteTo(&plsqlBuilder, assignment.Column.Name)
+			plsqlBuilder.WriteString(" = ")
+			writeMergeUpdateValue(db, &plsqlBuilder, assignment.Value)
 		}
 		plsqlBuilder.WriteString("\n")
-	} else if !onConflict.DoNothing {
+	} else if len(onConflict.DoUpdates) == 0 && !onConflict.DoNothing {
 		// Default behavior: update all non-conflict columns
 		plsqlBuilder.WriteString("    WHEN MATCHED THEN UPDATE SET ")
 
@@ -465,7 +582,13 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 				isAutoIncrement = true
 			}
 
-			if !isConflictColumn && !isAutoIncrement {
+			// Fields tagged `<-:create` keep their inserted values
+			isUpdatable := true
+			if field := stmt.Schema.LookUpField(column.Name); field != nil {
+				isUpdatable = field.Updatable
+			}
+
+			if !isConflictColumn && !isAutoIncrement && isUpdatable {
 				if updateCount > 0 {
 					plsqlBuilder.WriteString(", ")
 				}
@@ -505,7 +628,7 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 		// Add column names (excluding auto-increment primary key)
 		insertCount := 0
 		for _, column := range createValues.Columns {
-			if shouldIncludeColumnInInsert(stmt, column.Name) {
+			if shouldIncludeColumnInInsert(stmt, createValues, column.Name) {
 				if insertCount > 0 {
 					plsqlBuilder.WriteString(", ")
 				}
@@ -519,7 +642,7 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 		// Add values (excluding auto-increment primary key)
 		insertCount = 0
 		for _, column := range createValues.Columns {
-			if shouldIncludeColumnInInsert(stmt, column.Name) {
+			if shouldIncludeColumnInInsert(stmt, createValues, column.Name) {
 				if insertCount > 0 {
 					plsqlBuilder.WriteString(", ")
 				}
@@ -536,7 +659,7 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 		// Find at least one non-auto-increment column to satisfy Oracle syntax
 		insertCount := 0
 		for _, column := range createValues.Columns {
-			if shouldIncludeColumnInInsert(stmt, column.Name) {
+			if shouldIncludeColumnInInsert(stmt, createValues, column.Name) {
 				if insertCount > 0 {
 					plsqlBuilder.WriteString(", ")
 				}
@@ -549,7 +672,7 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 
 		insertCount = 0
 		for _, column := range createValues.Columns {
-			if shouldIncludeColumnInInsert(stmt, column.Name) {
+			if shouldIncludeColumnInInsert(stmt, createValues, column.Name) {
 				if insertCount > 0 {
 					plsqlBuilder.WriteString(", ")
 				}
@@ -563,21 +686,20 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 
 	// Add RETURNING clause with BULK COLLECT INTO
 	plsqlBuilder.WriteString("    RETURNING ")
-	allColumns := getMergableFields(sch)
 
 	for i, column := range allColumns {
 		if i > 0 {
 			plsqlBuilder.WriteString(", ")
 		}
-		db.QuoteTo(&plsqlBuilder, column)
+		quoteTo(db, &plsqlBuilder, column)
 	}
 	plsqlBuilder.WriteString("\n    BULK COLLECT INTO l_affected_records;\n")
 
 	// Add OUT parameter population (JSON serialized to CLOB)
 	outParamIndex := len(stmt.Vars)
 	for rowIdx := 0; rowIdx < len(createValues.Values); rowIdx++ {
-		for _, column := range allColumns {
-			if field := findFieldByDBName(sch, column); field != nil {
+		for colIdx, column := range allColumns {
+			if field := fields[colIdx]; field != nil {
 				if isJSONField(field) {
 					if isRawMessageField(field) {
 						// Column is a BLOB, return raw bytes; no JSON_SERIALIZE
@@ -586,7 +708,7 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 							"  IF l_affected_records.COUNT > %d THEN :%d := l_affected_records(%d).",
 							rowIdx, outParamIndex+1, rowIdx+1,
 						))
-						db.QuoteTo(&plsqlBuilder, column)
+						quoteTo(db, &plsqlBuilder, column)
 						plsqlBuilder.WriteString("; END IF;\n")
 					} else {
 						// datatypes.JSON (text-based) -> serialize to CLOB
@@ -595,7 +717,7 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 							"  IF l_affected_records.COUNT > %d THEN :%d := JSON_SERIALIZE(l_affected_records(%d).",
 							rowIdx, outParamIndex+1, rowIdx+1,
 						))
-						db.QuoteTo(&plsqlBuilder, column)
+						quoteTo(db, &plsqlBuilder, column)
 						plsqlBuilder.WriteString(" RETURNING CLOB); END IF;\n")
 					}
 				} else {
@@ -610,7 +732,7 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 					}
 					stmt.Vars = append(stmt.Vars, sql.Out{Dest: fieldType})
 					plsqlBuilder.WriteString(fmt.Sprintf("  IF l_affected_records.COUNT > %d THEN :%d := l_affected_records(%d).", rowIdx, outParamIndex+1, rowIdx+1))
-					db.QuoteTo(&plsqlBuilder, column)
+					quoteTo(db, &plsqlBuilder, column)
 					plsqlBuilder.WriteString("; END IF;\n")
 				}
 				outParamIndex++
@@ -624,33 +746,61 @@ func buildBulkMergePLSQL(db *gorm.DB, createValues clause.Values, onConflictClau
 	stmt.SQL.WriteString(plsqlBuilder.String())
 
 	if !db.DryRun && db.Error == nil {
-		result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+		result, err := unpreparedConnPool(stmt.ConnPool).ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
 		if db.AddError(err) == nil {
 			db.RowsAffected = int64(len(createValues.Values))
 			if stmt.Result != nil {
 				stmt.Result.Result = result
 				stmt.Result.RowsAffected = db.RowsAffected
 			}
-			getBulkReturningValues(db, len(createValues.Values))
+			getBulkReturningValues(db, len(createValues.Values), allColumns)
 		}
 	}
 }
 
+// writeMergeUpdateValue writes the value assigned by the WHEN MATCHED THEN
+// UPDATE branch of a bulk MERGE. The excluded columns GORM assigns for
+// UpdateAll and AssignmentColumns refer to the source row s, other values
+// are bound or built like in an UPDATE statement.
+func writeMergeUpdateValue(db *gorm.DB, plsqlBuilder *strings.Builder, value interface{}) {
+	stmt := db.Statement
+	if column, ok := value.(clause.Column); ok && column.Table == "excluded" {
+		plsqlBuilder.WriteString("s.")
+		db.QuoteTo(plsqlBuilder, column.Name)
+		return
+	}
+
+	valueStmt := &gorm.Statement{DB: db, Context: stmt.Context, Table: stmt.Table, Schema: stmt.Schema, Vars: stmt.Vars}
+	valueStmt.AddVar(valueStmt, value)
+	plsqlBuilder.WriteString(valueStmt.SQL.String())
+	stmt.Vars = valueStmt.Vars
+}
+
 // Build PL/SQL block for bulk INSERT only (no conflict handling)
 func buildBulkInsertOnlyPLSQL(db *gorm.DB, createValues clause.Values, bindMap plsqlBindVariableMap) {
 	stmt := db.Statement
 	sch := stmt.Schema
 
+	// Return the requested columns of the inserted rows
+	var allColumns []string
+	var fields []*schema.Field
+	if sch != nil {
+		allColumns = plsqlReturningColumns(stmt, getReturnableFields(sch))
+		fields = columnFields(sch, allColumns)
+	}
+
 	var plsqlBuilder strings.Builder
 
 	// Start PL/SQL block
 	plsqlBuilder.WriteString("DECLARE\n")
-	writeTableRecordCollectionDecl(db, &plsqlBuilder, getCreatableFields(stmt.Schema), stmt.Table)
-	plsqlBuilder.WriteString("  l_inserted_records t_records;\n")
+	if len(allColumns) > 0 {
+		writeTableRecordCollectionDecl(db, &plsqlBuilder, allColumns, stmt.Table)
+		plsqlBuilder.WriteString("  l_inserted_records t_records;\n")
+	}
 
 	// Create array types and variables for each column
 	for i, column := range createValues.Columns {
-		arrayType := getOracleArrayType(bindMap.variableMap[column.Name])
+		arrayType := columnArrayType(db, column.Name, bindMap.variableMap[column.Name])
 		plsqlBuilder.WriteString(fmt.Sprintf("  TYPE t_col_%d_array IS %s;\n", i, arrayType))
 		plsqlBuilder.WriteString(fmt.Sprintf("  l_col_%d_array t_col_%d_array;\n", i, i))
 	}
@@ -664,8 +814,8 @@ func buildBulkInsertOnlyPLSQL(db *gorm.DB, createValues clause.Values, bindMap p
 			if j > 0 {
 				plsqlBuilder.WriteString(", ")
 			}
-			plsqlBuilder.WriteString(fmt.Sprintf(":%d", len(stmt.Vars)+1))
 			stmt.Vars = append(stmt.Vars, value)
+			writeBindVar(&plsqlBuilder, stmt)
 		}
 		plsqlBuilder.WriteString(");\n")
 	}
@@ -691,28 +841,30 @@ func buildBulkInsertOnlyPLSQL(db *gorm.DB, createValues clause.Values, bindMap p
 		}
 		plsqlBuilder.WriteString(fmt.Sprintf("l_col_%d_array(i)", i))
 	}
-	plsqlBuilder.WriteString(")\n")
+	plsqlBuilder.WriteString(")")
 
 	// Add RETURNING clause with BULK COLLECT INTO
-	plsqlBuilder.WriteString("    RETURNING ")
-	allColumns := getCreatableFields(sch)
-	for i, column := range allColumns {
-		if i > 0 {
-			plsqlBuilder.WriteString(", ")
+	if len(allColumns) > 0 {
+		plsqlBuilder.WriteString("\n    RETURNING ")
+		for i, column := range allColumns {
+			if i > 0 {
+				plsqlBuilder.WriteString(", ")
+			}
+			quoteTo(db, &plsqlBuilder, column)
 		}
-		db.QuoteTo(&plsqlBuilder, column)
+		plsqlBuilder.WriteString("\n    BULK COLLECT INTO l_inserted_records")
 	}
-	plsqlBuilder.WriteString("\n    BULK COLLECT INTO l_inserted_records;\n")
+	plsqlBuilder.WriteString(";\n")
 
 	// Add OUT parameter population (JSON serialized to CLOB)
 	outParamIndex := len(stmt.Vars)
 	for rowIdx := 0; rowIdx < len(createValues.Values); rowIdx++ {
-		for _, column := range allColumns {
+		for colIdx, column := range allColumns {
 			var columnBuilder strings.Builder
-			db.QuoteTo(&columnBuilder, column)
+			quoteTo(db, &columnBuilder, column)
 			quotedColumn := columnBuilder.String()
 
-			if field := findFieldByDBName(sch, column); field != nil {
+			if field := fields[colIdx]; field != nil {
 				if isJSONField(field) {
 					if isRawMessageField(field) {
 						// Column is a BLOB, return raw bytes; no JSON_SERIALIZE
@@ -756,27 +908,124 @@ func buildBulkInsertOnlyPLSQL(db *gorm.DB, createValues clause.Values, bindMap p
 	stmt.SQL.WriteString(plsqlBuilder.String())
 
 	if !db.DryRun && db.Error == nil {
-		result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+		result, err := unpreparedConnPool(stmt.ConnPool).ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
 		if db.AddError(err) == nil {
 			db.RowsAffected = int64(len(createValues.Values))
 			if stmt.Result != nil {
 				stmt.Result.Result = result
 				stmt.Result.RowsAffected = db.RowsAffected
 			}
-			getBulkReturningValues(db, len(createValues.Values))
+			if len(allColumns) > 0 {
+				getBulkReturningValues(db, len(createValues.Values), allColumns)
+			}
 		}
 	}
 }
 
-// Helper function to determine if column should be included in INSERT
-func shouldIncludeColumnInInsert(stmt *gorm.Statement, columnName string) bool {
-	if stmt.Schema.PrioritizedPrimaryField != nil &&
+// Helper function to determine if column should be included in INSERT.
+// Auto-increment columns are left to their identity unless every row sets
+// them explicitly, as when loading rows with fixed IDs.
+func shouldIncludeColumnInInsert(stmt *gorm.Statement, createValues clause.Values, columnName string) bool {
+	isAutoIncrement := stmt.Schema.PrioritizedPrimaryField != nil &&
 		stmt.Schema.PrioritizedPrimaryField.AutoIncrement &&
-		strings.EqualFold(stmt.Schema.PrioritizedPrimaryField.DBName, columnName) {
-		return false
-	} else if stmt.Schema.LookUpField(columnName).AutoIncrement {
+		strings.EqualFold(stmt.Schema.PrioritizedPrimaryField.DBName, columnName)
+	if field := stmt.Schema.LookUpField(columnName); field != nil && field.AutoIncrement {
+		isAutoIncrement = true
+	}
+	if isAutoIncrement {
+		_, mixed := mixedKeys(stmt, createValues)
+		return mixed || hasExplicitValues(createValues, columnName)
+	}
+	return true
+}
+
+// mixedKeys returns the auto-increment primary key of a batch in which some
+// rows set the key and others leave it to the identity, as a Save of new and
+// loaded records does
+func mixedKeys(stmt *gorm.Statement, createValues clause.Values) (*schema.Field, bool) {
+	if stmt.Schema == nil || len(createValues.Values) < 2 {
+		return nil, false
+	}
+	field := stmt.Schema.PrioritizedPrimaryField
+	if field == nil || !field.AutoIncrement {
+		return nil, false
+	}
+	idx := slices.IndexFunc(createValues.Columns, func(c clause.Column) bool {
+		return strings.EqualFold(c.Name, field.DBName)
+	})
+	if idx < 0 || hasExplicitValues(createValues, field.DBName) {
+		return nil, false
+	}
+	return field, true
+}
+
+// writeIdentityFill writes the PL/SQL that sets the keys missing from the
+// array of the key column to values of the identity sequence of the table,
+// so that a single MERGE updates the rows with keys and inserts the others
+// with their new keys. Tables whose keys are set by triggers have no
+// identity sequence and keep the missing keys NULL.
+func writeIdentityFill(db *gorm.DB, plsqlBuilder *strings.Builder, createValues clause.Values, field *schema.Field) {
+	stmt := db.Statement
+	idx := slices.IndexFunc(createValues.Columns, func(c clause.Column) bool {
+		return strings.EqualFold(c.Name, field.DBName)
+	})
+
+	// The table of the statement may be qualified by its owner, whose
+	// identity columns are only listed in ALL_TAB_IDENTITY_COLS. gorm
+	// keeps that owner in the table expression only.
+	name := stmt.Table
+	if stmt.TableExpr != nil && len(stmt.TableExpr.Vars) == 0 && !strings.ContainsAny(stmt.TableExpr.SQL, " ?") {
+		name = stmt.TableExpr.SQL
+	}
+	owner, table := dictionaryObjectName(db, name)
+	_, column := dictionaryObjectName(db, field.DBName)
+
+	plsqlBuilder.WriteString("  BEGIN\n")
+	if owner == "" {
+		plsqlBuilder.WriteString("    SELECT '\"' || SEQUENCE_NAME || '\"' INTO l_identity_seq FROM USER_TAB_IDENTITY_COLS WHERE ")
+	} else {
+		plsqlBuilder.WriteString("    SELECT '\"' || OWNER || '\".\"' || SEQUENCE_NAME || '\"' INTO l_identity_seq FROM ALL_TAB_IDENTITY_COLS WHERE OWNER = ")
+		stmt.Vars = append(stmt.Vars, owner)
+		writeBindVar(plsqlBuilder, stmt)
+		plsqlBuilder.WriteString(" AND ")
+	}
+	plsqlBuilder.WriteString("TABLE_NAME = ")
+	stmt.Vars = append(stmt.Vars, table)
+	writeBindVar(plsqlBuilder, stmt)
+	plsqlBuilder.WriteString(" AND COLUMN_NAME = ")
+	stmt.Vars = append(stmt.Vars, column)
+	writeBindVar(plsqlBuilder, stmt)
+	plsqlBuilder.WriteString(";\n")
+	plsqlBuilder.WriteString("  EXCEPTION WHEN NO_DATA_FOUND THEN NULL;\n")
+	plsqlBuilder.WriteString("  END;\n")
+	plsqlBuilder.WriteString("  IF l_identity_seq IS NOT NULL THEN\n")
+	plsqlBuilder.WriteString(fmt.Sprintf("    FOR i IN 1..%d LOOP\n", len(createValues.Values)))
+	plsqlBuilder.WriteString(fmt.Sprintf("      IF l_col_%d_array(i) IS NULL THEN\n", idx))
+	plsqlBuilder.WriteString(fmt.Sprintf("        EXECUTE IMMEDIATE 'SELECT ' || l_identity_seq || '.NEXTVAL FROM DUAL' INTO l_col_%d_array(i);\n", idx))
+	plsqlBuilder.WriteString("      END IF;\n")
+	plsqlBuilder.WriteString("    END LOOP;\n")
+	plsqlBuilder.WriteString("  END IF;\n")
+}
+
+// hasExplicitValues reports whether every row sets a value for the column,
+// rather than leaving it NULL or to its database default
+func hasExplicitValues(createValues clause.Values, columnName string) bool {
+	idx := slices.IndexFunc(createValues.Columns, func(c clause.Column) bool {
+		return strings.EqualFold(c.Name, columnName)
+	})
+	if idx < 0 {
 		return false
 	}
+	for _, values := range createValues.Values {
+		switch v := values[idx].(type) {
+		case nil, clause.Expr:
+			return false
+		case driver.Valuer:
+			if value, err := v.Value(); err == nil && value == nil {
+				return false
+			}
+		}
+	}
 	return true
 }
 
@@ -848,6 +1097,277 @@ func buildStandardInsertSQL(db *gorm.DB, createValues clause.Values) {
 	}
 }
 
+// insertArrayValues returns the values of each column as a typed slice that
+// the driver binds as an array, so that the rows are inserted by executing
+// one statement for the whole batch instead of parsing a VALUES list that
+// grows with the batch. It reports false when array DML can't be used: for a
+// single row, an ON CONFLICT clause that is not merged per row, expressions,
+// or values whose column has no common array type.
+func insertArrayValues(db *gorm.DB, createValues clause.Values) ([]any, bool) {
+	if len(createValues.Values) < 2 || len(createValues.Columns) == 0 || !driverFeatures(db).ArrayDML {
+		return nil, false
+	}
+	if _, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		if _, ok := arrayMergeConflictColumns(db.Statement, createValues); !ok {
+			return nil, false
+		}
+	}
+
+	arrays := make([]any, len(createValues.Columns))
+	values := make([]any, len(createValues.Values))
+	for col := range createValues.Columns {
+		for row, rowValues := range createValues.Values {
+			if _, ok := rowValues[col].(clause.Expression); ok {
+				return nil, false
+			}
+			values[row] = convertValue(rowValues[col])
+		}
+		array, ok := insertArray(values)
+		if !ok {
+			return nil, false
+		}
+		arrays[col] = array
+	}
+	return arrays, true
+}
+
+// insertArray converts the values of a column to a slice of one type that the
+// driver binds as an array, with NULL for nil values
+func insertArray(values []any) (any, bool) {
+	kind, national, wallClocked := "", false, false
+	for _, value := range values {
+		if isNilValue(value) {
+			continue
+		}
+		if _, ok := value.(NString); ok {
+			national = true
+		}
+		if _, ok := value.(wallClock); ok {
+			wallClocked = true
+		}
+		var k string
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Bool:
+			k = "bool"
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			k = "int"
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if rv.Uint() > 1<<63-1 {
+				return nil, false
+			}
+			k = "int"
+		case reflect.Float32, reflect.Float64:
+			k = "float"
+		case reflect.String:
+			k = "string"
+		case reflect.Slice:
+			if rv.Type().Elem().Kind() != reflect.Uint8 {
+				return nil, false
+			}
+			k = "raw"
+		default:
+			if _, ok := value.(time.Time); !ok {
+				return nil, false
+			}
+			k = "time"
+		}
+		switch {
+		case kind == "" || kind == k:
+			kind = k
+		case kind == "int" && k == "float", kind == "float" && k == "int":
+			kind = "float"
+		case kind == "int" && k == "bool", kind == "bool" && k == "int":
+			kind = "int"
+		default:
+			return nil, false
+		}
+	}
+
+	switch kind {
+	case "int", "bool":
+		array := make([]sql.NullInt64, len(values))
+		for i, value := range values {
+			if !isNilValue(value) {
+				rv := reflect.ValueOf(value)
+				switch {
+				case rv.Kind() == reflect.Bool:
+					array[i] = sql.NullInt64{Valid: true}
+					if rv.Bool() {
+						array[i].Int64 = 1
+					}
+				case rv.CanInt():
+					array[i] = sql.NullInt64{Int64: rv.Int(), Valid: true}
+				default:
+					array[i] = sql.NullInt64{Int64: int64(rv.Uint()), Valid: true}
+				}
+			}
+		}
+		return array, true
+	case "float":
+		array := make([]sql.NullFloat64, len(values))
+		for i, value := range values {
+			if !isNilValue(value) {
+				rv := reflect.ValueOf(value)
+				switch {
+				case rv.CanFloat():
+					array[i] = sql.NullFloat64{Float64: rv.Float(), Valid: true}
+				case rv.CanInt():
+					array[i] = sql.NullFloat64{Float64: float64(rv.Int()), Valid: true}
+				default:
+					array[i] = sql.NullFloat64{Float64: float64(rv.Uint()), Valid: true}
+				}
+			}
+		}
+		return array, true
+	case "raw":
+		array := make([][]byte, len(values))
+		for i, value := range values {
+			if !isNilValue(value) {
+				array[i] = reflect.ValueOf(value).Bytes()
+			}
+		}
+		return array, true
+	case "time":
+		array := make([]sql.NullTime, len(values))
+		for i, value := range values {
+			if !isNilValue(value) {
+				array[i] = sql.NullTime{Time: value.(time.Time), Valid: true}
+			}
+		}
+		return array, true
+	}
+
+	// Strings, and columns that are NULL in every row; Oracle stores the
+	// empty string as NULL
+	array := make([]string, len(values))
+	for i, value := range values {
+		if !isNilValue(value) {
+			array[i] = reflect.ValueOf(value).String()
+		}
+	}
+	if national {
+		return nationalStrings(array), true
+	}
+	if wallClocked {
+		return wallClocks(array), true
+	}
+	return array, true
+}
+
+// isNilValue reports whether a converted value is nil or a nil pointer
+func isNilValue(value any) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// arrayMergeConflictColumns returns the conflict columns of an upsert that
+// can be merged one row at a time with array DML, as the rows of the many2many
+// join tables of associations are: every conflict column is inserted, and
+// the updates, if any, only set columns to their inserted values.
+func arrayMergeConflictColumns(stmt *gorm.Statement, createValues clause.Values) ([]clause.Column, bool) {
+	onConflict, ok := stmt.Clauses["ON CONFLICT"].Expression.(clause.OnConflict)
+	if !ok || stmt.Schema == nil || onConflict.Where.Exprs != nil || onConflict.TargetWhere.Exprs != nil {
+		return nil, false
+	}
+	if len(onConflict.DoUpdates) == 0 && !onConflict.DoNothing {
+		return nil, false
+	}
+	for _, assignment := range onConflict.DoUpdates {
+		if column, ok := assignment.Value.(clause.Column); !ok || column.Table != "excluded" {
+			return nil, false
+		}
+	}
+
+	conflictColumns := onConflict.Columns
+	if len(conflictColumns) == 0 {
+		for _, field := range stmt.Schema.PrimaryFields {
+			conflictColumns = append(conflictColumns, clause.Column{Name: field.DBName})
+		}
+	}
+	if len(conflictColumns) == 0 {
+		return nil, false
+	}
+	for _, conflictColumn := range conflictColumns {
+		if !slices.ContainsFunc(createValues.Columns, func(column clause.Column) bool {
+			return strings.EqualFold(column.Name, conflictColumn.Name)
+		}) {
+			return nil, false
+		}
+	}
+	return conflictColumns, true
+}
+
+// arrayVar is a bind variable holding an array, which GORM would otherwise
+// expand into a list of bind variables
+type arrayVar struct {
+	array any
+}
+
+// Build binds the array
+func (a arrayVar) Build(builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		stmt.Vars = append(stmt.Vars, a.array)
+		stmt.DB.Dialector.BindVarTo(stmt, stmt, a.array)
+	}
+}
+
+// Build INSERT binding one array per column, which the driver executes once
+// for every row. An upsert is built as a MERGE of a single row of arrays.
+func buildArrayInsertSQL(db *gorm.DB, createValues clause.Values, arrayVars []any) {
+	stmt := db.Statement
+
+	if conflictColumns, ok := arrayMergeConflictColumns(stmt, createValues); ok {
+		row := make([]interface{}, len(arrayVars))
+		for i, array := range arrayVars {
+			row[i] = arrayVar{array: array}
+		}
+		onConflict := stmt.Clauses["ON CONFLICT"].Expression.(clause.OnConflict)
+		buildMergeInClause(stmt, onConflict, clause.Values{Columns: createValues.Columns, Values: [][]interface{}{row}}, conflictColumns)
+		execArrayDML(db)
+		return
+	}
+
+	stmt.AddClauseIfNotExists(clause.Insert{})
+	stmt.Build("INSERT")
+	stmt.WriteString(" (")
+	for i, column := range createValues.Columns {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteQuoted(column)
+	}
+	stmt.WriteString(") VALUES (")
+	for i, array := range arrayVars {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.Vars = append(stmt.Vars, array)
+		db.Dialector.BindVarTo(stmt, stmt, array)
+	}
+	stmt.WriteByte(')')
+	execArrayDML(db)
+}
+
+// execArrayDML executes the statement with its array binds once, the driver
+// running it for every element of the arrays
+func execArrayDML(db *gorm.DB) {
+	stmt := db.Statement
+	if !db.DryRun && db.Error == nil {
+		result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+		if db.AddError(err) == nil {
+			db.RowsAffected, _ = result.RowsAffected()
+			if stmt.Result != nil {
+				stmt.Result.Result = result
+				stmt.Result.RowsAffected = db.RowsAffected
+			}
+		}
+	}
+}
+
 // Handle single row RETURNING results
 func handleSingleRowReturning(db *gorm.DB) {
 
@@ -903,8 +1423,14 @@ func handleSingleRowReturning(db *gorm.DB) {
 		if outParam, ok := db.Statement.Vars[i].(sql.Out); ok {
 
 			if outIndex < len(returning.Columns) {
-				columnName := returning.Columns[outIndex].Name
-				field := findFieldByDBName(db.Statement.Schema, columnName)
+				key := returningKey(returning.Columns[outIndex])
+				field := findFieldByDBName(db.Statement.Schema, key)
+
+				if values := returningValues(db.Statement); field == nil && values != nil && outParam.Dest != nil {
+					if destValue := reflect.ValueOf(outParam.Dest); destValue.Kind() == reflect.Ptr && !destValue.IsNil() {
+						values[key] = destValue.Elem().Interface()
+					}
+				}
 
 				if field != nil && outParam.Dest != nil {
 					// Extract the actual value from the OUT parameter destination
@@ -933,11 +1459,13 @@ func handleSingleRowReturning(db *gorm.DB) {
 	}
 }
 
-// Handle bulk RETURNING results for PL/SQL operations
-func getBulkReturningValues(db *gorm.DB, rowCount int) {
+// Handle bulk RETURNING results for PL/SQL operations, whose OUT parameters
+// hold the given columns for each row
+func getBulkReturningValues(db *gorm.DB, rowCount int, allColumns []string) {
 	if db.Statement.Schema == nil {
 		return
 	}
+	fields := columnFields(db.Statement.Schema, allColumns)
 
 	// Get target slice to populate
 	targetValue := db.Statement.ReflectValue
@@ -959,9 +1487,6 @@ func getBulkReturningValues(db *gorm.DB, rowCount int) {
 		targetValue.Set(newSlice)
 	}
 
-	// Get all table columns
-	allColumns := getCreatableFields(db.Statement.Schema)
-
 	// Find the actual starting index of OUT parameters
 	actualStartIndex := -1
 	for i := 0; i < len(db.Statement.Vars); i++ {
@@ -984,12 +1509,12 @@ func getBulkReturningValues(db *gorm.DB, rowCount int) {
 			targetElement = targetElement.Elem()
 		}
 
-		for colIdx, column := range allColumns {
+		for colIdx := range allColumns {
 			paramIndex := actualStartIndex + (rowIdx * len(allColumns)) + colIdx
 
 			if paramIndex < len(db.Statement.Vars) {
 				if outParam, ok := db.Statement.Vars[paramIndex].(sql.Out); ok {
-					if field := findFieldByDBName(db.Statement.Schema, column); field != nil && outParam.Dest != nil {
+					if field := fields[colIdx]; field != nil && outParam.Dest != nil {
 						destValue := reflect.ValueOf(outParam.Dest)
 						if destValue.Kind() == reflect.Ptr && !destValue.IsNil() {
 							actualValue := destValue.Elem().Interface()
@@ -1063,7 +1588,9 @@ func sanitizeCreateValuesForBulkArrays(stmt *gorm.Statement, cv *clause.Values)
 			v := cv.Values[r][c]
 			switch v.(type) {
 			case clause.Expr:
-				if f := findFieldByDBName(stmt.Schema, col.Name); f != nil {
+				if stmt.Schema == nil {
+					cv.Values[r][c] = nil
+				} else if f := findFieldByDBName(stmt.Schema, col.Name); f != nil {
 					switch f.DataType {
 					case schema.Int, schema.Uint:
 						cv.Values[r][c] = sql.NullInt64{}
@@ -1093,30 +1620,50 @@ func pluck[T any, N int](data [][]T, col int) []T {
 	return out
 }
 
-// fieldCanConflict checks if a field can be used as a conflict target in PL/SQL MERGE statements.
-// A field can be used as a conflict target if it is contains unique values. This includes primary key fields
-// and unique fields. However, in cases of composite primary keys where the identity column is auto-incremented,
-// even a primary key field cannot be used as a conflict target, as the auto-incremented primary key will ensure
-// a unique row.
-func fieldCanConflict(field *schema.Field, schema *schema.Schema) bool {
-	if field.PrimaryKey {
-		if schema != nil && schema.PrioritizedPrimaryField != nil && schema.PrioritizedPrimaryField.AutoIncrement {
-			// If the auto-incremented primary key is among the createValues, then it *can* be a conflict target
-			if schema.PrioritizedPrimaryField.Name == field.Name {
-				return true
+// conflictKey returns the columns of the first unique key of the schema
+// that is made only of conflict columns set by the INSERT, or nil if there is
+// none, in which case a MERGE could not tell existing rows apart. The keys
+// are the primary key, auto-increment and unique fields, and unique indexes;
+// primary keys and unique indexes may span several columns.
+func conflictKey(sch *schema.Schema, conflictColumns []clause.Column, insertedColumns map[string]bool) []clause.Column {
+	candidates := make(map[string]bool, len(conflictColumns))
+	for _, column := range conflictColumns {
+		if name := strings.ToUpper(column.Name); insertedColumns[name] {
+			candidates[name] = true
+		}
+	}
+
+	var keys [][]string
+	var primaryKey []string
+	for _, field := range sch.PrimaryFields {
+		primaryKey = append(primaryKey, field.DBName)
+	}
+	keys = append(keys, primaryKey)
+	for _, field := range sch.Fields {
+		// Identity values are unique even when the column is only part of
+		// a composite primary key
+		if field.DBName != "" && (field.AutoIncrement || field.Unique) {
+			keys = append(keys, []string{field.DBName})
+		}
+	}
+	for _, idx := range sch.ParseIndexes() {
+		if idx.Class == "UNIQUE" {
+			var key []string
+			for _, option := range idx.Fields {
+				key = append(key, option.DBName)
 			}
-			return false
+			keys = append(keys, key)
 		}
-		for _, primaryField := range schema.PrimaryFields {
-			if primaryField.AutoIncrement {
-				// If the auto-incremented primary key is among the createValues, then it *can* be a conflict target
-				if primaryField.Name == field.Name {
-					return true
-				}
-				return false
+	}
+
+	for _, key := range keys {
+		if len(key) > 0 && !slices.ContainsFunc(key, func(name string) bool { return !candidates[strings.ToUpper(name)] }) {
+			columns := make([]clause.Column, len(key))
+			for i, name := range key {
+				columns[i] = clause.Column{Name: name}
 			}
+			return columns
 		}
-		return true
 	}
-	return field.Unique
+	return nil
 }
@@ -0,0 +1,96 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// databaseTimeConfigKey is the instance key of the configuration that
+// DatabaseTimeHandler replaced for the statement
+const databaseTimeConfigKey = "oracle:database_time_config"
+
+// DatabaseTimeHandler makes the statement's creation, update and soft delete
+// times the SYSTIMESTAMP of the database rather than the clock of the
+// application host. It reads SYSTIMESTAMP once per statement, so every row
+// of a batch gets the same time, and converts it to the location of the
+// times returned by NowFunc.
+func DatabaseTimeHandler(db *gorm.DB) {
+	if db.Error != nil || db.DryRun || db.Statement.Schema == nil || !hasAutoTimeField(db.Statement.Schema) {
+		return
+	}
+
+	var now time.Time
+	row := db.Statement.ConnPool.QueryRowContext(db.Statement.Context, "SELECT SYSTIMESTAMP FROM DUAL")
+	if err := row.Scan(&now); err != nil {
+		db.AddError(err)
+		return
+	}
+	now = now.In(db.NowFunc().Location())
+
+	db.InstanceSet(databaseTimeConfigKey, db.Config)
+	config := *db.Config
+	config.NowFunc = func() time.Time { return now }
+	db.Config = &config
+}
+
+// restoreDatabaseTime restores the configuration DatabaseTimeHandler
+// replaced, so that the NowFunc of the statement does not outlive it
+func restoreDatabaseTime(db *gorm.DB) {
+	if config, ok := db.InstanceGet(databaseTimeConfigKey); ok {
+		db.Config = config.(*gorm.Config)
+	}
+}
+
+// hasAutoTimeField reports whether the schema has fields set from NowFunc:
+// creation and update times and soft delete times
+func hasAutoTimeField(sch *schema.Schema) bool {
+	for _, field := range sch.Fields {
+		if field.AutoCreateTime > 0 || field.AutoUpdateTime > 0 {
+			return true
+		}
+		if field.FieldType == reflect.TypeOf(gorm.DeletedAt{}) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,268 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// onlineDDL reports whether the dialector of the database builds and drops
+// indexes ONLINE
+func onlineDDL(db *gorm.DB) bool {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		return d.Config != nil && d.OnlineDDL
+	case Dialector:
+		return d.Config != nil && d.OnlineDDL
+	}
+	return false
+}
+
+// dictionaryName returns the condition matching the name column of a data
+// dictionary view with a bind, ignoring case with CaseInsensitiveNames
+func dictionaryName(db *gorm.DB, column string) string {
+	caseInsensitive := false
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		caseInsensitive = d.Config != nil && d.CaseInsensitiveNames
+	case Dialector:
+		caseInsensitive = d.Config != nil && d.CaseInsensitiveNames
+	}
+	if caseInsensitive {
+		return "UPPER(" + column + ") = UPPER(?)"
+	}
+	return column + " = ?"
+}
+
+// dictionaryObjectName returns the owner, if any, and the name of a table
+// or column as stored in the data dictionary. Names are written quoted,
+// keeping their case, unless SkipQuoteIdentifiers is set, in which case
+// Oracle stores the unquoted names in uppercase.
+func dictionaryObjectName(db *gorm.DB, name string) (owner, object string) {
+	skip, _ := skipQuoteIdentifiers(db)
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if strings.HasPrefix(part, `"`) {
+			parts[i] = strings.Trim(part, `"`)
+		} else if skip {
+			parts[i] = strings.ToUpper(part)
+		}
+	}
+	if len(parts) > 1 {
+		return parts[len(parts)-2], parts[len(parts)-1]
+	}
+	return "", parts[0]
+}
+
+// dropTableOrder reports whether the dialector of the database orders
+// DropTable by the foreign keys of the data dictionary
+func dropTableOrder(db *gorm.DB) bool {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		return d.Config != nil && d.DropTableOrder
+	case Dialector:
+		return d.Config != nil && d.DropTableOrder
+	}
+	return false
+}
+
+// uniqueIndexes reports whether the dialector of the database implements
+// unique tags as unique indexes
+func uniqueIndexes(db *gorm.DB) bool {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		return d.Config != nil && d.UniqueIndexes
+	case Dialector:
+		return d.Config != nil && d.UniqueIndexes
+	}
+	return false
+}
+
+// ddlLockTimeout returns the DDL lock timeout of the dialector of the database
+func ddlLockTimeout(db *gorm.DB) time.Duration {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		if d.Config != nil {
+			return d.DDLLockTimeout
+		}
+	case Dialector:
+		if d.Config != nil {
+			return d.DDLLockTimeout
+		}
+	}
+	return 0
+}
+
+// migrationLock returns the migration lock of the dialector of the database
+func migrationLock(db *gorm.DB) *MigrationLock {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		if d.Config != nil {
+			return d.MigrationLock
+		}
+	case Dialector:
+		if d.Config != nil {
+			return d.MigrationLock
+		}
+	}
+	return nil
+}
+
+// DefaultMigrationLockName is the name of the migration lock when
+// MigrationLock.Name is empty
+const DefaultMigrationLockName = "GORM_MIGRATION"
+
+// MigrationLock serializes AutoMigrate across processes with a DBMS_LOCK
+// user lock, so that application instances starting together don't race on
+// the same DDL and fail with ORA-00955 or ORA-01430. The user needs EXECUTE
+// on DBMS_LOCK.
+type MigrationLock struct {
+	// Name identifies the lock, DefaultMigrationLockName if empty. Processes
+	// migrating independent schemas can use different names.
+	Name string
+	// Timeout is how long AutoMigrate waits for the lock, forever if zero
+	Timeout time.Duration
+}
+
+// acquire takes the lock in exclusive mode on the session of tx and returns
+// its handle. The lock outlives the commits of the DDL statements.
+func (l *MigrationLock) acquire(tx *gorm.DB) (string, error) {
+	name := l.Name
+	if name == "" {
+		name = DefaultMigrationLockName
+	}
+	wait := 32767 // DBMS_LOCK.MAXWAIT
+	if l.Timeout > 0 {
+		wait = int(math.Ceil(l.Timeout.Seconds()))
+	}
+
+	var handle string
+	var status int
+	if err := tx.Exec(`DECLARE
+	h VARCHAR2(128);
+BEGIN
+	DBMS_LOCK.ALLOCATE_UNIQUE(?, h);
+	? := DBMS_LOCK.REQUEST(h, DBMS_LOCK.X_MODE, ?, FALSE);
+	? := h;
+END;`, name, sql.Out{Dest: &status}, wait, sql.Out{Dest: &handle}).Error; err != nil {
+		return "", err
+	}
+
+	switch status {
+	case 0, 4: // granted, or already held by this session
+		return handle, nil
+	case 1:
+		return "", fmt.Errorf("timed out after %v waiting for migration lock %s", l.Timeout, name)
+	default:
+		return "", fmt.Errorf("failed to acquire migration lock %s: DBMS_LOCK.REQUEST returned %d", name, status)
+	}
+}
+
+// release releases the lock acquired with the given handle
+func (l *MigrationLock) release(tx *gorm.DB, handle string) error {
+	var status int
+	if err := tx.Exec("BEGIN ? := DBMS_LOCK.RELEASE(?); END;", sql.Out{Dest: &status}, handle).Error; err != nil {
+		return err
+	}
+	if status != 0 && status != 4 {
+		return fmt.Errorf("failed to release migration lock: DBMS_LOCK.RELEASE returned %d", status)
+	}
+	return nil
+}
+
+// migrationSessionKey marks sessions prepared by withMigrationLock, whose
+// nested migrations run on them as they are
+const migrationSessionKey = "oracle:migration_session"
+
+// withMigrationSession runs fc on a single session prepared for DDL. With
+// DDL_LOCK_TIMEOUT set, statements wait for the locks of busy tables instead
+// of failing at once with ORA-00054, and with a MigrationLock the session
+// holds it throughout. The session is restored afterwards.
+func withMigrationSession(db *gorm.DB, fc func(tx *gorm.DB) error) error {
+	return withMigrationLock(db, migrationLock(db), fc)
+}
+
+// withMigrationLock is withMigrationSession holding the given lock
+func withMigrationLock(db *gorm.DB, lock *MigrationLock, fc func(tx *gorm.DB) error) error {
+	if _, ok := db.Get(migrationSessionKey); ok {
+		return fc(db)
+	}
+	timeout := ddlLockTimeout(db)
+	if timeout <= 0 && lock == nil {
+		return fc(db)
+	}
+
+	run := func(tx *gorm.DB) (err error) {
+		if lock != nil {
+			handle, err := lock.acquire(tx)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, lock.release(tx, handle))
+			}()
+		}
+		session := tx.Set(migrationSessionKey, true).Session(&gorm.Session{})
+
+		if timeout <= 0 {
+			return fc(session)
+		}
+
+		seconds := int(math.Ceil(timeout.Seconds()))
+		if err := tx.Exec(fmt.Sprintf("ALTER SESSION SET DDL_LOCK_TIMEOUT = %d", seconds)).Error; err != nil {
+			return err
+		}
+
+		err = fc(session)
+		return errors.Join(err, tx.Exec("ALTER SESSION SET DDL_LOCK_TIMEOUT = 0").Error)
+	}
+
+	// A transaction already holds its session
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return run(db)
+	}
+	return db.Connection(run)
+}
@@ -0,0 +1,176 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrMaxRowsExceeded is returned by queries of a session with
+// QueryDefaults.MaxRows that match more rows than the maximum
+var ErrMaxRowsExceeded = errors.New("query matches more rows than the maximum of the session")
+
+const (
+	// queryDefaultsKey is the setting holding the QueryDefaults of a session
+	queryDefaultsKey = "oracle:query_defaults"
+	// maxRowsKey marks the statements whose rows QueryDefaultsHandler capped
+	maxRowsKey = "oracle:max_rows"
+)
+
+// QueryDefaults are defaults that WithQueryDefaults applies to the queries
+// of a session, such as those of an interactive application that should
+// neither wait for nor load a whole table by accident
+type QueryDefaults struct {
+	// Hints are optimizer hints, such as FIRST_ROWS(100), added to the
+	// SELECTs that have no hint of their own, such as a PARALLEL clause
+	Hints []string
+	// MaxRows caps the rows of the queries into slices without a limit.
+	// Such a query
+	// fetches at most one row more than MaxRows, and when the extra row is
+	// found, the destination is cut to MaxRows records and the query
+	// returns ErrMaxRowsExceeded. Zero sets no cap.
+	MaxRows int
+}
+
+// WithQueryDefaults returns a session whose queries, built with Find, First,
+// Pluck or Count, get the given defaults. Queries built with Raw and
+// locking queries are left as they are, and FindEach, which does not hold
+// the rows it finds, only gets the hints.
+//
+//	interactive := oracle.WithQueryDefaults(db, oracle.QueryDefaults{
+//		Hints:   []string{"FIRST_ROWS(100)"},
+//		MaxRows: 10000,
+//	})
+//	err := interactive.Where(`"status" = ?`, "open").Find(&orders).Error
+//	// SELECT /*+ FIRST_ROWS(100) */ * FROM "orders" WHERE "status" = :1 FETCH NEXT 10001 ROWS ONLY
+//	if errors.Is(err, oracle.ErrMaxRowsExceeded) {
+//		// orders holds the first 10000 orders
+//	}
+func WithQueryDefaults(db *gorm.DB, defaults QueryDefaults) *gorm.DB {
+	return db.Set(queryDefaultsKey, defaults).Session(&gorm.Session{})
+}
+
+// queryDefaults returns the QueryDefaults of the session of db, if any
+func queryDefaults(db *gorm.DB) (QueryDefaults, bool) {
+	if v, ok := db.Get(queryDefaultsKey); ok {
+		if defaults, ok := v.(QueryDefaults); ok {
+			return defaults, true
+		}
+	}
+	return QueryDefaults{}, false
+}
+
+// optimizerHints writes hints in a hint comment
+type optimizerHints []string
+
+// Build writes the hint comment
+func (h optimizerHints) Build(builder clause.Builder) {
+	builder.WriteString("/*+ ")
+	builder.WriteString(strings.Join(h, " "))
+	builder.WriteString(" */")
+}
+
+// QueryDefaultsHandler adds the hints and the row cap of the QueryDefaults
+// of the session to a query. It is registered for every dialector and only
+// changes the queries of sessions made by WithQueryDefaults.
+func QueryDefaultsHandler(db *gorm.DB) {
+	defaults, ok := queryDefaults(db)
+	stmt := db.Statement
+	if !ok || db.Error != nil || stmt.SQL.Len() > 0 {
+		return
+	}
+	if defaults.MaxRows < 0 {
+		db.AddError(fmt.Errorf("invalid maximum number of rows %d", defaults.MaxRows))
+		return
+	}
+
+	if len(defaults.Hints) > 0 {
+		for _, hint := range defaults.Hints {
+			if strings.Contains(hint, "*/") {
+				db.AddError(fmt.Errorf("invalid optimizer hint %q", hint))
+				return
+			}
+		}
+		c := stmt.Clauses["SELECT"]
+		if _, parallel := parallelHint(stmt); c.AfterNameExpression == nil && !parallel {
+			c.Name = "SELECT"
+			c.AfterNameExpression = optimizerHints(defaults.Hints)
+			stmt.Clauses["SELECT"] = c
+		}
+	}
+
+	// Only slices hold more than one row, queries into a model or a count
+	// are left without a limit
+	if defaults.MaxRows > 0 && reflect.Indirect(reflect.ValueOf(stmt.Dest)).Kind() == reflect.Slice {
+		if _, locking := stmt.Clauses["FOR"]; locking {
+			return
+		}
+		limit, _ := stmt.Clauses["LIMIT"].Expression.(clause.Limit)
+		if limit.Limit == nil {
+			maxRows := defaults.MaxRows + 1
+			limit.Limit = &maxRows
+			stmt.AddClause(limit)
+			db.InstanceSet(maxRowsKey, true)
+		}
+	}
+}
+
+// MaxRowsHandler cuts the destination of a query that found more rows than
+// the MaxRows of the QueryDefaults of the session, and returns
+// ErrMaxRowsExceeded
+func MaxRowsHandler(db *gorm.DB) {
+	defaults, ok := queryDefaults(db)
+	if !ok || defaults.MaxRows <= 0 || db.Error != nil || db.RowsAffected <= int64(defaults.MaxRows) {
+		return
+	}
+	if _, ok := db.InstanceGet(maxRowsKey); !ok {
+		return
+	}
+
+	if rv := db.Statement.ReflectValue; rv.Kind() == reflect.Slice && rv.Len() > defaults.MaxRows {
+		rv.SetLen(defaults.MaxRows)
+	}
+	db.RowsAffected = int64(defaults.MaxRows)
+	db.AddError(fmt.Errorf("%w: %d", ErrMaxRowsExceeded, defaults.MaxRows))
+}
@@ -39,7 +39,6 @@
 package oracle
 
 import (
-	"database/sql"
 	"fmt"
 	"reflect"
 	"strings"
@@ -49,6 +48,54 @@ import (
 	"gorm.io/gorm/schema"
 )
 
+const ClauseDeleteChunks = "DELETE_CHUNKS"
+
+// DeleteChunks makes Delete remove the matching rows in chunks of Size rows,
+// running DELETE ... AND ROWNUM <= Size until a chunk deletes fewer rows.
+// Outside a transaction of the caller every chunk is committed on its own,
+// so that purging a large table does not hold the undo of all its rows.
+//
+//	db.Clauses(oracle.DeleteInChunks(10_000)).Where(`"created_at" < ?`, cutoff).Delete(&Event{})
+//
+// Inside a transaction the chunks are committed with the transaction. Soft
+// deletes and deletes with RETURNING are not chunked.
+type DeleteChunks struct {
+	// Size is the number of rows deleted by each chunk
+	Size int
+	// Progress, if set, is called after each chunk with the number of rows
+	// deleted so far
+	Progress func(deleted int64)
+}
+
+// DeleteInChunks returns a clause deleting the rows of a statement in chunks
+// of size rows
+func DeleteInChunks(size int) DeleteChunks {
+	return DeleteChunks{Size: size}
+}
+
+// Name returns the name of the clause
+func (DeleteChunks) Name() string {
+	return ClauseDeleteChunks
+}
+
+// Build writes nothing, the clause only changes how Delete runs the DELETE
+func (DeleteChunks) Build(clause.Builder) {}
+
+// MergeClause replaces any previous DELETE_CHUNKS clause
+func (d DeleteChunks) MergeClause(c *clause.Clause) {
+	c.Expression = d
+}
+
+// deleteChunks returns the DELETE_CHUNKS clause of the statement
+func deleteChunks(stmt *gorm.Statement) (DeleteChunks, bool) {
+	if c, ok := stmt.Clauses[ClauseDeleteChunks]; ok {
+		if d, ok := c.Expression.(DeleteChunks); ok && d.Size > 0 {
+			return d, true
+		}
+	}
+	return DeleteChunks{}, false
+}
+
 // Delete overrides GORM's delete callback for Oracle.
 //
 // Delete builds a safe, Oracle-compatible DELETE that supports soft deletes,
@@ -62,10 +109,10 @@ import (
 //     it lets GORM emit the UPDATE that marks rows as deleted. If a RETURNING
 //     clause is present with soft delete, it executes via QueryContext so the
 //     returned columns can be scanned.
-//   - Hard delete + RETURNING: it emits a PL/SQL block that performs DELETE …
-//     RETURNING BULK COLLECT INTO, wiring per-row sql.Out destinations so the
-//     deleted rows (or selected columns) can be populated back into the
-//     destination slice.
+//   - Hard delete + RETURNING: it emits a PL/SQL block that locks the rows,
+//     opens a cursor on them and deletes them, and returns the cursor with
+//     DBMS_SQL.RETURN_RESULT, so the deleted rows (or selected columns) are
+//     scanned into the destination.
 //   - Hard delete (no RETURNING): it emits a standard DELETE and executes it
 //     via ExecContext.
 //   - Expressions: it expands WHERE expressions, including IN with slices, and
@@ -130,6 +177,10 @@ func Delete(db *gorm.DB) {
 	if stmt.SQL.Len() == 0 {
 		stmt.SQL.Grow(100)
 		stmt.AddClauseIfNotExists(clause.Delete{})
+		if _, ok := limitedRows(stmt); ok {
+			// The limited rows are selected in the WHERE clause
+			stmt.AddClauseIfNotExists(clause.Where{})
+		}
 
 		// Build WHERE clause based on primary keys (like default callback)
 		if stmt.Schema != nil {
@@ -189,6 +240,8 @@ func addPrimaryKeyWhereClause(db *gorm.DB) {
 		}
 	}
 
+	addRowIDWhereClause(stmt)
+
 	// Handle the dual model check from default GORM
 	if stmt.ReflectValue.CanAddr() && stmt.Dest != stmt.Model && stmt.Model != nil {
 		_, additionalQueryValues := schema.GetIdentityFieldValuesMap(
@@ -233,7 +286,17 @@ func buildStandardDeleteSQL(db *gorm.DB) {
 	// The actual building will happen in executeDelete()
 }
 
-// Build PL/SQL block for bulk DELETE with RETURNING
+// deleteReturningRowIDs is the JSON_TABLE reading the ROWIDs of the rows
+// that the PL/SQL block of a DELETE with RETURNING deletes, in the order
+// they were locked
+const deleteReturningRowIDs = "JSON_TABLE(l_rowid_list, '$[*]' COLUMNS (seq FOR ORDINALITY, rid VARCHAR2(4000) PATH '$')) r"
+
+// Build PL/SQL block for bulk DELETE with RETURNING. The block locks the
+// rows matching the conditions, opens a cursor on them before deleting them,
+// so that it still reads them afterwards, and returns it as an implicit
+// result. A PL/SQL collection of a type declared in the block cannot be
+// queried, so the ROWIDs are passed to the cursor and the DELETE as a JSON
+// array.
 func buildBulkDeletePLSQL(db *gorm.DB) {
 	stmt := db.Statement
 	sch := stmt.Schema
@@ -251,80 +314,75 @@ func buildBulkDeletePLSQL(db *gorm.DB) {
 	}
 
 	// For hard delete with RETURNING, use PL/SQL
+	// Return the requested columns of the deleted rows
+	allColumns := plsqlReturningColumns(stmt, getReturnableFields(sch))
+
 	var plsqlBuilder strings.Builder
 
 	// Start PL/SQL block
 	plsqlBuilder.WriteString("DECLARE\n")
-	writeTableRecordCollectionDecl(db, &plsqlBuilder, stmt.Schema.DBNames, stmt.Table)
-	plsqlBuilder.WriteString("  l_deleted_records t_records;\n")
+	plsqlBuilder.WriteString("  TYPE t_rowids IS TABLE OF UROWID;\n")
+	plsqlBuilder.WriteString("  l_rowids t_rowids;\n")
+	plsqlBuilder.WriteString("  l_rowid_list CLOB := '[';\n")
+	plsqlBuilder.WriteString("  l_chunk VARCHAR2(32767);\n")
+	plsqlBuilder.WriteString("  l_deleted_records SYS_REFCURSOR;\n")
 	plsqlBuilder.WriteString("BEGIN\n")
 
-	// Build DELETE statement
-	plsqlBuilder.WriteString("  DELETE FROM ")
+	// Lock the rows to delete, so that the cursor and the DELETE see the same rows
+	plsqlBuilder.WriteString("  SELECT ROWID BULK COLLECT INTO l_rowids FROM ")
 	db.QuoteTo(&plsqlBuilder, stmt.Table)
+	writePartitionExtension(stmt, plsqlClauseBuilder{&plsqlBuilder, stmt})
 
 	// Add WHERE clause if it exists
-	if whereClause, hasWhere := stmt.Clauses["WHERE"]; hasWhere {
+	whereClause, hasWhere := stmt.Clauses["WHERE"]
+	if limit, ok := limitedRows(stmt); ok {
+		var writeConditions func()
+		if where, ok := whereClause.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			writeConditions = func() { buildWhereClause(db, &plsqlBuilder, where.Exprs) }
+		}
+		plsqlBuilder.WriteString(" WHERE ")
+		writeLimitedRowIDs(plsqlClauseBuilder{&plsqlBuilder, stmt}, stmt, limit, writeConditions)
+	} else if hasWhere {
 		plsqlBuilder.WriteString(" WHERE ")
 		if where, ok := whereClause.Expression.(clause.Where); ok {
 			buildWhereClause(db, &plsqlBuilder, where.Exprs)
 		}
 	}
-
-	// Add RETURNING clause
-	plsqlBuilder.WriteString("\n  RETURNING ")
-	allColumns := sch.DBNames
+	plsqlBuilder.WriteString("\n  FOR UPDATE;\n")
+
+	// Build the JSON array of the ROWIDs in chunks, as appending to a CLOB
+	// copies it
+	plsqlBuilder.WriteString("  FOR i IN 1 .. l_rowids.COUNT LOOP\n")
+	plsqlBuilder.WriteString("    IF LENGTHB(l_chunk) > 32000 THEN\n")
+	plsqlBuilder.WriteString("      l_rowid_list := l_rowid_list || l_chunk;\n")
+	plsqlBuilder.WriteString("      l_chunk := NULL;\n")
+	plsqlBuilder.WriteString("    END IF;\n")
+	plsqlBuilder.WriteString("    l_chunk := l_chunk || CASE WHEN i > 1 THEN ',' END || '\"' || l_rowids(i) || '\"';\n")
+	plsqlBuilder.WriteString("  END LOOP;\n")
+	plsqlBuilder.WriteString("  l_rowid_list := l_rowid_list || l_chunk || ']';\n")
+
+	// Open the cursor before the DELETE: it reads the rows as they were
+	// when it was opened
+	plsqlBuilder.WriteString("  OPEN l_deleted_records FOR SELECT /*+ LEADING(r) USE_NL(t) */ ")
 	for i, column := range allColumns {
 		if i > 0 {
 			plsqlBuilder.WriteString(", ")
 		}
-		db.QuoteTo(&plsqlBuilder, column)
-
-	}
-	plsqlBuilder.WriteString("\n  BULK COLLECT INTO l_deleted_records;\n")
-
-	// Create OUT parameters for each field and each row that will be deleted (JSON-safe)
-	outParamIndex := len(stmt.Vars)
-	// keep your current fixed cap (same as other callbacks)
-	estimatedRows := 100
-
-	for rowIdx := 0; rowIdx < estimatedRows; rowIdx++ {
-		for _, column := range allColumns {
-			if field := findFieldByDBName(sch, column); field != nil {
-				if isJSONField(field) {
-					if isRawMessageField(field) {
-						// Column is a BLOB, return raw bytes; no JSON_SERIALIZE
-						stmt.Vars = append(stmt.Vars, sql.Out{Dest: new([]byte)})
-						plsqlBuilder.WriteString(fmt.Sprintf(
-							"  IF l_deleted_records.COUNT > %d THEN :%d := l_deleted_records(%d).",
-							rowIdx, outParamIndex+1, rowIdx+1,
-						))
-						db.QuoteTo(&plsqlBuilder, column)
-						plsqlBuilder.WriteString("; END IF;\n")
-					} else {
-						// JSON -> text bind
-						stmt.Vars = append(stmt.Vars, sql.Out{Dest: new(string)})
-						plsqlBuilder.WriteString(fmt.Sprintf("  IF l_deleted_records.COUNT > %d THEN\n", rowIdx))
-						plsqlBuilder.WriteString(fmt.Sprintf("    :%d := JSON_SERIALIZE(l_deleted_records(%d).", outParamIndex+1, rowIdx+1))
-						db.QuoteTo(&plsqlBuilder, column)
-						plsqlBuilder.WriteString(" RETURNING CLOB);\n")
-						plsqlBuilder.WriteString("  END IF;\n")
-					}
-				} else {
-					// non-JSON as before
-					dest := createTypedDestination(field)
-					stmt.Vars = append(stmt.Vars, sql.Out{Dest: dest})
-					plsqlBuilder.WriteString(fmt.Sprintf("  IF l_deleted_records.COUNT > %d THEN\n", rowIdx))
-					plsqlBuilder.WriteString(fmt.Sprintf("    :%d := l_deleted_records(%d).", outParamIndex+1, rowIdx+1))
-					db.QuoteTo(&plsqlBuilder, column)
-					plsqlBuilder.WriteString(";\n")
-					plsqlBuilder.WriteString("  END IF;\n")
-				}
-				outParamIndex++
-			}
-		}
+		plsqlBuilder.WriteString("t.")
+		quoteTo(db, &plsqlBuilder, column)
+		// The column names of the result are those of the fields, whatever
+		// the case of the unquoted names
+		plsqlBuilder.WriteString(" AS ")
+		writeQuotedIdentifier(&plsqlBuilder, column)
 	}
+	plsqlBuilder.WriteString("\n    FROM " + deleteReturningRowIDs + ", ")
+	db.QuoteTo(&plsqlBuilder, stmt.Table)
+	plsqlBuilder.WriteString(" t WHERE t.ROWID = r.rid ORDER BY r.seq;\n")
 
+	plsqlBuilder.WriteString("  DELETE FROM ")
+	db.QuoteTo(&plsqlBuilder, stmt.Table)
+	plsqlBuilder.WriteString(" WHERE ROWID IN (SELECT r.rid FROM " + deleteReturningRowIDs + ");\n")
+	plsqlBuilder.WriteString("  DBMS_SQL.RETURN_RESULT(l_deleted_records);\n")
 	plsqlBuilder.WriteString("END;")
 
 	stmt.SQL.Reset()
@@ -374,8 +432,8 @@ func buildWhereClause(db *gorm.DB, plsqlBuilder *strings.Builder, expressions []
 				if j > 0 {
 					plsqlBuilder.WriteString(", ")
 				}
-				plsqlBuilder.WriteString(fmt.Sprintf(":%d", len(stmt.Vars)+1))
 				stmt.Vars = append(stmt.Vars, convertValue(val))
+				writeBindVar(plsqlBuilder, stmt)
 			}
 			plsqlBuilder.WriteString(")")
 
@@ -383,68 +441,82 @@ func buildWhereClause(db *gorm.DB, plsqlBuilder *strings.Builder, expressions []
 			buildExpressionClause(db, plsqlBuilder, e)
 
 		default:
-			// Safe fallback for unknown expression types
-			plsqlBuilder.WriteString("1=1")
+			expr.Build(plsqlClauseBuilder{plsqlBuilder, stmt})
 		}
 	}
 }
 
-// Build expression clause with proper handling of IN clauses
+// Build expression clause with proper handling of IN clauses. Only the ?
+// placeholders outside literals and comments are bound.
 func buildExpressionClause(db *gorm.DB, plsqlBuilder *strings.Builder, e clause.Expr) {
 	stmt := db.Statement
-	exprSQL := e.SQL
+	last := 0
 	varIndex := 0
 
-	for strings.Contains(exprSQL, "?") {
-		if varIndex < len(e.Vars) {
-			// Check if this is an IN expression with a slice
-			if strings.Contains(exprSQL, "IN ?") && varIndex < len(e.Vars) {
-				// Handle different slice types
-				var values []interface{}
-
-				switch v := e.Vars[varIndex].(type) {
-				case []interface{}:
-					values = v
-				case []string:
-					// Convert []string to []interface{}
-					values = make([]interface{}, len(v))
-					for i, s := range v {
-						values[i] = s
-					}
-				default:
-					// Fall back to regular parameter replacement
-					exprSQL = strings.Replace(exprSQL, "?", fmt.Sprintf(":%d", len(stmt.Vars)+1), 1)
-					stmt.Vars = append(stmt.Vars, convertValue(e.Vars[varIndex]))
-					varIndex++
-					continue
-				}
+	for _, pos := range bindPlaceholders(e.SQL) {
+		if varIndex >= len(e.Vars) {
+			break
+		}
+		plsqlBuilder.WriteString(e.SQL[last:pos])
+		last = pos + 1
+		value := e.Vars[varIndex]
+		varIndex++
+
+		switch v := value.(type) {
+		case clause.Column, clause.Table:
+			stmt.QuoteTo(plsqlBuilder, v)
+			continue
+		case clause.Expression:
+			v.Build(plsqlClauseBuilder{plsqlBuilder, stmt})
+			continue
+		}
 
-				if len(values) > 0 {
-					// Handle IN clause with multiple values
-					inClause := "("
-					for j, val := range values {
-						if j > 0 {
-							inClause += ", "
-						}
-						inClause += fmt.Sprintf(":%d", len(stmt.Vars)+1)
-						stmt.Vars = append(stmt.Vars, convertValue(val))
-					}
-					inClause += ")"
-					exprSQL = strings.Replace(exprSQL, "?", inClause, 1)
-					varIndex++
+		// Check if this is an IN expression with a slice
+		if values, ok := inListValues(e.SQL[:pos], value); ok {
+			plsqlBuilder.WriteString("(")
+			if len(values) == 0 {
+				plsqlBuilder.WriteString("NULL")
+			}
+			for j, val := range values {
+				if j > 0 {
+					plsqlBuilder.WriteString(", ")
 				}
-			} else {
-				// Regular parameter replacement
-				exprSQL = strings.Replace(exprSQL, "?", fmt.Sprintf(":%d", len(stmt.Vars)+1), 1)
-				stmt.Vars = append(stmt.Vars, convertValue(e.Vars[varIndex]))
-				varIndex++
+				stmt.Vars = append(stmt.Vars, convertValue(val))
+				writeBindVar(plsqlBuilder, stmt)
 			}
-		} else {
-			break
+			plsqlBuilder.WriteString(")")
+			continue
 		}
+
+		// Regular parameter replacement
+		stmt.Vars = append(stmt.Vars, convertValue(value))
+		writeBindVar(plsqlBuilder, stmt)
+	}
+
+	plsqlBuilder.WriteString(e.SQL[last:])
+}
+
+// inListValues returns the values of a slice bound to a placeholder that
+// directly follows the IN keyword
+func inListValues(preceding string, value interface{}) ([]interface{}, bool) {
+	preceding = strings.TrimRight(preceding, " \t\r\n")
+	if len(preceding) < 2 || !strings.EqualFold(preceding[len(preceding)-2:], "IN") ||
+		(len(preceding) > 2 && isIdentifierChar(preceding[len(preceding)-3])) {
+		return nil, false
 	}
 
-	plsqlBuilder.WriteString(exprSQL)
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case []string:
+		// Convert []string to []interface{}
+		values := make([]interface{}, len(v))
+		for i, s := range v {
+			values[i] = s
+		}
+		return values, true
+	}
+	return nil, false
 }
 
 // Execute the delete statement
@@ -455,6 +527,34 @@ func executeDelete(db *gorm.DB) {
 
 	stmt := db.Statement
 
+	// Check if we have RETURNING clause to determine execution method
+	_, hasReturning := stmt.Clauses["RETURNING"]
+
+	chunks, chunked := deleteChunks(stmt)
+	_, limited := limitedRows(stmt)
+	// A LIMIT already caps the rows deleted by the statement
+	chunked = chunked && !hasReturning && !limited && stmt.SQL.Len() == 0
+	if chunked {
+		// Group the conditions so that an OR does not bind the ROWNUM limit
+		exprs := []clause.Expression{clause.Expr{SQL: "ROWNUM <= ?", Vars: []interface{}{chunks.Size}}}
+		if c, ok := stmt.Clauses["WHERE"]; ok {
+			if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+				exprs = append([]clause.Expression{clause.And(where.Exprs...)}, exprs...)
+			}
+		}
+		stmt.Clauses["WHERE"] = clause.Clause{Name: "WHERE", Expression: clause.Where{Exprs: exprs}}
+	}
+
+	// Delete the rows of a composite key, such as those of a many2many join
+	// table, with one statement executed for every key
+	if !chunked && !hasReturning && !limited && stmt.SQL.Len() == 0 {
+		if columns, arrays, ok := deleteKeyArrays(db); ok {
+			buildArrayDeleteSQL(db, columns, arrays)
+			execArrayDML(db)
+			return
+		}
+	}
+
 	// Build SQL if not already built
 	if stmt.SQL.Len() == 0 {
 		stmt.Build("DELETE", "FROM", "WHERE")
@@ -465,23 +565,11 @@ func executeDelete(db *gorm.DB) {
 		}
 	}
 
-	// Check if we have RETURNING clause to determine execution method
-	_, hasReturning := stmt.Clauses["RETURNING"]
-
-	if hasReturning {
-		// Hard delete & soft delete with RETURNING - use ExecContext (for PL/SQL blocks)
-		result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
-		if err == nil {
-			db.RowsAffected, _ = result.RowsAffected()
-
-			if stmt.Result != nil {
-				stmt.Result.Result = result
-				stmt.Result.RowsAffected = db.RowsAffected
-			}
-			getDeleteBulkReturningValues(db)
-		} else {
-			db.AddError(err)
-		}
+	if chunked {
+		executeDeleteInChunks(db, chunks)
+	} else if hasReturning && stmt.Schema != nil && isPLSQLBlock(stmt.SQL.String()) {
+		// Hard delete with RETURNING - the PL/SQL block returns the deleted rows
+		queryDeleteReturning(db)
 	} else {
 		// Use ExecContext for regular DELETE
 		result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
@@ -499,90 +587,260 @@ func executeDelete(db *gorm.DB) {
 	}
 }
 
-// Handle bulk RETURNING results for DELETE operations
-func getDeleteBulkReturningValues(db *gorm.DB) {
-	if db.Statement.Schema == nil {
-		return
-	}
+// maxArrayDeleteKeys is the largest number of keys deleted with array DML,
+// above which the IN lists of the conditions are bound instead
+const maxArrayDeleteKeys = 100000
 
-	targetValue := db.Statement.ReflectValue
-	if targetValue.Kind() == reflect.Ptr {
-		targetValue = targetValue.Elem()
-	}
+// keyCondition is a set of values of the columns of an equality or IN
+// condition of a DELETE
+type keyCondition struct {
+	columns []string
+	rows    [][]interface{}
+}
 
-	if targetValue.Kind() != reflect.Slice {
-		return
+// deleteKeyArrays returns the columns of a DELETE whose conditions are only
+// equalities and IN lists covering the columns of a composite primary key,
+// like the DELETE of association Delete on a many2many join table, and for
+// each column the array of its values in every combination of the
+// conditions. It reports false when the rows can't be deleted with array DML.
+func deleteKeyArrays(db *gorm.DB) ([]string, []any, bool) {
+	stmt := db.Statement
+	if stmt.Schema == nil || len(stmt.Schema.PrimaryFields) < 2 || !driverFeatures(db).ArrayDML {
+		return nil, nil, false
+	}
+	where, ok := stmt.Clauses["WHERE"].Expression.(clause.Where)
+	if !ok || len(where.Exprs) == 0 {
+		return nil, nil, false
 	}
 
-	// Find first OUT parameter
-	actualStartIndex := -1
-	for i := 0; i < len(db.Statement.Vars); i++ {
-		if _, ok := db.Statement.Vars[i].(sql.Out); ok {
-			actualStartIndex = i
-			break
+	var conditions []keyCondition
+	covered := map[string]bool{}
+	for _, expr := range andedExprs(where.Exprs) {
+		condition, ok := deleteKeyCondition(stmt, expr)
+		if !ok {
+			return nil, nil, false
 		}
+		for _, column := range condition.columns {
+			if covered[column] {
+				return nil, nil, false
+			}
+			covered[column] = true
+		}
+		conditions = append(conditions, condition)
 	}
-
-	if actualStartIndex == -1 {
-		return
+	for _, field := range stmt.Schema.PrimaryFields {
+		if !covered[field.DBName] {
+			return nil, nil, false
+		}
 	}
 
-	allColumns := db.Statement.Schema.DBNames
-
-	// Count OUT parameters and calculate max rows
-	outParamCount := 0
-	for i := actualStartIndex; i < len(db.Statement.Vars); i++ {
-		if _, ok := db.Statement.Vars[i].(sql.Out); ok {
-			outParamCount++
+	keys := 1
+	for _, condition := range conditions {
+		keys *= len(condition.rows)
+		if keys == 0 || keys > maxArrayDeleteKeys {
+			return nil, nil, false
 		}
 	}
+	if keys < 2 {
+		return nil, nil, false
+	}
 
-	maxPossibleRows := outParamCount / len(allColumns)
-	var validRows []reflect.Value
-
-	// Process rows and collect valid data
-	for rowIdx := 0; rowIdx < maxPossibleRows; rowIdx++ {
-		targetStruct := reflect.New(targetValue.Type().Elem()).Elem()
-		hasRealData := false
+	// Every combination of the rows of the conditions, the last condition
+	// varying fastest
+	var columns []string
+	var values [][]any
+	repeat := keys
+	for _, condition := range conditions {
+		repeat /= len(condition.rows)
+		for col, column := range condition.columns {
+			columnValues := make([]any, keys)
+			for key := range columnValues {
+				columnValues[key] = convertValue(condition.rows[key/repeat%len(condition.rows)][col])
+			}
+			columns = append(columns, column)
+			values = append(values, columnValues)
+		}
+	}
 
-		for colIdx, column := range allColumns {
-			paramIndex := actualStartIndex + (rowIdx * len(allColumns)) + colIdx
+	arrays := make([]any, len(values))
+	for i, columnValues := range values {
+		array, ok := insertArray(columnValues)
+		if !ok {
+			return nil, nil, false
+		}
+		arrays[i] = array
+	}
+	return columns, arrays, true
+}
 
-			if paramIndex < len(db.Statement.Vars) {
-				if outParam, ok := db.Statement.Vars[paramIndex].(sql.Out); ok {
-					if field := findFieldByDBName(db.Statement.Schema, column); field != nil && outParam.Dest != nil {
-						destValue := reflect.ValueOf(outParam.Dest)
-						if destValue.Kind() == reflect.Ptr && !destValue.IsNil() {
-							actualValue := destValue.Elem().Interface()
+// andedExprs returns the conditions, with the conditions of nested WHERE
+// clauses and AND groups, like those of Where(clause.Where{...}), in their
+// place
+func andedExprs(exprs []clause.Expression) []clause.Expression {
+	var result []clause.Expression
+	for _, expr := range exprs {
+		switch e := expr.(type) {
+		case clause.Where:
+			result = append(result, andedExprs(e.Exprs)...)
+		case clause.AndConditions:
+			result = append(result, andedExprs(e.Exprs)...)
+		default:
+			result = append(result, expr)
+		}
+	}
+	return result
+}
 
-							if actualValue != nil && !reflect.ValueOf(actualValue).IsZero() {
-								hasRealData = true
-							}
+// deleteKeyCondition returns the columns and values of an equality or IN
+// condition on columns of the table of the statement
+func deleteKeyCondition(stmt *gorm.Statement, expr clause.Expression) (keyCondition, bool) {
+	columnName := func(column interface{}) (string, bool) {
+		switch c := column.(type) {
+		case string:
+			return c, !strings.ContainsAny(c, ` ."()`)
+		case clause.Column:
+			if c.Raw || c.Table != "" && c.Table != clause.CurrentTable && c.Table != stmt.Table {
+				return "", false
+			}
+			return c.Name, true
+		}
+		return "", false
+	}
+	isValue := func(value interface{}) bool {
+		switch value.(type) {
+		case clause.Expression, []interface{}:
+			return false
+		}
+		return true
+	}
 
-							if convertedValue := convertFromOracleToField(actualValue, field); convertedValue != nil {
-								if err := field.Set(db.Statement.Context, targetStruct, convertedValue); err != nil {
-									db.AddError(fmt.Errorf("failed to set field %s: %w", field.Name, err))
-								}
-							}
-						}
-					}
+	switch e := expr.(type) {
+	case clause.Eq:
+		name, ok := columnName(e.Column)
+		if !ok || e.Value == nil || !isValue(e.Value) {
+			return keyCondition{}, false
+		}
+		return keyCondition{columns: []string{name}, rows: [][]interface{}{{e.Value}}}, true
+	case clause.IN:
+		if columns, ok := e.Column.([]clause.Column); ok {
+			condition := keyCondition{}
+			for _, column := range columns {
+				name, ok := columnName(column)
+				if !ok {
+					return keyCondition{}, false
+				}
+				condition.columns = append(condition.columns, name)
+			}
+			for _, value := range e.Values {
+				row, ok := value.([]interface{})
+				if !ok || len(row) != len(columns) {
+					return keyCondition{}, false
 				}
+				condition.rows = append(condition.rows, row)
 			}
+			return condition, true
 		}
+		name, ok := columnName(e.Column)
+		if !ok {
+			return keyCondition{}, false
+		}
+		condition := keyCondition{columns: []string{name}}
+		for _, value := range e.Values {
+			if value == nil || !isValue(value) {
+				return keyCondition{}, false
+			}
+			condition.rows = append(condition.rows, []interface{}{value})
+		}
+		return condition, true
+	}
+	return keyCondition{}, false
+}
 
-		if hasRealData {
-			validRows = append(validRows, targetStruct)
+// buildArrayDeleteSQL builds a DELETE of the rows matching the values of the
+// columns, binding one array per column
+func buildArrayDeleteSQL(db *gorm.DB, columns []string, arrays []any) {
+	stmt := db.Statement
+	stmt.Build("DELETE", "FROM")
+	stmt.WriteString(" WHERE ")
+	for i, column := range columns {
+		if i > 0 {
+			stmt.WriteString(" AND ")
 		}
+		stmt.WriteQuoted(column)
+		stmt.WriteString(" = ")
+		stmt.Vars = append(stmt.Vars, arrays[i])
+		db.Dialector.BindVarTo(stmt, stmt, arrays[i])
+	}
+}
+
+// executeDeleteInChunks runs the DELETE limited to a chunk of rows until a
+// chunk deletes fewer rows. When the only transaction is the one GORM opens
+// around the callback, the chunks run on the connection pool so that each
+// one commits.
+func executeDeleteInChunks(db *gorm.DB, chunks DeleteChunks) {
+	stmt := db.Statement
+	pool := stmt.ConnPool
+	if _, ok := db.InstanceGet("gorm:started_transaction"); ok {
+		pool = db.ConnPool
 	}
 
-	// Set final results
-	if len(validRows) > 0 {
-		newSlice := reflect.MakeSlice(targetValue.Type(), len(validRows), len(validRows))
-		for i, row := range validRows {
-			newSlice.Index(i).Set(row)
+	for {
+		result, err := pool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+		if err != nil {
+			db.AddError(err)
+			return
 		}
-		targetValue.Set(newSlice)
-	} else {
-		targetValue.Set(reflect.MakeSlice(targetValue.Type(), 0, 0))
+		deleted, _ := result.RowsAffected()
+		db.RowsAffected += deleted
+
+		if stmt.Result != nil {
+			stmt.Result.Result = result
+			stmt.Result.RowsAffected = db.RowsAffected
+		}
+		if chunks.Progress != nil {
+			chunks.Progress(db.RowsAffected)
+		}
+		if deleted < int64(chunks.Size) {
+			return
+		}
+	}
+}
+
+// queryDeleteReturning runs the PL/SQL block of a DELETE with RETURNING and
+// scans the deleted rows it returns as an implicit result into the
+// destination. The block changes with the conditions, so it bypasses the
+// prepared statement cache.
+func queryDeleteReturning(db *gorm.DB) {
+	stmt := db.Statement
+	features := driverFeatures(db)
+	if !features.ImplicitResults {
+		db.AddError(fmt.Errorf("delete with RETURNING: %w", ErrUnsupportedByDriver))
+		return
+	}
+
+	rows, err := unpreparedConnPool(stmt.ConnPool).QueryContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+	if err == nil {
+		rows, err = implicitResult(stmt.SQL.String(), rows)
+	}
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	defer func() {
+		db.AddError(rows.Close())
+	}()
+
+	var result gorm.Rows = rows
+	columnTypes, _ := rows.ColumnTypes()
+	if features.LOBStreaming {
+		result = newLobRows(result, columnTypes)
+	}
+	if features.NativeJSON {
+		result = newJSONRows(result, columnTypes)
+	}
+	gorm.Scan(result, db, 0)
+
+	if stmt.Result != nil {
+		stmt.Result.RowsAffected = db.RowsAffected
 	}
 }
@@ -0,0 +1,713 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// ObjectStatus is the state of an index or constraint in an IndexReport
+type ObjectStatus string
+
+const (
+	// StatusValid is an index or enabled and validated constraint the
+	// optimizer can use
+	StatusValid ObjectStatus = "VALID"
+	// StatusUnusable is an index, or a partition of it, that is UNUSABLE and
+	// must be rebuilt, see Migrator.RebuildIndex
+	StatusUnusable ObjectStatus = "UNUSABLE"
+	// StatusInvisible is an index the optimizer ignores
+	StatusInvisible ObjectStatus = "INVISIBLE"
+	// StatusDisabled is a disabled constraint, or a function-based index
+	// whose function changed
+	StatusDisabled ObjectStatus = "DISABLED"
+	// StatusNotValidated is an enabled constraint that existing rows may
+	// violate
+	StatusNotValidated ObjectStatus = "NOT VALIDATED"
+	// StatusMissing is an index or constraint of the model that does not
+	// exist in the database
+	StatusMissing ObjectStatus = "MISSING"
+)
+
+// ReportedObject is an index or constraint of an IndexReport
+type ReportedObject struct {
+	// Kind is INDEX, UNIQUE INDEX, PRIMARY KEY, UNIQUE, FOREIGN KEY or CHECK
+	Kind string
+	// Name is the name declared by the model, empty for a primary key or an
+	// object that only exists in the database
+	Name string
+	// DatabaseName is the name in the database, empty when missing
+	DatabaseName string
+	// Columns are the columns or expressions of the object in the database,
+	// or declared by the model when missing
+	Columns []string
+	Status  ObjectStatus
+	// Declared is set for the objects declared by the model
+	Declared bool
+}
+
+// QueryIssue is a statement of the workload of an IndexReport that cannot use
+// an index on the columns it filters on
+type QueryIssue struct {
+	SQL    string
+	Index  string
+	Reason string
+}
+
+// IndexReport lists the indexes and constraints of a table, see
+// Migrator.IndexReport
+type IndexReport struct {
+	Table   string
+	Objects []ReportedObject
+	Queries []QueryIssue
+}
+
+// IndexReport compares the indexes and constraints the model of `value`
+// declares with those of its table: each declared index, primary key,
+// unique, foreign key and check constraint is reported VALID, or with the
+// state that keeps the optimizer from using it, or MISSING. An object whose
+// name differs from the declared one, only in case or entirely, is matched
+// by its columns. Objects that only exist in the database are reported too.
+//
+// The statements of `workload`, for instance recorded with a
+// WorkloadRecorder, are checked against the indexes of the table: a
+// statement whose WHERE clause filters on the leading column of an index
+// but can use none of them is reported with the reason, such as a column
+// wrapped in a function, a function-based index whose expression differs
+// from the one of the statement, or a column named in another case, which
+// Oracle resolves to another column when it is unquoted or quoted
+// differently.
+//
+//	report, err := db.Migrator().(oracle.Migrator).IndexReport(&User{}, recorder.Statements()...)
+func (m Migrator) IndexReport(value interface{}, workload ...string) (IndexReport, error) {
+	var report IndexReport
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		report.Table = stmt.Table
+		indexes, err := m.reportedIndexes(value, stmt.Table)
+		if err != nil {
+			return err
+		}
+		constraints, err := m.reportedConstraints(stmt.Table)
+		if err != nil {
+			return err
+		}
+
+		// Indexes backing primary key and unique constraints share their name
+		backing := map[string]bool{}
+		for _, constraint := range constraints {
+			if constraint.Kind == "PRIMARY KEY" || constraint.Kind == "UNIQUE" {
+				backing[constraint.DatabaseName] = true
+			}
+		}
+		var backed []ReportedObject
+		for _, index := range indexes {
+			if !backing[index.DatabaseName] {
+				backed = append(backed, index)
+			}
+		}
+
+		matched := map[*ReportedObject]bool{}
+		for _, declared := range declaredObjects(stmt.Schema, uniqueIndexes(m.DB)) {
+			candidates := constraints
+			if strings.HasSuffix(declared.Kind, "INDEX") {
+				candidates = backed
+			}
+			if object := matchObject(declared, candidates, matched); object != nil {
+				declared.DatabaseName, declared.Status = object.DatabaseName, object.Status
+				if len(object.Columns) > 0 {
+					declared.Columns = object.Columns
+				}
+			} else {
+				declared.Status = StatusMissing
+			}
+			report.Objects = append(report.Objects, declared)
+		}
+		for _, objects := range [][]ReportedObject{backed, constraints} {
+			for i := range objects {
+				if !matched[&objects[i]] {
+					report.Objects = append(report.Objects, objects[i])
+				}
+			}
+		}
+
+		report.Queries = workloadIssues(stmt.Table, indexes, workload)
+		return nil
+	})
+	return report, err
+}
+
+// declaredObjects returns the indexes and constraints the schema declares,
+// with the columns of the model. The unique tags of fields are unique
+// indexes with uniqueIndexes, and UNIQUE constraints otherwise.
+func declaredObjects(sch *schema.Schema, uniqueIndexes bool) []ReportedObject {
+	if sch == nil {
+		return nil
+	}
+
+	var objects []ReportedObject
+	for _, idx := range sch.ParseIndexes() {
+		kind := "INDEX"
+		if strings.EqualFold(idx.Class, "UNIQUE") {
+			kind = "UNIQUE INDEX"
+		}
+		object := ReportedObject{Kind: kind, Name: idx.Name, Declared: true}
+		for _, opt := range idx.Fields {
+			if opt.Expression != "" {
+				object.Columns = append(object.Columns, opt.Expression)
+			} else if opt.Field != nil {
+				object.Columns = append(object.Columns, opt.DBName)
+			}
+		}
+		objects = append(objects, object)
+	}
+	if len(sch.PrimaryFieldDBNames) > 0 {
+		objects = append(objects, ReportedObject{Kind: "PRIMARY KEY", Columns: sch.PrimaryFieldDBNames, Declared: true})
+	}
+	uniques := sch.ParseUniqueConstraints()
+	for _, name := range sortedKeys(uniques) {
+		uni := uniques[name]
+		kind := "UNIQUE"
+		if uniqueIndexes {
+			if uniqueFieldIndex(sch, uni.Field) != nil {
+				continue
+			}
+			kind = "UNIQUE INDEX"
+		}
+		objects = append(objects, ReportedObject{Kind: kind, Name: name, Columns: []string{uni.Field.DBName}, Declared: true})
+	}
+	for _, rel := range sch.Relationships.Relations {
+		if rel.Field.IgnoreMigration {
+			continue
+		}
+		if constraint := rel.ParseConstraint(); constraint != nil && constraint.Schema == sch {
+			object := ReportedObject{Kind: "FOREIGN KEY", Name: constraint.Name, Declared: true}
+			for _, fk := range constraint.ForeignKeys {
+				object.Columns = append(object.Columns, fk.DBName)
+			}
+			objects = append(objects, object)
+		}
+	}
+	checks := sch.ParseCheckConstraints()
+	for _, name := range sortedKeys(checks) {
+		objects = append(objects, ReportedObject{Kind: "CHECK", Name: name, Columns: []string{checks[name].Constraint}, Declared: true})
+	}
+	return objects
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// matchObject returns the object of the database of the same kind as the
+// declared one with its name, the name in another case, or the same columns
+func matchObject(declared ReportedObject, objects []ReportedObject, matched map[*ReportedObject]bool) *ReportedObject {
+	for _, byName := range []func(object *ReportedObject) bool{
+		func(object *ReportedObject) bool { return declared.Name != "" && object.DatabaseName == declared.Name },
+		func(object *ReportedObject) bool {
+			return declared.Name != "" && strings.EqualFold(object.DatabaseName, declared.Name)
+		},
+		func(object *ReportedObject) bool {
+			return declared.Kind != "CHECK" && slices.EqualFunc(object.Columns, declared.Columns, func(a, b string) bool {
+				return normalizeSQL(a) == normalizeSQL(QuoteIdentifier(b)) || normalizeSQL(a) == normalizeSQL(b)
+			})
+		},
+	} {
+		for i := range objects {
+			object := &objects[i]
+			if !matched[object] && object.Kind == declared.Kind && byName(object) {
+				matched[object] = true
+				return object
+			}
+		}
+	}
+	return nil
+}
+
+// reportedIndexes reads the indexes of the table with their columns, or the
+// expressions of function-based indexes, and their state
+func (m Migrator) reportedIndexes(value interface{}, table string) ([]ReportedObject, error) {
+	type indexColumn struct {
+		IndexName        string
+		Uniqueness       string
+		Status           string
+		Visibility       string
+		FuncidxStatus    string
+		ColumnName       string
+		ColumnExpression string
+	}
+	var columns []indexColumn
+	if err := m.DB.Raw(
+		`SELECT i.INDEX_NAME AS "index_name", i.UNIQUENESS AS "uniqueness", i.STATUS AS "status",
+	i.VISIBILITY AS "visibility", NVL(i.FUNCIDX_STATUS, 'ENABLED') AS "funcidx_status",
+	ic.COLUMN_NAME AS "column_name", e.COLUMN_EXPRESSION AS "column_expression"
+FROM USER_INDEXES i
+JOIN USER_IND_COLUMNS ic ON ic.INDEX_NAME = i.INDEX_NAME
+LEFT JOIN USER_IND_EXPRESSIONS e ON e.INDEX_NAME = ic.INDEX_NAME AND e.COLUMN_POSITION = ic.COLUMN_POSITION
+WHERE i.TABLE_NAME = ? AND i.INDEX_TYPE <> 'LOB'
+ORDER BY i.INDEX_NAME, ic.COLUMN_POSITION`,
+		table,
+	).Scan(&columns).Error; err != nil {
+		return nil, err
+	}
+
+	unusable, err := m.UnusableIndexes(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []ReportedObject
+	for _, column := range columns {
+		if len(indexes) == 0 || indexes[len(indexes)-1].DatabaseName != column.IndexName {
+			index := ReportedObject{Kind: "INDEX", DatabaseName: column.IndexName, Status: StatusValid}
+			if column.Uniqueness == "UNIQUE" {
+				index.Kind = "UNIQUE INDEX"
+			}
+			switch {
+			case column.Status == "UNUSABLE" || slices.ContainsFunc(unusable, func(u UnusableIndex) bool { return u.Index == column.IndexName }):
+				index.Status = StatusUnusable
+			case column.FuncidxStatus == "DISABLED":
+				index.Status = StatusDisabled
+			case column.Visibility == "INVISIBLE":
+				index.Status = StatusInvisible
+			}
+			indexes = append(indexes, index)
+		}
+		index := &indexes[len(indexes)-1]
+		if column.ColumnExpression != "" {
+			index.Columns = append(index.Columns, column.ColumnExpression)
+		} else {
+			index.Columns = append(index.Columns, QuoteIdentifier(column.ColumnName))
+		}
+	}
+	return indexes, nil
+}
+
+// reportedConstraints reads the primary key, unique, foreign key and named
+// check constraints of the table with their columns and state. Unnamed
+// check constraints, which include the NOT NULL constraints of the
+// columns, are left out.
+func (m Migrator) reportedConstraints(table string) ([]ReportedObject, error) {
+	type constraintColumn struct {
+		ConstraintName string
+		ConstraintType string
+		Status         string
+		Validated      string
+		ColumnName     string
+	}
+	var columns []constraintColumn
+	if err := m.DB.Raw(
+		`SELECT c.CONSTRAINT_NAME AS "constraint_name", c.CONSTRAINT_TYPE AS "constraint_type", c.STATUS AS "status",
+	c.VALIDATED AS "validated", cc.COLUMN_NAME AS "column_name"
+FROM USER_CONSTRAINTS c
+LEFT JOIN USER_CONS_COLUMNS cc ON cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+WHERE c.TABLE_NAME = ? AND c.CONSTRAINT_TYPE IN ('P', 'U', 'R', 'C')
+AND NOT (c.CONSTRAINT_TYPE = 'C' AND c.GENERATED = 'GENERATED NAME')
+ORDER BY c.CONSTRAINT_NAME, cc.POSITION`,
+		table,
+	).Scan(&columns).Error; err != nil {
+		return nil, err
+	}
+
+	kinds := map[string]string{"P": "PRIMARY KEY", "U": "UNIQUE", "R": "FOREIGN KEY", "C": "CHECK"}
+	var constraints []ReportedObject
+	for _, column := range columns {
+		if len(constraints) == 0 || constraints[len(constraints)-1].DatabaseName != column.ConstraintName {
+			constraint := ReportedObject{Kind: kinds[column.ConstraintType], DatabaseName: column.ConstraintName, Status: StatusValid}
+			switch {
+			case column.Status == "DISABLED":
+				constraint.Status = StatusDisabled
+			case column.Validated == "NOT VALIDATED":
+				constraint.Status = StatusNotValidated
+			}
+			constraints = append(constraints, constraint)
+		}
+		if column.ColumnName != "" && column.ConstraintType != "C" {
+			constraint := &constraints[len(constraints)-1]
+			constraint.Columns = append(constraint.Columns, QuoteIdentifier(column.ColumnName))
+		}
+	}
+	return constraints, nil
+}
+
+// sqlToken is a token of a SQL statement: a word, a quoted identifier, a
+// literal or a punctuation character
+type sqlToken struct {
+	text string
+	kind byte
+}
+
+const (
+	tokenWord    = 'w'
+	tokenQuoted  = 'q'
+	tokenLiteral = 'l'
+	tokenPunct   = 'p'
+)
+
+// sqlTokens splits the SQL into tokens, leaving out whitespace and comments
+func sqlTokens(sql string) []sqlToken {
+	var tokens []sqlToken
+	for i := 0; i < len(sql); {
+		if next := skipSQLLiteral(sql, i); next > i {
+			switch {
+			case sql[i] == '"':
+				tokens = append(tokens, sqlToken{text: sql[i:next], kind: tokenQuoted})
+			case sql[i] != '-' && sql[i] != '/':
+				tokens = append(tokens, sqlToken{text: sql[i:next], kind: tokenLiteral})
+			}
+			i = next
+			continue
+		}
+
+		switch c := sql[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isIdentifierChar(c):
+			j := i
+			for j < len(sql) && isIdentifierChar(sql[j]) {
+				j++
+			}
+			tokens = append(tokens, sqlToken{text: sql[i:j], kind: tokenWord})
+			i = j
+		default:
+			tokens = append(tokens, sqlToken{text: sql[i : i+1], kind: tokenPunct})
+			i++
+		}
+	}
+	return tokens
+}
+
+// isColumnRef reports whether the token at idx names a column: a quoted
+// identifier or a word that is not a keyword, a function, a number, a bind
+// placeholder or the qualifier of a column
+func isColumnRef(tokens []sqlToken, idx int) bool {
+	token := tokens[idx]
+	if token.kind != tokenQuoted && token.kind != tokenWord {
+		return false
+	}
+	if idx+1 < len(tokens) && tokens[idx+1].kind == tokenPunct && (tokens[idx+1].text == "." || tokens[idx+1].text == "(") {
+		return false
+	}
+	if token.kind == tokenWord {
+		if c := token.text[0]; c >= '0' && c <= '9' || sqlKeywords[strings.ToUpper(token.text)] {
+			return false
+		}
+		if idx > 0 && tokens[idx-1].text == ":" {
+			return false
+		}
+	}
+	return true
+}
+
+// columnRefName returns the name Oracle resolves a column token to, quoted
+func columnRefName(token sqlToken) string {
+	if token.kind == tokenQuoted {
+		return token.text
+	}
+	return QuoteIdentifier(strings.ToUpper(token.text))
+}
+
+// normalizeTokens joins the tokens in a canonical form to compare
+// expressions: without whitespace and column qualifiers, with keywords and
+// functions in uppercase and the columns as Oracle resolves them
+func normalizeTokens(tokens []sqlToken) string {
+	var builder strings.Builder
+	for idx := 0; idx < len(tokens); idx++ {
+		token := tokens[idx]
+		if (token.kind == tokenQuoted || token.kind == tokenWord) && idx+2 < len(tokens) && tokens[idx+1].text == "." &&
+			(tokens[idx+2].kind == tokenQuoted || tokens[idx+2].kind == tokenWord) {
+			// Qualifier of a column
+			idx++
+			continue
+		}
+		switch {
+		case isColumnRef(tokens, idx):
+			builder.WriteString(columnRefName(token))
+		case token.kind == tokenWord && (idx == 0 || tokens[idx-1].text != ":"):
+			builder.WriteString(strings.ToUpper(token.text))
+		default:
+			builder.WriteString(token.text)
+		}
+	}
+	return builder.String()
+}
+
+// normalizeSQL returns the canonical form of a SQL expression, see
+// normalizeTokens
+func normalizeSQL(sql string) string {
+	return normalizeTokens(sqlTokens(sql))
+}
+
+// columnRef is a column named in a condition, and the function it is
+// directly passed to, if any
+type columnRef struct {
+	name     string
+	function string
+}
+
+// whereTokens returns the tokens from the first WHERE of the statement to
+// the ORDER BY or GROUP BY clause of the same query
+func whereTokens(tokens []sqlToken) []sqlToken {
+	start, depth := -1, 0
+	for idx, token := range tokens {
+		switch {
+		case token.text == "(":
+			depth++
+		case token.text == ")":
+			depth--
+		case token.kind != tokenWord:
+		case start < 0 && strings.EqualFold(token.text, "WHERE"):
+			start = idx + 1
+			depth = 0
+		case start >= 0 && depth == 0 && (strings.EqualFold(token.text, "ORDER") || strings.EqualFold(token.text, "GROUP")):
+			return tokens[start:idx]
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+	return tokens[start:]
+}
+
+// columnRefs returns the columns named in the tokens
+func columnRefs(tokens []sqlToken) []columnRef {
+	var (
+		refs      []columnRef
+		functions []string
+	)
+	for idx, token := range tokens {
+		switch {
+		case token.text == "(":
+			function := ""
+			if idx > 0 && tokens[idx-1].kind == tokenWord && !sqlKeywords[strings.ToUpper(tokens[idx-1].text)] {
+				function = strings.ToUpper(tokens[idx-1].text)
+			}
+			functions = append(functions, function)
+		case token.text == ")":
+			if len(functions) > 0 {
+				functions = functions[:len(functions)-1]
+			}
+		case isColumnRef(tokens, idx):
+			ref := columnRef{name: columnRefName(token)}
+			if len(functions) > 0 {
+				ref.function = functions[len(functions)-1]
+			}
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// workloadIssues returns the statements of the workload on the table that
+// filter on the leading column of indexes without being able to use any of
+// them
+func workloadIssues(table string, indexes []ReportedObject, workload []string) []QueryIssue {
+	// The indexes by the columns of their leading key
+	type leadingKey struct {
+		index      ReportedObject
+		expression string
+		plain      bool
+	}
+	byColumn := map[string][]leadingKey{}
+	var columns []string
+	for _, index := range indexes {
+		if len(index.Columns) == 0 {
+			continue
+		}
+		tokens := sqlTokens(index.Columns[0])
+		key := leadingKey{index: index, expression: normalizeTokens(tokens), plain: len(tokens) == 1}
+		for idx := range tokens {
+			if isColumnRef(tokens, idx) {
+				column := columnRefName(tokens[idx])
+				if _, ok := byColumn[column]; !ok {
+					columns = append(columns, column)
+				}
+				byColumn[column] = append(byColumn[column], key)
+			}
+		}
+	}
+
+	var issues []QueryIssue
+	seen := map[string]bool{}
+	for _, sql := range workload {
+		if seen[sql] {
+			continue
+		}
+		seen[sql] = true
+
+		tokens := sqlTokens(sql)
+		if !slices.ContainsFunc(tokens, func(token sqlToken) bool {
+			return token.kind == tokenQuoted && token.text == QuoteIdentifier(table) ||
+				token.kind == tokenWord && strings.ToUpper(token.text) == table
+		}) {
+			continue
+		}
+		where := whereTokens(tokens)
+		refs := columnRefs(where)
+		normalized := normalizeTokens(where)
+
+		for _, column := range columns {
+			var wrapped, otherCase []string
+			referenced, usable := false, false
+			for _, ref := range refs {
+				switch {
+				case ref.name == column:
+					referenced = true
+					if ref.function != "" && !slices.Contains(wrapped, ref.function) {
+						wrapped = append(wrapped, ref.function)
+					}
+				case strings.EqualFold(ref.name, column) && byColumn[ref.name] == nil && !slices.Contains(otherCase, ref.name):
+					otherCase = append(otherCase, ref.name)
+				}
+			}
+			if !referenced && len(otherCase) == 0 {
+				continue
+			}
+			for _, key := range byColumn[column] {
+				if key.plain {
+					usable = usable || slices.ContainsFunc(refs, func(ref columnRef) bool { return ref.name == column && ref.function == "" })
+				} else {
+					usable = usable || strings.Contains(normalized, key.expression)
+				}
+			}
+			if usable {
+				continue
+			}
+
+			for _, key := range byColumn[column] {
+				issue := QueryIssue{SQL: sql, Index: key.index.DatabaseName}
+				switch {
+				case len(otherCase) > 0:
+					issue.Reason = fmt.Sprintf("the statement refers to %s, which Oracle does not resolve to the column %s of the index",
+						strings.Join(otherCase, ", "), column)
+				case key.plain:
+					issue.Reason = fmt.Sprintf("the column %s is only compared through %s(), which the index on the column does not cover",
+						column, strings.Join(wrapped, "(), "))
+				default:
+					issue.Reason = fmt.Sprintf("the statement filters on %s but not on the expression %s of the index",
+						column, key.index.Columns[0])
+				}
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
+
+// DefaultWorkloadSize is the number of distinct statements a
+// WorkloadRecorder keeps when created without a limit
+const DefaultWorkloadSize = 1000
+
+// WorkloadRecorder is a logger that records the distinct statements traced
+// through it, to check them against the indexes of their tables with
+// Migrator.IndexReport. Statements are recorded as traced, with their bind
+// values, until the limit of the recorder is reached.
+//
+//	recorder := oracle.NewWorkloadRecorder(logger.Default, 0)
+//	db = db.Session(&gorm.Session{Logger: recorder})
+//	// run the workload
+//	report, err := db.Migrator().(oracle.Migrator).IndexReport(&User{}, recorder.Statements()...)
+type WorkloadRecorder struct {
+	logger.Interface
+	workload *recordedWorkload
+}
+
+type recordedWorkload struct {
+	mu         sync.Mutex
+	limit      int
+	seen       map[string]bool
+	statements []string
+}
+
+// NewWorkloadRecorder returns a recorder that logs through l and keeps up to
+// limit statements, DefaultWorkloadSize if zero
+func NewWorkloadRecorder(l logger.Interface, limit int) *WorkloadRecorder {
+	if limit <= 0 {
+		limit = DefaultWorkloadSize
+	}
+	return &WorkloadRecorder{Interface: l, workload: &recordedWorkload{limit: limit, seen: map[string]bool{}}}
+}
+
+// LogMode returns a copy of the recorder with the log level, which records
+// into the same workload
+func (r *WorkloadRecorder) LogMode(level logger.LogLevel) logger.Interface {
+	return &WorkloadRecorder{Interface: r.Interface.LogMode(level), workload: r.workload}
+}
+
+// Trace records the statement and traces it
+func (r *WorkloadRecorder) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	w := r.workload
+	w.mu.Lock()
+	if len(w.statements) < w.limit {
+		if sql, _ := fc(); !w.seen[sql] {
+			w.seen[sql] = true
+			w.statements = append(w.statements, sql)
+		}
+	}
+	w.mu.Unlock()
+	r.Interface.Trace(ctx, begin, fc, err)
+}
+
+// Statements returns the recorded statements, in the order they first ran
+func (r *WorkloadRecorder) Statements() []string {
+	r.workload.mu.Lock()
+	defer r.workload.mu.Unlock()
+	return slices.Clone(r.workload.statements)
+}
+
+// Reset clears the recorded statements
+func (r *WorkloadRecorder) Reset() {
+	r.workload.mu.Lock()
+	defer r.workload.mu.Unlock()
+	r.workload.seen = map[string]bool{}
+	r.workload.statements = nil
+}
@@ -0,0 +1,103 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"errors"
+	"fmt"
+)
+
+// hasPrivilege reports whether the session has the system privilege,
+// directly or through a role. It reports true when SESSION_PRIVS cannot be
+// read, leaving the statement to fail.
+func (m Migrator) hasPrivilege(privilege string) bool {
+	var count int
+	err := m.DB.Raw(`SELECT COUNT(*) FROM SESSION_PRIVS WHERE PRIVILEGE = ?`, privilege).Scan(&count).Error
+	return err != nil || count > 0
+}
+
+// privilegeError returns a PrivilegeError for an ORA-01031 error, and the
+// error itself otherwise
+func privilegeError(err error, privilege, object string) error {
+	if err != nil && errors.Is(err, ErrInsufficientPrivileges) {
+		return &PrivilegeError{Privilege: privilege, Object: object, Err: err}
+	}
+	return err
+}
+
+// CreateDirectory creates the directory object name for the path of the
+// database server, or points it at path if it exists, as used by external
+// tables and BFILE columns. It returns a PrivilegeError when the user lacks
+// CREATE ANY DIRECTORY.
+func (m Migrator) CreateDirectory(name, path string) error {
+	if !identifierRegexp.MatchString(name) {
+		return fmt.Errorf("invalid directory name %q", name)
+	}
+	object := "directory " + name
+	if !m.hasPrivilege("CREATE ANY DIRECTORY") {
+		return &PrivilegeError{Privilege: "CREATE ANY DIRECTORY", Object: object}
+	}
+	err := m.DB.Exec("CREATE OR REPLACE DIRECTORY " + name + " AS " + quoteLiteral(path)).Error
+	return privilegeError(err, "CREATE ANY DIRECTORY", object)
+}
+
+// DropDirectory drops the directory object name. It returns a PrivilegeError
+// when the user lacks DROP ANY DIRECTORY.
+func (m Migrator) DropDirectory(name string) error {
+	if !identifierRegexp.MatchString(name) {
+		return fmt.Errorf("invalid directory name %q", name)
+	}
+	object := "directory " + name
+	if !m.hasPrivilege("DROP ANY DIRECTORY") {
+		return &PrivilegeError{Privilege: "DROP ANY DIRECTORY", Object: object}
+	}
+	err := m.DB.Exec("DROP DIRECTORY " + name).Error
+	return privilegeError(err, "DROP ANY DIRECTORY", object)
+}
+
+// HasDirectory reports whether the directory object name exists and the
+// user may access it
+func (m Migrator) HasDirectory(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	var count int
+	err := m.DB.Raw(`SELECT COUNT(*) FROM ALL_DIRECTORIES WHERE DIRECTORY_NAME = UPPER(?)`, name).Scan(&count).Error
+	return err == nil && count > 0
+}
@@ -0,0 +1,123 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnsupportedByDriver is returned for operations that rely on features
+// that the database/sql driver does not provide
+var ErrUnsupportedByDriver = errors.New("not supported by the database driver")
+
+// DriverFeatures are the capabilities of the database/sql driver that the
+// advanced code paths of the dialector rely on. They are detected from the
+// driver when the database is opened, unless Config.DriverFeatures is set.
+//
+// The dialector is built for godror, the driver based on ODPI-C and the
+// Oracle Client libraries. Pure Go drivers such as go-ora can be used by
+// importing them and setting Config.DriverName, for example to "oracle" for
+// go-ora, with the features below disabled.
+type DriverFeatures struct {
+	// LOBStreaming is set when LOB values can be bound and read as
+	// godror.Lob streams, and returned through OUT binds
+	LOBStreaming bool
+	// PLSQLArrays is set when slices can be bound as PL/SQL collections,
+	// which bulk creates with RETURNING use
+	PLSQLArrays bool
+	// ArrayDML is set when slices bound to an INSERT, MERGE or DELETE are
+	// executed once per element, which bulk creates without RETURNING, and
+	// the upserts and deletes of many2many join tables use
+	ArrayDML bool
+	// FetchOptions is set when the prefetch count and fetch array size of a
+	// query can be passed to the driver with its arguments
+	FetchOptions bool
+	// ImplicitResults is set when the result sets that a PL/SQL block
+	// returns with DBMS_SQL.RETURN_RESULT are read with Rows.NextResultSet
+	ImplicitResults bool
+	// NativeJSON is set when the driver returns the values of native JSON
+	// columns as documents that are converted to JSON text when scanned
+	NativeJSON bool
+}
+
+// godrorFeatures are the features of the godror driver
+var godrorFeatures = DriverFeatures{LOBStreaming: true, PLSQLArrays: true, ArrayDML: true, FetchOptions: true, ImplicitResults: true, NativeJSON: true}
+
+// detectDriverFeatures returns the features of the driver of the connection
+// pool. Drivers other than godror get no features.
+func detectDriverFeatures(pool gorm.ConnPool) DriverFeatures {
+	db, ok := pool.(*sql.DB)
+	if !ok {
+		return DriverFeatures{}
+	}
+
+	driverType := reflect.TypeOf(db.Driver())
+	for driverType.Kind() == reflect.Ptr {
+		driverType = driverType.Elem()
+	}
+	if strings.HasPrefix(driverType.PkgPath(), "github.com/godror/godror") {
+		return godrorFeatures
+	}
+	return DriverFeatures{}
+}
+
+// Features returns the features of the driver. They are known once the
+// database has been opened.
+func (d Dialector) Features() DriverFeatures {
+	if d.Config == nil || d.DriverFeatures == nil {
+		return godrorFeatures
+	}
+	return *d.DriverFeatures
+}
+
+// driverFeatures returns the features of the driver of the database
+func driverFeatures(db *gorm.DB) DriverFeatures {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		return d.Features()
+	case Dialector:
+		return d.Features()
+	}
+	return godrorFeatures
+}
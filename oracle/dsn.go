@@ -0,0 +1,451 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Address is a listener address of an EZConnect connect string
+type Address struct {
+	Host string
+	// Port defaults to 1521
+	Port int
+}
+
+// EZConnect describes an EZConnect Plus connect string, such as
+//
+//	tcps://host1:1521,host2:1521;host3:1522/sales.example.com?retry_count=3&transport_connect_timeout=5
+//
+// Each address list is tried in turn, and the addresses within a list are
+// load balanced, which suits RAC and Data Guard configurations:
+//
+//	connect := oracle.EZConnect{
+//		Protocol:     "tcps",
+//		AddressLists: [][]oracle.Address{{{Host: "host1"}, {Host: "host2"}}, {{Host: "standby"}}},
+//		ServiceName:  "sales.example.com",
+//		RetryCount:   3,
+//		TransportConnectTimeout: 5 * time.Second,
+//	}
+//	dsn, err := connect.DSN("scott", "tiger")
+//	db, err := gorm.Open(oracle.Open(dsn), &gorm.Config{})
+type EZConnect struct {
+	// Protocol is tcp or tcps. The default is tcp.
+	Protocol     string
+	AddressLists [][]Address
+	ServiceName  string
+	// Server is dedicated, shared or pooled
+	Server       string
+	InstanceName string
+
+	// RetryCount is the number of times the address lists are tried again
+	RetryCount int
+	// RetryDelay is the delay between retries, in whole seconds
+	RetryDelay time.Duration
+	// TransportConnectTimeout limits the time to establish a connection to
+	// an address
+	TransportConnectTimeout time.Duration
+	// ConnectTimeout limits the time to establish a session, in whole seconds
+	ConnectTimeout time.Duration
+	// ExpireTime is the interval of dead connection detection probes, in
+	// whole minutes
+	ExpireTime time.Duration
+
+	// HTTPSProxy and HTTPSProxyPort route tcps connections through a proxy
+	HTTPSProxy     string
+	HTTPSProxyPort int
+
+	WalletLocation   string
+	SSLServerDNMatch bool
+	SSLServerCertDN  string
+
+	// Params are additional EZConnect Plus parameters
+	Params map[string]string
+
+	// FailoverMode configures Transparent Application Failover. It cannot
+	// be expressed in EZConnect Plus syntax, so the connect string is
+	// written as a connect descriptor when it is set.
+	FailoverMode *FailoverMode
+	// Events enables Fast Application Notification, so that the connection
+	// pool learns of instances going down and of planned maintenance, which
+	// Application Continuity relies on to drain and replay sessions
+	Events bool
+}
+
+// FailoverMode is the FAILOVER_MODE of Transparent Application Failover:
+// when the instance of a session fails, the session reconnects to another
+// one, and with the SELECT type, open queries resume fetching. Uncommitted
+// transactions are rolled back. Application Continuity, which also replays
+// transactions, is enabled on the database service rather than in the
+// connect string.
+type FailoverMode struct {
+	// Type is SESSION or SELECT
+	Type string
+	// Method is BASIC, the default, or PRECONNECT
+	Method string
+	// Backup is the net service name of the backup connection of the
+	// PRECONNECT method
+	Backup string
+	// Retries is the number of reconnection attempts
+	Retries int
+	// Delay is the delay between reconnection attempts, in whole seconds
+	Delay time.Duration
+}
+
+var (
+	ezConnectHostRegexp  = regexp.MustCompile(`^[A-Za-z0-9._-]+$|^\[?[0-9A-Fa-f:.]+\]?$`)
+	ezConnectNameRegexp  = regexp.MustCompile(`^[A-Za-z0-9._$#-]+$`)
+	ezConnectParamRegexp = regexp.MustCompile(`^[a-z_]+$`)
+)
+
+// Validate checks that the connect string can be built
+func (c EZConnect) Validate() error {
+	var errs []error
+	if c.Protocol != "" && !slices.Contains([]string{"tcp", "tcps"}, strings.ToLower(c.Protocol)) {
+		errs = append(errs, fmt.Errorf("unsupported protocol %q", c.Protocol))
+	}
+	if len(c.AddressLists) == 0 {
+		errs = append(errs, errors.New("at least one address is required"))
+	}
+	for _, addresses := range c.AddressLists {
+		if len(addresses) == 0 {
+			errs = append(errs, errors.New("address lists cannot be empty"))
+		}
+		for _, address := range addresses {
+			if !ezConnectHostRegexp.MatchString(address.Host) {
+				errs = append(errs, fmt.Errorf("invalid host %q", address.Host))
+			}
+			if address.Port < 0 || address.Port > 65535 {
+				errs = append(errs, fmt.Errorf("invalid port %d", address.Port))
+			}
+		}
+	}
+	if c.ServiceName != "" && !ezConnectNameRegexp.MatchString(c.ServiceName) {
+		errs = append(errs, fmt.Errorf("invalid service name %q", c.ServiceName))
+	}
+	if c.InstanceName != "" && !ezConnectNameRegexp.MatchString(c.InstanceName) {
+		errs = append(errs, fmt.Errorf("invalid instance name %q", c.InstanceName))
+	}
+	if c.Server != "" && !slices.Contains([]string{"dedicated", "shared", "pooled"}, strings.ToLower(c.Server)) {
+		errs = append(errs, fmt.Errorf("unsupported server %q", c.Server))
+	}
+	if (c.Server != "" || c.InstanceName != "") && c.ServiceName == "" {
+		errs = append(errs, errors.New("server and instance name require a service name"))
+	}
+	if c.RetryCount < 0 || c.RetryDelay < 0 || c.TransportConnectTimeout < 0 || c.ConnectTimeout < 0 || c.ExpireTime < 0 {
+		errs = append(errs, errors.New("retries and timeouts cannot be negative"))
+	}
+	if c.HTTPSProxy != "" {
+		if !strings.EqualFold(c.Protocol, "tcps") {
+			errs = append(errs, errors.New("an HTTPS proxy requires the tcps protocol"))
+		}
+		if !ezConnectHostRegexp.MatchString(c.HTTPSProxy) {
+			errs = append(errs, fmt.Errorf("invalid HTTPS proxy %q", c.HTTPSProxy))
+		}
+		if c.HTTPSProxyPort <= 0 || c.HTTPSProxyPort > 65535 {
+			errs = append(errs, fmt.Errorf("invalid HTTPS proxy port %d", c.HTTPSProxyPort))
+		}
+	}
+	for key, value := range c.Params {
+		if !ezConnectParamRegexp.MatchString(key) {
+			errs = append(errs, fmt.Errorf("invalid parameter name %q", key))
+		}
+		if strings.ContainsAny(value, "&=?\"") {
+			errs = append(errs, fmt.Errorf("invalid value %q of parameter %s", value, key))
+		}
+	}
+	for _, value := range []string{c.WalletLocation, c.SSLServerCertDN} {
+		if strings.ContainsAny(value, "&?\"") {
+			errs = append(errs, fmt.Errorf("invalid parameter value %q", value))
+		}
+	}
+	if mode := c.FailoverMode; mode != nil {
+		if !slices.Contains([]string{"session", "select"}, strings.ToLower(mode.Type)) {
+			errs = append(errs, fmt.Errorf("unsupported failover type %q", mode.Type))
+		}
+		if mode.Method != "" && !slices.Contains([]string{"basic", "preconnect"}, strings.ToLower(mode.Method)) {
+			errs = append(errs, fmt.Errorf("unsupported failover method %q", mode.Method))
+		}
+		if strings.EqualFold(mode.Method, "preconnect") != (mode.Backup != "") {
+			errs = append(errs, errors.New("a failover backup is required by and only allowed with the PRECONNECT method"))
+		}
+		if mode.Backup != "" && !ezConnectNameRegexp.MatchString(mode.Backup) {
+			errs = append(errs, fmt.Errorf("invalid failover backup %q", mode.Backup))
+		}
+		if mode.Retries < 0 || mode.Delay < 0 {
+			errs = append(errs, errors.New("failover retries and delay cannot be negative"))
+		}
+		for _, value := range c.Params {
+			if strings.ContainsAny(value, "()") {
+				errs = append(errs, fmt.Errorf("invalid value %q of connect descriptor parameter", value))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String returns the connect string. It does not validate it.
+func (c EZConnect) String() string {
+	if c.FailoverMode != nil {
+		return c.Descriptor()
+	}
+
+	var builder strings.Builder
+	if c.Protocol != "" {
+		builder.WriteString(strings.ToLower(c.Protocol) + "://")
+	}
+
+	for i, addresses := range c.AddressLists {
+		if i > 0 {
+			builder.WriteByte(';')
+		}
+		for j, address := range addresses {
+			if j > 0 {
+				builder.WriteByte(',')
+			}
+			host := address.Host
+			if strings.Contains(host, ":") && !strings.HasPrefix(host, "[") {
+				// IPv6 addresses are enclosed in brackets
+				host = "[" + host + "]"
+			}
+			builder.WriteString(host)
+			if address.Port != 0 {
+				builder.WriteString(":" + strconv.Itoa(address.Port))
+			}
+		}
+	}
+
+	if c.ServiceName != "" {
+		builder.WriteString("/" + c.ServiceName)
+		if c.Server != "" {
+			builder.WriteString(":" + strings.ToLower(c.Server))
+		}
+		if c.InstanceName != "" {
+			builder.WriteString("/" + c.InstanceName)
+		}
+	}
+
+	var params []string
+	addParam := func(key, value string) {
+		params = append(params, key+"="+value)
+	}
+	if c.RetryCount > 0 {
+		addParam("retry_count", strconv.Itoa(c.RetryCount))
+	}
+	if c.RetryDelay > 0 {
+		addParam("retry_delay", strconv.Itoa(int(c.RetryDelay.Seconds())))
+	}
+	if c.TransportConnectTimeout > 0 {
+		if c.TransportConnectTimeout%time.Second == 0 {
+			addParam("transport_connect_timeout", strconv.Itoa(int(c.TransportConnectTimeout.Seconds())))
+		} else {
+			addParam("transport_connect_timeout", strconv.FormatInt(c.TransportConnectTimeout.Milliseconds(), 10)+"ms")
+		}
+	}
+	if c.ConnectTimeout > 0 {
+		addParam("connect_timeout", strconv.Itoa(int(c.ConnectTimeout.Seconds())))
+	}
+	if c.ExpireTime > 0 {
+		addParam("expire_time", strconv.Itoa(int(c.ExpireTime.Minutes())))
+	}
+	if c.HTTPSProxy != "" {
+		addParam("https_proxy", c.HTTPSProxy)
+		addParam("https_proxy_port", strconv.Itoa(c.HTTPSProxyPort))
+	}
+	if c.WalletLocation != "" {
+		addParam("wallet_location", c.WalletLocation)
+	}
+	if c.SSLServerDNMatch {
+		addParam("ssl_server_dn_match", "true")
+	}
+	if c.SSLServerCertDN != "" {
+		addParam("ssl_server_cert_dn", c.SSLServerCertDN)
+	}
+	keys := make([]string, 0, len(c.Params))
+	for key := range c.Params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		addParam(key, c.Params[key])
+	}
+
+	if len(params) > 0 {
+		builder.WriteString("?" + strings.Join(params, "&"))
+	}
+	return builder.String()
+}
+
+// Descriptor returns the connect string as a connect descriptor, such as
+//
+//	(DESCRIPTION=(RETRY_COUNT=3)(ADDRESS_LIST=(LOAD_BALANCE=ON)(ADDRESS=(PROTOCOL=TCP)(HOST=host1)(PORT=1521))(ADDRESS=(PROTOCOL=TCP)(HOST=host2)(PORT=1521)))(CONNECT_DATA=(SERVICE_NAME=sales.example.com)(FAILOVER_MODE=(TYPE=SELECT)(METHOD=BASIC))))
+//
+// Each address list is tried in turn, and the addresses within a list are
+// load balanced, as in EZConnect Plus syntax. Params are added to the
+// DESCRIPTION. It does not validate the connect string.
+func (c EZConnect) Descriptor() string {
+	var builder strings.Builder
+	writeParam := func(key, value string) {
+		builder.WriteString("(" + key + "=" + value + ")")
+	}
+
+	builder.WriteString("(DESCRIPTION=")
+	if c.RetryCount > 0 {
+		writeParam("RETRY_COUNT", strconv.Itoa(c.RetryCount))
+	}
+	if c.RetryDelay > 0 {
+		writeParam("RETRY_DELAY", strconv.Itoa(int(c.RetryDelay.Seconds())))
+	}
+	if c.TransportConnectTimeout > 0 {
+		if c.TransportConnectTimeout%time.Second == 0 {
+			writeParam("TRANSPORT_CONNECT_TIMEOUT", strconv.Itoa(int(c.TransportConnectTimeout.Seconds())))
+		} else {
+			writeParam("TRANSPORT_CONNECT_TIMEOUT", strconv.FormatInt(c.TransportConnectTimeout.Milliseconds(), 10)+"ms")
+		}
+	}
+	if c.ConnectTimeout > 0 {
+		writeParam("CONNECT_TIMEOUT", strconv.Itoa(int(c.ConnectTimeout.Seconds())))
+	}
+	if c.ExpireTime > 0 {
+		writeParam("EXPIRE_TIME", strconv.Itoa(int(c.ExpireTime.Minutes())))
+	}
+	keys := make([]string, 0, len(c.Params))
+	for key := range c.Params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		writeParam(strings.ToUpper(key), c.Params[key])
+	}
+
+	protocol := "TCP"
+	if c.Protocol != "" {
+		protocol = strings.ToUpper(c.Protocol)
+	}
+	for _, addresses := range c.AddressLists {
+		builder.WriteString("(ADDRESS_LIST=")
+		if len(addresses) > 1 {
+			writeParam("LOAD_BALANCE", "ON")
+		}
+		for _, address := range addresses {
+			port := address.Port
+			if port == 0 {
+				port = 1521
+			}
+			builder.WriteString("(ADDRESS=")
+			writeParam("PROTOCOL", protocol)
+			writeParam("HOST", strings.Trim(address.Host, "[]"))
+			writeParam("PORT", strconv.Itoa(port))
+			if c.HTTPSProxy != "" {
+				writeParam("HTTPS_PROXY", c.HTTPSProxy)
+				writeParam("HTTPS_PROXY_PORT", strconv.Itoa(c.HTTPSProxyPort))
+			}
+			builder.WriteString(")")
+		}
+		builder.WriteString(")")
+	}
+
+	builder.WriteString("(CONNECT_DATA=")
+	if c.ServiceName != "" {
+		writeParam("SERVICE_NAME", c.ServiceName)
+	}
+	if c.Server != "" {
+		writeParam("SERVER", strings.ToUpper(c.Server))
+	}
+	if c.InstanceName != "" {
+		writeParam("INSTANCE_NAME", c.InstanceName)
+	}
+	if mode := c.FailoverMode; mode != nil {
+		method := "BASIC"
+		if mode.Method != "" {
+			method = strings.ToUpper(mode.Method)
+		}
+		builder.WriteString("(FAILOVER_MODE=")
+		writeParam("TYPE", strings.ToUpper(mode.Type))
+		writeParam("METHOD", method)
+		if mode.Backup != "" {
+			writeParam("BACKUP", mode.Backup)
+		}
+		if mode.Retries > 0 {
+			writeParam("RETRIES", strconv.Itoa(mode.Retries))
+		}
+		if mode.Delay > 0 {
+			writeParam("DELAY", strconv.Itoa(int(mode.Delay.Seconds())))
+		}
+		builder.WriteString(")")
+	}
+	builder.WriteString(")")
+
+	if c.SSLServerDNMatch || c.SSLServerCertDN != "" || c.WalletLocation != "" {
+		builder.WriteString("(SECURITY=")
+		if c.SSLServerDNMatch {
+			writeParam("SSL_SERVER_DN_MATCH", "TRUE")
+		}
+		if c.SSLServerCertDN != "" {
+			writeParam("SSL_SERVER_CERT_DN", strconv.Quote(c.SSLServerCertDN))
+		}
+		if c.WalletLocation != "" {
+			writeParam("MY_WALLET_DIRECTORY", c.WalletLocation)
+		}
+		builder.WriteString(")")
+	}
+	builder.WriteString(")")
+	return builder.String()
+}
+
+// DSN validates the connect string and returns a godror data source name
+// that connects with it as the given user
+func (c EZConnect) DSN(user, password string) (string, error) {
+	if err := c.Validate(); err != nil {
+		return "", fmt.Errorf("invalid EZConnect connect string: %w", err)
+	}
+
+	dsn := fmt.Sprintf("user=%s password=%s connectString=%s",
+		strconv.Quote(user), strconv.Quote(password), strconv.Quote(c.String()))
+	if c.Events {
+		dsn += " enableEvents=1"
+	}
+	return dsn, nil
+}
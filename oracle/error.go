@@ -0,0 +1,334 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+)
+
+// Error is an Oracle error returned by the database, together with the
+// statement that raised it. The statement only contains bind placeholders,
+// never the bound values.
+//
+//	var oraErr *oracle.Error
+//	if errors.As(err, &oraErr) && oraErr.Code == 1400 {
+//		// cannot insert NULL
+//	}
+//
+// errors.Is matches errors with the same code:
+//
+//	errors.Is(err, &oracle.Error{Code: 1})
+//	errors.Is(err, oracle.ErrDeadlock)
+type Error struct {
+	// Code is the ORA error code, such as 1 for ORA-00001
+	Code int
+	// Offset is the position in the statement where the error was detected
+	Offset int
+	// Message is the error message without the ORA-NNNNN prefix
+	Message string
+	// SQL is the statement that raised the error
+	SQL string
+	// Err is the error returned by the driver
+	Err error
+
+	// Constraint is the name of the unique constraint or index violated by
+	// an ORA-00001 error
+	Constraint string
+	// Columns are the columns of the violated unique constraint or index
+	Columns []string
+	// Fields are the names of the fields of the model mapped to Columns
+	Fields []string
+
+	// translated is the GORM error this error was translated to
+	translated error
+}
+
+var (
+	// ErrDeadlock matches errors raised when a deadlock was detected while
+	// waiting for a resource (ORA-00060)
+	ErrDeadlock = &Error{Code: 60, Message: "deadlock detected while waiting for resource"}
+	// ErrLockTimeout matches errors raised when a lock could not be acquired
+	// within the WAIT timeout (ORA-30006)
+	ErrLockTimeout = &Error{Code: 30006, Message: "resource busy; acquire with WAIT timeout expired"}
+	// ErrInsufficientPrivileges matches errors raised when the user lacks a
+	// privilege the statement needs (ORA-01031)
+	ErrInsufficientPrivileges = &Error{Code: 1031, Message: "insufficient privileges"}
+)
+
+// PrivilegeError reports that the user lacks a system privilege that an
+// operation of the migrator needs. errors.Is matches it with
+// ErrInsufficientPrivileges.
+type PrivilegeError struct {
+	// Privilege is the missing privilege, such as CREATE ANY DIRECTORY
+	Privilege string
+	// Object is the object the operation was applied to
+	Object string
+	// Err is the error returned by the database, if the operation ran
+	Err error
+}
+
+// Error returns a message naming the missing privilege
+func (e *PrivilegeError) Error() string {
+	msg := fmt.Sprintf("privilege %s is required for %s", e.Privilege, e.Object)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the error returned by the database
+func (e *PrivilegeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrInsufficientPrivileges
+func (e *PrivilegeError) Is(target error) bool {
+	return target == ErrInsufficientPrivileges
+}
+
+// XAError reports that a DBMS_XA call of a distributed transaction failed
+type XAError struct {
+	// Operation is the DBMS_XA function, such as XA_PREPARE
+	Operation string
+	// XID is the branch the function was called for
+	XID XID
+	// Code is the XA return code, such as -4 for XAER_NOTA
+	Code int
+	// Err is the Oracle error reported by XA_GETLASTOER, if any
+	Err error
+}
+
+// Error returns a message naming the operation and the return code
+func (e *XAError) Error() string {
+	msg := fmt.Sprintf("%s of XA transaction %s failed with code %d", e.Operation, e.XID, e.Code)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the Oracle error
+func (e *XAError) Unwrap() error {
+	return e.Err
+}
+
+// Error returns the message of the driver error
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("ORA-%05d: %s", e.Code, e.Message)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the driver error and, with TranslateError enabled, the
+// GORM error it was translated to
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return nil
+	}
+	if e.translated != nil {
+		return []error{e.Err, e.translated}
+	}
+	return []error{e.Err}
+}
+
+// Is reports whether the target is an Error with the same code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// ErrorCode returns the ORA error code of the error, or 0 if it is not an
+// Oracle error
+func ErrorCode(err error) int {
+	if e := asError(err); e != nil {
+		return e.Code
+	}
+	return 0
+}
+
+// asError returns the Error wrapped by err, or a new Error for the driver
+// error wrapped by err. It returns nil if err is not an Oracle error.
+func asError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	if oraErr, ok := godror.AsOraErr(err); ok {
+		return &Error{
+			Code:    oraErr.Code(),
+			Offset:  oraErr.Offset(),
+			Message: oraErr.Message(),
+			Err:     err,
+		}
+	}
+	// Other drivers report the code in the message only
+	if matches := errorCodeRegexp.FindStringSubmatch(err.Error()); matches != nil {
+		code, _ := strconv.Atoi(matches[1])
+		return &Error{Code: code, Message: strings.TrimSpace(matches[2]), Err: err}
+	}
+	return nil
+}
+
+// errorCodeRegexp matches the code and message of an ORA-NNNNN error message
+var errorCodeRegexp = regexp.MustCompile(`ORA-(\d{5}):?(.*)`)
+
+// Translate translates Oracle errors to the GORM errors for duplicated keys,
+// foreign key and check constraint violations. It is used when the
+// TranslateError option of GORM is enabled. The returned error is an Error,
+// so that the Oracle details remain available.
+func (d Dialector) Translate(err error) error {
+	e := asError(err)
+	if e == nil {
+		return err
+	}
+
+	// The error may be shared, such as ErrDeadlock, so a copy is translated
+	translated := *e
+	switch translated.Code {
+	case 1:
+		translated.translated = gorm.ErrDuplicatedKey
+	case 2291, 2292:
+		translated.translated = gorm.ErrForeignKeyViolated
+	case 2290:
+		translated.translated = gorm.ErrCheckConstraintViolated
+	}
+	return &translated
+}
+
+// ErrorHandler wraps the Oracle error of the statement in an Error that
+// records the SQL of the statement. The Error is copied before it is
+// annotated, since it may be shared by several statements, such as the
+// ErrDeadlock sentinel returned by a callback. An Error wrapped in another
+// error is left as is, as replacing it would drop the wrapping error.
+func ErrorHandler(db *gorm.DB) {
+	if db.Error == nil || db.Statement == nil {
+		return
+	}
+	e := asError(db.Error)
+	if e == nil || (e != db.Error && errors.As(db.Error, new(*Error))) {
+		return
+	}
+	annotated := *e
+	if annotated.SQL == "" {
+		annotated.SQL = db.Statement.SQL.String()
+	}
+	if annotated.Code == 1 && annotated.Constraint == "" {
+		annotated.Columns, annotated.Fields = nil, nil
+		mapUniqueViolation(db, &annotated)
+	}
+	db.Error = &annotated
+}
+
+// uniqueConstraintRegexp matches the owner and name of the constraint in
+// messages like "unique constraint (APP.UNI_USERS_EMAIL) violated"
+var uniqueConstraintRegexp = regexp.MustCompile(`\(("?[^."()]+"?)\.("?[^"()]+"?)\)`)
+
+// mapUniqueViolation sets the constraint, columns and fields of an ORA-00001
+// error. The columns are looked up in the unique constraints and indexes of
+// the model, and in the data dictionary for other constraints, such as
+// primary keys and constraints with system generated names.
+func mapUniqueViolation(db *gorm.DB, e *Error) {
+	matches := uniqueConstraintRegexp.FindStringSubmatch(e.Error())
+	if matches == nil {
+		return
+	}
+	owner, name := strings.Trim(matches[1], `"`), strings.Trim(matches[2], `"`)
+	e.Constraint = name
+
+	stmt := db.Statement
+	if stmt.Schema != nil {
+		for _, uni := range stmt.Schema.ParseUniqueConstraints() {
+			if strings.EqualFold(uni.Name, name) {
+				e.Columns = []string{uni.Field.DBName}
+			}
+		}
+		for _, idx := range stmt.Schema.ParseIndexes() {
+			if idx.Class == "UNIQUE" && strings.EqualFold(idx.Name, name) {
+				for _, option := range idx.Fields {
+					e.Columns = append(e.Columns, option.DBName)
+				}
+			}
+		}
+	}
+
+	if len(e.Columns) == 0 && stmt.ConnPool != nil {
+		for _, query := range []string{
+			"SELECT column_name FROM all_cons_columns WHERE owner = :1 AND constraint_name = :2 ORDER BY position",
+			"SELECT column_name FROM all_ind_columns WHERE index_owner = :1 AND index_name = :2 ORDER BY column_position",
+		} {
+			if e.Columns = constraintColumns(stmt, query, owner, name); len(e.Columns) > 0 {
+				break
+			}
+		}
+	}
+
+	if stmt.Schema != nil {
+		for _, column := range e.Columns {
+			if field := stmt.Schema.LookUpField(column); field != nil {
+				e.Fields = append(e.Fields, field.Name)
+			}
+		}
+	}
+}
+
+// constraintColumns returns the columns of a constraint or index from the
+// data dictionary
+func constraintColumns(stmt *gorm.Statement, query string, owner, name string) (columns []string) {
+	rows, err := stmt.ConnPool.QueryContext(stmt.Context, query, owner, name)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var column string
+		if rows.Scan(&column) == nil {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
@@ -0,0 +1,363 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// maskedValue replaces the values of fields tagged with mask in the vars of
+// a statement once it has run, so that they are not logged
+type maskedValue struct{}
+
+// explainSQL replaces the bind placeholders of the SQL outside literals and
+// comments with the formatted vars. Positional :1 placeholders refer to the
+// vars by position, and :name placeholders refer to sql.Named vars.
+func explainSQL(sqlStr string, vars ...interface{}) string {
+	named := map[string]string{}
+	for _, v := range vars {
+		if arg, ok := v.(sql.NamedArg); ok && arg.Name != "" {
+			named[strings.ToUpper(arg.Name)] = explainVar(arg.Value)
+		}
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(sqlStr); {
+		if next := skipSQLLiteral(sqlStr, i); next > i {
+			builder.WriteString(sqlStr[i:next])
+			i = next
+			continue
+		}
+		if sqlStr[i] == ':' && i+1 < len(sqlStr) && isIdentifierChar(sqlStr[i+1]) {
+			j := i + 1
+			for j < len(sqlStr) && isIdentifierChar(sqlStr[j]) {
+				j++
+			}
+			name := sqlStr[i+1 : j]
+			if n, err := strconv.Atoi(name); err == nil && n >= 1 && n <= len(vars) {
+				builder.WriteString(explainVar(vars[n-1]))
+				i = j
+				continue
+			}
+			if value, ok := named[strings.ToUpper(name)]; ok {
+				builder.WriteString(value)
+				i = j
+				continue
+			}
+		}
+		builder.WriteByte(sqlStr[i])
+		i++
+	}
+	return builder.String()
+}
+
+// explainVar formats a bind value for the log. OUT binds are formatted with
+// the value returned by the database, and the array binds of PL/SQL blocks
+// with their elements. Times and floats are formatted as Oracle literals of
+// the bound value, so that the statement can be run as logged.
+func explainVar(v interface{}) string {
+	switch value := v.(type) {
+	case maskedValue:
+		return "'***'"
+	case sql.Out:
+		rv := reflect.ValueOf(value.Dest)
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			return explainVar(rv.Elem().Interface())
+		}
+		return "NULL"
+	case sql.NamedArg:
+		return explainVar(value.Value)
+	case godror.Lob, *godror.Lob:
+		return "'<LOB>'"
+	case time.Time:
+		return timestampLiteral(value)
+	case *time.Time:
+		if value == nil {
+			return "NULL"
+		}
+		return timestampLiteral(*value)
+	case []byte:
+	case driver.Valuer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "NULL"
+		}
+		if value, err := value.Value(); err == nil {
+			return explainVar(value)
+		}
+	default:
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			elements := make([]string, rv.Len())
+			for i := range elements {
+				elements[i] = explainVar(rv.Index(i).Interface())
+			}
+			return "[" + strings.Join(elements, ", ") + "]"
+		case reflect.Ptr:
+			if rv.IsNil() {
+				return "NULL"
+			}
+			return explainVar(rv.Elem().Interface())
+		case reflect.Struct:
+			if rv.Type().ConvertibleTo(timeType) {
+				return timestampLiteral(rv.Convert(timeType).Interface().(time.Time))
+			}
+		case reflect.Float32:
+			return floatLiteral(rv.Float(), 32)
+		case reflect.Float64:
+			return floatLiteral(rv.Float(), 64)
+		}
+	}
+	return logger.ExplainSQL("?", nil, "'", v)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// timestampLiteral formats a time as the TIMESTAMP WITH TIME ZONE it is
+// bound as, with explicit format masks so that the logged statement runs
+// whatever the NLS settings of the session
+func timestampLiteral(t time.Time) string {
+	if t.Nanosecond() == 0 {
+		return "TO_TIMESTAMP_TZ('" + t.Format("2006-01-02 15:04:05 -07:00") + "', 'YYYY-MM-DD HH24:MI:SS TZH:TZM')"
+	}
+	return "TO_TIMESTAMP_TZ('" + t.Format("2006-01-02 15:04:05.000000000 -07:00") + "', 'YYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM')"
+}
+
+// floatLiteral formats a float as a numeric literal with every digit of the
+// bound value, with an exponent for very large or small values, and the
+// BINARY_DOUBLE constants for NaN and infinities
+func floatLiteral(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "BINARY_DOUBLE_NAN"
+	case math.IsInf(f, 1):
+		return "BINARY_DOUBLE_INFINITY"
+	case math.IsInf(f, -1):
+		return "-BINARY_DOUBLE_INFINITY"
+	case f != 0 && (math.Abs(f) >= 1e21 || math.Abs(f) < 1e-6):
+		return strconv.FormatFloat(f, 'e', -1, bitSize)
+	}
+	return strconv.FormatFloat(f, 'f', -1, bitSize)
+}
+
+// MaskHandler hides the values of fields tagged with mask from the log:
+//
+//	type User struct {
+//		ID       uint
+//		Name     string
+//		Password string `gorm:"mask"`
+//	}
+//
+// After the statement has run, the vars bound to values of those fields
+// are replaced, so the logged statement shows '***' instead. Values are
+// matched by equality, so other vars with the same value are hidden too.
+func MaskHandler(db *gorm.DB) {
+	stmt := db.Statement
+	if stmt == nil || stmt.DryRun || stmt.Schema == nil || len(stmt.Vars) == 0 {
+		return
+	}
+
+	var maskedFields []*schema.Field
+	for _, field := range stmt.Schema.Fields {
+		if _, ok := field.TagSettings["MASK"]; ok {
+			maskedFields = append(maskedFields, field)
+		}
+	}
+	if len(maskedFields) == 0 {
+		return
+	}
+
+	masked := maskedValues(stmt, maskedFields)
+	if len(masked) == 0 {
+		return
+	}
+	for i, v := range stmt.Vars {
+		stmt.Vars[i] = maskVar(v, masked)
+	}
+}
+
+// maskedValues returns the values of the masked fields in the model, in
+// maps and in the SET and WHERE clauses of the statement
+func maskedValues(stmt *gorm.Statement, fields []*schema.Field) []interface{} {
+	var values []interface{}
+	add := func(v interface{}) {
+		if rv := reflect.ValueOf(v); rv.IsValid() && !rv.IsZero() {
+			values = append(values, reflect.Indirect(rv).Interface())
+		}
+	}
+	maskedField := func(name string) bool {
+		for _, field := range fields {
+			if name == field.DBName || name == field.Name {
+				return true
+			}
+		}
+		return false
+	}
+	addMap := func(m map[string]interface{}) {
+		for key, v := range m {
+			if maskedField(key) {
+				add(v)
+			}
+		}
+	}
+
+	rv := reflect.Indirect(stmt.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Struct:
+		for _, field := range fields {
+			v, _ := field.ValueOf(stmt.Context, rv)
+			add(v)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elem := reflect.Indirect(rv.Index(i))
+			if elem.Kind() != reflect.Struct {
+				continue
+			}
+			for _, field := range fields {
+				v, _ := field.ValueOf(stmt.Context, elem)
+				add(v)
+			}
+		}
+	}
+
+	switch dest := stmt.Dest.(type) {
+	case map[string]interface{}:
+		addMap(dest)
+	case *map[string]interface{}:
+		addMap(*dest)
+	case []map[string]interface{}:
+		for _, m := range dest {
+			addMap(m)
+		}
+	}
+
+	if c, ok := stmt.Clauses["SET"]; ok {
+		if set, ok := c.Expression.(clause.Set); ok {
+			for _, assignment := range set {
+				if maskedField(assignment.Column.Name) {
+					add(assignment.Value)
+				}
+			}
+		}
+	}
+
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			for _, expr := range where.Exprs {
+				switch e := expr.(type) {
+				case clause.Eq:
+					if column, ok := e.Column.(clause.Column); ok && maskedField(column.Name) {
+						add(e.Value)
+					} else if name, ok := e.Column.(string); ok && maskedField(name) {
+						add(e.Value)
+					}
+				case clause.Neq:
+					if column, ok := e.Column.(clause.Column); ok && maskedField(column.Name) {
+						add(e.Value)
+					} else if name, ok := e.Column.(string); ok && maskedField(name) {
+						add(e.Value)
+					}
+				}
+			}
+		}
+	}
+	return values
+}
+
+// maskVar replaces the var, the elements of an array bind, or the value of
+// an OUT bind, when it is one of the masked values
+func maskVar(v interface{}, masked []interface{}) interface{} {
+	isMasked := func(value interface{}) bool {
+		rv := reflect.ValueOf(value)
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		if !rv.IsValid() || rv.Kind() == reflect.Ptr {
+			return false
+		}
+		for _, m := range masked {
+			if reflect.DeepEqual(rv.Interface(), m) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch value := v.(type) {
+	case sql.Out:
+		if isMasked(value.Dest) {
+			return maskedValue{}
+		}
+		return v
+	case sql.NamedArg:
+		if isMasked(value.Value) {
+			return sql.Named(value.Name, maskedValue{})
+		}
+		return v
+	case []byte:
+	default:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+			elements := make([]interface{}, rv.Len())
+			for i := range elements {
+				elements[i] = maskVar(rv.Index(i).Interface(), masked)
+			}
+			return elements
+		}
+	}
+
+	if isMasked(v) {
+		return maskedValue{}
+	}
+	return v
+}
@@ -0,0 +1,452 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Identifies valid match parameters of the REGEXP functions
+var regexpFlagsRegexp = regexp.MustCompile(`^[icnmx]*$`)
+
+// CaseInsensitiveMode selects how string comparisons in WHERE conditions
+// are made case-insensitive, see Config.CaseInsensitive
+type CaseInsensitiveMode int
+
+const (
+	// CaseSensitive leaves comparisons unchanged
+	CaseSensitive CaseInsensitiveMode = iota
+	// CaseInsensitiveUpper compares both sides in uppercase:
+	// UPPER("name") = UPPER(:1)
+	CaseInsensitiveUpper
+	// CaseInsensitiveCollate compares the column using the BINARY_CI
+	// collation: "name" COLLATE BINARY_CI = :1. Requires Oracle 12.2 or
+	// later with MAX_STRING_SIZE set to EXTENDED.
+	CaseInsensitiveCollate
+)
+
+// ILikeExpr is a case-insensitive LIKE condition, like ILIKE in PostgreSQL
+type ILikeExpr struct {
+	Column  interface{}
+	Pattern interface{}
+}
+
+// ILike returns a condition matching the column against the pattern
+// regardless of case. The column is either a column name or an expression.
+//
+//	db.Where(oracle.ILike("name", "%smith%")).Find(&users)
+//	// SELECT * FROM "users" WHERE UPPER("name") LIKE UPPER(:1)
+func ILike(column interface{}, pattern interface{}) ILikeExpr {
+	return ILikeExpr{Column: column, Pattern: pattern}
+}
+
+// Build writes the condition
+func (l ILikeExpr) Build(builder clause.Builder) {
+	l.build(builder, " LIKE ")
+}
+
+// NegationBuild writes the negated condition
+func (l ILikeExpr) NegationBuild(builder clause.Builder) {
+	l.build(builder, " NOT LIKE ")
+}
+
+func (l ILikeExpr) build(builder clause.Builder, operator string) {
+	builder.WriteString("UPPER(")
+	writeOperand(builder, l.Column)
+	builder.WriteString(")")
+	builder.WriteString(operator)
+	builder.WriteString("UPPER(")
+	builder.AddVar(builder, l.Pattern)
+	builder.WriteString(")")
+}
+
+// caseInsensitiveExpr is a comparison of a column with string values that
+// ignores case, created from the conditions of a WHERE clause when
+// Config.CaseInsensitive is set
+type caseInsensitiveExpr struct {
+	Column          interface{}
+	Operator        string
+	NegatedOperator string
+	Values          []interface{}
+	In              bool
+	Mode            CaseInsensitiveMode
+}
+
+func (c caseInsensitiveExpr) Build(builder clause.Builder) {
+	c.build(builder, c.Operator)
+}
+
+func (c caseInsensitiveExpr) NegationBuild(builder clause.Builder) {
+	c.build(builder, c.NegatedOperator)
+}
+
+func (c caseInsensitiveExpr) build(builder clause.Builder, operator string) {
+	if c.Mode == CaseInsensitiveUpper {
+		builder.WriteString("UPPER(")
+		builder.WriteQuoted(c.Column)
+		builder.WriteByte(')')
+	} else {
+		builder.WriteQuoted(c.Column)
+		builder.WriteString(" COLLATE BINARY_CI")
+	}
+
+	builder.WriteByte(' ')
+	builder.WriteString(operator)
+	builder.WriteByte(' ')
+
+	if c.In {
+		builder.WriteByte('(')
+	}
+	for idx, value := range c.Values {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		if c.Mode == CaseInsensitiveUpper {
+			builder.WriteString("UPPER(")
+			builder.AddVar(builder, value)
+			builder.WriteByte(')')
+		} else {
+			builder.AddVar(builder, value)
+		}
+	}
+	if c.In {
+		builder.WriteByte(')')
+	}
+}
+
+// CaseInsensitiveHandler returns a callback that rewrites the equality, LIKE
+// and IN conditions with string values of the WHERE clause into
+// case-insensitive comparisons using the given mode. It is registered for
+// queries, updates and deletes when Config.CaseInsensitive is set.
+func CaseInsensitiveHandler(mode CaseInsensitiveMode) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.Statement == nil {
+			return
+		}
+		c, ok := db.Statement.Clauses["WHERE"]
+		if !ok {
+			return
+		}
+		if where, ok := c.Expression.(clause.Where); ok {
+			c.Expression = clause.Where{Exprs: caseInsensitiveExprs(where.Exprs, mode)}
+			db.Statement.Clauses["WHERE"] = c
+		}
+	}
+}
+
+func caseInsensitiveExprs(exprs []clause.Expression, mode CaseInsensitiveMode) []clause.Expression {
+	result := make([]clause.Expression, len(exprs))
+	for idx, expr := range exprs {
+		result[idx] = expr
+
+		switch e := expr.(type) {
+		case clause.Eq:
+			if isStringValue(e.Value) {
+				result[idx] = caseInsensitiveExpr{Column: e.Column, Operator: "=", NegatedOperator: "<>", Values: []interface{}{e.Value}, Mode: mode}
+			} else if values := stringSliceValues(e.Value); len(values) > 0 {
+				result[idx] = caseInsensitiveExpr{Column: e.Column, Operator: "IN", NegatedOperator: "NOT IN", Values: values, In: true, Mode: mode}
+			}
+		case clause.Neq:
+			if isStringValue(e.Value) {
+				result[idx] = caseInsensitiveExpr{Column: e.Column, Operator: "<>", NegatedOperator: "=", Values: []interface{}{e.Value}, Mode: mode}
+			}
+		case clause.Like:
+			if isStringValue(e.Value) {
+				result[idx] = caseInsensitiveExpr{Column: e.Column, Operator: "LIKE", NegatedOperator: "NOT LIKE", Values: []interface{}{e.Value}, Mode: mode}
+			}
+		case clause.IN:
+			if len(e.Values) == 0 {
+				continue
+			}
+			allStrings := true
+			for _, value := range e.Values {
+				allStrings = allStrings && isStringValue(value)
+			}
+			if allStrings {
+				result[idx] = caseInsensitiveExpr{Column: e.Column, Operator: "IN", NegatedOperator: "NOT IN", Values: e.Values, In: true, Mode: mode}
+			}
+		case clause.AndConditions:
+			result[idx] = clause.AndConditions{Exprs: caseInsensitiveExprs(e.Exprs, mode)}
+		case clause.OrConditions:
+			result[idx] = clause.OrConditions{Exprs: caseInsensitiveExprs(e.Exprs, mode)}
+		case clause.NotConditions:
+			result[idx] = clause.NotConditions{Exprs: caseInsensitiveExprs(e.Exprs, mode)}
+		}
+	}
+	return result
+}
+
+func isStringValue(value interface{}) bool {
+	return value != nil && reflect.TypeOf(value).Kind() == reflect.String
+}
+
+// stringSliceValues returns the elements of a slice of strings, or nil if
+// the value is not one
+func stringSliceValues(value interface{}) []interface{} {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() != reflect.String {
+		return nil
+	}
+	values := make([]interface{}, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values
+}
+
+// RegexpLikeExpr is a REGEXP_LIKE condition
+type RegexpLikeExpr struct {
+	Column  interface{}
+	Pattern interface{}
+	Flags   string
+}
+
+// RegexpLike returns a condition matching the column against a regular
+// expression. The pattern is passed as a bind variable, and the optional
+// flags are Oracle match parameters such as "i" for case-insensitive
+// matching.
+//
+//	db.Where(oracle.RegexpLike("email", `^[a-z]+@example\.com$`, "i")).Find(&users)
+//	// SELECT * FROM "users" WHERE REGEXP_LIKE("email", :1, 'i')
+func RegexpLike(column interface{}, pattern interface{}, flags ...string) RegexpLikeExpr {
+	return RegexpLikeExpr{Column: column, Pattern: pattern, Flags: strings.Join(flags, "")}
+}
+
+// Build writes the condition
+func (r RegexpLikeExpr) Build(builder clause.Builder) {
+	if !validRegexpFlags(builder, r.Flags) {
+		return
+	}
+	builder.WriteString("REGEXP_LIKE(")
+	writeOperand(builder, r.Column)
+	builder.WriteString(", ")
+	builder.AddVar(builder, r.Pattern)
+	if r.Flags != "" {
+		builder.WriteString(", ")
+		writeStringLiteral(builder, r.Flags)
+	}
+	builder.WriteByte(')')
+}
+
+// NegationBuild writes the negated condition
+func (r RegexpLikeExpr) NegationBuild(builder clause.Builder) {
+	builder.WriteString("NOT ")
+	r.Build(builder)
+}
+
+// RegexpSubstrExpr is a REGEXP_SUBSTR expression
+type RegexpSubstrExpr struct {
+	Column        interface{}
+	Pattern       interface{}
+	Position      int
+	Occurrence    int
+	Flags         string
+	Subexpression int
+	Alias         string
+}
+
+// RegexpSubstr returns the part of the column matching a regular expression
+//
+//	db.Model(&User{}).Select("?", oracle.RegexpSubstr("email", "@(.*)$").Group(1).As("domain")).Scan(&results)
+//	// SELECT REGEXP_SUBSTR("email", :1, 1, 1, NULL, 1) AS "domain" FROM "users"
+func RegexpSubstr(column interface{}, pattern interface{}) RegexpSubstrExpr {
+	return RegexpSubstrExpr{Column: column, Pattern: pattern}
+}
+
+// From sets the position in the column where the search starts, from 1
+func (r RegexpSubstrExpr) From(position int) RegexpSubstrExpr {
+	r.Position = position
+	return r
+}
+
+// Nth returns the nth occurrence of the pattern, from 1
+func (r RegexpSubstrExpr) Nth(occurrence int) RegexpSubstrExpr {
+	r.Occurrence = occurrence
+	return r
+}
+
+// WithFlags sets the match parameters
+func (r RegexpSubstrExpr) WithFlags(flags string) RegexpSubstrExpr {
+	r.Flags = flags
+	return r
+}
+
+// Group returns the given parenthesized subexpression of the match
+func (r RegexpSubstrExpr) Group(subexpression int) RegexpSubstrExpr {
+	r.Subexpression = subexpression
+	return r
+}
+
+// As sets the column alias of the expression
+func (r RegexpSubstrExpr) As(alias string) RegexpSubstrExpr {
+	r.Alias = alias
+	return r
+}
+
+// Build writes the REGEXP_SUBSTR expression
+func (r RegexpSubstrExpr) Build(builder clause.Builder) {
+	if !validRegexpFlags(builder, r.Flags) {
+		return
+	}
+	builder.WriteString("REGEXP_SUBSTR(")
+	writeOperand(builder, r.Column)
+	builder.WriteString(", ")
+	builder.AddVar(builder, r.Pattern)
+	writeRegexpOptions(builder, r.Position, r.Occurrence, 1, r.Flags, r.Subexpression)
+	builder.WriteByte(')')
+
+	writeAlias(builder, r.Alias)
+}
+
+// RegexpReplaceExpr is a REGEXP_REPLACE expression
+type RegexpReplaceExpr struct {
+	Column      interface{}
+	Pattern     interface{}
+	Replacement interface{}
+	Position    int
+	Occurrence  int
+	Flags       string
+	Alias       string
+}
+
+// RegexpReplace returns the column with the matches of a regular expression
+// replaced. It can be used in Select as well as in Update.
+//
+//	db.Model(&User{}).Where("id = ?", 1).Update("phone", oracle.RegexpReplace("phone", "[^0-9]", ""))
+//	// UPDATE "users" SET "phone"=REGEXP_REPLACE("phone", :1, :2) WHERE id = :3
+func RegexpReplace(column interface{}, pattern interface{}, replacement interface{}) RegexpReplaceExpr {
+	return RegexpReplaceExpr{Column: column, Pattern: pattern, Replacement: replacement}
+}
+
+// From sets the position in the column where the search starts, from 1
+func (r RegexpReplaceExpr) From(position int) RegexpReplaceExpr {
+	r.Position = position
+	return r
+}
+
+// Nth only replaces the nth occurrence of the pattern, from 1. By default
+// all occurrences are replaced.
+func (r RegexpReplaceExpr) Nth(occurrence int) RegexpReplaceExpr {
+	r.Occurrence = occurrence
+	return r
+}
+
+// WithFlags sets the match parameters
+func (r RegexpReplaceExpr) WithFlags(flags string) RegexpReplaceExpr {
+	r.Flags = flags
+	return r
+}
+
+// As sets the column alias of the expression
+func (r RegexpReplaceExpr) As(alias string) RegexpReplaceExpr {
+	r.Alias = alias
+	return r
+}
+
+// Build writes the REGEXP_REPLACE expression
+func (r RegexpReplaceExpr) Build(builder clause.Builder) {
+	if !validRegexpFlags(builder, r.Flags) {
+		return
+	}
+	builder.WriteString("REGEXP_REPLACE(")
+	writeOperand(builder, r.Column)
+	builder.WriteString(", ")
+	builder.AddVar(builder, r.Pattern)
+	builder.WriteString(", ")
+	builder.AddVar(builder, r.Replacement)
+	// An occurrence of 0 replaces all matches
+	writeRegexpOptions(builder, r.Position, r.Occurrence, 0, r.Flags, 0)
+	builder.WriteByte(')')
+
+	writeAlias(builder, r.Alias)
+}
+
+func validRegexpFlags(builder clause.Builder, flags string) bool {
+	if !regexpFlagsRegexp.MatchString(flags) {
+		builder.AddError(fmt.Errorf("invalid regular expression match parameter %q", flags))
+		return false
+	}
+	return true
+}
+
+// writeRegexpOptions writes the optional position, occurrence, match
+// parameter and subexpression arguments of the REGEXP functions, up to the
+// last one that is set. Unset positions and occurrences are written as 1
+// and defaultOccurrence respectively.
+func writeRegexpOptions(builder clause.Builder, position, occurrence, defaultOccurrence int, flags string, subexpression int) {
+	if position == 0 && occurrence == 0 && flags == "" && subexpression == 0 {
+		return
+	}
+
+	if position == 0 {
+		position = 1
+	}
+	builder.WriteString(", ")
+	builder.WriteString(strconv.Itoa(position))
+	if occurrence == 0 && flags == "" && subexpression == 0 {
+		return
+	}
+
+	if occurrence == 0 {
+		occurrence = defaultOccurrence
+	}
+	builder.WriteString(", ")
+	builder.WriteString(strconv.Itoa(occurrence))
+	if flags == "" && subexpression == 0 {
+		return
+	}
+
+	builder.WriteString(", ")
+	if flags == "" {
+		builder.WriteString("NULL")
+	} else {
+		writeStringLiteral(builder, flags)
+	}
+	if subexpression > 0 {
+		builder.WriteString(", ")
+		builder.WriteString(strconv.Itoa(subexpression))
+	}
+}
@@ -0,0 +1,210 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// ExternalLoader reads text files with SQL*Loader access parameters
+	ExternalLoader = "ORACLE_LOADER"
+	// ExternalDataPump reads dump files written by ORACLE_DATAPUMP
+	ExternalDataPump = "ORACLE_DATAPUMP"
+)
+
+// ExternalTable describes the files an external table reads
+type ExternalTable struct {
+	// Type is the access driver, ExternalLoader if empty
+	Type string
+	// DefaultDirectory is the directory object of the files
+	DefaultDirectory string
+	// AccessParameters are the access parameters of the driver, such as
+	// RECORDS DELIMITED BY NEWLINE FIELDS TERMINATED BY ','
+	AccessParameters string
+	// Location are the files the table reads. A file in another directory
+	// than DefaultDirectory is prefixed with the directory, as in
+	// "ARCHIVE_DIR:orders_2023.csv".
+	Location []string
+	// RejectLimit is the number of rows that may fail conversion, 0 if
+	// empty, or UNLIMITED
+	RejectLimit string
+}
+
+// ExternalTabler is implemented by models stored in external tables. The
+// migrator creates their tables with ORGANIZATION EXTERNAL, with columns
+// but no constraints or indexes, which external tables do not support.
+//
+//	func (StagedOrder) ExternalTable() oracle.ExternalTable {
+//		return oracle.ExternalTable{
+//			DefaultDirectory: "STAGING_DIR",
+//			AccessParameters: "RECORDS DELIMITED BY NEWLINE FIELDS TERMINATED BY ','",
+//			Location:         []string{"orders.csv"},
+//		}
+//	}
+type ExternalTabler interface {
+	ExternalTable() ExternalTable
+}
+
+// externalTableOf returns the external table of the model of the statement
+func externalTableOf(stmt *gorm.Statement) (ExternalTable, bool) {
+	if stmt.Schema == nil {
+		return ExternalTable{}, false
+	}
+	if tabler, ok := reflect.New(stmt.Schema.ModelType).Interface().(ExternalTabler); ok {
+		return tabler.ExternalTable(), true
+	}
+	return ExternalTable{}, false
+}
+
+// isExternalModel reports whether the model is stored in an external table
+func isExternalModel(db *gorm.DB, value interface{}) bool {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(value); err != nil {
+		return false
+	}
+	_, ok := externalTableOf(stmt)
+	return ok
+}
+
+// validate checks the identifiers written into the DDL
+func (e ExternalTable) validate() error {
+	if !identifierRegexp.MatchString(e.DefaultDirectory) {
+		return fmt.Errorf("invalid default directory %q of external table", e.DefaultDirectory)
+	}
+	if e.Type != "" && !identifierRegexp.MatchString(e.Type) {
+		return fmt.Errorf("invalid access driver %q of external table", e.Type)
+	}
+	if err := validateLocation(e.Location); err != nil {
+		return err
+	}
+	if e.RejectLimit != "" && !strings.EqualFold(e.RejectLimit, "UNLIMITED") && strings.Trim(e.RejectLimit, "0123456789") != "" {
+		return fmt.Errorf("invalid reject limit %q of external table", e.RejectLimit)
+	}
+	return nil
+}
+
+// validateLocation checks the directories of the files of an external table
+func validateLocation(location []string) error {
+	for _, file := range location {
+		if directory, _, ok := strings.Cut(file, ":"); ok && !identifierRegexp.MatchString(directory) {
+			return fmt.Errorf("invalid directory of external table location %q", file)
+		}
+	}
+	return nil
+}
+
+// locationSQL returns the LOCATION list of the files
+func locationSQL(location []string) string {
+	files := make([]string, len(location))
+	for i, file := range location {
+		if directory, name, ok := strings.Cut(file, ":"); ok {
+			files[i] = directory + ":" + quoteLiteral(name)
+		} else {
+			files[i] = quoteLiteral(file)
+		}
+	}
+	return "(" + strings.Join(files, ", ") + ")"
+}
+
+// quoteLiteral returns s as an SQL string literal
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// createExternalTable creates the external table of the statement's model
+func (m Migrator) createExternalTable(tx *gorm.DB, stmt *gorm.Statement, external ExternalTable) error {
+	if err := external.validate(); err != nil {
+		return err
+	}
+
+	createTableSQL := "CREATE TABLE ? ("
+	values := []interface{}{m.CurrentTable(stmt)}
+	for _, dbName := range stmt.Schema.DBNames {
+		field := stmt.Schema.FieldsByDBName[dbName]
+		if field.IgnoreMigration || isRowIDField(field) {
+			continue
+		}
+		// External tables have neither identity columns nor constraints
+		dataType := strings.TrimSuffix(m.DataTypeOf(field), " GENERATED BY DEFAULT AS IDENTITY")
+		createTableSQL += "? ?,"
+		values = append(values, clause.Column{Name: dbName}, clause.Expr{SQL: dataType})
+	}
+	createTableSQL = strings.TrimSuffix(createTableSQL, ",") + ")"
+
+	driver := external.Type
+	if driver == "" {
+		driver = ExternalLoader
+	}
+	// Access parameters and file names are written as they are, even with
+	// question marks
+	organization := "ORGANIZATION EXTERNAL (TYPE " + driver + " DEFAULT DIRECTORY " + external.DefaultDirectory
+	if external.AccessParameters != "" {
+		organization += " ACCESS PARAMETERS (" + external.AccessParameters + ")"
+	}
+	if len(external.Location) > 0 {
+		organization += " LOCATION " + locationSQL(external.Location)
+	}
+	organization += ")"
+	if external.RejectLimit != "" {
+		organization += " REJECT LIMIT " + strings.ToUpper(external.RejectLimit)
+	}
+	createTableSQL += " ?"
+	values = append(values, clause.Expr{SQL: organization})
+
+	return tx.Exec(createTableSQL, values...).Error
+}
+
+// SetExternalLocation makes the external table of the model read the given
+// files, for instance to stage the next batch of files of a feed
+func (m Migrator) SetExternalLocation(value interface{}, location ...string) error {
+	if len(location) == 0 {
+		return fmt.Errorf("no location for external table")
+	}
+	if err := validateLocation(location); err != nil {
+		return err
+	}
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Exec("ALTER TABLE ? LOCATION ?", m.CurrentTable(stmt), clause.Expr{SQL: locationSQL(location)}).Error
+	})
+}
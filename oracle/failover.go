@@ -0,0 +1,209 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// DefaultFailoverCodes are the ORA codes of the errors reported as
+// FailoverError by a Failover without Codes. They are raised when the
+// session of a statement is lost during a RAC or Data Guard failover and
+// Transparent Application Failover or Application Continuity could not
+// recover the call.
+var DefaultFailoverCodes = []int{
+	3113,  // end-of-file on communication channel
+	3114,  // not connected to ORACLE
+	3135,  // connection lost contact
+	25401, // can not continue fetches
+	25402, // transaction must roll back
+	25403, // could not reconnect
+	25404, // lost instance
+	25405, // transaction status unknown
+	25408, // can not safely replay call
+	25409, // failover happened during the network operation, cannot continue
+	41412, // results changed during replay; failover cannot continue
+}
+
+// ErrFailover matches the FailoverError of statements whose session failed
+// over
+var ErrFailover = errors.New("session failed over")
+
+// Failover reports the statements that fail because their session was lost
+// or moved to another instance. With Transparent Application Failover or
+// Application Continuity configured for the service, Oracle reconnects and
+// replays the interrupted calls itself; successful replays are transparent
+// and not reported. The statements that could not be replayed fail with a
+// FailoverError, after OnFailover was called.
+//
+//	db, err := gorm.Open(oracle.New(oracle.Config{
+//		DataSourceName: dsn,
+//		Failover: &oracle.Failover{
+//			OnFailover: func(ctx context.Context, err *oracle.FailoverError) {
+//				failovers.Inc()
+//			},
+//		},
+//	}), &gorm.Config{})
+type Failover struct {
+	// Codes are the ORA codes of the errors reported as failovers. The
+	// default is DefaultFailoverCodes.
+	Codes []int
+	// OnFailover is called with each failed statement, before the error is
+	// returned
+	OnFailover func(ctx context.Context, err *FailoverError)
+}
+
+// FailoverError reports that a statement failed because its session failed
+// over. errors.Is matches it with ErrFailover, and errors.As with the Error
+// returned by the database.
+type FailoverError struct {
+	// Code is the ORA code of the error
+	Code int
+	// InTransaction is set when the statement ran in a transaction, whose
+	// uncommitted changes were rolled back
+	InTransaction bool
+	// Retryable is set when the work can safely be run again: a query, or
+	// a statement of a transaction that is rolled back. It is not set when
+	// the outcome of the work is unknown, such as for a write that was
+	// committed on its own or a commit that was interrupted.
+	Retryable bool
+	// Err is the error returned by the database
+	Err error
+}
+
+// Error returns a message telling whether the work can be run again
+func (e *FailoverError) Error() string {
+	if e.Retryable {
+		return fmt.Sprintf("session failed over, the work can be run again: %v", e.Err)
+	}
+	return fmt.Sprintf("session failed over, the outcome of the work is unknown: %v", e.Err)
+}
+
+// Unwrap returns the error returned by the database
+func (e *FailoverError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is ErrFailover
+func (e *FailoverError) Is(target error) bool {
+	return target == ErrFailover
+}
+
+// isFailover reports whether the error is one of the failover errors of the
+// configuration
+func (f *Failover) isFailover(err error) bool {
+	code := ErrorCode(err)
+	if code == 0 {
+		return false
+	}
+	codes := f.Codes
+	if len(codes) == 0 {
+		codes = DefaultFailoverCodes
+	}
+	return slices.Contains(codes, code)
+}
+
+// FailoverHandler returns the callback that reports the failover errors of
+// statements as FailoverError. Queries are set to write false: their failed
+// statements can always be run again, while writes outside transactions
+// may have been committed before the session was lost.
+func FailoverHandler(failover *Failover, write bool) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error == nil || db.Statement == nil || errors.Is(db.Error, ErrFailover) || !failover.isFailover(db.Error) {
+			return
+		}
+
+		_, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter)
+		err := &FailoverError{
+			Code:          ErrorCode(db.Error),
+			InTransaction: inTransaction,
+			// ORA-25405 is raised when a commit was in progress
+			Retryable: ErrorCode(db.Error) != 25405 && (inTransaction || !write),
+			Err:       db.Error,
+		}
+		if failover.OnFailover != nil {
+			failover.OnFailover(db.Statement.Context, err)
+		}
+		db.Error = err
+	}
+}
+
+// failoverConfig returns the failover configuration of the dialector of the
+// database
+func failoverConfig(db *gorm.DB) *Failover {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		if d.Config != nil {
+			return d.Failover
+		}
+	case Dialector:
+		if d.Config != nil {
+			return d.Failover
+		}
+	}
+	return nil
+}
+
+// transactionFailover returns the error of a transaction that failed over
+// as a FailoverError. A failover error of its statements is returned as it
+// is, while a failover error without the statement that raised it comes
+// from the commit, whose outcome is unknown.
+func transactionFailover(db *gorm.DB, err error) *FailoverError {
+	if err == nil {
+		return nil
+	}
+	var failoverErr *FailoverError
+	if errors.As(err, &failoverErr) {
+		return failoverErr
+	}
+	failover := failoverConfig(db)
+	if failover == nil || !failover.isFailover(err) {
+		return nil
+	}
+	failoverErr = &FailoverError{Code: ErrorCode(err), InTransaction: true, Err: err}
+	if failover.OnFailover != nil {
+		failover.OnFailover(db.Statement.Context, failoverErr)
+	}
+	return failoverErr
+}
@@ -0,0 +1,177 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Flashback is the Oracle flashback query clause, which reads the tables of
+// a query as they were at an SCN or a point in time. It applies to the table
+// of the statement and to the tables joined by association.
+//
+//	db.Clauses(oracle.AsOfSCN(scn)).Joins("Company").Find(&users)
+//	// SELECT ... FROM "users" AS OF SCN :1 LEFT JOIN "companies" AS OF SCN :2 "Company" ON ...
+type Flashback struct {
+	SCN       uint64
+	Timestamp time.Time
+}
+
+// AsOfSCN returns a clause reading the tables as of the given SCN
+func AsOfSCN(scn uint64) Flashback {
+	return Flashback{SCN: scn}
+}
+
+// AsOfTimestamp returns a clause reading the tables as of the given time
+func AsOfTimestamp(t time.Time) Flashback {
+	return Flashback{Timestamp: t}
+}
+
+// Name returns the name of the clause
+func (f Flashback) Name() string {
+	return ClauseFlashback
+}
+
+// Build writes the AS OF clause
+func (f Flashback) Build(builder clause.Builder) {
+	switch {
+	case f.SCN != 0:
+		builder.WriteString("AS OF SCN ")
+		builder.AddVar(builder, f.SCN)
+	case !f.Timestamp.IsZero():
+		builder.WriteString("AS OF TIMESTAMP ")
+		builder.AddVar(builder, f.Timestamp)
+	default:
+		builder.AddError(errors.New("flashback clause requires an SCN or a timestamp"))
+	}
+}
+
+// MergeClause replaces any previous flashback clause
+func (f Flashback) MergeClause(c *clause.Clause) {
+	c.Expression = f
+}
+
+// writeJoin writes a join, with the flashback clause of the statement
+// between the joined table and its alias
+func writeJoin(builder clause.Builder, join clause.Join) {
+	if join.Expression != nil {
+		join.Expression = removeJoinAliasAS(join.Expression)
+	}
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok || join.Expression != nil {
+		join.Build(builder)
+		return
+	}
+	c, ok := stmt.Clauses[ClauseFlashback]
+	if !ok || c.Expression == nil {
+		join.Build(builder)
+		return
+	}
+
+	if join.Type != "" {
+		builder.WriteString(string(join.Type))
+		builder.WriteByte(' ')
+	}
+	builder.WriteString("JOIN ")
+	alias := join.Table.Alias
+	join.Table.Alias = ""
+	builder.WriteQuoted(join.Table)
+	builder.WriteByte(' ')
+	c.Expression.Build(builder)
+	if alias != "" {
+		builder.WriteByte(' ')
+		builder.WriteQuoted(clause.Table{Name: alias})
+	}
+
+	if len(join.ON.Exprs) > 0 {
+		builder.WriteString(" ON ")
+		join.ON.Build(builder)
+	} else if len(join.Using) > 0 {
+		builder.WriteString(" USING (")
+		for idx, column := range join.Using {
+			if idx > 0 {
+				builder.WriteByte(',')
+			}
+			builder.WriteQuoted(column)
+		}
+		builder.WriteByte(')')
+	}
+}
+
+// CurrentSCN returns the current system change number of the database. It
+// reads V$DATABASE, and falls back to the SCN of the current time, which is
+// up to a few seconds older, when the user cannot select from it.
+func CurrentSCN(db *gorm.DB) (uint64, error) {
+	var scn uint64
+	err := db.Raw("SELECT CURRENT_SCN FROM V$DATABASE").Scan(&scn).Error
+	if ErrorCode(err) == 942 {
+		err = db.Raw("SELECT TIMESTAMP_TO_SCN(SYSTIMESTAMP) FROM DUAL").Scan(&scn).Error
+	}
+	return scn, err
+}
+
+// WithSnapshot captures the current SCN and runs fc with a session whose
+// queries read the tables as of that SCN, so that the queries of an export
+// see one consistent state of the database without a serializable
+// transaction. The session is meant for queries only. Preloads run as
+// separate statements without the clause; pass it to them through the
+// conditions of Preload, or use Joins.
+//
+//	err := oracle.WithSnapshot(db, func(tx *gorm.DB, scn uint64) error {
+//		if err := tx.Find(&customers).Error; err != nil {
+//			return err
+//		}
+//		return tx.Preload("Items", func(db *gorm.DB) *gorm.DB {
+//			return db.Clauses(oracle.AsOfSCN(scn))
+//		}).Find(&orders).Error
+//	})
+//
+// Reading as of an SCN needs the undo data of the changes made since, which
+// the database keeps for UNDO_RETENTION seconds; longer exports fail with
+// ORA-01555.
+func WithSnapshot(db *gorm.DB, fc func(tx *gorm.DB, scn uint64) error) error {
+	scn, err := CurrentSCN(db)
+	if err != nil {
+		return err
+	}
+	return fc(db.Clauses(AsOfSCN(scn)).Session(&gorm.Session{}), scn)
+}
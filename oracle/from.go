@@ -0,0 +1,467 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ClausePartition = "PARTITION"
+	ClauseSample    = "SAMPLE"
+	ClauseFlashback = "AS_OF"
+)
+
+// Clauses that modify the primary table reference of the FROM clause.
+// They are written in this order, directly after the table name and
+// before any table alias.
+var tableModifierClauses = []string{ClausePartition, ClauseSample, ClauseFlashback}
+
+// Matches a single Oracle identifier, either unquoted or quoted
+const identifierPattern = `(?:[A-Za-z][\w$#]*|"[^"]+")`
+
+var (
+	// Identifies partition names that can be written as they are
+	partitionNameRegexp = regexp.MustCompile(`^` + identifierPattern + `$`)
+	// Identifies (optionally schema and package qualified) function names
+	functionNameRegexp = regexp.MustCompile(`^` + identifierPattern + `(?:\.` + identifierPattern + `){0,2}$`)
+)
+
+// Identifies a table expression of the form "table alias" so that table
+// modifiers can be placed between the table name and its alias
+var tableAliasRegexp = regexp.MustCompile(`^\s*("[^"]+"(?:\."[^"]+")?|[\w.$#]+)\s+("[^"]+"|\w+)\s*$`)
+
+// Identifies a table expression with an alias, such as users u, "users" u,
+// users AS u, app.users "u" or (subquery) u, to write it quoted like the
+// other identifiers
+var aliasedTableRegexp = regexp.MustCompile(`^\s*(` + identifierPattern + `(?:\.` + identifierPattern + `)?|\(.+\))\s+(?:(?i:AS)\s+)?(` + identifierPattern + `)\s*$`)
+
+// Identifies the AS keyword between a joined table or subquery and its
+// alias, which Oracle does not accept
+var joinAliasASRegexp = regexp.MustCompile(`(?i)(\bJOIN\s+(?:\(\?\)|@\w+|` + identifierPattern + `(?:\.` + identifierPattern + `)?))\s+AS(\s+)`)
+
+// TablePartition is the Oracle partition extension clause, which restricts
+// a statement to a single partition or subpartition of the table.
+//
+//	db.Clauses(oracle.Partition("p2024_01")).Find(&orders)
+//	// SELECT * FROM "orders" PARTITION (p2024_01)
+type TablePartition struct {
+	Subpartition bool
+	Partition    string
+	Values       []interface{}
+}
+
+// Partition returns a clause restricting the statement to the named partition.
+// Unquoted names are resolved by Oracle in uppercase, quote the name to
+// address a case-sensitive partition.
+func Partition(name string) TablePartition {
+	return TablePartition{Partition: name}
+}
+
+// Subpartition returns a clause restricting the statement to the named subpartition
+func Subpartition(name string) TablePartition {
+	return TablePartition{Subpartition: true, Partition: name}
+}
+
+// PartitionFor returns a clause restricting the statement to the partition
+// containing the given partition key values
+func PartitionFor(values ...interface{}) TablePartition {
+	return TablePartition{Values: values}
+}
+
+// SubpartitionFor returns a clause restricting the statement to the
+// subpartition containing the given subpartition key values
+func SubpartitionFor(values ...interface{}) TablePartition {
+	return TablePartition{Subpartition: true, Values: values}
+}
+
+// Name returns the name of the clause
+func (p TablePartition) Name() string {
+	return ClausePartition
+}
+
+// Build writes the partition extension clause
+func (p TablePartition) Build(builder clause.Builder) {
+	if p.Subpartition {
+		builder.WriteString("SUBPARTITION ")
+	} else {
+		builder.WriteString("PARTITION ")
+	}
+
+	if len(p.Values) > 0 {
+		builder.WriteString("FOR (")
+		for idx, value := range p.Values {
+			if idx > 0 {
+				builder.WriteByte(',')
+			}
+			builder.AddVar(builder, value)
+		}
+		builder.WriteByte(')')
+		return
+	}
+
+	if !partitionNameRegexp.MatchString(p.Partition) {
+		builder.AddError(fmt.Errorf("invalid partition name %q", p.Partition))
+		return
+	}
+	builder.WriteByte('(')
+	builder.WriteString(p.Partition)
+	builder.WriteByte(')')
+}
+
+// MergeClause replaces any previous partition extension clause
+func (p TablePartition) MergeClause(c *clause.Clause) {
+	c.Expression = p
+}
+
+// TableFunctionExpr is a table function call used as the source of a query,
+// such as a pipelined function returning a collection of rows.
+//
+//	db.Table("?", oracle.TableFunction("pkg.fn", 2024)).Find(&rows)
+//	// SELECT * FROM TABLE(pkg.fn(:1))
+//
+// It can also be passed to Clauses, in which case it replaces the table of
+// the statement:
+//
+//	db.Clauses(oracle.TableFunction("pkg.fn", 2024).As("t")).Find(&rows)
+//	// SELECT * FROM TABLE(pkg.fn(:1)) "t"
+type TableFunctionExpr struct {
+	Function string
+	Args     []interface{}
+	Alias    string
+}
+
+// TableFunction returns an expression calling the given table function with
+// the arguments passed as bind variables
+func TableFunction(function string, args ...interface{}) TableFunctionExpr {
+	return TableFunctionExpr{Function: function, Args: args}
+}
+
+// As sets the alias of the table function
+func (t TableFunctionExpr) As(alias string) TableFunctionExpr {
+	t.Alias = alias
+	return t
+}
+
+// Build writes the TABLE() expression
+func (t TableFunctionExpr) Build(builder clause.Builder) {
+	if !functionNameRegexp.MatchString(t.Function) {
+		builder.AddError(fmt.Errorf("invalid table function name %q", t.Function))
+		return
+	}
+
+	builder.WriteString("TABLE(")
+	builder.WriteString(t.Function)
+	builder.WriteByte('(')
+	for idx, arg := range t.Args {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+		builder.AddVar(builder, arg)
+	}
+	builder.WriteString("))")
+
+	if t.Alias != "" {
+		builder.WriteByte(' ')
+		builder.WriteQuoted(clause.Table{Name: t.Alias})
+	}
+}
+
+// ModifyStatement makes the table function the table of the statement.
+// Columns qualified with the table name refer to the alias, if one is set.
+func (t TableFunctionExpr) ModifyStatement(stmt *gorm.Statement) {
+	stmt.TableExpr = &clause.Expr{SQL: "?", Vars: []interface{}{t}}
+	if t.Alias != "" {
+		stmt.Table = t.Alias
+	}
+}
+
+// TableSample is the Oracle SAMPLE clause used for statistical sampling
+// of the rows of a table.
+//
+//	db.Clauses(oracle.Sample(10, 42)).Find(&users)
+//	// SELECT * FROM "users" SAMPLE (10) SEED (42)
+type TableSample struct {
+	Block   bool
+	Percent float64
+	Seed    *int64
+}
+
+// Sample returns a SAMPLE clause selecting roughly `percent` percent of the
+// rows of the queried table. An optional seed makes the sample repeatable.
+func Sample(percent float64, seed ...int64) TableSample {
+	sample := TableSample{Percent: percent}
+	if len(seed) > 0 {
+		sample.Seed = &seed[0]
+	}
+	return sample
+}
+
+// SampleBlock returns a SAMPLE BLOCK clause, which samples random data
+// blocks instead of individual rows
+func SampleBlock(percent float64, seed ...int64) TableSample {
+	sample := Sample(percent, seed...)
+	sample.Block = true
+	return sample
+}
+
+// Name returns the name of the clause
+func (s TableSample) Name() string {
+	return ClauseSample
+}
+
+// Build writes the SAMPLE clause
+func (s TableSample) Build(builder clause.Builder) {
+	// Oracle requires the sample percent to be in the range [0.000001, 100)
+	if s.Percent < 0.000001 || s.Percent >= 100 {
+		builder.AddError(fmt.Errorf("invalid sample percent %v: must be in the range [0.000001, 100)", s.Percent))
+		return
+	}
+
+	builder.WriteString("SAMPLE ")
+	if s.Block {
+		builder.WriteString("BLOCK ")
+	}
+	builder.WriteByte('(')
+	builder.WriteString(strconv.FormatFloat(s.Percent, 'f', -1, 64))
+	builder.WriteByte(')')
+
+	if s.Seed != nil {
+		builder.WriteString(" SEED (")
+		builder.WriteString(strconv.FormatInt(*s.Seed, 10))
+		builder.WriteByte(')')
+	}
+}
+
+// MergeClause replaces any previous SAMPLE clause
+func (s TableSample) MergeClause(c *clause.Clause) {
+	c.Expression = s
+}
+
+// writeTableReference writes the table followed by its modifiers and alias
+func writeTableReference(builder clause.Builder, table clause.Table) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok || !hasTableModifiers(stmt) {
+		builder.WriteQuoted(table)
+		return
+	}
+
+	alias := table.Alias
+	table.Alias = ""
+
+	if table.Name == clause.CurrentTable && stmt.TableExpr != nil {
+		// Table("users u"): split the expression so that the modifiers
+		// end up between the table name and the alias
+		if results := tableAliasRegexp.FindStringSubmatch(stmt.TableExpr.SQL); len(results) == 3 && len(stmt.TableExpr.Vars) == 0 {
+			builder.WriteString(results[1])
+			writeTableModifiers(stmt)
+			builder.WriteByte(' ')
+			builder.WriteString(results[2])
+			return
+		}
+	}
+
+	builder.WriteQuoted(table)
+	writeTableModifiers(stmt)
+	if alias != "" {
+		builder.WriteByte(' ')
+		builder.WriteQuoted(clause.Table{Name: alias})
+	}
+}
+
+func hasTableModifiers(stmt *gorm.Statement) bool {
+	for _, name := range tableModifierClauses {
+		if _, ok := stmt.Clauses[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func writeTableModifiers(stmt *gorm.Statement) {
+	for _, name := range tableModifierClauses {
+		if c, ok := stmt.Clauses[name]; ok && c.Expression != nil {
+			stmt.WriteByte(' ')
+			c.Expression.Build(stmt)
+		}
+	}
+}
+
+// writePartitionExtension writes the partition extension clause of the
+// statement, if any, for DML statements that address a single table
+func writePartitionExtension(stmt *gorm.Statement, builder clause.Builder) {
+	if c, ok := stmt.Clauses[ClausePartition]; ok && c.Expression != nil {
+		builder.WriteByte(' ')
+		c.Expression.Build(builder)
+	}
+}
+
+// TableAliasHandler rewrites a table expression with an alias, such as
+// Table("users u"), Table(`"users" u`), Table("users AS u") or
+// Table("(?) u", subquery), to the quoted table name and alias, as Oracle does not accept AS before a table alias.
+// The table name is quoted as written, like the one of Table("users"). An
+// unquoted alias is folded to uppercase, as Oracle does, so that both u.age
+// in raw SQL and the "U"."age" GORM writes refer to it. The alias becomes
+// the table of the statement, so that the columns GORM writes in SELECT,
+// WHERE and GROUP BY are qualified with it.
+func TableAliasHandler(db *gorm.DB) {
+	stmt := db.Statement
+	if stmt.TableExpr == nil {
+		return
+	}
+	results := aliasedTableRegexp.FindStringSubmatch(stmt.TableExpr.SQL)
+	subquery := len(results) == 3 && strings.HasPrefix(results[1], "(")
+	if len(results) != 3 || len(stmt.TableExpr.Vars) > 0 && !subquery {
+		return
+	}
+
+	alias := results[2]
+	if strings.HasPrefix(alias, `"`) {
+		alias = unquoteIdentifiers(alias)
+	} else {
+		alias = strings.ToUpper(alias)
+	}
+	var builder strings.Builder
+	if subquery {
+		builder.WriteString(results[1])
+		builder.WriteByte(' ')
+		stmt.QuoteTo(&builder, clause.Table{Name: alias})
+	} else {
+		stmt.QuoteTo(&builder, clause.Table{Name: unquoteIdentifiers(results[1]), Alias: alias})
+	}
+	stmt.TableExpr = &clause.Expr{SQL: builder.String(), Vars: stmt.TableExpr.Vars}
+
+	// Conditions added after Table() are qualified with the alias GORM took
+	// from the expression, which may differ in case
+	if stmt.Table != "" && stmt.Table != alias {
+		if c, ok := stmt.Clauses["WHERE"]; ok {
+			if where, ok := c.Expression.(clause.Where); ok {
+				c.Expression = clause.Where{Exprs: renameConditionsTable(where.Exprs, stmt.Table, alias)}
+				stmt.Clauses["WHERE"] = c
+			}
+		}
+	}
+	stmt.Table = alias
+}
+
+// renameConditionsTable qualifies the columns of conditions that are
+// qualified with table with alias instead, without changing the conditions
+// the statement shares with the session it was created from
+func renameConditionsTable(exprs []clause.Expression, table, alias string) []clause.Expression {
+	renamed := make([]clause.Expression, len(exprs))
+	for i, expr := range exprs {
+		renamed[i] = renameConditionTable(expr, table, alias)
+	}
+	return renamed
+}
+
+// renameConditionTable qualifies the columns of a condition that are
+// qualified with table with alias instead
+func renameConditionTable(expr clause.Expression, table, alias string) clause.Expression {
+	rename := func(column interface{}) interface{} {
+		if c, ok := column.(clause.Column); ok && c.Table == table {
+			c.Table = alias
+			return c
+		}
+		return column
+	}
+	switch e := expr.(type) {
+	case clause.Eq:
+		e.Column = rename(e.Column)
+		return e
+	case clause.Neq:
+		e.Column = rename(e.Column)
+		return e
+	case clause.Gt:
+		e.Column = rename(e.Column)
+		return e
+	case clause.Gte:
+		e.Column = rename(e.Column)
+		return e
+	case clause.Lt:
+		e.Column = rename(e.Column)
+		return e
+	case clause.Lte:
+		e.Column = rename(e.Column)
+		return e
+	case clause.Like:
+		e.Column = rename(e.Column)
+		return e
+	case clause.IN:
+		e.Column = rename(e.Column)
+		return e
+	case clause.AndConditions:
+		e.Exprs = renameConditionsTable(e.Exprs, table, alias)
+		return e
+	case clause.OrConditions:
+		e.Exprs = renameConditionsTable(e.Exprs, table, alias)
+		return e
+	case clause.NotConditions:
+		e.Exprs = renameConditionsTable(e.Exprs, table, alias)
+		return e
+	}
+	return expr
+}
+
+// unquoteIdentifiers removes the double quotes of the parts of a dotted
+// identifier
+func unquoteIdentifiers(identifier string) string {
+	return strings.ReplaceAll(identifier, `"`, "")
+}
+
+// removeJoinAliasAS removes the AS keyword between the joined table and its
+// alias from a raw join, and from the joins of subqueries that GORM writes
+// as JOIN (?) AS ?
+func removeJoinAliasAS(expr clause.Expression) clause.Expression {
+	switch e := expr.(type) {
+	case clause.Expr:
+		e.SQL = joinAliasASRegexp.ReplaceAllString(e.SQL, "$1$2")
+		return e
+	case clause.NamedExpr:
+		e.SQL = joinAliasASRegexp.ReplaceAllString(e.SQL, "$1$2")
+		return e
+	}
+	return expr
+}
@@ -0,0 +1,176 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// genDataTypes maps the database type names reported by the driver to the
+// Go types of generated fields. NUMBER is mapped by GenDataType from its
+// precision and scale.
+var genDataTypes = map[string]string{
+	"VARCHAR2":                       "string",
+	"NVARCHAR2":                      "string",
+	"CHAR":                           "string",
+	"NCHAR":                          "string",
+	"CLOB":                           "string",
+	"NCLOB":                          "string",
+	"LONG":                           "string",
+	"ROWID":                          "string",
+	"XMLTYPE":                        "string",
+	"JSON":                           "json.RawMessage",
+	"FLOAT":                          "float32",
+	"DOUBLE":                         "float64",
+	"BINARY_INTEGER":                 "int64",
+	"BOOLEAN":                        "bool",
+	"DATE":                           "time.Time",
+	"TIMESTAMP":                      "time.Time",
+	"TIMESTAMP WITH TIME ZONE":       "time.Time",
+	"TIMESTAMP WITH LOCAL TIME ZONE": "time.Time",
+	"INTERVAL DAY TO SECOND":         "time.Duration",
+	"RAW":                            "[]byte",
+	"LONG RAW":                       "[]byte",
+	"BLOB":                           "[]byte",
+}
+
+// GenDataType returns the Go type of the field generated for a column, as
+// returned by Migrator.ColumnTypes. It is the reverse of the types the
+// migrator creates: NUMBER(1) is a bool, NUMBER(p) an integer large enough
+// for p digits, and NUMBER with a scale or without a precision a float64.
+// Columns of other types are generated as strings.
+func GenDataType(columnType gorm.ColumnType) string {
+	name := strings.ToUpper(columnType.DatabaseTypeName())
+	if name != "NUMBER" {
+		if dataType, ok := genDataTypes[name]; ok {
+			return dataType
+		}
+		return "string"
+	}
+
+	precision, scale, ok := columnType.DecimalSize()
+	switch {
+	case !ok || precision <= 0 || scale != 0:
+		return "float64"
+	case precision == 1:
+		return "bool"
+	case precision <= 4:
+		return "int16"
+	case precision <= 9:
+		return "int32"
+	default:
+		return "int64"
+	}
+}
+
+// GenDataTypeMap returns the data type mapping of GenDataType for the
+// WithDataTypeMap option of gorm.io/gen. It is keyed by the type names in
+// upper and lower case.
+func GenDataTypeMap() map[string]func(columnType gorm.ColumnType) (dataType string) {
+	dataTypes := make(map[string]func(columnType gorm.ColumnType) (dataType string), 2*len(genDataTypes)+2)
+	for name := range genDataTypes {
+		dataTypes[name] = GenDataType
+		dataTypes[strings.ToLower(name)] = GenDataType
+	}
+	dataTypes["NUMBER"] = GenDataType
+	dataTypes["number"] = GenDataType
+	return dataTypes
+}
+
+// genIdentifier lowercases identifiers that Oracle stores in uppercase
+// because they were created unquoted, so that EMPLOYEE_ID is named like
+// employee_id. Mixed case identifiers were quoted and are kept.
+func genIdentifier(name string) string {
+	if name == strings.ToUpper(name) {
+		return strings.ToLower(name)
+	}
+	return name
+}
+
+// GenNamingStrategy is the naming strategy of the database used to generate
+// code with gorm.io/gen, which names models and fields with SchemaName. The
+// uppercase names of unquoted identifiers are lowercased first, so that the
+// column EMPLOYEE_ID becomes the field EmployeeID rather than EMPLOYEEID.
+type GenNamingStrategy struct {
+	schema.NamingStrategy
+}
+
+// SchemaName returns the Go name of a table or column
+func (ns GenNamingStrategy) SchemaName(table string) string {
+	return ns.NamingStrategy.SchemaName(genIdentifier(table))
+}
+
+// GenOptions configures gorm.io/gen for Oracle schemas. Each field is passed
+// to the generator option of the same name.
+//
+//	opts := oracle.GenConfig()
+//	g := gen.NewGenerator(gen.Config{OutPath: "query"})
+//	g.UseDB(db)
+//	g.WithDataTypeMap(opts.DataTypeMap)
+//	g.WithModelNameStrategy(opts.ModelNameStrategy)
+//	g.WithFileNameStrategy(opts.FileNameStrategy)
+//	g.WithJSONTagNameStrategy(opts.JSONTagNameStrategy)
+type GenOptions struct {
+	// DataTypeMap maps columns to Go types with GenDataType
+	DataTypeMap map[string]func(columnType gorm.ColumnType) (dataType string)
+	// ModelNameStrategy names the model of a table, such as Employee for
+	// EMPLOYEES
+	ModelNameStrategy func(tableName string) (modelName string)
+	// FileNameStrategy names the file of a model, such as employees for
+	// EMPLOYEES
+	FileNameStrategy func(tableName string) (fileName string)
+	// JSONTagNameStrategy names the JSON key of a column, such as
+	// employee_id for EMPLOYEE_ID
+	JSONTagNameStrategy func(columnName string) (tagContent string)
+}
+
+// GenConfig returns the options of gorm.io/gen for Oracle schemas. Open the
+// database with GenNamingStrategy as well, so that fields are named like
+// their models.
+func GenConfig() GenOptions {
+	ns := GenNamingStrategy{}
+	return GenOptions{
+		DataTypeMap:         GenDataTypeMap(),
+		ModelNameStrategy:   ns.SchemaName,
+		FileNameStrategy:    genIdentifier,
+		JSONTagNameStrategy: genIdentifier,
+	}
+}
@@ -0,0 +1,164 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/godror/godror"
+)
+
+// errHealthCheckDriver is returned when a health check is configured for
+// a driver other than godror
+var errHealthCheckDriver = errors.New("health checks require the godror driver")
+
+// HealthCheck configures how pooled connections are checked, so that
+// connections to failed database instances are dropped before they are used
+//
+//	db, err := gorm.Open(oracle.New(oracle.Config{
+//		DataSourceName: dsn,
+//		HealthCheck: &oracle.HealthCheck{
+//			PingInterval:       10 * time.Second,
+//			ValidationInterval: time.Minute,
+//			FAN:                true,
+//		},
+//	}), &gorm.Config{})
+type HealthCheck struct {
+	// PingInterval makes the godror session pool ping sessions that have
+	// been idle for longer than the interval when they are borrowed. The
+	// driver default is 60s, and a negative interval disables the ping.
+	PingInterval time.Duration
+	// ValidationInterval periodically validates the idle connections of the
+	// pool with SELECT 1 FROM DUAL, and closes the ones that fail
+	ValidationInterval time.Duration
+	// FAN subscribes the godror session pool to Fast Application
+	// Notification events, so that sessions of failed instances are removed
+	// from the pool as soon as the failure is notified. The database
+	// service must be configured for FAN.
+	FAN bool
+	// Context stops the validation when it is done. The validation also
+	// stops when the database is closed.
+	Context context.Context
+}
+
+// startValidation starts validating the idle connections of the pool when
+// a validation interval is set
+func (check *HealthCheck) startValidation(db *sql.DB) {
+	if check.ValidationInterval <= 0 {
+		return
+	}
+	ctx := check.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	go validateConnections(ctx, db, check.ValidationInterval)
+}
+
+// openWithHealthCheck opens a godror connection pool configured with the
+// health check, and starts validating its idle connections
+func openWithHealthCheck(dataSourceName string, check *HealthCheck) (*sql.DB, error) {
+	params, err := godror.ParseDSN(dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if check.PingInterval != 0 {
+		params.PingInterval = check.PingInterval
+	}
+	if check.FAN {
+		params.EnableEvents = true
+	}
+
+	db := sql.OpenDB(godror.NewConnector(params))
+	check.startValidation(db)
+	return db, nil
+}
+
+// validateConnections validates the idle connections of the pool at each
+// interval, until the context is done or the database is closed
+func validateConnections(ctx context.Context, db *sql.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := validateIdleConnections(ctx, db); err != nil && strings.Contains(err.Error(), "database is closed") {
+				return
+			}
+		}
+	}
+}
+
+// validateIdleConnections borrows as many connections as the pool has idle
+// and validates them. Connections that fail are closed instead of being
+// returned to the pool.
+func validateIdleConnections(ctx context.Context, db *sql.DB) error {
+	idle := db.Stats().Idle
+	conns := make([]*sql.Conn, 0, idle)
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	// Borrow all of them before validating, so that each one is checked
+	for i := 0; i < idle; i++ {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		conns = append(conns, conn)
+	}
+
+	for _, conn := range conns {
+		var one int
+		if err := conn.QueryRowContext(ctx, "SELECT 1 FROM DUAL").Scan(&one); err != nil {
+			// Returning driver.ErrBadConn makes database/sql discard the connection
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}
+	return nil
+}
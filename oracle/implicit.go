@@ -0,0 +1,94 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// ErrNoImplicitResult is returned when a PL/SQL block run as a query, for
+// instance with Raw().Scan or Find, returns no result set with
+// DBMS_SQL.RETURN_RESULT. Blocks without results are run with Exec.
+var ErrNoImplicitResult = errors.New("PL/SQL block returned no implicit result")
+
+// isPLSQLBlock reports whether the SQL is an anonymous PL/SQL block
+func isPLSQLBlock(sql string) bool {
+	sql = strings.TrimLeftFunc(sql, unicode.IsSpace)
+	for _, keyword := range []string{"BEGIN", "DECLARE"} {
+		if len(sql) > len(keyword) && strings.EqualFold(sql[:len(keyword)], keyword) &&
+			unicode.IsSpace(rune(sql[len(keyword)])) {
+			return true
+		}
+	}
+	return false
+}
+
+// implicitResult moves the rows of a PL/SQL block to the first result set
+// that the block returned with DBMS_SQL.RETURN_RESULT, so that it is scanned
+// like the result of a query:
+//
+//	db.Raw("BEGIN active_users(?); END;", 18).Scan(&users)
+//
+// The block is executed by the query and returns no columns of its own.
+// Further result sets are read with Rows().NextResultSet. Rows of other
+// statements are returned unchanged.
+func implicitResult(query string, rows *sql.Rows) (*sql.Rows, error) {
+	if !isPLSQLBlock(query) {
+		return rows, nil
+	}
+	if columns, err := rows.Columns(); err != nil || len(columns) > 0 {
+		return rows, err
+	}
+	if rows.NextResultSet() {
+		return rows, nil
+	}
+
+	// The driver reports the end of the result sets as an error wrapping
+	// io.EOF; a block without implicit results has nothing to scan
+	err := rows.Err()
+	rows.Close()
+	if err == nil || errors.Is(err, io.EOF) {
+		err = ErrNoImplicitResult
+	}
+	return nil, err
+}
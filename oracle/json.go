@@ -0,0 +1,444 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Identifies the data types of the RETURNING clause of JSON_VALUE, such as
+// NUMBER, VARCHAR2(100 CHAR) or TIMESTAMP WITH TIME ZONE
+var jsonReturningRegexp = regexp.MustCompile(`(?i)^[a-z][a-z0-9_]*( [a-z][a-z0-9_]*)*\s*(\(\s*\d+(\s*,\s*\d+)?(\s+(char|byte))?\s*\))?$`)
+
+// JSONOperation is an operation of a JSON_TRANSFORM expression
+type JSONOperation struct {
+	// Operator is SET, REMOVE, APPEND or RENAME
+	Operator string
+	// Path is the SQL/JSON path expression the operation applies to,
+	// starting with $
+	Path string
+	// Value is the bind value of SET and APPEND
+	Value interface{}
+	// FormatJSON binds the value as a JSON document rather than a scalar
+	FormatJSON bool
+	// Name is the new field name of RENAME
+	Name string
+}
+
+// JSONTransformExpr is a JSON_TRANSFORM expression, updating parts of a
+// JSON document in place
+type JSONTransformExpr struct {
+	Column     interface{}
+	Operations []JSONOperation
+	Alias      string
+}
+
+// JSONTransform returns a JSON_TRANSFORM expression on the column, to which
+// the operations are added with Set, SetJSON, Remove, Append and Rename. The
+// paths are written as string literals and the values as bind variables. It
+// can be used in Update and Updates as well as in Select.
+//
+//	db.Model(&doc).Update("doc", oracle.JSONTransform("doc").Set("$.status", "shipped").Remove("$.draft"))
+//	// UPDATE "docs" SET "doc"=JSON_TRANSFORM("doc", SET '$.status' = :1, REMOVE '$.draft') WHERE "id" = :2
+func JSONTransform(column interface{}) JSONTransformExpr {
+	return JSONTransformExpr{Column: column}
+}
+
+// Set sets the value at the path, creating the field if it is missing. A
+// string value is set as a JSON string.
+func (t JSONTransformExpr) Set(path string, value interface{}) JSONTransformExpr {
+	return t.with(JSONOperation{Operator: "SET", Path: path, Value: value})
+}
+
+// SetJSON sets the path to a JSON document, given as text, bytes or a value
+// encoded with encoding/json
+func (t JSONTransformExpr) SetJSON(path string, value interface{}) JSONTransformExpr {
+	return t.with(JSONOperation{Operator: "SET", Path: path, Value: value, FormatJSON: true})
+}
+
+// Remove removes the field or array element at the path
+func (t JSONTransformExpr) Remove(path string) JSONTransformExpr {
+	return t.with(JSONOperation{Operator: "REMOVE", Path: path})
+}
+
+// Append appends the value to the array at the path
+func (t JSONTransformExpr) Append(path string, value interface{}) JSONTransformExpr {
+	return t.with(JSONOperation{Operator: "APPEND", Path: path, Value: value})
+}
+
+// Rename renames the field at the path
+func (t JSONTransformExpr) Rename(path string, name string) JSONTransformExpr {
+	return t.with(JSONOperation{Operator: "RENAME", Path: path, Name: name})
+}
+
+// As sets the column alias of the expression
+func (t JSONTransformExpr) As(alias string) JSONTransformExpr {
+	t.Alias = alias
+	return t
+}
+
+func (t JSONTransformExpr) with(operation JSONOperation) JSONTransformExpr {
+	t.Operations = append(slices.Clip(t.Operations), operation)
+	return t
+}
+
+// Build writes the JSON_TRANSFORM expression
+func (t JSONTransformExpr) Build(builder clause.Builder) {
+	if len(t.Operations) == 0 {
+		builder.AddError(fmt.Errorf("JSON_TRANSFORM requires at least one operation"))
+		return
+	}
+	for _, operation := range t.Operations {
+		if !validJSONPath(builder, operation.Path) {
+			return
+		}
+	}
+
+	builder.WriteString("JSON_TRANSFORM(")
+	writeOperand(builder, t.Column)
+	for _, operation := range t.Operations {
+		builder.WriteString(", ")
+		builder.WriteString(operation.Operator)
+		builder.WriteByte(' ')
+		writeStringLiteral(builder, operation.Path)
+		switch operation.Operator {
+		case "REMOVE":
+		case "RENAME":
+			builder.WriteString(" = ")
+			writeStringLiteral(builder, operation.Name)
+		default:
+			builder.WriteString(" = ")
+			if operation.FormatJSON {
+				document, err := jsonDocument(operation.Value)
+				if err != nil {
+					builder.AddError(err)
+					return
+				}
+				builder.AddVar(builder, document)
+				builder.WriteString(" FORMAT JSON")
+			} else {
+				builder.AddVar(builder, operation.Value)
+			}
+		}
+	}
+	builder.WriteByte(')')
+
+	writeAlias(builder, t.Alias)
+}
+
+// validJSONPath reports whether the path is a SQL/JSON path expression,
+// adding an error to the statement otherwise
+func validJSONPath(builder clause.Builder, path string) bool {
+	if !strings.HasPrefix(strings.TrimSpace(path), "$") {
+		builder.AddError(fmt.Errorf("invalid JSON path %q: it must start with $", path))
+		return false
+	}
+	return true
+}
+
+// jsonDocument returns the text of a JSON document given as text, bytes or a
+// value to encode
+func jsonDocument(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case json.RawMessage:
+		return string(v), nil
+	}
+	document, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the JSON document: %w", err)
+	}
+	return string(document), nil
+}
+
+// JSONValueExpr is a JSON_VALUE expression
+type JSONValueExpr struct {
+	Column    interface{}
+	Path      string
+	Returning string
+	Alias     string
+}
+
+// JSONValue returns the scalar at the path of the JSON column, converted to
+// the returning data type, such as NUMBER or VARCHAR2(100), or to
+// VARCHAR2(4000) when it is empty. The path is written as a string literal
+// and the data type is checked, so neither can inject SQL.
+//
+//	db.Where("? > ?", oracle.JSONValue("doc", "$.price", "NUMBER"), 10).Find(&products)
+//	// SELECT * FROM "products" WHERE JSON_VALUE("doc", '$.price' RETURNING NUMBER) > :1
+func JSONValue(column interface{}, path string, returning string) JSONValueExpr {
+	return JSONValueExpr{Column: column, Path: path, Returning: returning}
+}
+
+// As sets the column alias of the expression
+func (v JSONValueExpr) As(alias string) JSONValueExpr {
+	v.Alias = alias
+	return v
+}
+
+// Build writes the JSON_VALUE expression
+func (v JSONValueExpr) Build(builder clause.Builder) {
+	if !validJSONPath(builder, v.Path) {
+		return
+	}
+	returning := strings.TrimSpace(v.Returning)
+	if returning != "" && !jsonReturningRegexp.MatchString(returning) {
+		builder.AddError(fmt.Errorf("invalid JSON_VALUE returning type %q", v.Returning))
+		return
+	}
+
+	builder.WriteString("JSON_VALUE(")
+	writeOperand(builder, v.Column)
+	builder.WriteString(", ")
+	writeStringLiteral(builder, v.Path)
+	if returning != "" {
+		builder.WriteString(" RETURNING ")
+		builder.WriteString(strings.ToUpper(returning))
+	}
+	builder.WriteByte(')')
+
+	writeAlias(builder, v.Alias)
+}
+
+// JSONExistsExpr is a JSON_EXISTS condition
+type JSONExistsExpr struct {
+	Column  interface{}
+	Path    string
+	Passing []interface{}
+}
+
+// JSONExists returns a condition testing whether the path of the JSON column
+// selects a value. The passing values are bound to the variables of the
+// path through the PASSING clause: a sql.NamedArg to the variable of its
+// name, and the other values to $p1, $p2 and so on in order. Values
+// compared in a filter are thus never interpolated into the path.
+//
+//	db.Where(oracle.JSONExists("doc", "$.items?(@.price > $min && @.sku == $p1)", sql.Named("min", 10), sku)).Find(&orders)
+//	// SELECT * FROM "orders" WHERE JSON_EXISTS("doc", '$.items?(@.price > $min && @.sku == $p1)' PASSING :1 AS "min", :2 AS "p1")
+func JSONExists(column interface{}, path string, passing ...interface{}) JSONExistsExpr {
+	return JSONExistsExpr{Column: column, Path: path, Passing: passing}
+}
+
+// Build writes the condition
+func (e JSONExistsExpr) Build(builder clause.Builder) {
+	if !validJSONPath(builder, e.Path) {
+		return
+	}
+
+	builder.WriteString("JSON_EXISTS(")
+	writeOperand(builder, e.Column)
+	builder.WriteString(", ")
+	writeStringLiteral(builder, e.Path)
+	position := 0
+	for idx, value := range e.Passing {
+		if idx == 0 {
+			builder.WriteString(" PASSING ")
+		} else {
+			builder.WriteString(", ")
+		}
+		var name string
+		if arg, ok := value.(sql.NamedArg); ok {
+			name, value = arg.Name, arg.Value
+		} else {
+			position++
+			name = "p" + strconv.Itoa(position)
+		}
+		builder.AddVar(builder, value)
+		builder.WriteString(" AS ")
+		builder.WriteQuoted(name)
+	}
+	builder.WriteByte(')')
+}
+
+// NegationBuild writes the negated condition
+func (e JSONExistsExpr) NegationBuild(builder clause.Builder) {
+	builder.WriteString("NOT ")
+	e.Build(builder)
+}
+
+// jsonRows converts the values of native JSON columns to JSON text, so that
+// they can be scanned into datatypes.JSON, json.RawMessage, fields with the
+// json serializer and nested structs
+type jsonRows struct {
+	gorm.Rows
+	jsonColumns []bool
+}
+
+// newJSONRows wraps the rows if the result has any native JSON column
+func newJSONRows(rows gorm.Rows, columnTypes []*sql.ColumnType) gorm.Rows {
+	jsonColumns := make([]bool, len(columnTypes))
+	hasJSON := false
+	for idx, columnType := range columnTypes {
+		if columnType.DatabaseTypeName() == "JSON" {
+			jsonColumns[idx] = true
+			hasJSON = true
+		}
+	}
+	if !hasJSON {
+		return rows
+	}
+	return jsonRows{Rows: rows, jsonColumns: jsonColumns}
+}
+
+func (r jsonRows) Scan(dest ...interface{}) error {
+	values := make([]interface{}, len(dest))
+	scanDest := make([]interface{}, len(dest))
+	for idx := range dest {
+		if idx < len(r.jsonColumns) && r.jsonColumns[idx] {
+			scanDest[idx] = &values[idx]
+		} else {
+			scanDest[idx] = dest[idx]
+		}
+	}
+
+	if err := r.Rows.Scan(scanDest...); err != nil {
+		return err
+	}
+
+	for idx := range dest {
+		if idx >= len(r.jsonColumns) || !r.jsonColumns[idx] {
+			continue
+		}
+		if err := assignJSONValue(dest[idx], values[idx]); err != nil {
+			return fmt.Errorf("scanning JSON column %d: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+// jsonText returns the JSON text of a native JSON value, keeping the
+// precision of numbers. Text returned by the driver is returned unchanged.
+func jsonText(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case godror.JSON:
+		document, err := v.GetValue(godror.JSONOptNumberAsString)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(jsonNumbers(document))
+	}
+	return nil, fmt.Errorf("unsupported JSON value %T", value)
+}
+
+// jsonNumbers replaces the numbers of a decoded JSON document, which the
+// driver returns as godror.Number strings, with json.Number
+func jsonNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case godror.Number:
+		return json.Number(v)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = jsonNumbers(item)
+		}
+	case []interface{}:
+		for idx, item := range v {
+			v[idx] = jsonNumbers(item)
+		}
+	}
+	return value
+}
+
+// assignJSONValue stores the value of a native JSON column in dest. An
+// interface receives the value of the driver, a sql.Scanner, a string or a
+// byte slice the JSON text, and any other destination is decoded from the
+// JSON text with encoding/json.
+func assignJSONValue(dest interface{}, value interface{}) error {
+	if d, ok := dest.(*interface{}); ok {
+		*d = value
+		return nil
+	}
+	if value == nil {
+		if scanner, ok := dest.(sql.Scanner); ok {
+			return scanner.Scan(nil)
+		}
+	}
+
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("destination %T is not a pointer", dest)
+	}
+	elem := rv.Elem()
+
+	if value == nil {
+		elem.Set(reflect.Zero(elem.Type()))
+		return nil
+	}
+
+	data, err := jsonText(value)
+	if err != nil {
+		return err
+	}
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(data)
+	}
+
+	switch {
+	case elem.Kind() == reflect.Ptr:
+		target := reflect.New(elem.Type().Elem())
+		if err := assignJSONValue(target.Interface(), value); err != nil {
+			return err
+		}
+		elem.Set(target)
+	case elem.Kind() == reflect.String:
+		elem.SetString(string(data))
+	case elem.Kind() == reflect.Slice && elem.Type().Elem().Kind() == reflect.Uint8:
+		elem.SetBytes(append([]byte(nil), data...))
+	default:
+		return json.Unmarshal(data, dest)
+	}
+	return nil
+}
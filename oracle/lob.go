@@ -0,0 +1,190 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+)
+
+// lobRows reads the LOB columns of a result into strings (CLOB, NCLOB) and
+// byte slices (BLOB) before assigning them to the scan destinations. The
+// driver already returns LOBs that way by default, but when a query asks
+// for godror.LobAsReader() it returns readers, which cannot be scanned into
+// plain string, []byte or map destinations. LONG and LONG RAW columns are
+// assigned the same way: the driver returns them whole with the fetched row,
+// and they are copied into the destination in the same pass, as the row
+// buffers are reused by the next fetch.
+type lobRows struct {
+	gorm.Rows
+	lobColumns []bool
+}
+
+// newLobRows wraps the rows if the result has any LOB, LONG or LONG RAW
+// column
+func newLobRows(rows gorm.Rows, columnTypes []*sql.ColumnType) gorm.Rows {
+	lobColumns := make([]bool, len(columnTypes))
+	hasLob := false
+	for idx, columnType := range columnTypes {
+		if typeName := columnType.DatabaseTypeName(); isLobType(typeName) || isLongType(typeName) {
+			lobColumns[idx] = true
+			hasLob = true
+		}
+	}
+	if !hasLob {
+		return rows
+	}
+	return lobRows{Rows: rows, lobColumns: lobColumns}
+}
+
+// isLobType reports whether the database type name is CLOB, NCLOB or BLOB
+func isLobType(typeName string) bool {
+	switch typeName {
+	case "CLOB", "NCLOB", "BLOB":
+		return true
+	}
+	return false
+}
+
+func (r lobRows) Scan(dest ...interface{}) error {
+	values := make([]interface{}, len(dest))
+	scanDest := make([]interface{}, len(dest))
+	for idx := range dest {
+		if idx < len(r.lobColumns) && r.lobColumns[idx] {
+			scanDest[idx] = &values[idx]
+		} else {
+			scanDest[idx] = dest[idx]
+		}
+	}
+
+	if err := r.Rows.Scan(scanDest...); err != nil {
+		return err
+	}
+
+	for idx := range dest {
+		if idx >= len(r.lobColumns) || !r.lobColumns[idx] {
+			continue
+		}
+		value, err := readLob(values[idx])
+		if err != nil {
+			return err
+		}
+		if err := assignLobValue(dest[idx], value); err != nil {
+			return fmt.Errorf("scanning LOB column %d: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+// readLob reads a LOB reader returned by the driver into a string or a byte
+// slice. Other values are returned unchanged.
+func readLob(value interface{}) (interface{}, error) {
+	lob, ok := value.(*godror.Lob)
+	if !ok || lob == nil {
+		return value, nil
+	}
+	data, err := io.ReadAll(lob)
+	if err != nil {
+		return nil, err
+	}
+	if lob.IsClob {
+		return string(data), nil
+	}
+	return data, nil
+}
+
+// assignLobValue stores a string, byte slice or nil value in dest, which is
+// a pointer to a sql.Scanner, an interface, a string or a byte slice, or a
+// pointer to one of those
+func assignLobValue(dest interface{}, value interface{}) error {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(value)
+	}
+	if d, ok := dest.(*interface{}); ok {
+		*d = value
+		return nil
+	}
+
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("destination %T is not a pointer", dest)
+	}
+	elem := rv.Elem()
+
+	if value == nil {
+		elem.Set(reflect.Zero(elem.Type()))
+		return nil
+	}
+
+	if elem.Kind() == reflect.Ptr {
+		target := reflect.New(elem.Type().Elem())
+		if err := assignLobValue(target.Interface(), value); err != nil {
+			return err
+		}
+		elem.Set(target)
+		return nil
+	}
+
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unsupported LOB value %T", value)
+	}
+
+	switch {
+	case elem.Kind() == reflect.String:
+		elem.SetString(string(data))
+	case elem.Kind() == reflect.Slice && elem.Type().Elem().Kind() == reflect.Uint8:
+		elem.SetBytes(append([]byte(nil), data...))
+	case elem.Kind() == reflect.Interface && elem.NumMethod() == 0:
+		elem.Set(reflect.ValueOf(value))
+	default:
+		return fmt.Errorf("cannot store LOB value in %T", dest)
+	}
+	return nil
+}
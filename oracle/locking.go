@@ -0,0 +1,137 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Locking is a FOR UPDATE clause naming the columns of FOR UPDATE OF. In a
+// join, Oracle only locks the rows of the tables those columns belong to:
+//
+//	db.Joins("Company").Clauses(oracle.Locking{
+//		Strength: clause.LockingStrengthUpdate,
+//		Columns:  []clause.Column{{Table: clause.CurrentTable, Name: "id"}},
+//	}).Find(&users)
+//	// SELECT ... FOR UPDATE OF "users"."id"
+//
+// clause.Locking with a Table is built the same way, with the primary key of
+// that table as the column.
+type Locking struct {
+	Strength string
+	Columns  []clause.Column
+	// Options is NOWAIT, SKIP LOCKED or WAIT n
+	Options string
+}
+
+// Name returns the name of the clause, which it shares with clause.Locking
+func (locking Locking) Name() string {
+	return "FOR"
+}
+
+// Build writes FOR UPDATE, the columns and the options
+func (locking Locking) Build(builder clause.Builder) {
+	strength := locking.Strength
+	if strength == "" {
+		strength = clause.LockingStrengthUpdate
+	}
+	builder.WriteString(strength)
+
+	for idx, column := range locking.Columns {
+		if idx == 0 {
+			builder.WriteString(" OF ")
+		} else {
+			builder.WriteByte(',')
+		}
+		builder.WriteQuoted(column)
+	}
+
+	if locking.Options != "" {
+		builder.WriteByte(' ')
+		builder.WriteString(locking.Options)
+	}
+}
+
+// MergeClause replaces the previous locking clause
+func (locking Locking) MergeClause(c *clause.Clause) {
+	c.Expression = locking
+}
+
+// lockingColumn returns the primary key column of the table named by
+// clause.Locking, which is the statement's table, a table of one of its
+// relationships, or the alias of a joined relationship
+func lockingColumn(stmt *gorm.Statement, table clause.Table) (clause.Column, bool) {
+	if stmt.Schema == nil {
+		return clause.Column{}, false
+	}
+
+	name := table.Name
+	if table.Alias != "" {
+		name = table.Alias
+	}
+
+	if name == clause.CurrentTable || name == stmt.Table || name == stmt.Schema.Table {
+		if field := stmt.Schema.PrioritizedPrimaryField; field != nil {
+			return clause.Column{Table: name, Name: field.DBName}, true
+		}
+		return clause.Column{}, false
+	}
+
+	for _, rel := range stmt.Schema.Relationships.Relations {
+		if rel.Name != name && rel.FieldSchema.Table != name {
+			continue
+		}
+		field := rel.FieldSchema.PrioritizedPrimaryField
+		if field == nil {
+			continue
+		}
+		// Joins of a relationship alias its table with the relationship name
+		for _, join := range stmt.Joins {
+			if join.Name == rel.Name {
+				name = rel.Name
+				if join.Alias != "" {
+					name = join.Alias
+				}
+			}
+		}
+		return clause.Column{Table: name, Name: field.DBName}, true
+	}
+	return clause.Column{}, false
+}
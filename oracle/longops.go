@@ -0,0 +1,220 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultLongOpsThreshold is how long a query runs before a LongOpsWatcher
+// without a Threshold reports its progress
+const DefaultLongOpsThreshold = 10 * time.Second
+
+// LongOpsWatcher reports the progress of long-running queries through the
+// logger. Once a query has run for Threshold, the operations Oracle records
+// for it in V$SESSION_LONGOPS, such as full table scans, sorts and hash
+// joins, are read every Interval from another connection of the pool and
+// logged as warnings until the query returns:
+//
+//	db, err := gorm.Open(oracle.New(oracle.Config{DataSourceName: dsn, LongOps: &oracle.LongOpsWatcher{Threshold: 30 * time.Second}}), &gorm.Config{})
+//	// query running for 30s (sql_id=a5ks9fhw2v9s1): Table Scan APP.ORDERS: 12000 of 48000 Blocks done, 25%, 1m30s remaining
+//
+// The session running the query is found by the SQL_ID of the statement,
+// the user and the time it started, so the operations of concurrent
+// executions of the same statement by the same user started meanwhile are
+// reported as well. Reading V$SESSION_LONGOPS requires the
+// SELECT_CATALOG_ROLE role or SELECT privilege on V_$SESSION_LONGOPS; the
+// error is logged once per query without failing it. Queries run by Rows
+// are watched until their rows are closed.
+type LongOpsWatcher struct {
+	// Threshold is how long a query runs before its progress is first read,
+	// DefaultLongOpsThreshold if zero
+	Threshold time.Duration
+	// Interval is the time between two reads of the progress, Threshold if
+	// zero
+	Interval time.Duration
+}
+
+// LongOp is an operation of a query in progress, as recorded in
+// V$SESSION_LONGOPS
+type LongOp struct {
+	SID       int64
+	OpName    string
+	Target    string
+	SoFar     int64
+	TotalWork int64
+	Units     string
+	Elapsed   time.Duration
+	Remaining time.Duration
+}
+
+// String formats the progress of the operation for the log
+func (op LongOp) String() string {
+	name := op.OpName
+	if op.Target != "" {
+		name += " " + op.Target
+	}
+	if op.TotalWork <= 0 {
+		return fmt.Sprintf("%s: %d %s done", name, op.SoFar, op.Units)
+	}
+	return fmt.Sprintf("%s: %d of %d %s done, %d%%, %s remaining",
+		name, op.SoFar, op.TotalWork, op.Units, op.SoFar*100/op.TotalWork, op.Remaining)
+}
+
+// longOpsSQL reads the operations in progress of the executions of a
+// statement by the current user started in the last seconds. The start time
+// is compared with SYSDATE, so that the clocks of the application and the
+// database need not agree.
+const longOpsSQL = `SELECT SID, OPNAME, TARGET, SOFAR, TOTALWORK, UNITS, ELAPSED_SECONDS, TIME_REMAINING
+FROM V$SESSION_LONGOPS
+WHERE SQL_ID = :1 AND USERNAME = SYS_CONTEXT('USERENV', 'SESSION_USER')
+AND SQL_EXEC_START >= SYSDATE - :2 / 86400 AND (SOFAR < TOTALWORK OR TOTALWORK = 0)
+ORDER BY SID, START_TIME`
+
+// longOpsWatcher returns the LongOpsWatcher of the dialector config, if any
+func longOpsWatcher(db *gorm.DB) *LongOpsWatcher {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		if d.Config != nil {
+			return d.LongOps
+		}
+	case Dialector:
+		if d.Config != nil {
+			return d.LongOps
+		}
+	}
+	return nil
+}
+
+// watchLongOps starts watching the progress of the query of the statement
+// when the dialector has a LongOpsWatcher, and returns the function that
+// stops watching once the query has returned
+func watchLongOps(db *gorm.DB) (stop func()) {
+	w := longOpsWatcher(db)
+	if w == nil || db.DryRun {
+		return func() {}
+	}
+	pool, err := db.DB()
+	if err != nil {
+		return func() {}
+	}
+
+	threshold := w.Threshold
+	if threshold <= 0 {
+		threshold = DefaultLongOpsThreshold
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = threshold
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(db.Statement.Context))
+	done := make(chan struct{})
+	sqlID := SQLID(db.Statement.SQL.String())
+	started := time.Now()
+	go func() {
+		defer close(done)
+		timer := time.NewTimer(threshold)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			elapsed := time.Since(started)
+			ops, err := readLongOps(ctx, pool, sqlID, elapsed)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				db.Logger.Warn(ctx, "cannot read the progress of query (sql_id=%s): %v", sqlID, err)
+				return
+			}
+			elapsed = elapsed.Round(time.Second)
+			if len(ops) == 0 {
+				db.Logger.Warn(ctx, "query running for %s (sql_id=%s)", elapsed, sqlID)
+			}
+			for _, op := range ops {
+				db.Logger.Warn(ctx, "query running for %s (sql_id=%s): %s", elapsed, sqlID, op)
+			}
+			timer.Reset(interval)
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// readLongOps reads the operations in progress of the statement with the
+// SQL_ID, which started at most elapsed ago
+func readLongOps(ctx context.Context, pool *sql.DB, sqlID string, elapsed time.Duration) ([]LongOp, error) {
+	rows, err := pool.QueryContext(ctx, longOpsSQL, sqlID, math.Ceil(elapsed.Seconds())+1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ops []LongOp
+	for rows.Next() {
+		var (
+			op             LongOp
+			target, units  sql.NullString
+			elapsedSeconds int64
+			remaining      sql.NullInt64
+		)
+		if err := rows.Scan(&op.SID, &op.OpName, &target, &op.SoFar, &op.TotalWork, &units, &elapsedSeconds, &remaining); err != nil {
+			return nil, err
+		}
+		op.Target, op.Units = target.String, units.String
+		op.Elapsed = time.Duration(elapsedSeconds) * time.Second
+		op.Remaining = time.Duration(remaining.Int64) * time.Second
+		ops = append(ops, op)
+	}
+	return ops, rows.Err()
+}
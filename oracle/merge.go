@@ -0,0 +1,274 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ClauseMergeCounts = "MERGE_COUNTS"
+
+	mergeResultKey = "oracle:merge_result"
+
+	// mergeCountBatchSize is the number of rows whose keys are looked up by
+	// one query, below the limit of 1000 expressions of an IN list
+	mergeCountBatchSize = 1000
+)
+
+// MergeCountsMode makes an upsert report how many rows it inserted and how
+// many it updated, see MergeResult. Upserts count them when the statement
+// has this clause, or when it was created with gorm.WithResult().
+//
+//	tx := db.Clauses(clause.OnConflict{UpdateAll: true}, oracle.MergeCounts()).Create(&users)
+//	if result, ok := oracle.MergeResultOf(tx); ok {
+//		log.Printf("%d inserted, %d updated", result.Inserted, result.Updated)
+//	}
+//
+// Oracle does not tell the rows a MERGE inserted from those it updated, so
+// the rows whose conflict columns match an existing row are counted by a
+// query run right before the MERGE, in the same transaction. Rows inserted
+// or deleted concurrently between both statements can make the counts
+// inaccurate.
+type MergeCountsMode struct{}
+
+// MergeCounts returns a clause making an upsert count the inserted and
+// updated rows
+func MergeCounts() MergeCountsMode {
+	return MergeCountsMode{}
+}
+
+// Name returns the name of the clause
+func (MergeCountsMode) Name() string {
+	return ClauseMergeCounts
+}
+
+// Build writes nothing, the clause only makes Create count the rows
+func (MergeCountsMode) Build(clause.Builder) {}
+
+// MergeClause replaces any previous MERGE_COUNTS clause
+func (m MergeCountsMode) MergeClause(c *clause.Clause) {
+	c.Expression = m
+}
+
+// MergeResult is the result of an upsert run as a MERGE. It is the
+// sql.Result of the result created with gorm.WithResult(), and is returned
+// by MergeResultOf.
+type MergeResult struct {
+	// Inserted is the number of rows that did not exist and were inserted
+	Inserted int64
+	// Updated is the number of existing rows that were updated. Existing
+	// rows are not updated by upserts with DoNothing.
+	Updated int64
+
+	result sql.Result
+}
+
+// LastInsertId returns the result of the driver, if the statement returned
+// one
+func (r MergeResult) LastInsertId() (int64, error) {
+	if r.result == nil {
+		return 0, errors.New("LastInsertId is not supported by this statement")
+	}
+	return r.result.LastInsertId()
+}
+
+// RowsAffected returns the number of inserted and updated rows
+func (r MergeResult) RowsAffected() (int64, error) {
+	return r.Inserted + r.Updated, nil
+}
+
+// MergeResultOf returns the counts of the upsert run by the statement, if it
+// counted them
+func MergeResultOf(db *gorm.DB) (MergeResult, bool) {
+	if v, ok := db.InstanceGet(mergeResultKey); ok {
+		result, ok := v.(MergeResult)
+		return result, ok
+	}
+	return MergeResult{}, false
+}
+
+// countsMerge reports whether Create should count the rows an upsert
+// inserts and updates
+func countsMerge(stmt *gorm.Statement) bool {
+	if _, ok := stmt.Clauses["ON CONFLICT"]; !ok || stmt.Schema == nil {
+		return false
+	}
+	_, ok := stmt.Clauses[ClauseMergeCounts]
+	return ok || stmt.Result != nil
+}
+
+// countMatchedRows returns the number of rows of the values whose conflict
+// columns match an existing row, that the MERGE of the upsert will update.
+// No row matches when a conflict column is not inserted, as the upsert is
+// then a plain INSERT.
+func countMatchedRows(db *gorm.DB, values clause.Values) (int64, error) {
+	stmt := db.Statement
+	onConflict, ok := stmt.Clauses["ON CONFLICT"].Expression.(clause.OnConflict)
+	if !ok {
+		return 0, nil
+	}
+	conflictColumns := onConflict.Columns
+	if len(conflictColumns) == 0 {
+		for _, field := range stmt.Schema.PrimaryFields {
+			conflictColumns = append(conflictColumns, clause.Column{Name: field.DBName})
+		}
+	}
+
+	indexes := make([]int, 0, len(conflictColumns))
+	for _, conflictColumn := range conflictColumns {
+		idx := -1
+		for i, column := range values.Columns {
+			if strings.EqualFold(column.Name, conflictColumn.Name) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return 0, nil
+		}
+		indexes = append(indexes, idx)
+	}
+	if len(indexes) == 0 {
+		return 0, nil
+	}
+
+	var matched int64
+	for start := 0; start < len(values.Values); start += mergeCountBatchSize {
+		end := min(start+mergeCountBatchSize, len(values.Values))
+
+		var query strings.Builder
+		var vars []interface{}
+		query.WriteString("SELECT COUNT(*) FROM ")
+		db.QuoteTo(&query, stmt.Table)
+		query.WriteString(" WHERE ")
+		writeColumnTuple(db, &query, conflictColumns)
+		query.WriteString(" IN (")
+		for row := start; row < end; row++ {
+			if row > start {
+				query.WriteByte(',')
+			}
+			if len(indexes) > 1 {
+				query.WriteByte('(')
+			}
+			for i, idx := range indexes {
+				if i > 0 {
+					query.WriteByte(',')
+				}
+				vars = append(vars, convertValue(values.Values[row][idx]))
+				query.WriteByte(':')
+				query.WriteString(strconv.Itoa(len(vars)))
+			}
+			if len(indexes) > 1 {
+				query.WriteByte(')')
+			}
+		}
+		query.WriteByte(')')
+
+		var count int64
+		if err := stmt.ConnPool.QueryRowContext(stmt.Context, query.String(), vars...).Scan(&count); err != nil {
+			return 0, err
+		}
+		matched += count
+	}
+	return matched, nil
+}
+
+// writeColumnTuple writes a column, or a parenthesized list of columns
+func writeColumnTuple(db *gorm.DB, builder *strings.Builder, columns []clause.Column) {
+	if len(columns) > 1 {
+		builder.WriteByte('(')
+	}
+	for i, column := range columns {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		db.QuoteTo(builder, column.Name)
+	}
+	if len(columns) > 1 {
+		builder.WriteByte(')')
+	}
+}
+
+// recordMergeResult records the counts of an upsert of rows, of which
+// matched existed, and sets them as the result of the statement
+func recordMergeResult(db *gorm.DB, rows int, matched int64) {
+	stmt := db.Statement
+	matched = min(matched, int64(rows))
+	result := MergeResult{Inserted: int64(rows) - matched}
+	if onConflict, ok := stmt.Clauses["ON CONFLICT"].Expression.(clause.OnConflict); ok && updatesMatchedRows(onConflict) {
+		result.Updated = matched
+	}
+	if stmt.Result != nil {
+		result.result = stmt.Result.Result
+		stmt.Result.Result = result
+		stmt.Result.RowsAffected = result.Inserted + result.Updated
+	}
+	db.InstanceSet(mergeResultKey, result)
+}
+
+// updatesMatchedRows reports whether the MERGE of an upsert updates the
+// rows that already exist
+func updatesMatchedRows(onConflict clause.OnConflict) bool {
+	if onConflict.DoNothing {
+		return false
+	}
+	if len(onConflict.DoUpdates) == 0 {
+		return true
+	}
+	for _, assignment := range onConflict.DoUpdates {
+		isConflictColumn := false
+		for _, column := range onConflict.Columns {
+			if strings.EqualFold(assignment.Column.Name, column.Name) {
+				isConflictColumn = true
+				break
+			}
+		}
+		if !isConflictColumn {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,280 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultMigrationsTable is the table recording applied migrations when
+// MigrationRunner.Table is empty
+const DefaultMigrationsTable = "schema_migrations"
+
+// Migration is a versioned change of the schema, applied once by a
+// MigrationRunner
+type Migration struct {
+	// ID identifies the migration, such as "202401150930_create_orders"
+	ID string
+	// Migrate applies the migration
+	Migrate func(tx *gorm.DB) error
+	// Rollback reverts the migration, if it can be reverted
+	Rollback func(tx *gorm.DB) error
+}
+
+// AppliedMigration is a migration recorded as applied
+type AppliedMigration struct {
+	ID        string
+	AppliedAt time.Time
+	// Dirty is set while the migration runs, and remains set when it failed
+	// after a DDL statement committed part of it
+	Dirty bool
+}
+
+// DirtyMigrationError reports a migration that failed after committing
+// part of its changes. It must be repaired by hand, and its row removed
+// from the migrations table, before other migrations run.
+type DirtyMigrationError struct {
+	ID string
+}
+
+// Error returns a message naming the dirty migration
+func (e *DirtyMigrationError) Error() string {
+	return fmt.Sprintf("migration %s failed partway and must be repaired before migrating", e.ID)
+}
+
+// MigrationRunner applies versioned migrations in order, recording each in
+// a table so that it runs once. The runner holds a MigrationLock, so that
+// processes starting together apply the migrations one after the other.
+//
+//	runner := oracle.MigrationRunner{Migrations: []oracle.Migration{{
+//		ID: "202401150930_create_orders",
+//		Migrate: func(tx *gorm.DB) error {
+//			return tx.Migrator().CreateTable(&Order{})
+//		},
+//		Rollback: func(tx *gorm.DB) error {
+//			return tx.Migrator().DropTable(&Order{})
+//		},
+//	}}}
+//	err := runner.Migrate(db)
+//
+// Each migration runs in a transaction with the update of its row. Oracle
+// commits DDL statements implicitly, so a migration that fails after one
+// can't be rolled back: its row is left dirty, and the runner refuses to
+// continue with a DirtyMigrationError. Migrations failing before any DDL
+// are rolled back entirely.
+type MigrationRunner struct {
+	// Table records the applied migrations, DefaultMigrationsTable if empty
+	Table string
+	// Lock is held while migrating. It defaults to the MigrationLock of the
+	// dialector, or to a lock named DefaultMigrationLockName.
+	Lock *MigrationLock
+	// Migrations are applied in this order
+	Migrations []Migration
+}
+
+// table returns the migrations table
+func (r MigrationRunner) table() clause.Table {
+	if r.Table == "" {
+		return clause.Table{Name: DefaultMigrationsTable}
+	}
+	return clause.Table{Name: r.Table}
+}
+
+// validate checks that the migrations have distinct IDs
+func (r MigrationRunner) validate() error {
+	seen := make(map[string]bool, len(r.Migrations))
+	for _, migration := range r.Migrations {
+		if migration.ID == "" {
+			return errors.New("migration ID must not be empty")
+		}
+		if seen[migration.ID] {
+			return fmt.Errorf("duplicate migration ID %s", migration.ID)
+		}
+		seen[migration.ID] = true
+	}
+	return nil
+}
+
+// run runs fc on a session holding the lock, once the migrations table
+// exists
+func (r MigrationRunner) run(db *gorm.DB, fc func(tx *gorm.DB) error) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+	lock := r.Lock
+	if lock == nil {
+		lock = migrationLock(db)
+	}
+	if lock == nil {
+		lock = &MigrationLock{}
+	}
+
+	return withMigrationLock(db, lock, func(tx *gorm.DB) error {
+		if !tx.Migrator().HasTable(r.table().Name) {
+			if err := tx.Exec(`CREATE TABLE ? (
+	"id" VARCHAR2(255) NOT NULL PRIMARY KEY,
+	"applied_at" TIMESTAMP WITH TIME ZONE DEFAULT SYSTIMESTAMP NOT NULL,
+	"dirty" NUMBER(1) DEFAULT 0 NOT NULL
+)`, r.table()).Error; err != nil {
+				return err
+			}
+		}
+		return fc(tx)
+	})
+}
+
+// applied returns the recorded migrations by ID, and fails when one of them
+// is dirty
+func (r MigrationRunner) applied(tx *gorm.DB) (map[string]AppliedMigration, error) {
+	var rows []AppliedMigration
+	if err := tx.Table("?", r.table()).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[string]AppliedMigration, len(rows))
+	for _, row := range rows {
+		if row.Dirty {
+			return nil, &DirtyMigrationError{ID: row.ID}
+		}
+		applied[row.ID] = row
+	}
+	return applied, nil
+}
+
+// Migrate applies the migrations that are not recorded yet, in order
+func (r MigrationRunner) Migrate(db *gorm.DB) error {
+	return r.run(db, func(tx *gorm.DB) error {
+		applied, err := r.applied(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range r.Migrations {
+			if _, ok := applied[migration.ID]; ok {
+				continue
+			}
+			if migration.Migrate == nil {
+				return fmt.Errorf("migration %s has no Migrate function", migration.ID)
+			}
+
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(`INSERT INTO ? ("id", "dirty") VALUES (?, 1)`, r.table(), migration.ID).Error; err != nil {
+					return err
+				}
+				if err := migration.Migrate(tx); err != nil {
+					return err
+				}
+				return tx.Exec(`UPDATE ? SET "dirty" = 0, "applied_at" = SYSTIMESTAMP WHERE "id" = ?`, r.table(), migration.ID).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %s failed: %w", migration.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Rollback reverts the last applied migration, the latest in the order of
+// Migrations. It does nothing when no migration is applied.
+func (r MigrationRunner) Rollback(db *gorm.DB) error {
+	return r.run(db, func(tx *gorm.DB) error {
+		applied, err := r.applied(tx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(r.Migrations) - 1; i >= 0; i-- {
+			migration := r.Migrations[i]
+			if _, ok := applied[migration.ID]; !ok {
+				continue
+			}
+			if migration.Rollback == nil {
+				return fmt.Errorf("migration %s cannot be rolled back", migration.ID)
+			}
+
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(`UPDATE ? SET "dirty" = 1 WHERE "id" = ?`, r.table(), migration.ID).Error; err != nil {
+					return err
+				}
+				if err := migration.Rollback(tx); err != nil {
+					return err
+				}
+				return tx.Exec(`DELETE FROM ? WHERE "id" = ?`, r.table(), migration.ID).Error
+			})
+			if err != nil {
+				return fmt.Errorf("rollback of migration %s failed: %w", migration.ID, err)
+			}
+			return nil
+		}
+		return nil
+	})
+}
+
+// Applied returns the recorded migrations in the order of Migrations,
+// followed by those that Migrations doesn't know in the order of their IDs
+func (r MigrationRunner) Applied(db *gorm.DB) ([]AppliedMigration, error) {
+	if !db.Migrator().HasTable(r.table().Name) {
+		return nil, nil
+	}
+	var rows []AppliedMigration
+	if err := db.Table("?", r.table()).Order(`"id"`).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]AppliedMigration, len(rows))
+	for _, row := range rows {
+		byID[row.ID] = row
+	}
+	result := make([]AppliedMigration, 0, len(rows))
+	for _, migration := range r.Migrations {
+		if row, ok := byID[migration.ID]; ok {
+			result = append(result, row)
+			delete(byID, migration.ID)
+		}
+	}
+	for _, row := range rows {
+		if _, ok := byID[row.ID]; ok {
+			result = append(result, row)
+		}
+	}
+	return result, nil
+}
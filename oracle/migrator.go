@@ -66,6 +66,155 @@ func (m Migrator) RunWithValue(value interface{}, fc func(*gorm.Statement) error
 	return m.Migrator.RunWithValue(value, fc)
 }
 
+// AutoMigrate creates and migrates the tables of the given values, on a
+// session that honours the configured DDLLockTimeout and MigrationLock.
+// External tables are created when missing and otherwise left as they are,
+// as their columns describe files rather than stored data.
+func (m Migrator) AutoMigrate(values ...interface{}) error {
+	values = slices.DeleteFunc(slices.Clone(values), func(value interface{}) bool {
+		return isExternalModel(m.DB, value) && m.HasTable(value)
+	})
+
+	return withMigrationSession(m.DB, func(tx *gorm.DB) error {
+		if tx == m.DB {
+			if err := m.Migrator.AutoMigrate(values...); err != nil {
+				return err
+			}
+			return m.migrateCheckConstraints(values...)
+		}
+		config := m.Config
+		config.DB = tx
+		if err := (migrator.Migrator{Config: config}).AutoMigrate(values...); err != nil {
+			return err
+		}
+		return Migrator{Migrator: migrator.Migrator{Config: config}}.migrateCheckConstraints(values...)
+	})
+}
+
+// migrateCheckConstraints re-creates the check constraints of the given
+// values whose stored condition differs from the declared one. AutoMigrate
+// only creates the constraints that are missing by name.
+func (m Migrator) migrateCheckConstraints(values ...interface{}) error {
+	for _, value := range values {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			if stmt.Schema == nil {
+				return nil
+			}
+			for _, chk := range stmt.Schema.ParseCheckConstraints() {
+				var condition sql.NullString
+				err := m.DB.Raw(
+					"SELECT SEARCH_CONDITION_VC FROM USER_CONSTRAINTS WHERE TABLE_NAME = ? AND CONSTRAINT_NAME = ? AND CONSTRAINT_TYPE = 'C'",
+					stmt.Table, chk.Name,
+				).Row().Scan(&condition)
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				} else if err != nil {
+					return err
+				}
+				if sameCondition(condition.String, checkConstraintSQL(stmt.Schema, chk)) {
+					continue
+				}
+				if err := m.DropConstraint(value, chk.Name); err != nil {
+					return err
+				}
+				if err := m.CreateConstraint(value, chk.Name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkConstraintSQL returns the condition of a check constraint with the
+// column names of the schema quoted
+func checkConstraintSQL(sch *schema.Schema, chk schema.CheckConstraint) string {
+	if condition, ok := jsonCheckSQL(chk); ok {
+		return condition
+	}
+	constraintSQL := chk.Constraint
+	for _, f := range sch.Fields {
+		if f.DBName != "" && strings.Contains(constraintSQL, f.DBName) {
+			re := regexp.MustCompile(`\b` + regexp.QuoteMeta(f.DBName) + `\b`)
+			constraintSQL = re.ReplaceAllString(constraintSQL, QuoteIdentifier(f.DBName))
+		}
+	}
+	return constraintSQL
+}
+
+// jsonCheck matches the `json [strict] [validate '<schema>']` shorthand of a
+// check tag
+var jsonCheck = regexp.MustCompile(`(?is)^\s*json(\s+strict)?(?:\s+validate\s+('.*'))?\s*$`)
+
+// jsonCheckSQL expands the json shorthand of a check constraint to an IS JSON
+// condition on its column, with the JSON schema of the VALIDATE clause
+// (Oracle 23ai) when given
+func jsonCheckSQL(chk schema.CheckConstraint) (string, bool) {
+	match := jsonCheck.FindStringSubmatch(chk.Constraint)
+	if match == nil || chk.Field == nil || chk.Field.DBName == "" {
+		return "", false
+	}
+	condition := QuoteIdentifier(chk.Field.DBName) + " IS JSON"
+	if match[1] != "" {
+		condition += " (STRICT)"
+	}
+	if match[2] != "" {
+		condition += " VALIDATE " + match[2]
+	}
+	return condition, true
+}
+
+// sameCondition reports whether two check conditions are the same, ignoring
+// whitespace, the case of keywords and unquoted identifiers, the quotes of
+// upper-case identifiers and enclosing parentheses
+func sameCondition(stored, declared string) bool {
+	normalize := func(condition string) []string {
+		tokens := sqlTokens(condition)
+		for len(tokens) >= 2 && tokens[0].text == "(" && tokens[len(tokens)-1].text == ")" && enclosed(tokens) {
+			tokens = tokens[1 : len(tokens)-1]
+		}
+		texts := make([]string, len(tokens))
+		for i, token := range tokens {
+			switch token.kind {
+			case tokenWord:
+				texts[i] = strings.ToUpper(token.text)
+			case tokenQuoted:
+				name := strings.ReplaceAll(token.text[1:len(token.text)-1], `""`, `"`)
+				if name != "" && strings.IndexFunc(name, func(r rune) bool { return r > 127 || !isIdentifierChar(byte(r)) }) < 0 && strings.ToUpper(name) == name {
+					texts[i] = name
+				} else {
+					texts[i] = token.text
+				}
+			default:
+				texts[i] = token.text
+			}
+		}
+		return texts
+	}
+	return slices.Equal(normalize(stored), normalize(declared))
+}
+
+// enclosed reports whether the first and last tokens are parentheses that
+// match each other
+func enclosed(tokens []sqlToken) bool {
+	depth := 0
+	for i, token := range tokens {
+		switch token.text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+		if depth == 0 && i < len(tokens)-1 {
+			return false
+		}
+	}
+	return depth == 0
+}
+
 // CurrentDatabase returns the the name of the current Oracle database
 func (m Migrator) CurrentDatabase() string {
 	var name string
@@ -84,6 +233,16 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 				return errors.New("failed to get schema")
 			}
 
+			if external, ok := externalTableOf(stmt); ok {
+				return m.createExternalTable(tx, stmt, external)
+			}
+
+			// ORA_ARCHIVE_STATE is added by ROW ARCHIVAL rather than created
+			archiveField, err := archiveStateField(stmt.Schema)
+			if err != nil {
+				return err
+			}
+
 			var (
 				createTableSQL          = "CREATE TABLE ? ("
 				values                  = []interface{}{m.CurrentTable(stmt)}
@@ -95,7 +254,7 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 
 			for _, dbName := range stmt.Schema.DBNames {
 				field := stmt.Schema.FieldsByDBName[dbName]
-				if !field.IgnoreMigration {
+				if !field.IgnoreMigration && field != archiveField && !isRowIDField(field) {
 					createTableSQL += "? ?"
 					hasPrimaryKeyInDataType = hasPrimaryKeyInDataType || strings.Contains(m.DataTypeOf(field), "PRIMARY KEY")
 					values = append(values, clause.Column{Name: dbName}, m.DB.Migrator().FullDataTypeOf(field))
@@ -113,6 +272,36 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 				values = append(values, primaryKeys)
 			}
 
+			// A unique constraint declared next to a unique index on the same
+			// column is added once the index exists, so that it is enforced through
+			// it; Oracle refuses to build the index after the constraint's own one.
+			// With UniqueIndexes, unique tags are created as unique indexes, unless
+			// a unique index is declared on the column.
+			uniqueConstraints := stmt.Schema.ParseUniqueConstraints()
+			if uniqueIndexes(m.DB) {
+				for name, uni := range uniqueConstraints {
+					if uniqueFieldIndex(stmt.Schema, uni.Field) == nil {
+						defer func(stmt *gorm.Statement, name, column string) {
+							if err == nil {
+								err = m.createUniqueIndex(tx, stmt, name, column)
+							}
+						}(stmt, name, uni.Field.DBName)
+					}
+					delete(uniqueConstraints, name)
+				}
+			} else if m.CreateIndexAfterCreateTable {
+				for name, uni := range uniqueConstraints {
+					if uniqueFieldIndex(stmt.Schema, uni.Field) != nil {
+						defer func(value interface{}, name string) {
+							if err == nil {
+								err = tx.Migrator().CreateConstraint(value, name)
+							}
+						}(value, name)
+						delete(uniqueConstraints, name)
+					}
+				}
+			}
+
 			for _, idx := range stmt.Schema.ParseIndexes() {
 				if m.CreateIndexAfterCreateTable {
 					defer func(value interface{}, name string) {
@@ -188,29 +377,24 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 				}
 			}
 
-			for _, uni := range stmt.Schema.ParseUniqueConstraints() {
+			for _, uni := range uniqueConstraints {
 				createTableSQL += "CONSTRAINT ? UNIQUE (?),"
 				values = append(values, clause.Column{Name: uni.Name}, clause.Expr{SQL: stmt.Quote(uni.Field.DBName)})
 			}
 
 			for _, chk := range stmt.Schema.ParseCheckConstraints() {
-				constraintSQL := chk.Constraint
-
-				// Quote column names that appear in the constraint
-				for _, f := range stmt.Schema.Fields {
-					if strings.Contains(constraintSQL, f.DBName) {
-						re := regexp.MustCompile(`\b` + regexp.QuoteMeta(f.DBName) + `\b`)
-						constraintSQL = re.ReplaceAllString(constraintSQL, QuoteIdentifier(f.DBName))
-					}
-				}
 				createTableSQL += "CONSTRAINT ? CHECK (?),"
-				values = append(values, clause.Column{Name: chk.Name}, clause.Expr{SQL: constraintSQL})
+				values = append(values, clause.Column{Name: chk.Name}, clause.Expr{SQL: checkConstraintSQL(stmt.Schema, chk)})
 			}
 
 			createTableSQL = strings.TrimSuffix(createTableSQL, ",")
 
 			createTableSQL += ")"
 
+			if archiveField != nil {
+				createTableSQL += " ROW ARCHIVAL"
+			}
+
 			if tableOption, ok := m.DB.Get("gorm:table_options"); ok {
 				createTableSQL += " " + fmt.Sprint(tableOption)
 			}
@@ -228,6 +412,10 @@ func (m Migrator) CreateTable(values ...interface{}) error {
 // The function returns an error when Oracle databases report a missing table.
 // If multiple errors occur, it returns a combined (joint) error.
 func (m Migrator) DropTable(values ...interface{}) error {
+	if dropTableOrder(m.DB) {
+		return m.dropTablesInOrder(values...)
+	}
+
 	var errorList []error
 	values = m.ReorderModels(values, false)
 	for i := len(values) - 1; i >= 0; i-- {
@@ -248,12 +436,93 @@ func (m Migrator) DropTable(values ...interface{}) error {
 	return nil
 }
 
+// dropTablesInOrder drops the tables of the values without CASCADE
+// CONSTRAINTS, each one after the tables of the set referencing it. The
+// foreign keys are read from USER_CONSTRAINTS, so the order holds for
+// tables without models and for constraints the models do not declare.
+// Foreign keys forming a cycle between the tables are dropped first. A
+// table still referenced by a table outside of the set is not dropped and
+// fails with ORA-02449.
+func (m Migrator) dropTablesInOrder(values ...interface{}) error {
+	var tables []string
+	for _, value := range values {
+		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			if !slices.Contains(tables, stmt.Table) {
+				tables = append(tables, stmt.Table)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	var references []struct {
+		Child      string
+		Parent     string
+		Constraint string
+	}
+	if err := m.DB.Raw(
+		`SELECT c.TABLE_NAME AS "child", p.TABLE_NAME AS "parent", c.CONSTRAINT_NAME AS "constraint"
+FROM USER_CONSTRAINTS c
+JOIN USER_CONSTRAINTS p ON p.OWNER = c.R_OWNER AND p.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME
+WHERE c.CONSTRAINT_TYPE = 'R' AND c.TABLE_NAME IN ? AND p.TABLE_NAME IN ? AND c.TABLE_NAME <> p.TABLE_NAME`,
+		tables, tables,
+	).Scan(&references).Error; err != nil {
+		return err
+	}
+
+	var errorList []error
+	dropped := map[string]bool{}
+	for len(dropped) < len(tables) {
+		// Tables referenced by no remaining table, in the reverse order of
+		// the values like the models without the option
+		referenced := map[string]bool{}
+		for _, reference := range references {
+			if !dropped[reference.Child] {
+				referenced[reference.Parent] = true
+			}
+		}
+		var next []string
+		for i := len(tables) - 1; i >= 0; i-- {
+			if !dropped[tables[i]] && !referenced[tables[i]] {
+				next = append(next, tables[i])
+			}
+		}
+
+		if len(next) == 0 {
+			// The remaining tables reference each other
+			for _, reference := range references {
+				if !dropped[reference.Child] && !dropped[reference.Parent] {
+					if err := m.DB.Exec("ALTER TABLE ? DROP CONSTRAINT ?",
+						clause.Table{Name: reference.Child}, clause.Column{Name: reference.Constraint}).Error; err != nil {
+						return errors.Join(append(errorList, err)...)
+					}
+				}
+			}
+			references = nil
+			continue
+		}
+
+		for _, table := range next {
+			if err := m.DB.Session(&gorm.Session{}).Exec("DROP TABLE ?", clause.Table{Name: table}).Error; err != nil {
+				errorList = append(errorList, err)
+			}
+			dropped[table] = true
+		}
+	}
+
+	return errors.Join(errorList...)
+}
+
 // HasTable returns table exists or not for value, value could be a struct or string
 func (m Migrator) HasTable(value interface{}) bool {
 	var count int64
 
 	m.RunWithValue(value, func(stmt *gorm.Statement) (err error) {
-		return m.DB.Raw("SELECT COUNT(*) FROM USER_TABLES WHERE TABLE_NAME = ?", stmt.Table).Row().Scan(&count)
+		return m.DB.Raw("SELECT COUNT(*) FROM USER_TABLES WHERE "+dictionaryName(m.DB, "TABLE_NAME"), stmt.Table).Row().Scan(&count)
 	})
 
 	return count > 0
@@ -299,31 +568,140 @@ func (m Migrator) AddColumn(value interface{}, name string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		// Check if the column name is already used
 		if f := stmt.Schema.LookUpField(name); f != nil {
-			return m.DB.Exec(
+			if isArchiveStateField(f) {
+				return m.enableRowArchival(stmt)
+			}
+			// ROWID is a pseudo-column of every table
+			if isRowIDField(f) {
+				return nil
+			}
+			fullDataType := m.DB.Migrator().FullDataTypeOf(f)
+			err := m.DB.Exec(
 				"ALTER TABLE ? ADD (? ?)",
 				clause.Table{Name: stmt.Schema.Table},
 				clause.Column{Name: f.DBName},
-				m.DB.Migrator().FullDataTypeOf(f),
+				fullDataType,
 			).Error
+			// A NOT NULL column with a default is normally added as metadata only.
+			// Where Oracle refuses that, such as on compressed tables, add it
+			// nullable, backfill the default and then make it mandatory.
+			if err != nil && f.NotNull && strings.Contains(fullDataType.SQL, "DEFAULT") && !m.HasColumn(value, f.DBName) {
+				return m.addColumnWithBackfill(stmt, f)
+			}
+			return err
 		}
 		return fmt.Errorf("failed to look up field with name: %s", name)
 	})
 }
 
+// addColumnWithBackfill adds a NOT NULL column with a default in three steps,
+// filling the existing rows in batches of the configured BackfillBatchSize
+func (m Migrator) addColumnWithBackfill(stmt *gorm.Statement, field *schema.Field) error {
+	nullable := *field
+	nullable.NotNull = false
+	table := clause.Table{Name: stmt.Schema.Table}
+	column := clause.Column{Name: field.DBName}
+
+	if err := m.DB.Exec("ALTER TABLE ? ADD (? ?)", table, column, m.DB.Migrator().FullDataTypeOf(&nullable)).Error; err != nil {
+		return err
+	}
+
+	batchSize := backfillBatchSize(m.DB)
+	for {
+		result := m.DB.Exec("UPDATE ? SET ? = DEFAULT WHERE ? IS NULL AND ROWNUM <= ?", table, column, column, batchSize)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < int64(batchSize) {
+			break
+		}
+	}
+
+	return m.DB.Exec("ALTER TABLE ? MODIFY (? NOT NULL)", table, column).Error
+}
+
+// DefaultBackfillBatchSize is the number of rows AddColumn fills per UPDATE
+// when Config.BackfillBatchSize is not set
+const DefaultBackfillBatchSize = 10000
+
+// backfillBatchSize returns the backfill batch size of the dialector of the database
+func backfillBatchSize(db *gorm.DB) int {
+	var size int
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		if d.Config != nil {
+			size = d.BackfillBatchSize
+		}
+	case Dialector:
+		if d.Config != nil {
+			size = d.BackfillBatchSize
+		}
+	}
+	if size <= 0 {
+		return DefaultBackfillBatchSize
+	}
+	return size
+}
+
+// enableRowArchival enables ROW ARCHIVAL on the table of the statement,
+// which adds its ORA_ARCHIVE_STATE column, unless it is already enabled
+func (m Migrator) enableRowArchival(stmt *gorm.Statement) error {
+	if m.HasColumn(stmt.Table, archiveStateColumn) {
+		return nil
+	}
+	return m.DB.Exec("ALTER TABLE ? ROW ARCHIVAL", m.CurrentTable(stmt)).Error
+}
+
 // DropColumn drops value's `name` column
 func (m Migrator) DropColumn(value interface{}, name string) error {
+	return m.DropColumnWithOptions(value, name, DropColumnOptions{})
+}
+
+// DropColumnOptions configures how DropColumnWithOptions removes a column
+type DropColumnOptions struct {
+	// SetUnused marks the column UNUSED instead of dropping it. The column is
+	// no longer visible, while its storage is only reclaimed by a later
+	// DropUnusedColumns, which avoids rewriting every row of a large table.
+	SetUnused bool
+	// CascadeConstraints drops the multi-column constraints and the foreign
+	// keys of other tables that depend on the column
+	CascadeConstraints bool
+}
+
+// DropColumnWithOptions drops the column `name` from the table for the given
+// value, optionally marking it UNUSED or cascading its constraints
+func (m Migrator) DropColumnWithOptions(value interface{}, name string, options DropColumnOptions) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if stmt.Schema != nil {
 			if field := stmt.Schema.LookUpField(name); field != nil {
+				if isArchiveStateField(field) {
+					return m.DB.Exec("ALTER TABLE ? NO ROW ARCHIVAL", m.CurrentTable(stmt)).Error
+				}
 				name = field.DBName
 			}
 		}
 
-		return m.DB.Exec(
-			"ALTER TABLE ? DROP COLUMN ?",
-			clause.Table{Name: stmt.Schema.Table},
-			clause.Column{Name: name},
-		).Error
+		sql := "ALTER TABLE ? DROP COLUMN ?"
+		if options.SetUnused {
+			sql = "ALTER TABLE ? SET UNUSED (?)"
+		}
+		if options.CascadeConstraints {
+			sql += " CASCADE CONSTRAINTS"
+		}
+
+		return m.DB.Exec(sql, m.CurrentTable(stmt), clause.Column{Name: name}).Error
+	})
+}
+
+// DropUnusedColumns reclaims the storage of the columns of the table that were
+// marked UNUSED. A positive checkpoint applies a checkpoint every that many
+// rows, which limits the undo generated on large tables.
+func (m Migrator) DropUnusedColumns(value interface{}, checkpoint int) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if checkpoint > 0 {
+			return m.DB.Exec("ALTER TABLE ? DROP UNUSED COLUMNS CHECKPOINT ?", m.CurrentTable(stmt), clause.Expr{SQL: strconv.Itoa(checkpoint)}).Error
+		}
+		return m.DB.Exec("ALTER TABLE ? DROP UNUSED COLUMNS", m.CurrentTable(stmt)).Error
 	})
 }
 
@@ -350,6 +728,21 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 				desiredNullable := !f.NotNull
 				desiredType := strings.ToUpper(m.DataTypeOf(f))
 
+				// LONG and LONG RAW columns of legacy schemas are read
+				// as they are; converting them to a LOB takes a rewrite
+				// of the table that is left to an explicit migration
+				if isLongType(currentType) {
+					return nil
+				}
+
+				// Oracle can't modify a LOB column to another type
+				// (ORA-22859), such as a CLOB column of a string field
+				// that fits a VARCHAR2 with MAX_STRING_SIZE set to
+				// EXTENDED
+				if isLobType(currentType) && currentType != desiredType {
+					return nil
+				}
+
 				// nullable → non-nullable → skip
 				if currentNullable && !desiredNullable {
 					return nil
@@ -378,12 +771,155 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 	})
 }
 
+// MigrateColumnUnique adds or drops the single-column UNIQUE constraint of a
+// field to match its `unique` tag. When a unique index is declared on the same
+// column, the index is created first and the constraint is enforced through
+// it, as Oracle cannot index the same column list twice. With UniqueIndexes,
+// the tag is implemented by a unique index instead, and the constraints of
+// the column are replaced by it; without, the unique index of the tag is
+// replaced by a constraint.
+func (m Migrator) MigrateColumnUnique(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+	if _, ok := columnType.Unique(); !ok || field.PrimaryKey {
+		return nil
+	}
+
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		constraints, err := m.uniqueConstraintNames(stmt, field.DBName)
+		if err != nil {
+			return err
+		}
+
+		dropConstraints := func() error {
+			for _, name := range constraints {
+				if err := m.DB.Exec("ALTER TABLE ? DROP CONSTRAINT ?", m.CurrentTable(stmt), clause.Column{Name: name}).Error; err != nil {
+					return err
+				}
+			}
+			constraints = nil
+			return nil
+		}
+
+		// The unique index created for the tag with UniqueIndexes
+		name := m.DB.NamingStrategy.UniqueName(stmt.Table, field.DBName)
+		index := uniqueFieldIndex(stmt.Schema, field)
+		tagIndex := false
+		if index == nil && (field.Unique || uniqueIndexes(m.DB)) {
+			if tagIndex, err = m.hasUniqueIndex(stmt, name); err != nil {
+				return err
+			}
+		}
+		dropTagIndex := func() error {
+			if !tagIndex {
+				return nil
+			}
+			tagIndex = false
+			return m.DB.Migrator().DropIndex(value, name)
+		}
+
+		if !field.Unique {
+			if err := dropConstraints(); err != nil {
+				return err
+			}
+			return dropTagIndex()
+		}
+
+		if uniqueIndexes(m.DB) {
+			if err := dropConstraints(); err != nil {
+				return err
+			}
+			if index != nil {
+				if !m.DB.Migrator().HasIndex(value, index.Name) {
+					return m.DB.Migrator().CreateIndex(value, index.Name)
+				}
+				return nil
+			}
+			if !tagIndex {
+				return m.createUniqueIndex(m.DB, stmt, name, field.DBName)
+			}
+			return nil
+		}
+
+		// The constraint would otherwise be enforced through the index of
+		// the tag, which could then not be dropped
+		if err := dropTagIndex(); err != nil {
+			return err
+		}
+
+		if index != nil && !m.DB.Migrator().HasIndex(value, index.Name) {
+			// The index of an existing constraint would block the declared one
+			if err := dropConstraints(); err != nil {
+				return err
+			}
+			if err := m.DB.Migrator().CreateIndex(value, index.Name); err != nil {
+				return err
+			}
+		}
+
+		if len(constraints) == 0 {
+			return m.DB.Migrator().CreateConstraint(value, name)
+		}
+		return nil
+	})
+}
+
+// hasUniqueIndex reports whether the table has a unique index of the name
+// that backs no constraint
+func (m Migrator) hasUniqueIndex(stmt *gorm.Statement, name string) (bool, error) {
+	var count int64
+	err := m.DB.Raw(
+		`SELECT COUNT(*) FROM USER_INDEXES ui
+WHERE ui.TABLE_NAME = ? AND ui.INDEX_NAME = ? AND ui.UNIQUENESS = 'UNIQUE'
+AND NOT EXISTS (SELECT 1 FROM USER_CONSTRAINTS uc WHERE uc.TABLE_NAME = ui.TABLE_NAME AND uc.INDEX_NAME = ui.INDEX_NAME)`,
+		stmt.Table, name,
+	).Row().Scan(&count)
+	return count > 0, err
+}
+
+// createUniqueIndex creates the unique index implementing the unique tag of
+// a column with UniqueIndexes
+func (m Migrator) createUniqueIndex(tx *gorm.DB, stmt *gorm.Statement, name, column string) error {
+	createIndexSQL := "CREATE UNIQUE INDEX ? ON ? (?)"
+	if onlineDDL(tx) {
+		createIndexSQL += " ONLINE"
+	}
+	return tx.Exec(createIndexSQL, clause.Column{Name: name}, m.CurrentTable(stmt), clause.Column{Name: column}).Error
+}
+
+// uniqueConstraintNames returns the UNIQUE constraints of the table that cover
+// exactly the given column.
+func (m Migrator) uniqueConstraintNames(stmt *gorm.Statement, column string) (names []string, err error) {
+	err = m.DB.Raw(
+		`SELECT uc.CONSTRAINT_NAME
+FROM USER_CONSTRAINTS uc
+JOIN USER_CONS_COLUMNS ucc ON ucc.CONSTRAINT_NAME = uc.CONSTRAINT_NAME
+WHERE uc.TABLE_NAME = ? AND uc.CONSTRAINT_TYPE = 'U' AND ucc.COLUMN_NAME = ?
+AND (SELECT COUNT(*) FROM USER_CONS_COLUMNS o WHERE o.CONSTRAINT_NAME = uc.CONSTRAINT_NAME) = 1`,
+		stmt.Table, column,
+	).Scan(&names).Error
+	return names, err
+}
+
+// uniqueFieldIndex returns the unique index declared on field alone, if any.
+func uniqueFieldIndex(sch *schema.Schema, field *schema.Field) *schema.Index {
+	for _, idx := range sch.ParseIndexes() {
+		if idx.Class == "UNIQUE" && len(idx.Fields) == 1 && idx.Fields[0].DBName == field.DBName {
+			return idx
+		}
+	}
+	return nil
+}
+
 // HasColumn checks whether the table for the given value contains the specified column `field`
 func (m Migrator) HasColumn(value interface{}, field string) bool {
 	var count int64
 
 	m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		return m.DB.Raw("SELECT COUNT(*) FROM USER_TAB_COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?",
+		// ORA_ARCHIVE_STATE is a hidden column, only listed in USER_TAB_COLS
+		view := "USER_TAB_COLUMNS"
+		if strings.EqualFold(field, archiveStateColumn) {
+			field, view = archiveStateColumn, "USER_TAB_COLS"
+		}
+		return m.DB.Raw("SELECT COUNT(*) FROM "+view+" WHERE "+dictionaryName(m.DB, "TABLE_NAME")+" AND "+dictionaryName(m.DB, "COLUMN_NAME"),
 			stmt.Table,
 			field,
 		).Row().Scan(&count)
@@ -412,8 +948,48 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 			return err
 		}
 
+		var details []columnDetail
+		if err = m.DB.Raw(
+			`SELECT c.COLUMN_NAME AS "column_name", c.IDENTITY_COLUMN AS "identity_column", c.VIRTUAL_COLUMN AS "virtual_column",
+	c.CHAR_USED AS "char_used", c.DATA_TYPE AS "data_type", c.DATA_LENGTH AS "data_length", c.DATA_DEFAULT AS "data_default", cc.COMMENTS AS "comments",
+	(SELECT COUNT(*) FROM USER_CONS_COLUMNS ucc
+		JOIN USER_CONSTRAINTS uc ON uc.CONSTRAINT_NAME = ucc.CONSTRAINT_NAME
+		WHERE uc.TABLE_NAME = c.TABLE_NAME AND uc.CONSTRAINT_TYPE = 'U' AND ucc.COLUMN_NAME = c.COLUMN_NAME
+		AND (SELECT COUNT(*) FROM USER_CONS_COLUMNS o WHERE o.CONSTRAINT_NAME = uc.CONSTRAINT_NAME) = 1) AS "unique_constraints",
+	(SELECT COUNT(*) FROM USER_CONS_COLUMNS ucc
+		JOIN USER_CONSTRAINTS uc ON uc.CONSTRAINT_NAME = ucc.CONSTRAINT_NAME
+		WHERE uc.TABLE_NAME = c.TABLE_NAME AND uc.CONSTRAINT_TYPE = 'P' AND ucc.COLUMN_NAME = c.COLUMN_NAME) AS "primary_key"
+FROM USER_TAB_COLS c
+LEFT JOIN USER_COL_COMMENTS cc ON cc.TABLE_NAME = c.TABLE_NAME AND cc.COLUMN_NAME = c.COLUMN_NAME
+WHERE c.TABLE_NAME = ?`,
+			stmt.Table,
+		).Scan(&details).Error; err != nil {
+			return err
+		}
+
+		byName := make(map[string]columnDetail, len(details))
+		for _, detail := range details {
+			byName[strings.ToUpper(detail.ColumnName)] = detail
+		}
+
 		for _, c := range rawColumnTypes {
-			columnTypes = append(columnTypes, migrator.ColumnType{SQLColumnType: c})
+			columnType := ColumnType{driverColumnType: driverColumnType{SQLColumnType: c}}
+			if detail, ok := byName[strings.ToUpper(c.Name())]; ok {
+				columnType.identity = detail.IdentityColumn == "YES"
+				columnType.virtual = detail.VirtualColumn == "YES"
+				columnType.charUsed = detail.CharUsed.String
+				if detail.DataType == "RAW" {
+					columnType.rawLength = detail.DataLength
+				}
+				if detail.DataDefault.Valid {
+					columnType.defaultExpression = sql.NullString{String: strings.TrimSpace(detail.DataDefault.String), Valid: true}
+				}
+				columnType.comment = detail.Comments
+				columnType.AutoIncrementValue = sql.NullBool{Bool: columnType.identity, Valid: true}
+				columnType.UniqueValue = sql.NullBool{Bool: detail.UniqueConstraints > 0, Valid: true}
+				columnType.PrimaryKeyValue = sql.NullBool{Bool: detail.PrimaryKey > 0, Valid: true}
+			}
+			columnTypes = append(columnTypes, columnType)
 		}
 
 		return
@@ -422,6 +998,86 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 	return columnTypes, execErr
 }
 
+// columnDetail is a row of the dictionary query used by ColumnTypes.
+type columnDetail struct {
+	ColumnName        string
+	IdentityColumn    string
+	VirtualColumn     string
+	CharUsed          sql.NullString
+	DataType          string
+	DataLength        int64
+	DataDefault       sql.NullString
+	Comments          sql.NullString
+	UniqueConstraints int
+	PrimaryKey        int
+}
+
+// driverColumnType lets ColumnType embed migrator.ColumnType without the field
+// name hiding its ColumnType method.
+type driverColumnType = migrator.ColumnType
+
+// ColumnType is the gorm.ColumnType returned by Migrator.ColumnTypes. On top of
+// the driver metadata it carries what Oracle records in the data dictionary:
+// identity and virtual columns, character length semantics, the size of RAW
+// columns, the default expression and the column comment.
+//
+// The default expression and comment are exposed through their own methods
+// rather than DefaultValue and Comment, so AutoMigrate keeps comparing models
+// against the same metadata as before.
+type ColumnType struct {
+	driverColumnType
+
+	identity          bool
+	virtual           bool
+	charUsed          string
+	rawLength         int64
+	defaultExpression sql.NullString
+	comment           sql.NullString
+}
+
+// Length returns the size of RAW columns, which the driver doesn't report,
+// from the data dictionary, and the length reported by the driver otherwise
+func (ct ColumnType) Length() (int64, bool) {
+	if ct.rawLength > 0 {
+		return ct.rawLength, true
+	}
+	return ct.driverColumnType.Length()
+}
+
+// Identity reports whether the column is an identity column.
+func (ct ColumnType) Identity() bool {
+	return ct.identity
+}
+
+// Virtual reports whether the column is a virtual (computed) column.
+func (ct ColumnType) Virtual() bool {
+	return ct.virtual
+}
+
+// CharSemantics returns "BYTE" or "CHAR" for character columns, and false for
+// columns without length semantics.
+func (ct ColumnType) CharSemantics() (string, bool) {
+	switch ct.charUsed {
+	case "B":
+		return "BYTE", true
+	case "C":
+		return "CHAR", true
+	}
+	return "", false
+}
+
+// DefaultExpression returns the column's DATA_DEFAULT text as stored by Oracle,
+// such as 'active', SYSTIMESTAMP or the sequence call behind an identity column.
+// For virtual columns it is the generating expression.
+func (ct ColumnType) DefaultExpression() (string, bool) {
+	return ct.defaultExpression.String, ct.defaultExpression.Valid
+}
+
+// ColumnComment returns the comment set with COMMENT ON COLUMN.
+func (ct ColumnType) ColumnComment() (string, bool) {
+	return ct.comment.String, ct.comment.Valid && ct.comment.String != ""
+}
+
 // CreateConstraint creates constraint based on the given 'value' and 'name'
 func (m Migrator) CreateConstraint(value interface{}, name string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
@@ -437,6 +1093,12 @@ func (m Migrator) CreateConstraint(value interface{}, name string) error {
 				}
 			}
 
+			if chk, ok := constraint.(*schema.CheckConstraint); ok && stmt.Schema != nil {
+				quoted := *chk
+				quoted.Constraint = checkConstraintSQL(stmt.Schema, *chk)
+				constraint = &quoted
+			}
+
 			vars := []interface{}{clause.Table{Name: table}}
 			if stmt.TableExpr != nil {
 				vars[0] = stmt.TableExpr
@@ -569,6 +1231,137 @@ func (m Migrator) HasType(typeName string) bool {
 	return err == nil && count > 0
 }
 
+// UserType describes a user-defined type, as listed by GetTypes
+type UserType struct {
+	Name string
+	// Kind is OBJECT or COLLECTION
+	Kind string
+	// Version is the number of the latest version of the type, which ALTER
+	// TYPE increments
+	Version int
+	// Attributes are the attributes of an object type, in order
+	Attributes []TypeAttribute
+	// CollectionKind is VARYING ARRAY or TABLE for collection types
+	CollectionKind string
+	// Limit is the maximum number of elements of a varray
+	Limit int
+	// Element is the element type of a collection type
+	Element *TypeAttribute
+}
+
+// TypeAttribute is an attribute of an object type, or the element of a
+// collection type
+type TypeAttribute struct {
+	Name      string
+	Type      string
+	Length    int
+	Precision int
+	Scale     int
+}
+
+// GetTypes returns the user-defined types of the schema
+func (m Migrator) GetTypes() ([]UserType, error) {
+	var types []struct {
+		TypeName string
+		Typecode string
+		Version  int
+	}
+	if err := m.DB.Raw(
+		`SELECT t.TYPE_NAME AS "type_name", t.TYPECODE AS "typecode",
+	(SELECT NVL(MAX(v.VERSION#), 1) FROM USER_TYPE_VERSIONS v WHERE v.TYPE_NAME = t.TYPE_NAME) AS "version"
+FROM USER_TYPES t
+ORDER BY t.TYPE_NAME`,
+	).Scan(&types).Error; err != nil {
+		return nil, err
+	}
+
+	var attributes []struct {
+		TypeName     string
+		AttrName     string
+		AttrTypeName string
+		Length       sql.NullInt64
+		Precision    sql.NullInt64
+		Scale        sql.NullInt64
+	}
+	if err := m.DB.Raw(
+		`SELECT TYPE_NAME AS "type_name", ATTR_NAME AS "attr_name", ATTR_TYPE_NAME AS "attr_type_name",
+	LENGTH AS "length", PRECISION AS "precision", SCALE AS "scale"
+FROM USER_TYPE_ATTRS
+ORDER BY TYPE_NAME, ATTR_NO`,
+	).Scan(&attributes).Error; err != nil {
+		return nil, err
+	}
+
+	var collections []struct {
+		TypeName     string
+		CollType     string
+		UpperBound   sql.NullInt64
+		ElemTypeName string
+		Length       sql.NullInt64
+		Precision    sql.NullInt64
+		Scale        sql.NullInt64
+	}
+	if err := m.DB.Raw(
+		`SELECT TYPE_NAME AS "type_name", COLL_TYPE AS "coll_type", UPPER_BOUND AS "upper_bound",
+	ELEM_TYPE_NAME AS "elem_type_name", LENGTH AS "length", PRECISION AS "precision", SCALE AS "scale"
+FROM USER_COLL_TYPES`,
+	).Scan(&collections).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]UserType, 0, len(types))
+	byName := make(map[string]int, len(types))
+	for _, t := range types {
+		byName[t.TypeName] = len(result)
+		result = append(result, UserType{Name: t.TypeName, Kind: t.Typecode, Version: t.Version})
+	}
+	for _, a := range attributes {
+		if i, ok := byName[a.TypeName]; ok {
+			result[i].Attributes = append(result[i].Attributes, TypeAttribute{
+				Name:      a.AttrName,
+				Type:      a.AttrTypeName,
+				Length:    int(a.Length.Int64),
+				Precision: int(a.Precision.Int64),
+				Scale:     int(a.Scale.Int64),
+			})
+		}
+	}
+	for _, c := range collections {
+		if i, ok := byName[c.TypeName]; ok {
+			result[i].CollectionKind = c.CollType
+			result[i].Limit = int(c.UpperBound.Int64)
+			result[i].Element = &TypeAttribute{
+				Type:      c.ElemTypeName,
+				Length:    int(c.Length.Int64),
+				Precision: int(c.Precision.Int64),
+				Scale:     int(c.Scale.Int64),
+			}
+		}
+	}
+	return result, nil
+}
+
+// AlterType changes a user-defined type with an ALTER TYPE clause, such as
+// "ADD ATTRIBUTE (email VARCHAR2(100))" or "MODIFY LIMIT 20". With cascade,
+// the change is propagated to the dependent types and tables; otherwise
+// Oracle refuses to change a type that has dependents.
+func (m Migrator) AlterType(typeName string, change string, cascade bool) error {
+	typeName = strings.TrimSpace(typeName)
+	change = strings.TrimSpace(change)
+	if typeName == "" {
+		return fmt.Errorf("alterType: typeName is required")
+	}
+	if change == "" {
+		return fmt.Errorf("alterType: change is required")
+	}
+
+	ddl := fmt.Sprintf(`ALTER TYPE "%s" %s`, strings.ToLower(typeName), change)
+	if cascade {
+		ddl += " CASCADE"
+	}
+	return m.DB.Exec(ddl).Error
+}
+
 // DropIndex drops the index with the specified `name` from the table associated with `value`
 func (m Migrator) DropIndex(value interface{}, name string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
@@ -578,7 +1371,42 @@ func (m Migrator) DropIndex(value interface{}, name string) error {
 			}
 		}
 
-		return m.DB.Exec("DROP INDEX ?", clause.Column{Name: name}).Error
+		sql := "DROP INDEX ?"
+		if onlineDDL(m.DB) {
+			sql += " ONLINE"
+		}
+		return m.DB.Exec(sql, clause.Column{Name: name}).Error
+	})
+}
+
+// CreateIndex creates the index `name` of the table for the given value,
+// ONLINE when the dialector is configured with OnlineDDL
+func (m Migrator) CreateIndex(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return errors.New("failed to get schema")
+		}
+		if idx := stmt.Schema.LookIndex(name); idx != nil {
+			opts := m.DB.Migrator().(migrator.BuildIndexOptionsInterface).BuildIndexOptions(idx.Fields, stmt)
+			values := []interface{}{clause.Column{Name: idx.Name}, m.CurrentTable(stmt), opts}
+
+			createIndexSQL := "CREATE "
+			if idx.Class != "" {
+				createIndexSQL += idx.Class + " "
+			}
+			createIndexSQL += "INDEX ? ON ??"
+
+			if idx.Option != "" {
+				createIndexSQL += " " + idx.Option
+			}
+			if onlineDDL(m.DB) && !strings.Contains(strings.ToUpper(idx.Option), "ONLINE") {
+				createIndexSQL += " ONLINE"
+			}
+
+			return m.DB.Exec(createIndexSQL, values...).Error
+		}
+
+		return fmt.Errorf("failed to create index with name %s", name)
 	})
 }
 
@@ -602,13 +1430,306 @@ func (m Migrator) HasIndex(value interface{}, name string) bool {
 	return count > 0
 }
 
-// RenameIndex renames index from oldName to newName on the table for the given `value`
+// GetIndexes returns the indexes of the table for the given value, including
+// those that back primary key and unique constraints
+func (m Migrator) GetIndexes(value interface{}) ([]gorm.Index, error) {
+	indexes := make([]gorm.Index, 0)
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		type indexColumn struct {
+			IndexName  string
+			Uniqueness string
+			ColumnName string
+			PrimaryKey int
+		}
+		var columns []indexColumn
+		if err := m.DB.Raw(
+			`SELECT i.INDEX_NAME AS "index_name", i.UNIQUENESS AS "uniqueness", ic.COLUMN_NAME AS "column_name",
+	(SELECT COUNT(*) FROM USER_CONSTRAINTS c
+		WHERE c.TABLE_NAME = i.TABLE_NAME AND c.INDEX_NAME = i.INDEX_NAME AND c.CONSTRAINT_TYPE = 'P') AS "primary_key"
+FROM USER_INDEXES i
+JOIN USER_IND_COLUMNS ic ON ic.INDEX_NAME = i.INDEX_NAME
+WHERE i.TABLE_NAME = ? AND i.INDEX_TYPE <> 'LOB'
+ORDER BY i.INDEX_NAME, ic.COLUMN_POSITION`,
+			stmt.Table,
+		).Scan(&columns).Error; err != nil {
+			return err
+		}
+
+		byName := make(map[string]*migrator.Index)
+		for _, column := range columns {
+			index, ok := byName[column.IndexName]
+			if !ok {
+				index = &migrator.Index{
+					TableName:       stmt.Table,
+					NameValue:       column.IndexName,
+					PrimaryKeyValue: sql.NullBool{Bool: column.PrimaryKey > 0, Valid: true},
+					UniqueValue:     sql.NullBool{Bool: column.Uniqueness == "UNIQUE", Valid: true},
+				}
+				byName[column.IndexName] = index
+				indexes = append(indexes, index)
+			}
+			index.ColumnList = append(index.ColumnList, column.ColumnName)
+		}
+		return nil
+	})
+	return indexes, err
+}
+
+// RenameIndex renames index from oldName to newName on the table for the given `value`.
+// A unique or primary key constraint that is enforced through the index and
+// named after it is renamed along with it.
 func (m Migrator) RenameIndex(value interface{}, oldName, newName string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
-		return m.DB.Exec(
+		var constraints []string
+		if err := m.DB.Raw(
+			"SELECT CONSTRAINT_NAME FROM USER_CONSTRAINTS WHERE TABLE_NAME = ? AND INDEX_NAME = ? AND CONSTRAINT_NAME = INDEX_NAME",
+			stmt.Table, oldName,
+		).Scan(&constraints).Error; err != nil {
+			return err
+		}
+
+		if err := m.DB.Exec(
 			"ALTER INDEX ? RENAME TO ?",
 			clause.Column{Name: oldName}, clause.Column{Name: newName},
-		).Error
+		).Error; err != nil {
+			return err
+		}
+
+		for _, constraint := range constraints {
+			if err := m.DB.Exec(
+				"ALTER TABLE ? RENAME CONSTRAINT ? TO ?",
+				m.CurrentTable(stmt), clause.Column{Name: constraint}, clause.Column{Name: newName},
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RenameConstraint renames constraint oldName to newName on the table for the
+// given `value`. The index that enforces a unique or primary key constraint is
+// renamed along with it when it carries the constraint's name.
+func (m Migrator) RenameConstraint(value interface{}, oldName, newName string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if constraint, _ := m.GuessConstraintInterfaceAndTable(stmt, oldName); constraint != nil {
+			oldName = constraint.GetName()
+		}
+
+		var indexes []string
+		if err := m.DB.Raw(
+			"SELECT INDEX_NAME FROM USER_CONSTRAINTS WHERE TABLE_NAME = ? AND CONSTRAINT_NAME = ? AND INDEX_NAME = CONSTRAINT_NAME",
+			stmt.Table, oldName,
+		).Scan(&indexes).Error; err != nil {
+			return err
+		}
+
+		if err := m.DB.Exec(
+			"ALTER TABLE ? RENAME CONSTRAINT ? TO ?",
+			m.CurrentTable(stmt), clause.Column{Name: oldName}, clause.Column{Name: newName},
+		).Error; err != nil {
+			return err
+		}
+
+		for _, index := range indexes {
+			if err := m.DB.Exec(
+				"ALTER INDEX ? RENAME TO ?",
+				clause.Column{Name: index}, clause.Column{Name: newName},
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RebuildIndex rebuilds the index with the specified `name`, e.g. after
+// partition maintenance left it UNUSABLE. Partitioned indexes are rebuilt
+// one partition, or subpartition, at a time. With `online`, DML on the
+// table is allowed during the rebuild.
+func (m Migrator) RebuildIndex(name string, online bool) error {
+	var partitioning struct {
+		Partitioned string
+		Composite   string
+	}
+	err := m.DB.Raw(
+		`SELECT i.PARTITIONED AS "partitioned", NVL(p.COMPOSITE, 'NO') AS "composite"
+FROM USER_INDEXES i LEFT JOIN USER_PART_INDEXES p ON p.INDEX_NAME = i.INDEX_NAME
+WHERE i.INDEX_NAME = ?`,
+		name,
+	).Scan(&partitioning).Error
+	if err != nil {
+		return err
+	}
+
+	suffix := ""
+	if online {
+		suffix = " ONLINE"
+	}
+
+	if partitioning.Partitioned != "YES" {
+		return m.DB.Exec("ALTER INDEX ? REBUILD"+suffix, clause.Column{Name: name}).Error
+	}
+
+	query, kind := "SELECT PARTITION_NAME FROM USER_IND_PARTITIONS WHERE INDEX_NAME = ? ORDER BY PARTITION_POSITION", "PARTITION"
+	if partitioning.Composite == "YES" {
+		query, kind = "SELECT SUBPARTITION_NAME FROM USER_IND_SUBPARTITIONS WHERE INDEX_NAME = ? ORDER BY PARTITION_NAME, SUBPARTITION_POSITION", "SUBPARTITION"
+	}
+
+	var partitions []string
+	if err := m.DB.Raw(query, name).Scan(&partitions).Error; err != nil {
+		return err
+	}
+	for _, partition := range partitions {
+		if err := m.DB.Exec(
+			"ALTER INDEX ? REBUILD "+kind+" ?"+suffix,
+			clause.Column{Name: name}, clause.Column{Name: partition},
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnusableIndex is an index, or a partition or subpartition of an index,
+// whose status is UNUSABLE
+type UnusableIndex struct {
+	Table        string
+	Index        string
+	Partition    string
+	Subpartition string
+}
+
+// UnusableIndexes lists the UNUSABLE indexes, index partitions and index
+// subpartitions of the table for the given `value`, or of all the tables
+// of the current schema when `value` is nil
+func (m Migrator) UnusableIndexes(value interface{}) ([]UnusableIndex, error) {
+	query := `SELECT * FROM (
+SELECT TABLE_NAME AS "table", INDEX_NAME AS "index", NULL AS "partition", NULL AS "subpartition"
+FROM USER_INDEXES WHERE STATUS = 'UNUSABLE'
+UNION ALL
+SELECT i.TABLE_NAME, p.INDEX_NAME, p.PARTITION_NAME, NULL
+FROM USER_IND_PARTITIONS p JOIN USER_INDEXES i ON i.INDEX_NAME = p.INDEX_NAME
+WHERE p.STATUS = 'UNUSABLE'
+UNION ALL
+SELECT i.TABLE_NAME, s.INDEX_NAME, s.PARTITION_NAME, s.SUBPARTITION_NAME
+FROM USER_IND_SUBPARTITIONS s JOIN USER_INDEXES i ON i.INDEX_NAME = s.INDEX_NAME
+WHERE s.STATUS = 'UNUSABLE'
+)`
+
+	var indexes []UnusableIndex
+	if value == nil {
+		err := m.DB.Raw(query + ` ORDER BY "table", "index", "partition", "subpartition"`).Scan(&indexes).Error
+		return indexes, err
+	}
+
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.DB.Raw(query+` WHERE "table" = ? ORDER BY "index", "partition", "subpartition"`, stmt.Table).Scan(&indexes).Error
+	})
+	return indexes, err
+}
+
+// ResyncIdentity restarts the identity columns of the table for the given
+// `value` after the largest value they hold, so that rows inserted with
+// explicit auto-increment values, e.g. by a data migration, don't collide
+// with the values generated afterwards. Like any DDL, it commits the
+// current transaction.
+func (m Migrator) ResyncIdentity(value interface{}) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		type identityColumn struct {
+			ColumnName     string
+			GenerationType string
+			DefaultOnNull  string
+		}
+		var columns []identityColumn
+		err := m.DB.Raw(
+			`SELECT c.COLUMN_NAME AS "column_name", c.GENERATION_TYPE AS "generation_type", t.DEFAULT_ON_NULL AS "default_on_null"
+FROM USER_TAB_IDENTITY_COLS c
+JOIN USER_TAB_COLUMNS t ON t.TABLE_NAME = c.TABLE_NAME AND t.COLUMN_NAME = c.COLUMN_NAME
+WHERE c.TABLE_NAME = ?`,
+			stmt.Table,
+		).Scan(&columns).Error
+		if err != nil {
+			return err
+		}
+
+		for _, column := range columns {
+			// Keep the generation type, which MODIFY would otherwise reset
+			generation := "ALWAYS"
+			if column.GenerationType != "ALWAYS" {
+				generation = "BY DEFAULT"
+				if column.DefaultOnNull == "YES" {
+					generation += " ON NULL"
+				}
+			}
+
+			if err := m.DB.Exec(
+				fmt.Sprintf("ALTER TABLE ? MODIFY (? GENERATED %s AS IDENTITY (START WITH LIMIT VALUE))", generation),
+				m.CurrentTable(stmt),
+				clause.Column{Name: column.ColumnName},
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GatherStatsOptions configures how GatherTableStats samples a table
+type GatherStatsOptions struct {
+	// EstimatePercent is the percentage of rows sampled, up to 100. Zero
+	// lets Oracle choose the sample size (DBMS_STATS.AUTO_SAMPLE_SIZE).
+	EstimatePercent float64
+	// Degree is the degree of parallelism. Zero uses the table preference.
+	Degree int
+	// Cascade gathers statistics on the indexes of the table too. Otherwise
+	// Oracle decides whether to gather them.
+	Cascade bool
+}
+
+// GatherTableStats gathers the optimizer statistics of the table for the
+// given `value` with DBMS_STATS.GATHER_TABLE_STATS, which is typically
+// needed after bulk loads and large schema changes
+func (m Migrator) GatherTableStats(value interface{}, options GatherStatsOptions) error {
+	if options.EstimatePercent < 0 || options.EstimatePercent > 100 {
+		return fmt.Errorf("invalid estimate percent %v, expected a value between 0 and 100", options.EstimatePercent)
+	}
+	if options.Degree < 0 {
+		return fmt.Errorf("invalid degree of parallelism %d", options.Degree)
+	}
+
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		var (
+			plsql strings.Builder
+			vars  []interface{}
+		)
+
+		// DBMS_STATS upper-cases names unless they are quoted
+		plsql.WriteString("BEGIN DBMS_STATS.GATHER_TABLE_STATS(ownname => ")
+		if owner, table, ok := strings.Cut(stmt.Table, "."); ok {
+			plsql.WriteString("?, tabname => ?")
+			vars = append(vars, stmt.Quote(owner), stmt.Quote(table))
+		} else {
+			plsql.WriteString("USER, tabname => ?")
+			vars = append(vars, stmt.Quote(stmt.Table))
+		}
+
+		if options.EstimatePercent > 0 {
+			plsql.WriteString(", estimate_percent => ?")
+			vars = append(vars, options.EstimatePercent)
+		} else {
+			plsql.WriteString(", estimate_percent => DBMS_STATS.AUTO_SAMPLE_SIZE")
+		}
+		if options.Degree > 0 {
+			plsql.WriteString(", degree => ?")
+			vars = append(vars, options.Degree)
+		}
+		if options.Cascade {
+			plsql.WriteString(", cascade => TRUE")
+		}
+		plsql.WriteString("); END;")
+
+		return m.DB.Exec(plsql.String(), vars...).Error
 	})
 }
 
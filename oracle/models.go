@@ -0,0 +1,385 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"go/format"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// ModelOptions configures GenerateModels
+type ModelOptions struct {
+	// Package is the package of the generated file, "models" if empty
+	Package string
+	// Tables are the tables to generate models for, every table of the
+	// schema if empty. Names are matched as stored in the data dictionary.
+	Tables []string
+	// NullablePointers generates pointer fields for nullable columns, other
+	// than []byte columns
+	NullablePointers bool
+}
+
+// modelColumn is a row of the column query of GenerateModels
+type modelColumn struct {
+	TableName      string
+	ColumnName     string
+	DataType       string
+	CharLength     int64
+	CharUsed       sql.NullString
+	DataPrecision  sql.NullInt64
+	DataScale      sql.NullInt64
+	Nullable       string
+	IdentityColumn string
+	VirtualColumn  string
+	DataDefault    sql.NullString
+	Comments       sql.NullString
+}
+
+// modelConstraint is a column of a constraint, as read by GenerateModels
+type modelConstraint struct {
+	TableName      string
+	ConstraintName string
+	ConstraintType string
+	DeleteRule     sql.NullString
+	ColumnName     string
+	RTableName     sql.NullString
+	RColumnName    sql.NullString
+	ColumnCount    int
+}
+
+// dictionaryPrecisionRegexp matches the precisions in the type names of the
+// data dictionary, such as TIMESTAMP(6) WITH TIME ZONE
+var dictionaryPrecisionRegexp = regexp.MustCompile(`\(\d+\)`)
+
+// dictionaryTypeName returns the type name the driver reports for a column
+// of the given dictionary type
+func dictionaryTypeName(dataType string) string {
+	switch dataType {
+	case "FLOAT":
+		// FLOAT(p) is a NUMBER, unlike BINARY_FLOAT
+		return "NUMBER"
+	case "BINARY_FLOAT":
+		return "FLOAT"
+	case "BINARY_DOUBLE":
+		return "DOUBLE"
+	}
+	return dictionaryPrecisionRegexp.ReplaceAllString(dataType, "")
+}
+
+// columnType returns the gorm.ColumnType of the column, for GenDataType
+func (c modelColumn) columnType() migrator.ColumnType {
+	ct := migrator.ColumnType{
+		NameValue:     sql.NullString{String: c.ColumnName, Valid: true},
+		DataTypeValue: sql.NullString{String: dictionaryTypeName(c.DataType), Valid: true},
+	}
+	precision, scale := c.DataPrecision.Int64, c.DataScale.Int64
+	if c.DataType == "NUMBER" && !c.DataPrecision.Valid && c.DataScale.Valid && scale == 0 {
+		// INTEGER is stored as NUMBER(*,0)
+		precision = 38
+	}
+	if c.DataType == "FLOAT" || !c.DataScale.Valid {
+		precision, scale = 0, 0
+	}
+	ct.DecimalSizeValue = sql.NullInt64{Int64: precision, Valid: true}
+	ct.ScaleValue = sql.NullInt64{Int64: scale, Valid: true}
+	return ct
+}
+
+// fullType returns the type of the column as written in DDL
+func (c modelColumn) fullType() string {
+	switch c.DataType {
+	case "VARCHAR2", "CHAR":
+		semantics := "BYTE"
+		if c.CharUsed.String == "C" {
+			semantics = "CHAR"
+		}
+		return fmt.Sprintf("%s(%d %s)", c.DataType, c.CharLength, semantics)
+	case "NVARCHAR2", "NCHAR":
+		return fmt.Sprintf("%s(%d)", c.DataType, c.CharLength)
+	case "NUMBER":
+		switch {
+		case c.DataPrecision.Valid && c.DataScale.Int64 != 0:
+			return fmt.Sprintf("NUMBER(%d,%d)", c.DataPrecision.Int64, c.DataScale.Int64)
+		case c.DataPrecision.Valid:
+			return fmt.Sprintf("NUMBER(%d)", c.DataPrecision.Int64)
+		case c.DataScale.Valid && c.DataScale.Int64 == 0:
+			return "INTEGER"
+		}
+		return "NUMBER"
+	case "FLOAT":
+		return fmt.Sprintf("FLOAT(%d)", c.DataPrecision.Int64)
+	case "RAW":
+		return fmt.Sprintf("RAW(%d)", c.CharLength)
+	}
+	return c.DataType
+}
+
+// modelTable is a table whose model GenerateModels writes
+type modelTable struct {
+	name        string
+	model       string
+	columns     []modelColumn
+	constraints map[string][]modelConstraint
+}
+
+// GenerateModels writes the Go source of models for the tables of the
+// schema, read from USER_TABLES, USER_TAB_COLS and USER_CONSTRAINTS, so
+// that GORM can be adopted over an existing schema. Each model has a
+// TableName method and its fields have tags with their column, type, size
+// or precision, primary and unique keys, identity, nullability and default.
+// Foreign keys between generated tables become belongs-to associations,
+// with their ON DELETE rule.
+//
+//	var src bytes.Buffer
+//	err := db.Migrator().(oracle.Migrator).GenerateModels(&src, oracle.ModelOptions{Package: "hr"})
+//
+// Field types are those of GenDataType, and names are those of
+// GenNamingStrategy, such as Employee for EMPLOYEES and EmployeeID for
+// EMPLOYEE_ID.
+func (m Migrator) GenerateModels(w io.Writer, opts ModelOptions) error {
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = "models"
+	}
+
+	tableNames := opts.Tables
+	if len(tableNames) == 0 {
+		if err := m.DB.Raw(`SELECT TABLE_NAME FROM USER_TABLES
+WHERE NESTED = 'NO' AND SECONDARY = 'N' AND IOT_TYPE IS NULL AND DROPPED = 'NO'
+ORDER BY TABLE_NAME`).Scan(&tableNames).Error; err != nil {
+			return err
+		}
+	}
+	if len(tableNames) == 0 {
+		return fmt.Errorf("no tables to generate models for")
+	}
+
+	var columns []modelColumn
+	if err := m.DB.Raw(`SELECT c.TABLE_NAME AS "table_name", c.COLUMN_NAME AS "column_name", c.DATA_TYPE AS "data_type",
+	c.CHAR_LENGTH AS "char_length", c.CHAR_USED AS "char_used", c.DATA_PRECISION AS "data_precision",
+	c.DATA_SCALE AS "data_scale", c.NULLABLE AS "nullable", c.IDENTITY_COLUMN AS "identity_column",
+	c.VIRTUAL_COLUMN AS "virtual_column", c.DATA_DEFAULT AS "data_default", cc.COMMENTS AS "comments"
+FROM USER_TAB_COLS c
+LEFT JOIN USER_COL_COMMENTS cc ON cc.TABLE_NAME = c.TABLE_NAME AND cc.COLUMN_NAME = c.COLUMN_NAME
+WHERE c.TABLE_NAME IN ? AND c.HIDDEN_COLUMN = 'NO'
+ORDER BY c.TABLE_NAME, c.COLUMN_ID`, tableNames).Scan(&columns).Error; err != nil {
+		return err
+	}
+
+	var constraints []modelConstraint
+	if err := m.DB.Raw(`SELECT c.TABLE_NAME AS "table_name", c.CONSTRAINT_NAME AS "constraint_name",
+	c.CONSTRAINT_TYPE AS "constraint_type", c.DELETE_RULE AS "delete_rule", cc.COLUMN_NAME AS "column_name",
+	r.TABLE_NAME AS "r_table_name", rc.COLUMN_NAME AS "r_column_name",
+	(SELECT COUNT(*) FROM USER_CONS_COLUMNS o WHERE o.CONSTRAINT_NAME = c.CONSTRAINT_NAME) AS "column_count"
+FROM USER_CONSTRAINTS c
+JOIN USER_CONS_COLUMNS cc ON cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+LEFT JOIN USER_CONSTRAINTS r ON r.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME
+LEFT JOIN USER_CONS_COLUMNS rc ON rc.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME AND rc.POSITION = cc.POSITION
+WHERE c.TABLE_NAME IN ? AND c.CONSTRAINT_TYPE IN ('P', 'U', 'R')
+ORDER BY c.TABLE_NAME, c.CONSTRAINT_NAME, cc.POSITION`, tableNames).Scan(&constraints).Error; err != nil {
+		return err
+	}
+
+	ns := GenNamingStrategy{}
+	tables := make(map[string]*modelTable, len(tableNames))
+	for _, name := range tableNames {
+		tables[name] = &modelTable{name: name, model: ns.SchemaName(name), constraints: map[string][]modelConstraint{}}
+	}
+	for _, column := range columns {
+		if table, ok := tables[column.TableName]; ok {
+			table.columns = append(table.columns, column)
+		}
+	}
+	for _, constraint := range constraints {
+		if table, ok := tables[constraint.TableName]; ok {
+			table.constraints[constraint.ColumnName] = append(table.constraints[constraint.ColumnName], constraint)
+		}
+	}
+
+	var src bytes.Buffer
+	var imports []string
+	for _, name := range tableNames {
+		table := tables[name]
+		if len(table.columns) == 0 {
+			return fmt.Errorf("table %s not found", name)
+		}
+		writeModel(&src, table, tables, opts, &imports)
+	}
+
+	var file bytes.Buffer
+	fmt.Fprintf(&file, "// Code generated by gorm-oracle from the %s schema.\n\npackage %s\n\n", m.CurrentDatabase(), pkg)
+	if len(imports) > 0 {
+		slices.Sort(imports)
+		file.WriteString("import (\n")
+		for _, path := range slices.Compact(imports) {
+			fmt.Fprintf(&file, "\t%q\n", path)
+		}
+		file.WriteString(")\n\n")
+	}
+	file.Write(src.Bytes())
+
+	formatted, err := format.Source(file.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated models: %w", err)
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+// writeModel writes the struct and TableName method of a table
+func writeModel(src *bytes.Buffer, table *modelTable, tables map[string]*modelTable, opts ModelOptions, imports *[]string) {
+	fmt.Fprintf(src, "// %s maps the %s table\ntype %s struct {\n", table.model, table.name, table.model)
+
+	var associations []string
+	fieldNames := map[string]bool{}
+	for _, column := range table.columns {
+		fieldName := modelFieldName(column.ColumnName)
+		fieldNames[fieldName] = true
+
+		dataType := GenDataType(column.columnType())
+		switch {
+		case strings.HasPrefix(dataType, "time."):
+			*imports = append(*imports, "time")
+		case strings.HasPrefix(dataType, "json."):
+			*imports = append(*imports, "encoding/json")
+		}
+
+		var tags []string
+		tags = append(tags, "column:"+column.ColumnName)
+		primaryKey := false
+		for _, constraint := range table.constraints[column.ColumnName] {
+			switch constraint.ConstraintType {
+			case "P":
+				primaryKey = true
+				tags = append(tags, "primaryKey")
+			case "U":
+				if constraint.ColumnCount == 1 {
+					tags = append(tags, "unique")
+				} else {
+					tags = append(tags, "uniqueIndex:"+constraint.ConstraintName)
+				}
+			}
+		}
+		tags = append(tags, "type:"+column.fullType())
+		switch column.DataType {
+		case "VARCHAR2", "CHAR", "NVARCHAR2", "NCHAR", "RAW":
+			tags = append(tags, "size:"+strconv.FormatInt(column.CharLength, 10))
+		case "NUMBER":
+			if column.DataPrecision.Valid && column.DataScale.Int64 > 0 {
+				tags = append(tags, fmt.Sprintf("precision:%d", column.DataPrecision.Int64), fmt.Sprintf("scale:%d", column.DataScale.Int64))
+			}
+		}
+		if column.IdentityColumn == "YES" {
+			tags = append(tags, "autoIncrement")
+		} else if column.DataDefault.Valid && column.VirtualColumn != "YES" {
+			if value := strings.TrimSpace(column.DataDefault.String); value != "" && !strings.EqualFold(value, "NULL") && !strings.ContainsAny(value, ";\"`") {
+				tags = append(tags, "default:"+value)
+			}
+		}
+		if column.Nullable == "N" && !primaryKey {
+			tags = append(tags, "not null")
+		}
+		if column.VirtualColumn == "YES" {
+			tags = append(tags, "->")
+		}
+
+		nullable := column.Nullable == "Y" && !primaryKey
+		if opts.NullablePointers && nullable && dataType != "[]byte" {
+			dataType = "*" + dataType
+		}
+
+		if column.Comments.Valid && column.Comments.String != "" {
+			for _, line := range strings.Split(column.Comments.String, "\n") {
+				fmt.Fprintf(src, "\t// %s\n", strings.TrimSpace(line))
+			}
+		}
+		fmt.Fprintf(src, "\t%s %s `gorm:\"%s\"`\n", fieldName, dataType, strings.Join(tags, ";"))
+
+		// Single column foreign keys to generated tables are associations
+		for _, constraint := range table.constraints[column.ColumnName] {
+			if constraint.ConstraintType != "R" || constraint.ColumnCount != 1 || !constraint.RTableName.Valid {
+				continue
+			}
+			parent, ok := tables[constraint.RTableName.String]
+			if !ok {
+				continue
+			}
+			tag := fmt.Sprintf("foreignKey:%s;references:%s", fieldName, modelFieldName(constraint.RColumnName.String))
+			if rule := constraint.DeleteRule.String; rule == "CASCADE" || rule == "SET NULL" {
+				tag += ";constraint:OnDelete:" + rule
+			}
+			associations = append(associations, fmt.Sprintf("%s\x00*%s `gorm:\"%s\"`", associationName(column.ColumnName, parent.model), parent.model, tag))
+		}
+	}
+
+	for _, association := range associations {
+		name, rest, _ := strings.Cut(association, "\x00")
+		for fieldNames[name] {
+			name += "Ref"
+		}
+		fieldNames[name] = true
+		fmt.Fprintf(src, "\t%s %s\n", name, rest)
+	}
+	fmt.Fprintf(src, "}\n\n// TableName returns the name of the table\nfunc (%s) TableName() string {\n\treturn %q\n}\n\n", table.model, table.name)
+}
+
+// modelFieldName names the field of a column like GenNamingStrategy, without
+// making it singular, such as Notes for NOTES
+func modelFieldName(column string) string {
+	return schema.NamingStrategy{SingularTable: true}.SchemaName(genIdentifier(column))
+}
+
+// associationName names the association of a foreign key column after the
+// column without its _ID suffix, such as Manager for MANAGER_ID, or after
+// the referenced model
+func associationName(column, model string) string {
+	if name, ok := strings.CutSuffix(strings.ToUpper(column), "_ID"); ok && name != "" {
+		return modelFieldName(column[:len(name)])
+	}
+	return model
+}
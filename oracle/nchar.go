@@ -0,0 +1,202 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf16"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// NString is a string bound for NVARCHAR2, NCHAR and NCLOB columns. Binds
+// are sent in the character set of the database, which may not represent
+// every character; an NString that is not ASCII is bound escaped and
+// converted with UNISTR, which returns its characters in the national
+// character set whatever the database character set.
+//
+//	type Customer struct {
+//		ID   uint
+//		Name oracle.NString `gorm:"size:100"` // NVARCHAR2(100)
+//	}
+//
+// The escaped value must fit in a VARCHAR2 bind, five bytes for each
+// character outside ASCII.
+type NString string
+
+// isNStringField reports whether the field is an NString or a pointer to one
+func isNStringField(field *schema.Field) bool {
+	fieldType := field.FieldType
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType == reflect.TypeOf(NString(""))
+}
+
+// getNationalStringType returns the column type of an NString field:
+// NVARCHAR2 holds up to half the largest VARCHAR2 size in characters, 2000
+// or 16383, longer fields are NCLOB
+func getNationalStringType(field *schema.Field, maxStringSize uint) string {
+	switch {
+	case field.Size == 0:
+		return "NVARCHAR2(2000)"
+	case uint(field.Size) <= maxStringSize/2:
+		return fmt.Sprintf("NVARCHAR2(%d)", field.Size)
+	}
+	return "NCLOB"
+}
+
+// isASCII reports whether the string has ASCII characters only
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// unistrEscape escapes the string for UNISTR: the backslash and the
+// characters outside ASCII are written as \XXXX UTF-16 code units
+func unistrEscape(s string) string {
+	var builder strings.Builder
+	builder.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case r == '\\':
+			builder.WriteString(`\005C`)
+		case r < 0x80:
+			builder.WriteRune(r)
+		default:
+			for _, unit := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&builder, `\%04X`, unit)
+			}
+		}
+	}
+	return builder.String()
+}
+
+// nationalCharacterBinds reports whether the NationalCharacterBinds option
+// is set
+func nationalCharacterBinds(db *gorm.DB) bool {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		return d.Config != nil && d.NationalCharacterBinds
+	case Dialector:
+		return d.Config != nil && d.NationalCharacterBinds
+	}
+	return false
+}
+
+// nationalBind returns the var bound for v, and whether its placeholder is
+// wrapped in UNISTR: NString values and arrays of them that are not ASCII,
+// and strings and arrays of strings that are not ASCII with the
+// NationalCharacterBinds option, are escaped. Other NString values are
+// bound as strings.
+func nationalBind(db *gorm.DB, v interface{}) (interface{}, bool) {
+	switch value := v.(type) {
+	case NString:
+		if isASCII(string(value)) {
+			return string(value), false
+		}
+		return unistrEscape(string(value)), true
+	case *NString:
+		if value == nil {
+			return (*string)(nil), false
+		}
+		return nationalBind(db, *value)
+	case nationalStrings:
+		return escapeStrings(value), true
+	case string:
+		if isASCII(value) || !nationalCharacterBinds(db) {
+			return v, false
+		}
+		return unistrEscape(value), true
+	case []string:
+		if !nationalCharacterBinds(db) {
+			return v, false
+		}
+		for _, s := range value {
+			if !isASCII(s) {
+				return escapeStrings(value), true
+			}
+		}
+	}
+	return v, false
+}
+
+// nationalCreateValues makes the string values that are not ASCII NString
+// values with the NationalCharacterBinds option, so that the bulk inserts
+// bind them as such
+func nationalCreateValues(db *gorm.DB, createValues clause.Values) {
+	if !nationalCharacterBinds(db) {
+		return
+	}
+	for _, row := range createValues.Values {
+		for i, value := range row {
+			switch s := value.(type) {
+			case string:
+				if !isASCII(s) {
+					row[i] = NString(s)
+				}
+			case *string:
+				if s != nil && !isASCII(*s) {
+					row[i] = NString(*s)
+				}
+			}
+		}
+	}
+}
+
+// nationalStrings is an array of strings bound for a column with NString
+// values
+type nationalStrings []string
+
+// escapeStrings escapes each string of the array for UNISTR
+func escapeStrings(values []string) []string {
+	escaped := make([]string, len(values))
+	for i, s := range values {
+		escaped[i] = unistrEscape(s)
+	}
+	return escaped
+}
@@ -39,13 +39,12 @@
 package oracle
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"math"
-	"reflect"
-	"regexp"
-	"strconv"
 	"strings"
+	"time"
 
 	"maps"
 
@@ -53,7 +52,6 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
-	"gorm.io/gorm/logger"
 	"gorm.io/gorm/migrator"
 	"gorm.io/gorm/schema"
 	"gorm.io/gorm/utils"
@@ -62,11 +60,95 @@ import (
 const DefaultDriverName string = "godror"
 
 type Config struct {
-	DriverName           string
-	DataSourceName       string
-	Conn                 *sql.DB
-	DefaultStringSize    uint
+	DriverName     string
+	DataSourceName string
+	Conn           *sql.DB
+	// DefaultStringSize is the size of the VARCHAR2 columns of string fields
+	// without a size tag, 4000 if zero. Above MaxStringSize, they are CLOB.
+	DefaultStringSize uint
+	// MaxStringSize is the largest VARCHAR2 size in bytes, above which string
+	// fields are CLOB columns. If zero, it is detected when the database is
+	// opened: ExtendedMaxStringSize if MAX_STRING_SIZE is EXTENDED, and
+	// StandardMaxStringSize otherwise.
+	MaxStringSize        uint
 	SkipQuoteIdentifiers bool
+	// CaseInsensitive makes equality, LIKE and IN conditions on string
+	// values in WHERE clauses case-insensitive
+	CaseInsensitive CaseInsensitiveMode
+	// RetryPolicy retries connecting and querying on transient errors
+	RetryPolicy *RetryPolicy
+	// Failover reports the statements that fail because their session
+	// failed over as FailoverError, see Failover
+	Failover *Failover
+	// Sharding routes the statements with a sharding key to their shard,
+	// see Sharding
+	Sharding *Sharding
+	// SQLStats adds the SQL_ID and statistics of the statements to their
+	// context, for loggers created with NewStatsLogger
+	SQLStats SQLStatsMode
+	// DriverFeatures overrides the features detected from the driver
+	DriverFeatures *DriverFeatures
+	// HealthCheck configures how the godror pool checks its connections
+	HealthCheck *HealthCheck
+	// InsertOnly makes Create skip returning default values, see InsertOnlyMode
+	InsertOnly bool
+	// PreloadTempTable makes preloads with many parent keys join against a
+	// global temporary table instead of binding an IN list
+	PreloadTempTable *PreloadTempTable
+	// BackfillBatchSize is the number of rows AddColumn fills per UPDATE when a
+	// NOT NULL column with a default cannot be added in a single statement,
+	// DefaultBackfillBatchSize if zero
+	BackfillBatchSize int
+	// OnlineDDL makes the migrator create and drop indexes ONLINE, so that DML
+	// on the table is not blocked meanwhile
+	OnlineDDL bool
+	// CaseInsensitiveNames makes HasTable and HasColumn find tables and
+	// columns whose names differ in case, such as tables created unquoted
+	// with upper-case names for models with lower-case ones
+	CaseInsensitiveNames bool
+	// DropTableOrder makes DropTable drop the tables in the order of the
+	// foreign keys between them found in the data dictionary, without
+	// CASCADE CONSTRAINTS, so that the foreign keys of other tables are kept
+	DropTableOrder bool
+	// UniqueIndexes makes the migrator implement the `unique` tags of fields
+	// as unique indexes instead of UNIQUE constraints, converting existing
+	// ones either way in AutoMigrate
+	UniqueIndexes bool
+	// DDLLockTimeout makes AutoMigrate wait up to that long for the locks of
+	// busy tables instead of failing with ORA-00054
+	DDLLockTimeout time.Duration
+	// MigrationLock makes AutoMigrate hold a database lock, so that concurrent
+	// processes migrate one after the other
+	MigrationLock *MigrationLock
+	// DatabaseTime sets creation, update and soft delete times from the
+	// SYSTIMESTAMP of the database instead of the application clock, see
+	// DatabaseTimeHandler
+	DatabaseTime bool
+	// QuoteColumns quotes the bare column names of the model in string
+	// conditions and SELECT, ORDER BY and GROUP BY expressions, see
+	// ColumnQuotingHandler
+	QuoteColumns bool
+	// UpsertOmitColumns are never updated by upserts with UpdateAll, see
+	// UpsertOmitClause
+	UpsertOmitColumns []string
+	// LongOps logs the progress of queries running longer than a threshold,
+	// see LongOpsWatcher
+	LongOps *LongOpsWatcher
+	// ConversionWarnings logs a warning when a WHERE clause compares a
+	// character column with a number bind or the opposite, see
+	// ConversionWarningHandler
+	ConversionWarnings bool
+	// NationalCharacterBinds binds strings that are not ASCII through UNISTR,
+	// so that they keep the characters the database character set does not
+	// have, see NString
+	NationalCharacterBinds bool
+	// DateLocation writes the times of DATE and TIMESTAMP columns, which have
+	// no time zone, with their wall clock in the location, and reads them as
+	// times of the location. SessionTimeZone uses the time zone of the
+	// database session. See DateLocationHandler.
+	DateLocation *time.Location
+	// ZeroTime selects how the zero time.Time is written, see ZeroTimeMode
+	ZeroTime ZeroTimeMode
 }
 
 type Dialector struct {
@@ -94,7 +176,9 @@ func (d Dialector) Initialize(db *gorm.DB) (err error) {
 		d.DriverName = DefaultDriverName
 	}
 
-	d.DefaultStringSize = 4000
+	if d.DefaultStringSize == 0 {
+		d.DefaultStringSize = StandardMaxStringSize
+	}
 
 	config := &callbacks.Config{
 		CreateClauses: []string{"INSERT", "VALUES", "ON CONFLICT", "RETURNING"},
@@ -107,8 +191,36 @@ func (d Dialector) Initialize(db *gorm.DB) (err error) {
 	callback.Create().Replace("gorm:create", Create)
 	callback.Delete().Replace("gorm:delete", Delete)
 	callback.Update().Replace("gorm:update", Update)
+	callback.Query().Replace("gorm:query", Query)
+	callback.Row().Replace("gorm:row", RowQuery)
 	callback.Query().After("gorm:query").Register("oracle:after_query", AfterQuery)
 	callback.Query().Before("gorm:query").Register("oracle:before_query", BeforeQuery)
+	callback.Query().Before("gorm:query").Register("oracle:table_alias", TableAliasHandler)
+	callback.Row().Before("gorm:row").Register("oracle:table_alias", TableAliasHandler)
+	callback.Query().Before("gorm:query").Register("oracle:raw_placeholders", RawPlaceholderHandler)
+	callback.Row().Before("gorm:row").Register("oracle:raw_placeholders", RawPlaceholderHandler)
+	callback.Raw().Before("gorm:raw").Register("oracle:raw_placeholders", RawPlaceholderHandler)
+	callback.Query().Before("gorm:query").Register("oracle:named_binds", NamedBindHandler)
+	callback.Row().Before("gorm:row").Register("oracle:named_binds", NamedBindHandler)
+	callback.Raw().Before("gorm:raw").Register("oracle:named_binds", NamedBindHandler)
+	callback.Query().Before("gorm:query").Register("oracle:placeholders", PlaceholderHandler)
+	callback.Query().Before("gorm:query").Register("oracle:query_defaults", QueryDefaultsHandler)
+	callback.Query().After("gorm:query").Before("gorm:preload").Register("oracle:max_rows", MaxRowsHandler)
+	callback.Row().Before("gorm:row").Register("oracle:placeholders", PlaceholderHandler)
+	callback.Update().Before("gorm:update").Register("oracle:placeholders", PlaceholderHandler)
+	callback.Delete().Before("gorm:delete").Register("oracle:placeholders", PlaceholderHandler)
+	callback.Create().After("gorm:create").Register("oracle:error", ErrorHandler)
+	callback.Query().After("gorm:query").Register("oracle:error", ErrorHandler)
+	callback.Update().After("gorm:update").Register("oracle:error", ErrorHandler)
+	callback.Delete().After("gorm:delete").Register("oracle:error", ErrorHandler)
+	callback.Row().After("gorm:row").Register("oracle:error", ErrorHandler)
+	callback.Raw().After("gorm:raw").Register("oracle:error", ErrorHandler)
+	callback.Create().After("gorm:create").Register("oracle:mask", MaskHandler)
+	callback.Query().After("gorm:query").Register("oracle:mask", MaskHandler)
+	callback.Update().After("gorm:update").Register("oracle:mask", MaskHandler)
+	callback.Delete().After("gorm:delete").Register("oracle:mask", MaskHandler)
+	callback.Row().After("gorm:row").Register("oracle:mask", MaskHandler)
+	callback.Raw().After("gorm:raw").Register("oracle:mask", MaskHandler)
 
 	if d.SkipQuoteIdentifiers {
 		// When identifiers are not quoted, columns are returned by Oracle in uppercase.
@@ -122,18 +234,236 @@ func (d Dialector) Initialize(db *gorm.DB) (err error) {
 		}
 	}
 
+	if d.CaseInsensitive != CaseSensitive {
+		caseInsensitiveHandler := "oracle:case_insensitive"
+		handler := CaseInsensitiveHandler(d.CaseInsensitive)
+		if callback.Query().Get(caseInsensitiveHandler) == nil {
+			if err := callback.Query().Before("gorm:query").Register(caseInsensitiveHandler, handler); err != nil {
+				return err
+			}
+			if err := callback.Row().Before("gorm:row").Register(caseInsensitiveHandler, handler); err != nil {
+				return err
+			}
+			if err := callback.Update().Before("gorm:update").Register(caseInsensitiveHandler, handler); err != nil {
+				return err
+			}
+			if err := callback.Delete().Before("gorm:delete").Register(caseInsensitiveHandler, handler); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.QuoteColumns && !d.SkipQuoteIdentifiers {
+		columnQuotingHandler := "oracle:quote_columns"
+		if callback.Query().Get(columnQuotingHandler) == nil {
+			if err := callback.Query().Before("gorm:query").Register(columnQuotingHandler, ColumnQuotingHandler); err != nil {
+				return err
+			}
+			if err := callback.Row().Before("gorm:row").Register(columnQuotingHandler, ColumnQuotingHandler); err != nil {
+				return err
+			}
+			if err := callback.Update().Before("gorm:update").Register(columnQuotingHandler, ColumnQuotingHandler); err != nil {
+				return err
+			}
+			if err := callback.Delete().Before("gorm:delete").Register(columnQuotingHandler, ColumnQuotingHandler); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.SQLStats != SQLStatsOff {
+		sqlStatsHandler := "oracle:sql_stats"
+		handler := SQLStatsHandler(d.SQLStats == SQLStatsFetch)
+		if callback.Create().Get(sqlStatsHandler) == nil {
+			if err := callback.Create().After("gorm:create").Register(sqlStatsHandler, handler); err != nil {
+				return err
+			}
+			if err := callback.Query().After("gorm:query").Register(sqlStatsHandler, handler); err != nil {
+				return err
+			}
+			if err := callback.Update().After("gorm:update").Register(sqlStatsHandler, handler); err != nil {
+				return err
+			}
+			if err := callback.Delete().After("gorm:delete").Register(sqlStatsHandler, handler); err != nil {
+				return err
+			}
+			if err := callback.Row().After("gorm:row").Register(sqlStatsHandler, handler); err != nil {
+				return err
+			}
+			if err := callback.Raw().After("gorm:raw").Register(sqlStatsHandler, handler); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.DatabaseTime {
+		if callback.Create().Get("oracle:database_time") == nil {
+			if err := callback.Create().Before("gorm:create").Register("oracle:database_time", DatabaseTimeHandler); err != nil {
+				return err
+			}
+			if err := callback.Update().Before("gorm:update").Register("oracle:database_time", DatabaseTimeHandler); err != nil {
+				return err
+			}
+			if err := callback.Delete().Before("gorm:delete").Register("oracle:database_time", DatabaseTimeHandler); err != nil {
+				return err
+			}
+			if err := callback.Create().After("gorm:create").Register("oracle:database_time_restore", restoreDatabaseTime); err != nil {
+				return err
+			}
+			if err := callback.Update().After("gorm:update").Register("oracle:database_time_restore", restoreDatabaseTime); err != nil {
+				return err
+			}
+			if err := callback.Delete().After("gorm:delete").Register("oracle:database_time_restore", restoreDatabaseTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.ConversionWarnings {
+		conversionWarnings := "oracle:conversion_warnings"
+		if callback.Query().Get(conversionWarnings) == nil {
+			if err := callback.Query().After("gorm:query").Before("oracle:mask").Register(conversionWarnings, ConversionWarningHandler); err != nil {
+				return err
+			}
+			if err := callback.Row().After("gorm:row").Before("oracle:mask").Register(conversionWarnings, ConversionWarningHandler); err != nil {
+				return err
+			}
+			if err := callback.Update().After("gorm:update").Before("oracle:mask").Register(conversionWarnings, ConversionWarningHandler); err != nil {
+				return err
+			}
+			if err := callback.Delete().After("gorm:delete").Before("oracle:mask").Register(conversionWarnings, ConversionWarningHandler); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.Failover != nil {
+		if callback.Query().Get("oracle:failover") == nil {
+			if err := callback.Create().After("oracle:error").Register("oracle:failover", FailoverHandler(d.Failover, true)); err != nil {
+				return err
+			}
+			if err := callback.Query().After("oracle:error").Register("oracle:failover", FailoverHandler(d.Failover, false)); err != nil {
+				return err
+			}
+			if err := callback.Update().After("oracle:error").Register("oracle:failover", FailoverHandler(d.Failover, true)); err != nil {
+				return err
+			}
+			if err := callback.Delete().After("oracle:error").Register("oracle:failover", FailoverHandler(d.Failover, true)); err != nil {
+				return err
+			}
+			if err := callback.Row().After("oracle:error").Register("oracle:failover", FailoverHandler(d.Failover, false)); err != nil {
+				return err
+			}
+			if err := callback.Raw().After("oracle:error").Register("oracle:failover", FailoverHandler(d.Failover, true)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.Sharding != nil {
+		if callback.Query().Get("oracle:sharding") == nil {
+			if err := callback.Create().Before("gorm:begin_transaction").Register("oracle:sharding", ShardingHandler); err != nil {
+				return err
+			}
+			if err := callback.Query().Before("gorm:query").Register("oracle:sharding", ShardingHandler); err != nil {
+				return err
+			}
+			if err := callback.Update().Before("gorm:begin_transaction").Register("oracle:sharding", ShardingHandler); err != nil {
+				return err
+			}
+			if err := callback.Delete().Before("gorm:begin_transaction").Register("oracle:sharding", ShardingHandler); err != nil {
+				return err
+			}
+			if err := callback.Row().Before("gorm:row").Register("oracle:sharding", ShardingHandler); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.DateLocation != nil {
+		if callback.Query().Get("oracle:date_location") == nil {
+			if err := callback.Query().After("gorm:query").Before("gorm:preload").Register("oracle:date_location", DateLocationHandler); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.ZeroTime == ZeroTimeStore {
+		if callback.Query().Get("oracle:zero_time") == nil {
+			if err := callback.Query().After("gorm:query").Before("gorm:preload").Register("oracle:zero_time", ZeroTimeHandler); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.PreloadTempTable != nil {
+		if callback.Query().Get("oracle:preload_temp_table") == nil {
+			if err := callback.Query().After("gorm:query").Before("gorm:preload").Register("oracle:mark_preload", markPreload); err != nil {
+				return err
+			}
+			if err := callback.Query().Before("gorm:query").Register("oracle:preload_temp_table", d.PreloadTempTable.beforeQuery); err != nil {
+				return err
+			}
+			if err := callback.Query().After("gorm:after_query").Register("oracle:preload_temp_table_cleanup", d.PreloadTempTable.afterQuery); err != nil {
+				return err
+			}
+		}
+	}
+
 	maps.Copy(db.ClauseBuilders, OracleClauseBuilders())
 
-	if d.Conn == nil {
+	if d.Conn == nil && d.HealthCheck != nil {
+		if d.DriverName != DefaultDriverName {
+			return errHealthCheckDriver
+		}
+		db.ConnPool, err = openWithHealthCheck(d.DataSourceName, d.HealthCheck)
+	} else if d.Conn == nil {
 		db.ConnPool, err = sql.Open(d.DriverName, d.DataSourceName)
 	} else {
 		db.ConnPool = d.Conn
+		if d.HealthCheck != nil {
+			// The pool is configured by the application, only validate it
+			d.HealthCheck.startValidation(d.Conn)
+		}
 	}
 
 	if err != nil {
 		return err
 	}
 
+	if d.Sharding != nil && d.Sharding.pool == nil {
+		if d.DriverName != DefaultDriverName {
+			return errShardingDriver
+		}
+		if err := d.Sharding.open(d.DataSourceName); err != nil {
+			return err
+		}
+	}
+
+	if d.DriverFeatures == nil {
+		features := detectDriverFeatures(db.ConnPool)
+		d.DriverFeatures = &features
+	}
+
+	if d.RetryPolicy != nil && !db.DisableAutomaticPing {
+		if pinger, ok := db.ConnPool.(interface{ PingContext(context.Context) error }); ok {
+			ctx := context.Background()
+			if err := d.RetryPolicy.Do(ctx, func() error { return pinger.PingContext(ctx) }); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.MaxStringSize == 0 && !db.DryRun && !db.DisableAutomaticPing {
+		d.MaxStringSize = detectMaxStringSize(db.ConnPool)
+	}
+
+	if d.DateLocation == SessionTimeZone && !db.DryRun {
+		if d.DateLocation, err = resolveSessionTimeZone(db.ConnPool); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -162,9 +492,9 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 	case schema.String:
 		return d.getStringType(field)
 	case schema.Time:
-		return d.getDataTimeType()
+		return d.getDataTimeType(field)
 	case schema.Bytes:
-		return d.getBLOBType()
+		return d.getBytesType(field)
 	default:
 		dataType := strings.ToUpper(string(field.DataType))
 		if dataType == "" {
@@ -175,13 +505,17 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 }
 
 func (d Dialector) getStringType(field *schema.Field) string {
-	var sqlType string
-	if field.Size > 0 && field.Size <= 4000 {
-		sqlType = fmt.Sprintf("VARCHAR2(%d)", field.Size)
-	} else if field.Size == 0 {
-		sqlType = fmt.Sprintf("VARCHAR2(%d)", d.DefaultStringSize)
-	} else {
-		sqlType = "CLOB"
+	if isNStringField(field) {
+		return getNationalStringType(field, d.maxStringSize())
+	}
+
+	size := uint(field.Size)
+	if field.Size <= 0 {
+		size = d.DefaultStringSize
+	}
+	sqlType := "CLOB"
+	if size > 0 && size <= d.maxStringSize() {
+		sqlType = fmt.Sprintf("VARCHAR2(%d)", size)
 	}
 
 	// Don't add NOT NULL here - let GORM handle it to avoid duplicates
@@ -193,14 +527,22 @@ func (d Dialector) getBooleanType() string {
 	return "NUMBER(1)"
 }
 
-func (d Dialector) getDataTimeType() string {
+func (d Dialector) getDataTimeType(field *schema.Field) string {
 	sqlType := "TIMESTAMP WITH TIME ZONE"
+	// Fractional seconds default to 6 digits, precision:9 keeps nanoseconds
+	if field.Precision > 0 && field.Precision <= 9 {
+		sqlType = fmt.Sprintf("TIMESTAMP(%d) WITH TIME ZONE", field.Precision)
+	}
 	return sqlType
 }
 
-func (d Dialector) getBLOBType() string {
-	sqlType := "BLOB"
-	return sqlType
+// getBytesType returns RAW for byte slices with a size that fits a RAW
+// column, and BLOB for the others
+func (d Dialector) getBytesType(field *schema.Field) string {
+	if field.Size > 0 && uint(field.Size) <= d.maxRawSize() {
+		return fmt.Sprintf("RAW(%d)", field.Size)
+	}
+	return "BLOB"
 }
 
 func (d Dialector) getIntegerType(field *schema.Field) string {
@@ -249,8 +591,7 @@ func (d Dialector) DefaultValueOf(field *schema.Field) clause.Expression {
 
 // Handles variable binding in SQL statements
 func (d Dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
-	writer.WriteByte(':')
-	writer.WriteString(strconv.Itoa(len(stmt.Vars)))
+	bindRawVar(writer, stmt)
 }
 
 // Manages quoting of identifiers
@@ -264,24 +605,10 @@ func (d Dialector) QuoteTo(writer clause.Writer, str string) {
 	_, _ = writer.WriteString(out)
 }
 
-var numericPlaceholder = regexp.MustCompile(`:(\d+)`)
-
 // Explain Formats SQL statements with variables, string literals will be encoded
-// with in ”
+// with in ”. OUT binds are formatted with the values returned by the database.
 func (d Dialector) Explain(sqlStr string, vars ...interface{}) string {
-	clonedVars := make([]interface{}, len(vars))
-	copy(clonedVars, vars)
-
-	// Unwrap sql.Out vars to actual values in the cloned slice
-	for i, val := range clonedVars {
-		if out, ok := val.(sql.Out); ok {
-			valPtr := reflect.ValueOf(out.Dest)
-			if valPtr.Kind() == reflect.Ptr && !valPtr.IsNil() {
-				clonedVars[i] = valPtr.Elem().Interface()
-			}
-		}
-	}
-	return logger.ExplainSQL(sqlStr, numericPlaceholder, "'", clonedVars...)
+	return explainSQL(sqlStr, vars...)
 }
 
 // SavePoint creates a save point with the given name
@@ -0,0 +1,94 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Col returns the column with the given name, to be used in Order, Group,
+// Having and Select instead of a quoted name in a SQL string. The name is
+// either the column name, the field name of the model, or either of them
+// qualified with a table, like "users.name". GORM quotes the column, so
+// that Order(oracle.Col("name")) refers to the "name" column GORM creates.
+func Col(name string) clause.Column {
+	if idx := strings.LastIndexByte(name, '.'); idx > 0 {
+		return clause.Column{Table: name[:idx], Name: name[idx+1:]}
+	}
+	return clause.Column{Name: name}
+}
+
+// Asc returns an ascending ORDER BY column, see Col
+func Asc(name string) clause.OrderByColumn {
+	return clause.OrderByColumn{Column: Col(name)}
+}
+
+// Desc returns a descending ORDER BY column, see Col
+func Desc(name string) clause.OrderByColumn {
+	return clause.OrderByColumn{Column: Col(name), Desc: true}
+}
+
+// resolveColumn returns the column as GORM should quote it. A field name of
+// the model is replaced with its column name, and a qualifier that names
+// the aliased table of the statement in another case is replaced with the
+// alias, which is folded to uppercase when it was written unquoted.
+func resolveColumn(stmt *gorm.Statement, column clause.Column) clause.Column {
+	if column.Raw || column.Name == "" || column.Name == "*" {
+		return column
+	}
+	if column.Table == "" && !strings.Contains(column.Name, `"`) {
+		// Group("users.name") puts the qualifier in the name
+		qualified := Col(column.Name)
+		column.Table, column.Name = qualified.Table, qualified.Name
+	}
+	if column.Table != "" && column.Table != clause.CurrentTable && column.Table != stmt.Table &&
+		stmt.Table == strings.ToUpper(stmt.Table) && strings.ToUpper(column.Table) == stmt.Table {
+		column.Table = stmt.Table
+	}
+	if stmt.Schema != nil && (column.Table == "" || column.Table == clause.CurrentTable ||
+		column.Table == stmt.Table || column.Table == stmt.Schema.Table) {
+		if field := stmt.Schema.LookUpField(column.Name); field != nil && field.DBName != "" {
+			column.Name = field.DBName
+		}
+	}
+	return column
+}
@@ -0,0 +1,146 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const ClauseTotalCount = "TOTAL_COUNT"
+
+// TotalCount adds COUNT(*) OVER () to the select list of a query and scans
+// it into Dest, so that a paged Find also returns the number of rows
+// matching the query without a second Count query.
+//
+//	var total int64
+//	db.Clauses(oracle.WithTotalCount(&total)).Where("age > ?", 18).Limit(10).Offset(20).Find(&users)
+//	// SELECT "users".*, COUNT(*) OVER () AS "total_rows" FROM "users" WHERE age > :1
+//	// ORDER BY "id" OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY
+//
+// The count is read from the returned rows, so Dest is set to 0 when the
+// requested page is empty. It cannot be combined with Distinct, since the
+// window function is evaluated before duplicate rows are removed.
+type TotalCount struct {
+	Dest *int64
+}
+
+// WithTotalCount returns a TotalCount clause scanning the total into dest
+func WithTotalCount(dest *int64) TotalCount {
+	return TotalCount{Dest: dest}
+}
+
+// Name returns the name of the clause
+func (TotalCount) Name() string {
+	return ClauseTotalCount
+}
+
+// Build is a no-op, the count is written by SelectClauseBuilder
+func (TotalCount) Build(clause.Builder) {}
+
+// MergeClause replaces any previous TotalCount clause
+func (t TotalCount) MergeClause(c *clause.Clause) {
+	c.Expression = t
+}
+
+// totalCount returns the TotalCount clause of the statement, if any
+func totalCount(stmt *gorm.Statement) (TotalCount, bool) {
+	if c, ok := stmt.Clauses[ClauseTotalCount]; ok {
+		if t, ok := c.Expression.(TotalCount); ok && t.Dest != nil {
+			return t, true
+		}
+	}
+	return TotalCount{}, false
+}
+
+// writeTotalCountColumn appends the total count column to the select list of
+// the clause. A plain "*" is qualified with the table name, since Oracle does
+// not allow it to be combined with other expressions.
+func writeTotalCountColumn(c clause.Clause, builder clause.Builder) clause.Clause {
+	expr := c.Expression
+	switch sel := expr.(type) {
+	case clause.Select:
+		if sel.Distinct {
+			builder.AddError(fmt.Errorf("total count cannot be combined with DISTINCT"))
+		}
+		if len(sel.Columns) == 0 {
+			expr = clause.Expr{SQL: "?.*", Vars: []interface{}{clause.Table{Name: clause.CurrentTable}}}
+		}
+	case clause.Expr:
+		if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(sel.SQL)), "DISTINCT ") {
+			builder.AddError(fmt.Errorf("total count cannot be combined with DISTINCT"))
+		}
+	}
+	c.Expression = clause.CommaExpression{Exprs: []clause.Expression{
+		expr,
+		clause.Expr{SQL: "COUNT(*) OVER () AS ?", Vars: []interface{}{clause.Column{Name: "total_rows"}}},
+	}}
+	return c
+}
+
+// totalCountRows hides the trailing total count column from GORM and scans
+// it into the destination of the TotalCount clause
+type totalCountRows struct {
+	*sql.Rows
+	dest *int64
+}
+
+func (r totalCountRows) Columns() ([]string, error) {
+	columns, err := r.Rows.Columns()
+	if err != nil || len(columns) == 0 {
+		return columns, err
+	}
+	return columns[:len(columns)-1], nil
+}
+
+func (r totalCountRows) ColumnTypes() ([]*sql.ColumnType, error) {
+	columnTypes, err := r.Rows.ColumnTypes()
+	if err != nil || len(columnTypes) == 0 {
+		return columnTypes, err
+	}
+	return columnTypes[:len(columnTypes)-1], nil
+}
+
+func (r totalCountRows) Scan(dest ...interface{}) error {
+	return r.Rows.Scan(append(dest, r.dest)...)
+}
@@ -0,0 +1,150 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const ClauseParallel = "PARALLEL"
+
+// ParallelHint adds a PARALLEL optimizer hint to a SELECT, INSERT, UPDATE or
+// DELETE statement.
+//
+//	db.Clauses(oracle.Parallel(8)).Find(&orders)
+//	// SELECT /*+ PARALLEL(8) */ * FROM "orders"
+//
+//	db.Clauses(oracle.Parallel(8).WithDML()).Where("status = ?", "closed").Delete(&Order{})
+//	// DELETE /*+ ENABLE_PARALLEL_DML PARALLEL(8) */ FROM "orders" WHERE status = :1
+//
+// Oracle executes DML with a RETURNING clause serially, so the hint has no
+// effect on statements that return values into the model.
+type ParallelHint struct {
+	// Degree is the degree of parallelism. Zero lets Oracle choose the degree.
+	Degree int
+	// EnableDML enables parallel DML for the statement, which otherwise
+	// only parallelizes the query part of INSERT, UPDATE and DELETE.
+	EnableDML bool
+}
+
+// Parallel returns a PARALLEL hint with the given degree of parallelism
+func Parallel(degree int) ParallelHint {
+	return ParallelHint{Degree: degree}
+}
+
+// WithDML enables parallel DML for the statement
+func (p ParallelHint) WithDML() ParallelHint {
+	p.EnableDML = true
+	return p
+}
+
+// Name returns the name of the clause
+func (ParallelHint) Name() string {
+	return ClauseParallel
+}
+
+// Build writes the hint comment
+func (p ParallelHint) Build(builder clause.Builder) {
+	if p.Degree < 0 {
+		builder.AddError(fmt.Errorf("invalid degree of parallelism %d", p.Degree))
+		return
+	}
+
+	builder.WriteString("/*+ ")
+	if p.EnableDML {
+		builder.WriteString("ENABLE_PARALLEL_DML ")
+	}
+	builder.WriteString("PARALLEL")
+	if p.Degree > 0 {
+		builder.WriteByte('(')
+		builder.WriteString(strconv.Itoa(p.Degree))
+		builder.WriteByte(')')
+	}
+	builder.WriteString(" */")
+}
+
+// MergeClause replaces any previous PARALLEL clause
+func (p ParallelHint) MergeClause(c *clause.Clause) {
+	c.Expression = p
+}
+
+// parallelHint returns the PARALLEL clause of the statement, if any
+func parallelHint(stmt *gorm.Statement) (ParallelHint, bool) {
+	if c, ok := stmt.Clauses[ClauseParallel]; ok {
+		if p, ok := c.Expression.(ParallelHint); ok {
+			return p, true
+		}
+	}
+	return ParallelHint{}, false
+}
+
+// writeParallelHint writes the PARALLEL hint of the statement followed by a
+// space, if the statement has one
+func writeParallelHint(builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		if p, ok := parallelHint(stmt); ok {
+			p.Build(builder)
+			builder.WriteByte(' ')
+		}
+	}
+}
+
+// ParallelDML runs fc in a transaction with parallel DML enabled for the
+// session, so that every DML statement in it can run in parallel when its
+// table or a PARALLEL hint requests it. Parallel DML is disabled again
+// before the connection is returned to the pool.
+//
+// Oracle does not allow a table modified in parallel to be read or modified
+// again in the same transaction, so fc should commit its work in one
+// statement per table.
+func ParallelDML(db *gorm.DB, fc func(tx *gorm.DB) error) error {
+	return db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("ALTER SESSION ENABLE PARALLEL DML").Error; err != nil {
+			return err
+		}
+
+		err := conn.Transaction(fc)
+		return errors.Join(err, conn.Exec("ALTER SESSION DISABLE PARALLEL DML").Error)
+	})
+}
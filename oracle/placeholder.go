@@ -0,0 +1,383 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// bindPlaceholders returns the positions of the ? bind placeholders in the
+// SQL. Question marks inside string literals, q-quoted literals, quoted
+// identifiers and comments, such as the ones in JSON path expressions, are
+// not placeholders.
+func bindPlaceholders(sql string) []int {
+	var positions []int
+	for i := 0; i < len(sql); {
+		if next := skipSQLLiteral(sql, i); next > i {
+			i = next
+			continue
+		}
+		if sql[i] == '?' {
+			positions = append(positions, i)
+		}
+		i++
+	}
+	return positions
+}
+
+// hasQuotedQuestionMark reports whether the SQL has question marks that are
+// not bind placeholders
+func hasQuotedQuestionMark(sql string) bool {
+	return strings.Count(sql, "?") != len(bindPlaceholders(sql))
+}
+
+// SQLExpr is a raw SQL expression like clause.Expr, except that only the ?
+// placeholders outside literals and comments are bound to its vars.
+//
+//	db.Where("JSON_EXISTS(?, '$.tags?(@ == \"go\")') AND \"age\" > ?", clause.Column{Name: "doc"}, 18)
+//
+// Expressions passed to Where, Select and Update are converted to SQLExpr
+// automatically, and the SQL of Raw and Exec is bound the same way, see
+// RawPlaceholderHandler.
+type SQLExpr struct {
+	SQL                string
+	Vars               []interface{}
+	WithoutParentheses bool
+}
+
+// Expr creates an SQLExpr
+func Expr(sql string, vars ...interface{}) SQLExpr {
+	return SQLExpr{SQL: sql, Vars: vars}
+}
+
+// Build writes the SQL, binding the vars to its placeholders in the same way
+// as clause.Expr
+func (expr SQLExpr) Build(builder clause.Builder) {
+	var (
+		afterParenthesis bool
+		idx              int
+	)
+
+	for i := 0; i < len(expr.SQL); {
+		if next := skipSQLLiteral(expr.SQL, i); next > i {
+			builder.WriteString(expr.SQL[i:next])
+			afterParenthesis = false
+			i = next
+			continue
+		}
+
+		v := expr.SQL[i]
+		i++
+		if v != '?' || len(expr.Vars) <= idx {
+			afterParenthesis = v == '('
+			builder.WriteByte(v)
+			continue
+		}
+
+		if afterParenthesis || expr.WithoutParentheses {
+			writeExpandedVar(builder, expr.Vars[idx])
+		} else {
+			builder.AddVar(builder, expr.Vars[idx])
+		}
+		idx++
+	}
+
+	for _, v := range expr.Vars[idx:] {
+		builder.AddVar(builder, sql.NamedArg{Value: v})
+	}
+}
+
+// writeExpandedVar binds the elements of a slice as a comma-separated list
+func writeExpandedVar(builder clause.Builder, v interface{}) {
+	if _, ok := v.(driver.Valuer); ok {
+		builder.AddVar(builder, v)
+		return
+	}
+
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			builder.AddVar(builder, nil)
+			return
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				builder.WriteByte(',')
+			}
+			builder.AddVar(builder, rv.Index(i).Interface())
+		}
+	default:
+		builder.AddVar(builder, v)
+	}
+}
+
+// PlaceholderHandler converts the clause.Expr expressions of the WHERE, SELECT
+// and SET clauses that contain question marks inside literals or comments to
+// SQLExpr, so that those question marks are not bound as placeholders.
+func PlaceholderHandler(db *gorm.DB) {
+	if db.Error != nil || db.Statement == nil {
+		return
+	}
+	stmt := db.Statement
+
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			c.Expression = clause.Where{Exprs: placeholderExprs(where.Exprs)}
+			stmt.Clauses["WHERE"] = c
+		}
+	}
+
+	if c, ok := stmt.Clauses["SELECT"]; ok {
+		if sel, ok := c.Expression.(clause.Select); ok {
+			if expr, ok := sel.Expression.(clause.Expr); ok && hasQuotedQuestionMark(expr.SQL) {
+				sel.Expression = SQLExpr(expr)
+				c.Expression = sel
+				stmt.Clauses["SELECT"] = c
+			}
+		} else if expr, ok := c.Expression.(clause.Expr); ok && hasQuotedQuestionMark(expr.SQL) {
+			c.Expression = SQLExpr(expr)
+			stmt.Clauses["SELECT"] = c
+		}
+	}
+
+	if c, ok := stmt.Clauses["SET"]; ok {
+		if set, ok := c.Expression.(clause.Set); ok {
+			for i, assignment := range set {
+				if expr, ok := assignment.Value.(clause.Expr); ok && hasQuotedQuestionMark(expr.SQL) {
+					set[i].Value = SQLExpr(expr)
+				}
+			}
+		}
+	}
+}
+
+func placeholderExprs(exprs []clause.Expression) []clause.Expression {
+	result := make([]clause.Expression, len(exprs))
+	for i, expr := range exprs {
+		switch e := expr.(type) {
+		case clause.Expr:
+			if hasQuotedQuestionMark(e.SQL) {
+				// Keep the expression a clause.Expr, so that the WHERE clause
+				// still wraps conditions with AND or OR in parentheses
+				sql := "?"
+				if upper := strings.ToUpper(e.SQL); strings.Contains(upper, clause.AndWithSpace) || strings.Contains(upper, clause.OrWithSpace) {
+					sql = "(?)"
+				}
+				result[i] = clause.Expr{SQL: sql, Vars: []interface{}{SQLExpr(e)}}
+				continue
+			}
+		case clause.AndConditions:
+			result[i] = clause.AndConditions{Exprs: placeholderExprs(e.Exprs)}
+			continue
+		case clause.OrConditions:
+			result[i] = clause.OrConditions{Exprs: placeholderExprs(e.Exprs)}
+			continue
+		case clause.NotConditions:
+			result[i] = clause.NotConditions{Exprs: placeholderExprs(e.Exprs)}
+			continue
+		}
+		result[i] = expr
+	}
+	return result
+}
+
+// rawBindsKey is the setting holding the rawBinds of a statement
+const rawBindsKey = "oracle:raw_binds"
+
+// rawBind is a placeholder written while GORM builds the SQL of Raw or
+// Exec, from start to end in the SQL, and the index of its var
+type rawBind struct {
+	start, end int
+	varIndex   int
+}
+
+type rawBinds struct {
+	binds []rawBind
+}
+
+// bindRawVar binds the last var of the statement like writeBindVar. Raw and
+// Exec build their SQL as soon as they are called, binding every question
+// mark of the SQL, so the placeholders written for them are recorded for
+// RawPlaceholderHandler. Statements built by the callbacks have their
+// BuildClauses set.
+func bindRawVar(writer clause.Writer, stmt *gorm.Statement) {
+	if w, ok := writer.(*gorm.Statement); !ok || w != stmt || len(stmt.BuildClauses) > 0 {
+		writeBindVar(writer, stmt)
+		return
+	}
+
+	start := stmt.SQL.Len()
+	writeBindVar(writer, stmt)
+	bind := rawBind{start: start, end: stmt.SQL.Len(), varIndex: len(stmt.Vars) - 1}
+
+	// The records may be shared with clones of the statement, so they are
+	// replaced rather than changed when the SQL is built again
+	recorded, _ := stmt.Settings.Load(rawBindsKey)
+	binds, _ := recorded.(*rawBinds)
+	if binds == nil || len(binds.binds) == 0 || binds.binds[len(binds.binds)-1].end > start {
+		stmt.Settings.Store(rawBindsKey, &rawBinds{binds: []rawBind{bind}})
+		return
+	}
+	binds.binds = append(binds.binds, bind)
+}
+
+// RawPlaceholderHandler binds the vars of Raw and Exec to the ? placeholders
+// outside literals and comments of their SQL, like SQLExpr, so that
+//
+//	db.Raw(`SELECT JSON_VALUE("doc", '$.tags?(@ == "go")') FROM "docs" WHERE "id" = ?`, 1)
+//
+// binds 1 to the "id" condition, and keeps the question mark of the JSON
+// path. GORM has already bound the vars to every question mark in order:
+// the SQL is restored from the recorded placeholders and bound again.
+func RawPlaceholderHandler(db *gorm.DB) {
+	if db.Error != nil || db.Statement == nil {
+		return
+	}
+	stmt := db.Statement
+	recorded, ok := stmt.Settings.Load(rawBindsKey)
+	if !ok {
+		return
+	}
+	stmt.Settings.Delete(rawBindsKey)
+
+	binds := recorded.(*rawBinds).binds
+	built := stmt.SQL.String()
+	if len(binds) == 0 || binds[len(binds)-1].end > len(built) || binds[len(binds)-1].varIndex >= len(stmt.Vars) {
+		return
+	}
+
+	// A slice bound to a question mark is written as (:1,:2,...), which is
+	// restored as a single question mark
+	var groups [][2]int
+	for i := 0; i < len(binds); {
+		j := i
+		if binds[i].start > 0 && built[binds[i].start-1] == '(' {
+			for j+1 < len(binds) && binds[j+1].start == binds[j].end+1 && built[binds[j].end] == ',' {
+				j++
+			}
+			if binds[j].end < len(built) && built[binds[j].end] == ')' {
+				groups = append(groups, [2]int{i, j + 1})
+				i = j + 1
+				continue
+			}
+			j = i
+		}
+		groups = append(groups, [2]int{i, i + 1})
+		i = j + 1
+	}
+	span := func(group [2]int) (start, end int) {
+		start, end = binds[group[0]].start, binds[group[1]-1].end
+		if group[1]-group[0] > 1 || (start > 0 && built[start-1] == '(' && end < len(built) && built[end] == ')') {
+			start, end = start-1, end+1
+		}
+		return start, end
+	}
+
+	var raw strings.Builder
+	last := 0
+	for _, group := range groups {
+		start, end := span(group)
+		raw.WriteString(built[last:start])
+		raw.WriteByte('?')
+		last = end
+	}
+	raw.WriteString(built[last:])
+	rawSQL := raw.String()
+	if !hasQuotedQuestionMark(rawSQL) {
+		return
+	}
+
+	var (
+		sql  strings.Builder
+		vars = make([]interface{}, 0, len(stmt.Vars))
+		next int
+	)
+	// writeGroup writes the placeholders of the group bound to the next vars
+	writeGroup := func(group [2]int) {
+		start, end := span(group)
+		for _, bind := range binds[group[0]:group[1]] {
+			vars = append(vars, stmt.Vars[bind.varIndex])
+			sql.WriteString(built[start:bind.start])
+			sql.WriteString(strings.Replace(built[bind.start:bind.end], ":"+strconv.Itoa(bind.varIndex+1), ":"+strconv.Itoa(len(vars)), 1))
+			start = bind.end
+		}
+		sql.WriteString(built[start:end])
+	}
+
+	last = 0
+	for _, pos := range bindPlaceholders(rawSQL) {
+		// Question marks left without a var are kept, like clause.Expr does
+		if next >= len(groups) {
+			break
+		}
+		sql.WriteString(rawSQL[last:pos])
+		writeGroup(groups[next])
+		next++
+		last = pos + 1
+	}
+	sql.WriteString(rawSQL[last:])
+
+	// Vars without a placeholder, such as sql.Named values and driver
+	// options, are passed on after the bound ones
+	bound := make(map[int]bool, len(binds))
+	for _, bind := range binds {
+		bound[bind.varIndex] = true
+	}
+	for _, group := range groups[next:] {
+		for _, bind := range binds[group[0]:group[1]] {
+			vars = append(vars, stmt.Vars[bind.varIndex])
+		}
+	}
+	for idx, v := range stmt.Vars {
+		if !bound[idx] {
+			vars = append(vars, v)
+		}
+	}
+
+	stmt.SQL.Reset()
+	stmt.SQL.WriteString(sql.String())
+	stmt.Vars = vars
+}
@@ -0,0 +1,344 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultPreloadThreshold = 1000
+	defaultPreloadTable     = "gorm_preload_keys"
+
+	preloadingSetting     = "oracle:preloading"
+	preloadConnSetting    = "oracle:preload_conn"
+	preloadCleanupSetting = "oracle:preload_cleanup"
+)
+
+// PreloadTempTable makes the queries that preload associations join against
+// a global temporary table holding the parent keys, rather than binding the
+// keys as an IN list, when there are more than Threshold of them. This saves
+// parsing huge statements, and avoids ORA-01795 for more than 1000 keys.
+//
+//	db, err := gorm.Open(oracle.New(oracle.Config{
+//		DataSourceName:   dsn,
+//		PreloadTempTable: &oracle.PreloadTempTable{Threshold: 500},
+//	}), &gorm.Config{})
+//
+// The table is created by the first preload that needs it if it doesn't
+// exist, and preloads fall back to IN lists if that fails. Its rows are
+// private to each session, so the keys are inserted and the query is run on
+// the same connection, and the keys are deleted afterwards.
+// Preloads on composite keys still use IN lists.
+type PreloadTempTable struct {
+	// Threshold is the number of keys above which the temporary table is
+	// used, 1000 if zero
+	Threshold int
+	// Table is the name of the global temporary table, gorm_preload_keys if
+	// empty
+	Table string
+
+	create  sync.Once
+	created bool
+}
+
+// threshold returns the number of keys above which the table is used
+func (p *PreloadTempTable) threshold() int {
+	if p.Threshold > 0 {
+		return p.Threshold
+	}
+	return defaultPreloadThreshold
+}
+
+// table returns the global temporary table
+func (p *PreloadTempTable) table() clause.Table {
+	if p.Table != "" {
+		return clause.Table{Name: p.Table}
+	}
+	return clause.Table{Name: defaultPreloadTable}
+}
+
+// ensureTable creates the global temporary table the first time a preload
+// needs it, and reports whether it exists. DDL commits the transaction of its
+// session, so the table is created on a connection of its own. When it can't
+// be created, preloads keep binding the keys as IN lists.
+func (p *PreloadTempTable) ensureTable(db *gorm.DB) bool {
+	p.create.Do(func() {
+		stmt := db.Statement
+		_, err := db.Config.ConnPool.ExecContext(context.WithoutCancel(stmt.Context), fmt.Sprintf(`CREATE GLOBAL TEMPORARY TABLE %s (
+  "set_id" NUMBER(10) NOT NULL,
+  "num_value" NUMBER,
+  "str_value" VARCHAR2(4000),
+  "time_value" TIMESTAMP WITH TIME ZONE,
+  "raw_value" RAW(2000)
+) ON COMMIT PRESERVE ROWS`, stmt.Quote(p.table())))
+		if err != nil {
+			if e := asError(err); e == nil || e.Code != 955 { // ORA-00955: name is already used
+				db.Logger.Warn(stmt.Context, "cannot create the preload temporary table %s, preloading with IN lists: %v", p.table().Name, err)
+				return
+			}
+		}
+		p.created = true
+	})
+	return p.created
+}
+
+// markPreload flags the statement as preloading associations. The flag is
+// copied to the statements that query the associations.
+func markPreload(db *gorm.DB) {
+	if len(db.Statement.Preloads) > 0 {
+		db.Statement.Settings.Store(preloadingSetting, true)
+	}
+}
+
+// beforeQuery moves the keys of large IN conditions of preload queries to
+// the temporary table, pinning the query to a single connection when it
+// doesn't run in a transaction
+func (p *PreloadTempTable) beforeQuery(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || db.DryRun {
+		return
+	}
+	if preloading, ok := stmt.Settings.Load(preloadingSetting); !ok || !preloading.(bool) {
+		return
+	}
+
+	where, ok := stmt.Clauses["WHERE"].Expression.(clause.Where)
+	if !ok {
+		return
+	}
+
+	// The conditions may be shared with the statements of other preloads
+	where.Exprs = slices.Clone(where.Exprs)
+
+	var inserted bool
+	for i, expr := range where.Exprs {
+		in, ok := expr.(clause.IN)
+		if !ok || len(in.Values) <= p.threshold() {
+			continue
+		}
+		if _, ok := in.Column.(clause.Column); !ok {
+			continue
+		}
+		valueColumn, values, ok := arrayBindValues(in.Values)
+		if !ok {
+			continue
+		}
+
+		if !inserted {
+			if !p.ensureTable(db) || !p.pinConnection(db) {
+				return
+			}
+			if db.AddError(p.deleteKeys(db)) != nil {
+				return
+			}
+			db.InstanceSet(preloadCleanupSetting, true)
+			inserted = true
+		}
+
+		setID := i + 1
+		insert := fmt.Sprintf(`INSERT INTO %s ("set_id", %s) VALUES (%d, :1)`,
+			stmt.Quote(p.table()), stmt.Quote(clause.Column{Name: valueColumn}), setID)
+		if _, err := stmt.ConnPool.ExecContext(stmt.Context, insert, values); db.AddError(err) != nil {
+			return
+		}
+
+		where.Exprs[i] = clause.Expr{
+			SQL:  `? IN (SELECT ? FROM ? WHERE "set_id" = ?)`,
+			Vars: []interface{}{in.Column, clause.Column{Name: valueColumn}, p.table(), setID},
+		}
+	}
+
+	if inserted {
+		stmt.Clauses["WHERE"] = clause.Clause{Name: "WHERE", Expression: where}
+	}
+}
+
+// afterQuery deletes the keys inserted for the query and releases the
+// pinned connection. It runs even when the query failed.
+func (p *PreloadTempTable) afterQuery(db *gorm.DB) {
+	stmt := db.Statement
+	if _, ok := db.InstanceGet(preloadCleanupSetting); ok {
+		db.AddError(p.deleteKeys(db))
+	}
+	if conn, ok := db.InstanceGet(preloadConnSetting); ok {
+		stmt.ConnPool = conn.(pinnedConn).pool
+		db.AddError(conn.(pinnedConn).Close())
+	}
+	if len(stmt.Preloads) > 0 {
+		stmt.Settings.Delete(preloadingSetting)
+	}
+}
+
+// deleteKeys deletes the keys of the session from the temporary table
+func (p *PreloadTempTable) deleteKeys(db *gorm.DB) error {
+	_, err := db.Statement.ConnPool.ExecContext(db.Statement.Context, "DELETE FROM "+db.Statement.Quote(p.table()))
+	return err
+}
+
+// pinnedConn is a connection borrowed from a pool for a single statement
+type pinnedConn struct {
+	*sql.Conn
+	pool gorm.ConnPool
+}
+
+// pinConnection makes the statement run on a single connection of the
+// pool, so that the keys it inserts in the temporary table are visible to
+// its query. Statements running in a transaction already do.
+func (p *PreloadTempTable) pinConnection(db *gorm.DB) bool {
+	stmt := db.Statement
+	if _, ok := stmt.ConnPool.(gorm.TxCommitter); ok {
+		return true
+	}
+	sqlDB, ok := stmt.ConnPool.(*sql.DB)
+	if !ok {
+		return false
+	}
+
+	conn, err := sqlDB.Conn(stmt.Context)
+	if db.AddError(err) != nil {
+		return false
+	}
+	db.InstanceSet(preloadConnSetting, pinnedConn{Conn: conn, pool: stmt.ConnPool})
+	stmt.ConnPool = conn
+	return true
+}
+
+// arrayBindValues converts the values of an IN condition to a slice that
+// godror binds as an array, and returns the column of the temporary table
+// that holds values of their type. NULL values are dropped, as they never
+// match. It fails for values of mixed or unsupported types.
+func arrayBindValues(values []interface{}) (string, interface{}, bool) {
+	var (
+		kind   string
+		floats []float64
+		ints   []int64
+		strs   []string
+		times  []time.Time
+		raws   [][]byte
+	)
+
+	// setKind records the kind of the values, and fails when they are mixed
+	setKind := func(k string) bool {
+		if kind == "" {
+			kind = k
+		}
+		return kind == k
+	}
+
+	for _, value := range values {
+		if valuer, ok := value.(driver.Valuer); ok {
+			v, err := valuer.Value()
+			if err != nil {
+				return "", nil, false
+			}
+			value = v
+		}
+
+		rv := reflect.ValueOf(value)
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		if !rv.IsValid() || rv.Kind() == reflect.Ptr {
+			continue
+		}
+
+		switch v := rv.Interface().(type) {
+		case time.Time:
+			if !setKind("time") {
+				return "", nil, false
+			}
+			times = append(times, v)
+		case []byte:
+			if !setKind("raw") {
+				return "", nil, false
+			}
+			raws = append(raws, v)
+		default:
+			switch rv.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				if !setKind("int") {
+					return "", nil, false
+				}
+				ints = append(ints, rv.Int())
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				if !setKind("int") || rv.Uint() > 1<<63-1 {
+					return "", nil, false
+				}
+				ints = append(ints, int64(rv.Uint()))
+			case reflect.Float32, reflect.Float64:
+				if !setKind("float") {
+					return "", nil, false
+				}
+				floats = append(floats, rv.Float())
+			case reflect.String:
+				if !setKind("string") {
+					return "", nil, false
+				}
+				strs = append(strs, rv.String())
+			default:
+				return "", nil, false
+			}
+		}
+	}
+
+	switch kind {
+	case "int":
+		return "num_value", ints, true
+	case "float":
+		return "num_value", floats, true
+	case "string":
+		return "str_value", strs, true
+	case "time":
+		return "time_value", times, true
+	case "raw":
+		return "raw_value", raws, true
+	}
+	return "", nil, false
+}
@@ -39,11 +39,15 @@
 package oracle
 
 import (
+	"database/sql"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/godror/godror"
 	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 	"gorm.io/gorm/utils"
@@ -54,6 +58,117 @@ import (
 // the other formats like "users u", "users AS u" etc.
 var tableRegexp = regexp.MustCompile(`^"(\w+)"\s+"?(\w+)"?$`)
 
+// Query executes the query and scans the result like GORM's default query
+// callback, additionally reading the total count of a TotalCount clause,
+// retrying the query on transient errors and logging the progress of long
+// queries
+func Query(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	callbacks.BuildQuerySQL(db)
+	if db.DryRun || db.Error != nil {
+		return
+	}
+
+	stop := watchLongOps(db)
+	defer stop()
+	rows, err := queryContext(db)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	defer func() {
+		db.AddError(rows.Close())
+	}()
+
+	var result gorm.Rows = rows
+	if t, ok := totalCount(db.Statement); ok {
+		*t.Dest = 0
+		result = totalCountRows{Rows: rows, dest: t.Dest}
+	}
+
+	columns, _ := result.Columns()
+	columnTypes, _ := result.ColumnTypes()
+	if driverFeatures(db).LOBStreaming {
+		result = newLobRows(result, columnTypes)
+	}
+	if driverFeatures(db).NativeJSON {
+		result = newJSONRows(result, columnTypes)
+	}
+	gorm.Scan(result, db, 0)
+	convertMapNumbers(db.Statement, columns, columnTypes)
+
+	if db.Statement.Result != nil {
+		db.Statement.Result.RowsAffected = db.RowsAffected
+	}
+}
+
+// convertMapNumbers converts the NUMBER values scanned into map destinations
+// to int64 when the column has no fractional digits and the value fits.
+// The driver returns NUMBER values without a native integer type as strings,
+// which are kept for decimals and for integers beyond the int64 range so
+// that no precision is lost.
+func convertMapNumbers(stmt *gorm.Statement, columns []string, columnTypes []*sql.ColumnType) {
+	if len(columnTypes) != len(columns) {
+		return
+	}
+
+	var numberColumns []string
+	for idx, columnType := range columnTypes {
+		if columnType.DatabaseTypeName() != "NUMBER" {
+			continue
+		}
+		if _, scale, ok := columnType.DecimalSize(); ok && scale > 0 {
+			continue
+		}
+		column := columns[idx]
+		if mapped, ok := stmt.ColumnMapping[column]; ok {
+			column = mapped
+		}
+		numberColumns = append(numberColumns, column)
+	}
+	if len(numberColumns) == 0 {
+		return
+	}
+
+	convert := func(mapValue map[string]interface{}) {
+		for _, column := range numberColumns {
+			if n, ok := numberInt64(mapValue[column]); ok {
+				mapValue[column] = n
+			}
+		}
+	}
+
+	switch dest := stmt.Dest.(type) {
+	case map[string]interface{}:
+		convert(dest)
+	case *map[string]interface{}:
+		convert(*dest)
+	case *[]map[string]interface{}:
+		for _, mapValue := range *dest {
+			convert(mapValue)
+		}
+	}
+}
+
+// numberInt64 returns a NUMBER value returned by the driver as a string
+// as an int64, if it is an integer in the int64 range
+func numberInt64(value interface{}) (int64, bool) {
+	var number string
+	switch v := value.(type) {
+	case string:
+		number = v
+	case godror.Number:
+		number = string(v)
+	default:
+		return 0, false
+	}
+	n, err := strconv.ParseInt(number, 10, 64)
+	return n, err == nil
+}
+
 func BeforeQuery(db *gorm.DB) {
 	if db == nil || db.Statement == nil || db.Statement.TableExpr == nil {
 		return
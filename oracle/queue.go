@@ -0,0 +1,143 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Dequeue takes up to batchSize rows of a table used as a job queue, in a
+// transaction: it locks rows that no other consumer has locked with FOR
+// UPDATE SKIP LOCKED, scans them into dest, a pointer to a slice of models,
+// calls handler and deletes the rows. The conditions and order of db select
+// the rows. When handler fails, the transaction is rolled back and the rows
+// are left for the next consumer.
+//
+//	for {
+//		var jobs []Job
+//		n, err := oracle.Dequeue(db.Where(`"queue" = ?`, "mail").Order(`"id"`), &jobs, 10, func(tx *gorm.DB) error {
+//			return sendMails(tx, jobs)
+//		})
+//		if err != nil || n == 0 {
+//			break
+//		}
+//	}
+//
+// Oracle locks the rows of a SKIP LOCKED query as they are fetched, so the
+// rows are read from a cursor rather than with a row limit, which would be
+// applied before locked rows are skipped. With the FetchOptions driver
+// feature, the cursor fetches no more rows than it takes. Models with a
+// soft-delete field are soft deleted. It returns the number of rows taken.
+func Dequeue(db *gorm.DB, dest interface{}, batchSize int, handler func(tx *gorm.DB) error) (int, error) {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Slice {
+		return 0, errors.New("dequeue destination must be a pointer to a slice")
+	}
+	if batchSize <= 0 {
+		return 0, fmt.Errorf("invalid dequeue batch size %d", batchSize)
+	}
+
+	slice := destValue.Elem()
+	elemType := slice.Type().Elem()
+	taken := 0
+	err := db.Transaction(func(tx *gorm.DB) error {
+		slice.Set(reflect.MakeSlice(slice.Type(), 0, batchSize))
+		taken = 0
+
+		query := tx.Session(&gorm.Session{DryRun: true}).Clauses(clause.Locking{
+			Strength: clause.LockingStrengthUpdate,
+			Options:  clause.LockingOptionsSkipLocked,
+		}).Find(dest)
+		if query.Error != nil {
+			return query.Error
+		}
+
+		args := query.Statement.Vars
+		if driverFeatures(tx).FetchOptions {
+			args = append(slices.Clone(args), godror.PrefetchCount(batchSize), godror.FetchArraySize(batchSize))
+		}
+		rows, err := tx.Statement.ConnPool.QueryContext(tx.Statement.Context, query.Statement.SQL.String(), args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		// Statements of the handler do not inherit the conditions of db
+		session := tx.Session(&gorm.Session{NewDB: true})
+		for taken < batchSize && rows.Next() {
+			elem := reflect.New(elemType)
+			if elemType.Kind() == reflect.Ptr {
+				elem = reflect.New(elemType.Elem())
+			}
+			if err := session.ScanRows(rows, elem.Interface()); err != nil {
+				return err
+			}
+			if elemType.Kind() == reflect.Ptr {
+				slice.Set(reflect.Append(slice, elem))
+			} else {
+				slice.Set(reflect.Append(slice, elem.Elem()))
+			}
+			taken++
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		if taken == 0 {
+			return nil
+		}
+
+		if err := handler(session); err != nil {
+			return err
+		}
+		return session.Delete(dest).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return taken, nil
+}
@@ -0,0 +1,260 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// sqlKeywords are the words of simple conditions and ORDER BY expressions
+// that are never quoted, even when the model has a column with that name
+var sqlKeywords = map[string]bool{
+	"AND": true, "OR": true, "NOT": true, "IN": true, "IS": true, "NULL": true,
+	"LIKE": true, "BETWEEN": true, "ESCAPE": true, "EXISTS": true,
+	"ASC": true, "DESC": true, "NULLS": true, "FIRST": true, "LAST": true,
+	"CASE": true, "WHEN": true, "THEN": true, "ELSE": true, "END": true,
+	"TRUE": true, "FALSE": true, "SELECT": true, "FROM": true, "WHERE": true,
+}
+
+// ColumnQuotingHandler quotes the bare column names of the model in the
+// string conditions of the WHERE and HAVING clauses and in the SELECT, ORDER
+// BY and GROUP BY expressions, so that Where("name = ?") refers
+// to the "name" column GORM creates. A name is quoted when it is the column
+// or field name of a field of the model, outside literals, quoted
+// identifiers and comments, and is not a function call, a bind placeholder
+// or a keyword. A qualifier that is the table of the statement is quoted
+// too. It is registered for queries, updates and deletes when
+// Config.QuoteColumns is set.
+func ColumnQuotingHandler(db *gorm.DB) {
+	if db.Error != nil || db.Statement == nil || db.Statement.Schema == nil {
+		return
+	}
+	stmt := db.Statement
+
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			c.Expression = clause.Where{Exprs: quoteColumnExprs(stmt, where.Exprs)}
+			stmt.Clauses["WHERE"] = c
+		}
+	}
+
+	if len(stmt.Selects) > 0 {
+		// Plain field names are quoted by GORM
+		selects := make([]string, len(stmt.Selects))
+		for i, sel := range stmt.Selects {
+			if stmt.Schema.LookUpField(sel) == nil {
+				sel = quoteColumns(stmt, sel)
+			}
+			selects[i] = sel
+		}
+		stmt.Selects = selects
+	}
+	if c, ok := stmt.Clauses["SELECT"]; ok {
+		if sel, ok := c.Expression.(clause.Select); ok && sel.Expression != nil {
+			sel.Expression = quoteColumnExpr(stmt, sel.Expression)
+			c.Expression = sel
+			stmt.Clauses["SELECT"] = c
+		}
+	}
+
+	if c, ok := stmt.Clauses["ORDER BY"]; ok {
+		if orderBy, ok := c.Expression.(clause.OrderBy); ok {
+			columns := make([]clause.OrderByColumn, len(orderBy.Columns))
+			for i, column := range orderBy.Columns {
+				if column.Column.Raw {
+					column.Column.Name = quoteColumns(stmt, column.Column.Name)
+				}
+				columns[i] = column
+			}
+			orderBy.Columns = columns
+			if orderBy.Expression != nil {
+				orderBy.Expression = quoteColumnExpr(stmt, orderBy.Expression)
+			}
+			c.Expression = orderBy
+			stmt.Clauses["ORDER BY"] = c
+		}
+	}
+
+	if c, ok := stmt.Clauses["GROUP BY"]; ok {
+		if groupBy, ok := c.Expression.(clause.GroupBy); ok {
+			columns := make([]clause.Column, len(groupBy.Columns))
+			for i, column := range groupBy.Columns {
+				if column.Raw {
+					column.Name = quoteColumns(stmt, column.Name)
+				}
+				columns[i] = column
+			}
+			groupBy.Columns = columns
+			groupBy.Having = quoteColumnExprs(stmt, groupBy.Having)
+			c.Expression = groupBy
+			stmt.Clauses["GROUP BY"] = c
+		}
+	}
+}
+
+func quoteColumnExprs(stmt *gorm.Statement, exprs []clause.Expression) []clause.Expression {
+	if len(exprs) == 0 {
+		return exprs
+	}
+	result := make([]clause.Expression, len(exprs))
+	for i, expr := range exprs {
+		result[i] = quoteColumnExpr(stmt, expr)
+	}
+	return result
+}
+
+func quoteColumnExpr(stmt *gorm.Statement, expr clause.Expression) clause.Expression {
+	switch e := expr.(type) {
+	case clause.Expr:
+		e.SQL = quoteColumns(stmt, e.SQL)
+		return e
+	case SQLExpr:
+		e.SQL = quoteColumns(stmt, e.SQL)
+		return e
+	case clause.NamedExpr:
+		e.SQL = quoteColumns(stmt, e.SQL)
+		return e
+	case clause.AndConditions:
+		e.Exprs = quoteColumnExprs(stmt, e.Exprs)
+		return e
+	case clause.OrConditions:
+		e.Exprs = quoteColumnExprs(stmt, e.Exprs)
+		return e
+	case clause.NotConditions:
+		e.Exprs = quoteColumnExprs(stmt, e.Exprs)
+		return e
+	}
+	return expr
+}
+
+// quoteColumns quotes the bare column names of the model of the statement
+// in the SQL
+func quoteColumns(stmt *gorm.Statement, sql string) string {
+	var builder strings.Builder
+	for i := 0; i < len(sql); {
+		if next := skipSQLLiteral(sql, i); next > i {
+			builder.WriteString(sql[i:next])
+			i = next
+			continue
+		}
+
+		c := sql[i]
+		if !isIdentifierChar(c) || (c >= '0' && c <= '9') || c == '$' || c == '#' {
+			builder.WriteByte(c)
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(sql) && isIdentifierChar(sql[j]) {
+			j++
+		}
+		word := sql[i:j]
+		builder.WriteString(quoteColumnWord(stmt, sql, i, j, word))
+		i = j
+	}
+	return builder.String()
+}
+
+// quoteColumnWord returns the word of the SQL between start and end, quoted
+// when it is a column of the model or the table qualifying one
+func quoteColumnWord(stmt *gorm.Statement, sql string, start, end int, word string) string {
+	if start > 0 {
+		switch prev := sql[start-1]; {
+		case prev == ':' || prev == '@':
+			// Named bind placeholder
+			return word
+		case isIdentifierChar(prev):
+			// The rest of a number, as in 1e5
+			return word
+		}
+	}
+	if sqlKeywords[strings.ToUpper(word)] {
+		return word
+	}
+
+	next := nextNonSpace(sql, end)
+	if next < len(sql) && sql[next] == '(' {
+		// Function call
+		return word
+	}
+
+	qualified := start > 0 && sql[start-1] == '.'
+	if end < len(sql) && sql[end] == '.' {
+		// Qualifier of a column
+		if !qualified && word == stmt.Table {
+			return QuoteIdentifier(word)
+		}
+		return word
+	}
+	if qualified && !isQualifiedByTable(stmt, sql[:start-1]) {
+		// Column of another table
+		return word
+	}
+
+	if field := stmt.Schema.LookUpField(word); field != nil && field.DBName != "" {
+		return QuoteIdentifier(field.DBName)
+	}
+	return word
+}
+
+// isQualifiedByTable reports whether the SQL before a column ends with the
+// table of the statement, either quoted or as an unquoted name that Oracle
+// resolves to it
+func isQualifiedByTable(stmt *gorm.Statement, before string) bool {
+	if strings.HasSuffix(before, `"`) {
+		return strings.HasSuffix(before, QuoteIdentifier(stmt.Table))
+	}
+	start := len(before)
+	for start > 0 && isIdentifierChar(before[start-1]) {
+		start--
+	}
+	qualifier := before[start:]
+	return qualifier == stmt.Table || strings.ToUpper(qualifier) == stmt.Table
+}
+
+func nextNonSpace(sql string, i int) int {
+	for i < len(sql) && (sql[i] == ' ' || sql[i] == '\t' || sql[i] == '\n' || sql[i] == '\r') {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,268 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+)
+
+// DefaultRetryCodes are the ORA codes of the transient errors retried by a
+// RetryPolicy without Codes. They are raised while a database or service is
+// starting, stopping or failing over to another instance.
+var DefaultRetryCodes = []int{
+	1033,  // ORACLE initialization or shutdown in progress
+	1034,  // ORACLE not available
+	1089,  // immediate shutdown or close in progress
+	3113,  // end-of-file on communication channel
+	3114,  // not connected to ORACLE
+	3135,  // connection lost contact
+	12514, // listener does not currently know of service
+	12521, // listener does not currently know of instance
+	12528, // all appropriate instances are blocking new connections
+	12537, // TNS:connection closed
+	12541, // TNS:no listener
+}
+
+// DefaultTransactionRetryCodes are the ORA codes of the errors for which
+// RetryTransaction reruns a transaction by default: deadlocks (ORA-00060)
+// and lock wait timeouts (ORA-30006).
+var DefaultTransactionRetryCodes = []int{60, 30006}
+
+// RetryPolicy retries connecting to the database, and queries outside
+// transactions, when they fail with a transient error. Writes are never
+// retried, since they may have been applied before the error was raised.
+//
+//	db, err := gorm.Open(oracle.New(oracle.Config{
+//		DataSourceName: dsn,
+//		RetryPolicy:    &oracle.RetryPolicy{MaxAttempts: 5},
+//	}), &gorm.Config{})
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts, including the first one.
+	// The default is 3.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, doubled for each
+	// following retry. The default is 100ms.
+	InitialBackoff time.Duration
+	// MaxBackoff limits the delay between retries. The default is 2s.
+	MaxBackoff time.Duration
+	// Codes are the ORA codes of the errors to retry. The default is
+	// DefaultRetryCodes.
+	Codes []int
+}
+
+// Retryable reports whether the error is one of the transient errors of the
+// policy, or a FailoverError of work that can be run again
+func (p *RetryPolicy) Retryable(err error) bool {
+	var failoverErr *FailoverError
+	if errors.As(err, &failoverErr) {
+		return failoverErr.Retryable
+	}
+	code := ErrorCode(err)
+	if code == 0 {
+		return false
+	}
+	codes := p.Codes
+	if len(codes) == 0 {
+		codes = DefaultRetryCodes
+	}
+	return slices.Contains(codes, code)
+}
+
+// backoff returns the delay before the given retry, starting at 1
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	delay, maxDelay := p.InitialBackoff, p.MaxBackoff
+	if delay <= 0 {
+		delay = 100 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = 2 * time.Second
+	}
+	for i := 1; i < retry && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// Do calls fn until it succeeds, fails with an error that is not retryable,
+// runs out of attempts or the context is done
+func (p *RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	attempts := p.MaxAttempts
+	if attempts <= 0 {
+		attempts = 3
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || attempt >= attempts || !p.Retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(p.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// RetryTransaction runs fc in a transaction, and runs the whole transaction
+// again when it fails with a deadlock or lock wait timeout, as Oracle only
+// rolls back the statement that failed. The policy may be nil, and its
+// Codes default to DefaultTransactionRetryCodes. With the Failover option,
+// a transaction whose session failed over before it committed is run again
+// too, and a commit interrupted by a failover fails with a FailoverError
+// that is not Retryable.
+//
+//	err := oracle.RetryTransaction(db, &oracle.RetryPolicy{MaxAttempts: 5}, func(tx *gorm.DB) error {
+//		if err := tx.Model(&from).Update("balance", gorm.Expr("balance - ?", 100)).Error; err != nil {
+//			return err
+//		}
+//		return tx.Model(&to).Update("balance", gorm.Expr("balance + ?", 100)).Error
+//	})
+func RetryTransaction(db *gorm.DB, policy *RetryPolicy, fc func(tx *gorm.DB) error, opts ...*sql.TxOptions) error {
+	retry := RetryPolicy{}
+	if policy != nil {
+		retry = *policy
+	}
+	if len(retry.Codes) == 0 {
+		retry.Codes = DefaultTransactionRetryCodes
+	}
+
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	attempt := 0
+	return retry.Do(ctx, func() error {
+		if attempt++; attempt > 1 {
+			db.Logger.Warn(ctx, "retrying transaction after lock error, attempt %d", attempt)
+		}
+		err := db.Transaction(fc, opts...)
+		if failoverErr := transactionFailover(db, err); failoverErr != nil {
+			return failoverErr
+		}
+		return err
+	})
+}
+
+// retryPolicy returns the retry policy of the dialector of the database
+func retryPolicy(db *gorm.DB) *RetryPolicy {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		if d.Config != nil {
+			return d.RetryPolicy
+		}
+	case Dialector:
+		if d.Config != nil {
+			return d.RetryPolicy
+		}
+	}
+	return nil
+}
+
+// queryContext runs the query of the statement, retrying it on transient
+// errors when it does not run in a transaction. The rows of a PL/SQL block
+// are those of its first implicit result.
+func queryContext(db *gorm.DB) (*sql.Rows, error) {
+	rows, err := retryQueryContext(db)
+	if err != nil || !driverFeatures(db).ImplicitResults {
+		return rows, err
+	}
+	return implicitResult(db.Statement.SQL.String(), rows)
+}
+
+// retryQueryContext runs the query of the statement, retrying it on
+// transient errors when it does not run in a transaction
+func retryQueryContext(db *gorm.DB) (*sql.Rows, error) {
+	stmt := db.Statement
+	query := func() (rows *sql.Rows, err error) {
+		return stmt.ConnPool.QueryContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+	}
+
+	policy := retryPolicy(db)
+	if _, inTransaction := stmt.ConnPool.(gorm.TxCommitter); policy == nil || inTransaction {
+		return query()
+	}
+
+	var rows *sql.Rows
+	attempt := 0
+	err := policy.Do(stmt.Context, func() (err error) {
+		if attempt++; attempt > 1 {
+			db.Logger.Warn(stmt.Context, "retrying query after transient error, attempt %d", attempt)
+		}
+		rows, err = query()
+		return err
+	})
+	return rows, err
+}
+
+// RowQuery runs the query of Row and Rows like GORM's default row callback,
+// retrying the query of Rows on transient errors and converting its rows
+// like those scanned by the Query callback
+func RowQuery(db *gorm.DB) {
+	if isRows, ok := db.Get("rows"); !ok || !isRows.(bool) || db.Error != nil {
+		callbacks.RowQuery(db)
+		return
+	}
+
+	callbacks.BuildQuerySQL(db)
+	if db.DryRun || db.Error != nil {
+		return
+	}
+
+	db.Statement.Settings.Delete("rows")
+	stop := watchLongOps(db)
+	rows, err := queryContext(db)
+	if err != nil {
+		stop()
+	} else {
+		rows, err = convertRows(db, rows, stop)
+	}
+	db.Statement.Dest, db.Error = rows, err
+	db.RowsAffected = -1
+}
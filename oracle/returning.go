@@ -0,0 +1,235 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const ClauseReturningValues = "RETURNING_VALUES"
+
+// ReturningValues receives the values of RETURNING entries that do not map to
+// a field of the model. Entries of clause.Returning may be expressions, given
+// as raw columns whose Alias names the value:
+//
+//	values := oracle.ReturningValues{}
+//	db.Clauses(clause.Returning{Columns: []clause.Column{
+//		{Name: "id"},
+//		{Name: "ROWID", Raw: true, Alias: "row_id"},
+//		{Name: `SYS_EXTRACT_UTC("created_at")`, Raw: true, Alias: "created_utc"},
+//	}}, values).Create(&user)
+//	// values["row_id"], values["created_utc"]
+//
+// An alias that names a column of the model, such as one of a read-only
+// field tagged `->`, sets that field instead. Values without a field are
+// returned as strings.
+type ReturningValues map[string]interface{}
+
+// Name returns the name of the clause
+func (ReturningValues) Name() string {
+	return ClauseReturningValues
+}
+
+// Build writes nothing, the clause only collects the returned values
+func (ReturningValues) Build(clause.Builder) {}
+
+// MergeClause replaces any previous RETURNING_VALUES clause
+func (values ReturningValues) MergeClause(c *clause.Clause) {
+	c.Expression = values
+}
+
+// returningValues returns the ReturningValues clause of the statement
+func returningValues(stmt *gorm.Statement) ReturningValues {
+	if c, ok := stmt.Clauses[ClauseReturningValues]; ok {
+		if values, ok := c.Expression.(ReturningValues); ok {
+			return values
+		}
+	}
+	return nil
+}
+
+// returningKey returns the name a RETURNING entry is mapped by: the alias of
+// an expression, or the column name
+func returningKey(column clause.Column) string {
+	if column.Alias != "" {
+		return column.Alias
+	}
+	return column.Name
+}
+
+// plsqlReturningColumns returns the columns that the PL/SQL blocks of bulk
+// creates, updates and deletes return for each row, out of the given table
+// columns. These are the columns of the RETURNING clause, or all of them
+// when the clause lists none. Without a RETURNING clause, only the fields
+// with database defaults are returned. Each returned column costs an OUT
+// bind per row, so a batch returning just the primary key binds far fewer
+// variables than one returning every column.
+func plsqlReturningColumns(stmt *gorm.Statement, columns []string) []string {
+	wanted := map[string]bool{}
+	if c, ok := stmt.Clauses["RETURNING"]; ok {
+		returning, _ := c.Expression.(clause.Returning)
+		if len(returning.Columns) == 0 {
+			return columns
+		}
+		for _, column := range returning.Columns {
+			if column.Raw {
+				continue
+			}
+			wanted[column.Name] = true
+			if field := stmt.Schema.LookUpField(column.Name); field != nil {
+				wanted[field.DBName] = true
+			}
+		}
+	} else {
+		for _, field := range returnedDefaultFields(stmt.Schema) {
+			wanted[field.DBName] = true
+		}
+	}
+
+	selected := make([]string, 0, len(wanted))
+	for _, column := range columns {
+		if wanted[column] {
+			selected = append(selected, column)
+		}
+	}
+	if len(selected) == 0 {
+		// The block needs a RETURNING list to collect the affected rows
+		return columns
+	}
+	return selected
+}
+
+// returnedDefaultFields returns the fields with database defaults whose
+// values inserts return, leaving out fields that are not readable, as GORM
+// does
+func returnedDefaultFields(sch *schema.Schema) []*schema.Field {
+	if sch == nil {
+		return nil
+	}
+	fields := make([]*schema.Field, 0, len(sch.FieldsWithDefaultDBValue))
+	for _, field := range sch.FieldsWithDefaultDBValue {
+		if field.Readable && !isLongField(field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// returningAlwaysFields returns the fields tagged `returning-always`, whose
+// values are returned by every insert and update of the model, such as
+// columns that BEFORE triggers modify:
+//
+//	type User struct {
+//		ID    uint
+//		Email string `gorm:"returning-always"` // lower-cased by a trigger
+//	}
+func returningAlwaysFields(sch *schema.Schema) []*schema.Field {
+	if sch == nil {
+		return nil
+	}
+	var fields []*schema.Field
+	for _, field := range sch.Fields {
+		if _, ok := field.TagSettings["RETURNING-ALWAYS"]; ok && field.DBName != "" && field.Readable {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// addReturningAlways adds the columns of the fields tagged returning-always
+// to the RETURNING clause of the statement, adding the clause if it has
+// none. It reports whether the statement returns any of them.
+func addReturningAlways(stmt *gorm.Statement) bool {
+	fields := returningAlwaysFields(stmt.Schema)
+	if len(fields) == 0 {
+		return false
+	}
+
+	var returning clause.Returning
+	if c, ok := stmt.Clauses["RETURNING"]; ok {
+		returning, _ = c.Expression.(clause.Returning)
+		if len(returning.Columns) == 0 {
+			// Every column is returned
+			return true
+		}
+	}
+	listed := map[string]bool{}
+	for _, column := range returning.Columns {
+		if !column.Raw {
+			listed[column.Name] = true
+		}
+	}
+	columns := append([]clause.Column(nil), returning.Columns...)
+	for _, field := range fields {
+		if !listed[field.DBName] && !listed[field.Name] {
+			columns = append(columns, clause.Column{Name: field.DBName})
+		}
+	}
+	stmt.Clauses["RETURNING"] = clause.Clause{Name: "RETURNING", Expression: clause.Returning{Columns: columns}}
+	return true
+}
+
+// updatesModel reports whether an update changes the row of a single struct
+// with its primary key set, the model or the destination of the statement,
+// which the values it returns are set into
+func updatesModel(stmt *gorm.Statement) bool {
+	if stmt.Schema == nil || !stmt.ReflectValue.IsValid() {
+		return false
+	}
+	rv := stmt.ReflectValue
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || !rv.CanAddr() || rv.Type() != stmt.Schema.ModelType || len(stmt.Schema.PrimaryFields) == 0 {
+		return false
+	}
+	for _, field := range stmt.Schema.PrimaryFields {
+		if _, zero := field.ValueOf(stmt.Context, rv); zero {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// isRowIDField reports whether the field maps the ROWID pseudo-column, as
+// declared with `gorm:"column:rowid;->"`
+func isRowIDField(field *schema.Field) bool {
+	return strings.EqualFold(field.DBName, "rowid")
+}
+
+// rowIDField returns the field of the schema that maps the ROWID
+// pseudo-column, if any
+func rowIDField(sch *schema.Schema) *schema.Field {
+	if sch == nil {
+		return nil
+	}
+	for _, field := range sch.Fields {
+		if isRowIDField(field) {
+			return field
+		}
+	}
+	return nil
+}
+
+// rowIDCondition matches the row with the given ROWID
+type rowIDCondition struct {
+	rowID interface{}
+}
+
+// Build writes the ROWID comparison
+func (c rowIDCondition) Build(builder clause.Builder) {
+	builder.WriteQuoted(clause.Table{Name: clause.CurrentTable})
+	builder.WriteString(".ROWID = CHARTOROWID(")
+	builder.AddVar(builder, c.rowID)
+	builder.WriteByte(')')
+}
+
+// addRowIDWhereClause narrows the update or delete of a single model that
+// was loaded with its ROWID to that row. Oracle then reads the row by its
+// address instead of through the primary key index, and the primary key
+// conditions still guard against a ROWID reused by another row.
+func addRowIDWhereClause(stmt *gorm.Statement) {
+	field := rowIDField(stmt.Schema)
+	if field == nil {
+		return
+	}
+
+	value := stmt.ReflectValue
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	rowID, isZero := field.ValueOf(stmt.Context, value)
+	if isZero {
+		return
+	}
+
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			for _, expr := range where.Exprs {
+				if _, ok := expr.(rowIDCondition); ok {
+					return
+				}
+			}
+		}
+	}
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{rowIDCondition{rowID: rowID}}})
+}
+
+// selectRowID selects the ROWID pseudo-column for the fields that map it:
+// a query without selected columns selects every column and the ROWID, and
+// a selected ROWID column, for instance of a joined table, is read from the
+// pseudo-column
+func selectRowID(c clause.Clause, stmt *gorm.Statement) clause.Clause {
+	sel, ok := c.Expression.(clause.Select)
+	if !ok {
+		return c
+	}
+
+	if len(sel.Columns) == 0 && !sel.Distinct && c.AfterNameExpression == nil {
+		if field := rowIDField(stmt.Schema); field != nil {
+			c.Expression = clause.Expr{
+				SQL: "?.*,ROWIDTOCHAR(?.ROWID) AS ?",
+				Vars: []interface{}{
+					clause.Table{Name: clause.CurrentTable},
+					clause.Table{Name: clause.CurrentTable},
+					clause.Column{Name: field.DBName},
+				},
+			}
+		}
+		return c
+	}
+
+	var columns []clause.Column
+	for idx, column := range sel.Columns {
+		if column.Raw || !strings.EqualFold(column.Name, "rowid") {
+			continue
+		}
+		if columns == nil {
+			columns = append([]clause.Column(nil), sel.Columns...)
+		}
+
+		alias := column.Alias
+		if alias == "" {
+			alias = column.Name
+		}
+		table := column.Table
+		if table == "" {
+			table = clause.CurrentTable
+		}
+		columns[idx] = clause.Column{
+			Name: "ROWIDTOCHAR(" + stmt.Quote(clause.Table{Name: table}) + ".ROWID) AS " + stmt.Quote(alias),
+			Raw:  true,
+		}
+	}
+	if columns != nil {
+		sel.Columns = columns
+		c.Expression = sel
+	}
+	return c
+}
+
+// limitedRows returns the LIMIT clause of a statement when it limits the
+// number of rows or skips some of them
+func limitedRows(stmt *gorm.Statement) (clause.Limit, bool) {
+	c, ok := stmt.Clauses["LIMIT"]
+	if !ok {
+		return clause.Limit{}, false
+	}
+	limit, ok := c.Expression.(clause.Limit)
+	return limit, ok && (limit.Limit != nil && *limit.Limit >= 0 || limit.Offset > 0)
+}
+
+// writeLimitedRowIDs writes the condition of an UPDATE or DELETE with a
+// LIMIT. Oracle has no LIMIT on DML, so the statement is narrowed to the
+// ROWIDs of the rows a query with the conditions, the ORDER BY clause and
+// the FETCH clause returns. Without an ORDER BY clause the rows are taken
+// in the order of the primary key.
+func writeLimitedRowIDs(builder clause.Builder, stmt *gorm.Statement, limit clause.Limit, writeConditions func()) {
+	builder.WriteString("ROWID IN (SELECT ROWID FROM ")
+	builder.WriteQuoted(stmt.Table)
+	if writeConditions != nil {
+		builder.WriteString(" WHERE ")
+		writeConditions()
+	}
+	builder.WriteByte(' ')
+	if c, ok := stmt.Clauses["ORDER BY"]; ok {
+		OrderByClauseBuilder(c, builder)
+		builder.WriteByte(' ')
+	}
+	buildOracleFetchLimit(limit, builder, stmt)
+	builder.WriteByte(')')
+}
@@ -0,0 +1,255 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+)
+
+// convertedRowsDB returns the in-memory pool serving convertedRows. The
+// rows of Rows and Raw().Scan are read by the caller through database/sql
+// once the callbacks have returned, so unlike those scanned by the Query
+// callback they cannot be wrapped in a gorm.Rows. They are read by a
+// driver.Rows instead, for which this pool returns a *sql.Rows.
+var convertedRowsDB = sync.OnceValue(func() *sql.DB {
+	return sql.OpenDB(convertedRowsConnector{})
+})
+
+type convertedRowsConnector struct{}
+
+func (convertedRowsConnector) Connect(context.Context) (driver.Conn, error) {
+	return convertedRowsConn{}, nil
+}
+
+func (convertedRowsConnector) Driver() driver.Driver { return convertedRowsDriver{} }
+
+type convertedRowsDriver struct{}
+
+func (convertedRowsDriver) Open(string) (driver.Conn, error) { return convertedRowsConn{}, nil }
+
+// convertedRowsConn returns the convertedRows passed as the only argument
+// of a query
+type convertedRowsConn struct{}
+
+var errConvertedRowsConn = errors.New("convertedRowsConn only returns converted rows")
+
+func (convertedRowsConn) Prepare(string) (driver.Stmt, error) { return nil, errConvertedRowsConn }
+func (convertedRowsConn) Close() error                        { return nil }
+func (convertedRowsConn) Begin() (driver.Tx, error)           { return nil, errConvertedRowsConn }
+
+func (convertedRowsConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if _, ok := nv.Value.(*convertedRows); !ok {
+		return errConvertedRowsConn
+	}
+	return nil
+}
+
+func (convertedRowsConn) QueryContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Rows, error) {
+	if len(args) != 1 {
+		return nil, errConvertedRowsConn
+	}
+	return args[0].Value.(*convertedRows), nil
+}
+
+// convertedRows reads the rows of the driver like the Query callback scans
+// them: NUMBER columns without fractional digits are reported with the scan
+// type integerNumber, so that map destinations receive int64 values, and
+// the LOB readers returned for godror.LobAsReader() are read into strings
+// and byte slices. onClose stops watching the progress of the query, see
+// LongOpsWatcher, once the rows are closed.
+type convertedRows struct {
+	rows          *sql.Rows
+	columns       []string
+	columnTypes   []*sql.ColumnType
+	numberColumns []bool
+	lobColumns    []bool
+	values        []interface{}
+	scanDest      []interface{}
+	onClose       func()
+}
+
+// convertRows returns rows converted for the scan destinations of Rows and
+// Raw().Scan, or the rows themselves when no column needs converting and
+// the query is not watched. onClose is called once the rows are closed.
+func convertRows(db *gorm.DB, rows *sql.Rows, onClose func()) (*sql.Rows, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		onClose()
+		return rows, nil
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil || len(columnTypes) != len(columns) {
+		onClose()
+		return rows, nil
+	}
+
+	converted := &convertedRows{
+		rows:          rows,
+		columns:       columns,
+		columnTypes:   columnTypes,
+		numberColumns: make([]bool, len(columns)),
+		lobColumns:    make([]bool, len(columns)),
+		values:        make([]interface{}, len(columns)),
+		scanDest:      make([]interface{}, len(columns)),
+		onClose:       onClose,
+	}
+	// The rows are read once the callback has returned, so a long query is
+	// watched until they are closed
+	convert := longOpsWatcher(db) != nil
+	lobStreaming := driverFeatures(db).LOBStreaming
+	for idx, columnType := range columnTypes {
+		if isIntegerNumberColumn(columnType) {
+			converted.numberColumns[idx] = true
+			convert = true
+		}
+		if lobStreaming && isLobType(columnType.DatabaseTypeName()) {
+			converted.lobColumns[idx] = true
+			convert = true
+		}
+		converted.scanDest[idx] = &converted.values[idx]
+	}
+	if !convert {
+		onClose()
+		return rows, nil
+	}
+
+	result, err := convertedRowsDB().QueryContext(context.Background(), "", converted)
+	if err != nil {
+		converted.Close()
+		return nil, err
+	}
+	return result, nil
+}
+
+// isIntegerNumberColumn reports whether the column is a NUMBER without
+// fractional digits that the driver returns as a string
+func isIntegerNumberColumn(columnType *sql.ColumnType) bool {
+	if columnType.DatabaseTypeName() != "NUMBER" {
+		return false
+	}
+	if _, scale, ok := columnType.DecimalSize(); ok && scale > 0 {
+		return false
+	}
+	return columnType.ScanType() == reflect.TypeOf(godror.Number(""))
+}
+
+func (r *convertedRows) Columns() []string { return r.columns }
+
+func (r *convertedRows) Close() error {
+	err := r.rows.Close()
+	if r.onClose != nil {
+		r.onClose()
+		r.onClose = nil
+	}
+	return err
+}
+
+func (r *convertedRows) Next(dest []driver.Value) error {
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	if err := r.rows.Scan(r.scanDest...); err != nil {
+		return err
+	}
+	for idx, value := range r.values {
+		if r.lobColumns[idx] {
+			var err error
+			if value, err = readLob(value); err != nil {
+				return err
+			}
+		}
+		dest[idx] = value
+	}
+	return nil
+}
+
+func (r *convertedRows) ColumnTypeScanType(index int) reflect.Type {
+	if r.numberColumns[index] {
+		return reflect.TypeOf(integerNumber{})
+	}
+	return r.columnTypes[index].ScanType()
+}
+
+func (r *convertedRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.columnTypes[index].DatabaseTypeName()
+}
+
+func (r *convertedRows) ColumnTypeLength(index int) (int64, bool) {
+	return r.columnTypes[index].Length()
+}
+
+func (r *convertedRows) ColumnTypeNullable(index int) (bool, bool) {
+	return r.columnTypes[index].Nullable()
+}
+
+func (r *convertedRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	return r.columnTypes[index].DecimalSize()
+}
+
+// integerNumber is a NUMBER without fractional digits, valued as an int64
+// when it fits and as a string otherwise
+type integerNumber struct {
+	value driver.Value
+}
+
+func (n *integerNumber) Scan(src interface{}) error {
+	n.value = src
+	if i, ok := numberInt64(src); ok {
+		n.value = i
+	} else if number, ok := src.(godror.Number); ok {
+		n.value = string(number)
+	}
+	return nil
+}
+
+func (n integerNumber) Value() (driver.Value, error) {
+	return n.value, nil
+}
@@ -0,0 +1,185 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// seedLookupBatchSize is the number of keys looked up at once to load the
+// primary keys of seeded records, within Oracle's limit of 1000 expressions
+// in a list
+const seedLookupBatchSize = 1000
+
+// Seed loads reference data idempotently, such as the rows of a lookup
+// table: records, a pointer to a struct or to a slice of structs, are merged
+// into the table on the natural key made of the given fields or columns.
+// Rows whose key exists are updated with the other fields of the record,
+// and the others are inserted.
+//
+//	err := oracle.Seed(db, &[]Country{{Code: "FR", Name: "France"}}, "Code")
+//	// MERGE INTO "countries" USING (SELECT :1 AS "code",:2 AS "name" FROM DUAL) "excluded"
+//	// ON ("countries"."code" = "excluded"."code")
+//	// WHEN MATCHED THEN UPDATE SET "name"="excluded"."name"
+//	// WHEN NOT MATCHED THEN INSERT ("code","name") VALUES ("excluded"."code","excluded"."name")
+//
+// Auto-increment primary keys of the records are loaded afterwards, for
+// existing and inserted rows alike, so that seeded records can be
+// referenced by other seeds.
+func Seed(db *gorm.DB, records interface{}, keys ...string) error {
+	return seed(db, records, keys, true)
+}
+
+// SeedMissing is Seed leaving existing rows as they are, so that reference
+// data edited in the database is not reset
+func SeedMissing(db *gorm.DB, records interface{}, keys ...string) error {
+	return seed(db, records, keys, false)
+}
+
+func seed(db *gorm.DB, records interface{}, keys []string, update bool) error {
+	if len(keys) == 0 {
+		return errors.New("seed requires the fields of a natural key")
+	}
+	stmt := &gorm.Statement{DB: db, Context: db.Statement.Context}
+	if err := stmt.Parse(records); err != nil {
+		return err
+	}
+	sch := stmt.Schema
+
+	keyFields := make([]*schema.Field, len(keys))
+	keyColumns := make([]clause.Column, len(keys))
+	isKey := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		field := sch.LookUpField(key)
+		if field == nil || field.DBName == "" {
+			return fmt.Errorf("seed key %s is not a column of %s", key, sch.Name)
+		}
+		keyFields[i] = field
+		keyColumns[i] = clause.Column{Name: field.DBName}
+		isKey[field.DBName] = true
+	}
+
+	onConflict := clause.OnConflict{Columns: keyColumns, DoNothing: true}
+	if update {
+		var columns []string
+		for _, field := range sch.Fields {
+			if field.DBName != "" && field.Creatable && !field.PrimaryKey && !isKey[field.DBName] && field.AutoCreateTime == 0 {
+				columns = append(columns, field.DBName)
+			}
+		}
+		if len(columns) > 0 {
+			onConflict = clause.OnConflict{Columns: keyColumns, DoUpdates: clause.AssignmentColumns(columns)}
+		}
+	}
+
+	// Without RETURNING, Create merges batches with a single MERGE on any
+	// key rather than on the unique keys of the schema
+	if err := db.Session(&gorm.Session{}).Clauses(InsertOnly(), onConflict).Create(records).Error; err != nil {
+		return err
+	}
+	return loadSeedPrimaryKeys(db, stmt, records, keyFields)
+}
+
+// loadSeedPrimaryKeys sets the auto-increment primary key of the seeded
+// records from the rows with the same natural keys
+func loadSeedPrimaryKeys(db *gorm.DB, stmt *gorm.Statement, records interface{}, keyFields []*schema.Field) error {
+	pk := stmt.Schema.PrioritizedPrimaryField
+	if pk == nil || !pk.AutoIncrement {
+		return nil
+	}
+
+	var elems []reflect.Value
+	rv := reflect.Indirect(reflect.ValueOf(records))
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elems = append(elems, reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		elems = append(elems, rv)
+	}
+
+	seedKey := func(elem reflect.Value) string {
+		values := make([]interface{}, len(keyFields))
+		for i, field := range keyFields {
+			values[i], _ = field.ValueOf(stmt.Context, elem)
+		}
+		return fmt.Sprintf("%#v", values)
+	}
+
+	columns := make([]clause.Column, len(keyFields))
+	for i, field := range keyFields {
+		columns[i] = clause.Column{Name: field.DBName}
+	}
+	for start := 0; start < len(elems); start += seedLookupBatchSize {
+		batch := elems[start:min(start+seedLookupBatchSize, len(elems))]
+		tuples := make([][]interface{}, len(batch))
+		for i, elem := range batch {
+			tuples[i] = make([]interface{}, len(keyFields))
+			for j, field := range keyFields {
+				tuples[i][j], _ = field.ValueOf(stmt.Context, elem)
+			}
+		}
+
+		rows := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+		if err := db.Session(&gorm.Session{NewDB: true}).Unscoped().Table("?", clause.Table{Name: stmt.Table}).
+			Where("(?) IN ?", columns, tuples).Find(rows.Interface()).Error; err != nil {
+			return err
+		}
+
+		ids := make(map[string]interface{}, rows.Elem().Len())
+		for i := 0; i < rows.Elem().Len(); i++ {
+			row := rows.Elem().Index(i)
+			ids[seedKey(row)], _ = pk.ValueOf(stmt.Context, row)
+		}
+		for _, elem := range batch {
+			if id, ok := ids[seedKey(elem)]; ok {
+				if err := pk.Set(stmt.Context, elem, id); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
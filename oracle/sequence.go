@@ -0,0 +1,243 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultSequenceBlockSize is the number of sequence values fetched per
+// round trip when SequenceAllocator.BlockSize is not set
+const defaultSequenceBlockSize = 100
+
+// sequenceNameRegexp matches a sequence name with an optional owner
+var sequenceNameRegexp = regexp.MustCompile(`^(` + identifierPattern + `)(?:\.(` + identifierPattern + `))?$`)
+
+// SequenceAllocator hands out values of a sequence from blocks fetched in a
+// single round trip, so that primary keys can be assigned client-side
+// before inserting a batch. Combined with a primary key that is not
+// auto-increment, or with InsertOnly, the batch is inserted without a
+// RETURNING clause.
+//
+//	orderIDs := &oracle.SequenceAllocator{Sequence: "orders_seq", BlockSize: 1000}
+//
+//	if err := orderIDs.Assign(db, &orders); err != nil {
+//		return err
+//	}
+//	db.Clauses(oracle.InsertOnly()).Create(&orders)
+//
+// A SequenceAllocator is safe for concurrent use. Values fetched but not
+// used are lost when the allocator is discarded, leaving gaps in the keys.
+type SequenceAllocator struct {
+	// Sequence is the name of the sequence as written in SQL, so that it is
+	// matched case-insensitively unless it is quoted
+	Sequence string
+	// BlockSize is the number of values fetched per round trip, 100 if zero
+	BlockSize int
+	// IncrementByBlock tells that the sequence is created with an INCREMENT
+	// BY of BlockSize, so that each NEXTVAL reserves the BlockSize values
+	// that follow it. The INCREMENT BY of the sequence is checked against
+	// BlockSize before the first block is fetched. Otherwise, the values of
+	// a block are fetched with a single query, which is fastest when the
+	// sequence CACHE is at least BlockSize.
+	IncrementByBlock bool
+
+	mu               sync.Mutex
+	values           []int64
+	incrementChecked bool
+}
+
+// Next returns the next value of the sequence
+func (a *SequenceAllocator) Next(db *gorm.DB) (int64, error) {
+	values, err := a.Allocate(db, 1)
+	if err != nil {
+		return 0, err
+	}
+	return values[0], nil
+}
+
+// Allocate returns n values of the sequence, fetching as many blocks as
+// needed
+func (a *SequenceAllocator) Allocate(db *gorm.DB, n int) ([]int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for len(a.values) < n {
+		block, err := a.fetch(db, n-len(a.values))
+		if err != nil {
+			return nil, err
+		}
+		a.values = append(a.values, block...)
+	}
+
+	values := slices.Clone(a.values[:n])
+	a.values = a.values[n:]
+	return values, nil
+}
+
+// Assign sets the primary key of the given struct, or of each element of
+// the given slice, to a value of the sequence when it is zero
+func (a *SequenceAllocator) Assign(db *gorm.DB, value interface{}) error {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(value); err != nil {
+		return err
+	}
+
+	field := stmt.Schema.PrioritizedPrimaryField
+	if field == nil {
+		return fmt.Errorf("%s has no primary key to assign a sequence value to", stmt.Schema.Name)
+	}
+
+	ctx := db.Statement.Context
+	var targets []reflect.Value
+	rv := reflect.Indirect(reflect.ValueOf(value))
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elem := reflect.Indirect(rv.Index(i))
+			if _, isZero := field.ValueOf(ctx, elem); isZero {
+				targets = append(targets, elem)
+			}
+		}
+	case reflect.Struct:
+		if _, isZero := field.ValueOf(ctx, rv); isZero {
+			targets = append(targets, rv)
+		}
+	default:
+		return gorm.ErrInvalidData
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+
+	values, err := a.Allocate(db, len(targets))
+	if err != nil {
+		return err
+	}
+	for i, target := range targets {
+		if err := field.Set(ctx, target, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetch fetches at least n values of the sequence
+func (a *SequenceAllocator) fetch(db *gorm.DB, n int) ([]int64, error) {
+	size := a.BlockSize
+	if size <= 0 {
+		size = defaultSequenceBlockSize
+	}
+	if !sequenceNameRegexp.MatchString(a.Sequence) {
+		return nil, fmt.Errorf("invalid sequence name %q", a.Sequence)
+	}
+	nextval := clause.Expr{SQL: a.Sequence + ".NEXTVAL"}
+
+	if !a.IncrementByBlock {
+		var values []int64
+		err := db.Raw("SELECT ? FROM DUAL CONNECT BY LEVEL <= ?", nextval, max(n, size)).Scan(&values).Error
+		return values, err
+	}
+
+	if !a.incrementChecked {
+		if err := a.checkIncrement(db, size); err != nil {
+			return nil, err
+		}
+		a.incrementChecked = true
+	}
+
+	values := make([]int64, 0, n+size)
+	for len(values) < n {
+		var start int64
+		if err := db.Raw("SELECT ? FROM DUAL", nextval).Row().Scan(&start); err != nil {
+			return nil, err
+		}
+		for v := start; v < start+int64(size); v++ {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// checkIncrement checks that the INCREMENT BY of the sequence is the block
+// size, as otherwise the values of the blocks would overlap or be skipped
+func (a *SequenceAllocator) checkIncrement(db *gorm.DB, size int) error {
+	matches := sequenceNameRegexp.FindStringSubmatch(a.Sequence)
+	owner, name := "", sequenceDictionaryName(matches[1])
+	if matches[2] != "" {
+		owner, name = name, sequenceDictionaryName(matches[2])
+	}
+
+	var row *sql.Row
+	if owner == "" {
+		row = db.Raw("SELECT INCREMENT_BY FROM USER_SEQUENCES WHERE SEQUENCE_NAME = ?", name).Row()
+	} else {
+		row = db.Raw("SELECT INCREMENT_BY FROM ALL_SEQUENCES WHERE SEQUENCE_OWNER = ? AND SEQUENCE_NAME = ?", owner, name).Row()
+	}
+	var increment int64
+	if err := row.Scan(&increment); errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("sequence %s not found", a.Sequence)
+	} else if err != nil {
+		return err
+	}
+	if increment != int64(size) {
+		return fmt.Errorf("sequence %s has INCREMENT BY %d, expected the block size %d", a.Sequence, increment, size)
+	}
+	return nil
+}
+
+// sequenceDictionaryName returns the name of a sequence or owner as stored
+// in the data dictionary, where unquoted names are uppercase
+func sequenceDictionaryName(identifier string) string {
+	if strings.HasPrefix(identifier, `"`) {
+		return strings.Trim(identifier, `"`)
+	}
+	return strings.ToUpper(identifier)
+}
@@ -0,0 +1,313 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SessionContext is the application context that Virtual Private Database
+// policies read with SYS_CONTEXT, such as the tenant or the end user of a
+// request. WithSessionContext sets it on the session that runs a function.
+type SessionContext struct {
+	// ClientIdentifier is set with DBMS_SESSION.SET_IDENTIFIER, and read as
+	// SYS_CONTEXT('USERENV', 'CLIENT_IDENTIFIER')
+	ClientIdentifier string
+	// Setter is the procedure, of the package trusted by the namespace in
+	// CREATE CONTEXT ... USING, that sets an attribute of the namespace with
+	// DBMS_SESSION.SET_CONTEXT. It is called with the name and value of each
+	// attribute, and with a NULL value to reset it.
+	Setter string
+	// Attributes are the attributes set through Setter
+	Attributes map[string]string
+}
+
+// setterRegexp matches a procedure name, qualified by its package and schema
+var setterRegexp = regexp.MustCompile(`^[A-Za-z][\w$#]*(\.[A-Za-z][\w$#]*){0,2}$`)
+
+// validate checks that the attributes can be set
+func (c SessionContext) validate() error {
+	if len(c.Attributes) > 0 && !setterRegexp.MatchString(c.Setter) {
+		return fmt.Errorf("invalid session context setter %q", c.Setter)
+	}
+	return nil
+}
+
+// block returns the PL/SQL block setting the context, or resetting it when
+// reset is set, and its binds
+func (c SessionContext) block(reset bool) (string, []interface{}) {
+	var sql strings.Builder
+	var vars []interface{}
+	sql.WriteString("BEGIN\n")
+
+	if c.ClientIdentifier != "" {
+		if reset {
+			sql.WriteString("  DBMS_SESSION.CLEAR_IDENTIFIER;\n")
+		} else {
+			sql.WriteString("  DBMS_SESSION.SET_IDENTIFIER(?);\n")
+			vars = append(vars, c.ClientIdentifier)
+		}
+	}
+
+	names := make([]string, 0, len(c.Attributes))
+	for name := range c.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if reset {
+			sql.WriteString("  " + c.Setter + "(?, NULL);\n")
+			vars = append(vars, name)
+		} else {
+			sql.WriteString("  " + c.Setter + "(?, ?);\n")
+			vars = append(vars, name, c.Attributes[name])
+		}
+	}
+
+	sql.WriteString("END;")
+	return sql.String(), vars
+}
+
+// empty reports whether the context sets nothing
+func (c SessionContext) empty() bool {
+	return c.ClientIdentifier == "" && len(c.Attributes) == 0
+}
+
+// apply sets the context on the session of tx
+func (c SessionContext) apply(tx *gorm.DB) error {
+	if c.empty() {
+		return nil
+	}
+	sql, vars := c.block(false)
+	return tx.Exec(sql, vars...).Error
+}
+
+// reset clears the context from the session of tx
+func (c SessionContext) reset(tx *gorm.DB) error {
+	if c.empty() {
+		return nil
+	}
+	sql, vars := c.block(true)
+	return tx.Exec(sql, vars...).Error
+}
+
+// WithSessionContext runs fc on a connection whose session has the given
+// application context, so that VPD policies keyed on it apply to the
+// statements of fc. It is WithPinnedSession with only a context.
+//
+//	oracle.WithSessionContext(db, oracle.SessionContext{
+//		ClientIdentifier: user.Name,
+//		Setter:           "app.tenant_ctx.set_attribute",
+//		Attributes:       map[string]string{"TENANT_ID": tenantID},
+//	}, func(tx *gorm.DB) error {
+//		return tx.Find(&orders).Error
+//	})
+func WithSessionContext(db *gorm.DB, sc SessionContext, fc func(tx *gorm.DB) error) error {
+	return WithPinnedSession(db, SessionState{Context: sc}, fc)
+}
+
+// SessionState is the per-session state that WithPinnedSession applies to
+// the session it pins
+type SessionState struct {
+	// Context is the application context of the session
+	Context SessionContext
+	// NLS are NLS session parameters, such as NLS_DATE_FORMAT, and their
+	// values
+	NLS map[string]string
+	// CurrentSchema is the schema that resolves unqualified names
+	CurrentSchema string
+}
+
+// nlsParameterRegexp matches the name of an NLS session parameter
+var nlsParameterRegexp = regexp.MustCompile(`(?i)^NLS_\w+$`)
+
+// identifierRegexp matches an unquoted identifier, such as a schema name
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z][\w$#]*$`)
+
+// validate checks that the state can be applied
+func (s SessionState) validate() error {
+	for name := range s.NLS {
+		if !nlsParameterRegexp.MatchString(name) {
+			return fmt.Errorf("invalid NLS parameter %q", name)
+		}
+	}
+	if s.CurrentSchema != "" && !identifierRegexp.MatchString(s.CurrentSchema) {
+		return fmt.Errorf("invalid current schema %q", s.CurrentSchema)
+	}
+	return s.Context.validate()
+}
+
+// nls returns the NLS parameters with uppercase names, and the names in a
+// stable order
+func (s SessionState) nls() (map[string]string, []string) {
+	nls := make(map[string]string, len(s.NLS))
+	names := make([]string, 0, len(s.NLS))
+	for name, value := range s.NLS {
+		name = strings.ToUpper(name)
+		nls[name] = value
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return nls, names
+}
+
+// apply sets the state on the session of tx, and returns the function that
+// restores the state the session had before
+func (s SessionState) apply(tx *gorm.DB) (func(tx *gorm.DB) error, error) {
+	previous := SessionState{NLS: map[string]string{}}
+	nls, names := s.nls()
+
+	if len(s.NLS) > 0 {
+		var params []struct {
+			Parameter string
+			Value     *string
+		}
+		if err := tx.Raw(`SELECT PARAMETER AS "parameter", VALUE AS "value" FROM NLS_SESSION_PARAMETERS WHERE PARAMETER IN ?`, names).Scan(&params).Error; err != nil {
+			return nil, err
+		}
+		for _, param := range params {
+			if param.Value != nil {
+				previous.NLS[param.Parameter] = *param.Value
+			}
+		}
+	}
+	if s.CurrentSchema != "" {
+		if err := tx.Raw("SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL").Scan(&previous.CurrentSchema).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	restore := func(tx *gorm.DB) error {
+		errs := []error{s.Context.reset(tx)}
+		for _, name := range names {
+			if value, ok := previous.NLS[name]; ok {
+				errs = append(errs, alterSessionNLS(tx, name, value))
+			}
+		}
+		if previous.CurrentSchema != "" {
+			errs = append(errs, tx.Exec(`ALTER SESSION SET CURRENT_SCHEMA = "`+previous.CurrentSchema+`"`).Error)
+		}
+		return errors.Join(errs...)
+	}
+
+	for _, name := range names {
+		if err := alterSessionNLS(tx, name, nls[name]); err != nil {
+			return restore, err
+		}
+	}
+	if s.CurrentSchema != "" {
+		if err := tx.Exec("ALTER SESSION SET CURRENT_SCHEMA = " + s.CurrentSchema).Error; err != nil {
+			return restore, err
+		}
+	}
+	return restore, s.Context.apply(tx)
+}
+
+// alterSessionNLS sets an NLS session parameter
+func alterSessionNLS(tx *gorm.DB, name, value string) error {
+	return tx.Exec(fmt.Sprintf("ALTER SESSION SET %s = '%s'", name, strings.ReplaceAll(value, "'", "''"))).Error
+}
+
+// WithPinnedSession runs fc on one connection checked out of the pool, whose
+// session has the given state: application context, NLS parameters and
+// current schema. No other goroutine gets the connection until fc returns.
+// The session state is then restored, also when fc panics, before the
+// connection is returned to the pool; a connection that cannot be restored
+// is closed instead, so that no other request runs with the state. Inside a
+// transaction, fc runs on the session of the transaction.
+//
+//	oracle.WithPinnedSession(db, oracle.SessionState{
+//		Context:       oracle.SessionContext{ClientIdentifier: user.Name},
+//		NLS:           map[string]string{"NLS_SORT": "BINARY_CI", "NLS_COMP": "LINGUISTIC"},
+//		CurrentSchema: tenant.Schema,
+//	}, func(tx *gorm.DB) error {
+//		return tx.Find(&orders).Error
+//	})
+func WithPinnedSession(db *gorm.DB, state SessionState, fc func(tx *gorm.DB) error) error {
+	if err := state.validate(); err != nil {
+		return err
+	}
+
+	run := func(tx *gorm.DB) (err error) {
+		restore, err := state.apply(tx)
+		if restore == nil {
+			return err
+		}
+		// The state is restored even if fc panics, before the connection
+		// is returned to the pool and the panic goes on
+		defer func() {
+			if restoreErr := restore(tx); restoreErr != nil {
+				err = errors.Join(err, restoreErr, discardConn(tx))
+			}
+		}()
+		if err == nil {
+			err = fc(tx)
+		}
+		return err
+	}
+
+	// A transaction already holds its session
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return run(db)
+	}
+	return db.Connection(run)
+}
+
+// discardConn closes the connection of tx rather than returning it to the
+// pool, for sessions whose state could not be reset
+func discardConn(tx *gorm.DB) error {
+	conn, ok := tx.Statement.ConnPool.(*sql.Conn)
+	if !ok {
+		return nil
+	}
+	err := conn.Raw(func(interface{}) error {
+		return driver.ErrBadConn
+	})
+	if errors.Is(err, driver.ErrBadConn) {
+		return nil
+	}
+	return err
+}
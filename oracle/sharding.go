@@ -0,0 +1,400 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// errShardingDriver is returned when sharding is configured for a driver
+// other than godror
+var errShardingDriver = errors.New("sharding requires the godror driver")
+
+// Sharding routes the statements that have a sharding key to the shard that
+// holds the key, for databases using Oracle Sharding. The key is given per
+// session with WithShardingKey, or read from the fields of models tagged
+// `shardingkey` and `supershardingkey`:
+//
+//	type Order struct {
+//		CustomerID int64 `gorm:"primaryKey;shardingkey"`
+//		OrderID    int64 `gorm:"primaryKey"`
+//	}
+//
+//	db, err := gorm.Open(oracle.New(oracle.Config{
+//		DataSourceName: catalogDSN,
+//		Sharding:       &oracle.Sharding{DataSourceName: globalServiceDSN},
+//	}), &gorm.Config{})
+//
+//	db.Create(&Order{CustomerID: 42, OrderID: 1})           // on the shard of customer 42
+//	db.Where(&Order{CustomerID: 42}).Find(&orders)          // on the shard of customer 42
+//	db.Where(`"order_id" > ?`, 100).Find(&orders)           // cross-shard, through the catalog
+//
+// Statements without a sharding key, and statements of transactions begun
+// without one, run on the pool of the Config, which connects to the shard
+// catalog that coordinates cross-shard queries.
+type Sharding struct {
+	// DataSourceName connects to the global service of the sharded
+	// database, which routes sessions to the shard of their sharding key.
+	// The default is the DataSourceName of the Config.
+	DataSourceName string
+	// MaxSessionsPerShard limits the sessions of the pool on each shard
+	MaxSessionsPerShard int
+
+	// pool holds the sessions to the shards. The Go pool keeps no idle
+	// connections, so that each statement gets a session for its key from
+	// the godror session pool.
+	pool   *sql.DB
+	params godror.ConnectionParams
+}
+
+// open opens the pool of the sessions to the shards
+func (s *Sharding) open(dataSourceName string) error {
+	if s.DataSourceName != "" {
+		dataSourceName = s.DataSourceName
+	}
+	if dataSourceName == "" {
+		return errors.New("sharding requires a data source name")
+	}
+	params, err := godror.ParseDSN(dataSourceName)
+	if err != nil {
+		return err
+	}
+	if s.MaxSessionsPerShard > 0 {
+		params.MaxSessionsPerShard = s.MaxSessionsPerShard
+	}
+	s.params = params
+	s.pool = sql.OpenDB(godror.NewConnector(params))
+	s.pool.SetMaxIdleConns(0)
+	return nil
+}
+
+// shardingConfig returns the sharding configuration of the dialector of the
+// database
+func shardingConfig(db *gorm.DB) *Sharding {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		if d.Config != nil {
+			return d.Sharding
+		}
+	case Dialector:
+		if d.Config != nil {
+			return d.Sharding
+		}
+	}
+	return nil
+}
+
+// shardConnPool runs statements on sessions of the shard of a sharding key
+type shardConnPool struct {
+	pool   *sql.DB
+	params godror.ConnParams
+	common godror.CommonParams
+}
+
+// newShardConnPool returns the connection pool of the shard of the keys,
+// converted to the int, string and []byte values that godror supports
+func newShardConnPool(sharding *Sharding, key, superKey []interface{}) (*shardConnPool, error) {
+	params := sharding.params.ConnParams
+	var err error
+	if params.ShardingKey, err = shardingKeyValues(key); err != nil {
+		return nil, err
+	}
+	if params.SuperShardingKey, err = shardingKeyValues(superKey); err != nil {
+		return nil, err
+	}
+	return &shardConnPool{pool: sharding.pool, params: params, common: sharding.params.CommonParams}, nil
+}
+
+// shardingKeyValues converts the values of a sharding key
+func shardingKeyValues(values []interface{}) ([]interface{}, error) {
+	converted := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		rv := reflect.ValueOf(value)
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			converted = append(converted, int(rv.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			converted = append(converted, int(rv.Uint()))
+		case reflect.String:
+			converted = append(converted, rv.String())
+		case reflect.Slice:
+			if rv.Type().Elem().Kind() != reflect.Uint8 {
+				return nil, fmt.Errorf("unsupported sharding key value %T", value)
+			}
+			converted = append(converted, rv.Bytes())
+		default:
+			return nil, fmt.Errorf("unsupported sharding key value %T", value)
+		}
+	}
+	return converted, nil
+}
+
+// context returns the context that makes godror connect to the shard
+func (p *shardConnPool) context(ctx context.Context) context.Context {
+	return godror.ContextWithParams(ctx, p.common, p.params)
+}
+
+func (p *shardConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.pool.PrepareContext(p.context(ctx), query)
+}
+
+func (p *shardConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.pool.ExecContext(p.context(ctx), query, args...)
+}
+
+func (p *shardConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.pool.QueryContext(p.context(ctx), query, args...)
+}
+
+func (p *shardConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.pool.QueryRowContext(p.context(ctx), query, args...)
+}
+
+// BeginTx begins a transaction on a session of the shard
+func (p *shardConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return p.pool.BeginTx(p.context(ctx), opts)
+}
+
+// WithShardingKey returns a session whose statements and transactions run
+// on the shard of the sharding key, which has a value for each column of
+// the key. Values are integers, strings or byte slices.
+//
+//	err := oracle.WithShardingKey(db, customerID).Transaction(func(tx *gorm.DB) error {
+//		...
+//	})
+//
+// The key of a sharded table family with a super sharding key is set with
+// WithSuperShardingKey on the returned session. Sharding must be
+// configured.
+func WithShardingKey(db *gorm.DB, key ...interface{}) *gorm.DB {
+	return withShardingKeys(db, key, nil)
+}
+
+// WithSuperShardingKey returns a session whose statements run on the shard
+// of the super sharding key, together with the sharding key of the session
+// if it has one
+func WithSuperShardingKey(db *gorm.DB, superKey ...interface{}) *gorm.DB {
+	var key []interface{}
+	if pool, ok := db.Statement.ConnPool.(*shardConnPool); ok {
+		key = pool.params.ShardingKey
+	}
+	return withShardingKeys(db, key, superKey)
+}
+
+func withShardingKeys(db *gorm.DB, key, superKey []interface{}) *gorm.DB {
+	tx := db.Session(&gorm.Session{})
+	sharding := shardingConfig(db)
+	if sharding == nil || sharding.pool == nil {
+		tx.AddError(errors.New("sharding keys require the Sharding option"))
+		return tx
+	}
+	if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		tx.AddError(errors.New("the sharding key cannot be changed in a transaction"))
+		return tx
+	}
+	if len(key) == 0 && len(superKey) == 0 {
+		tx.AddError(errors.New("a sharding key requires at least one value"))
+		return tx
+	}
+	pool, err := newShardConnPool(sharding, key, superKey)
+	if err != nil {
+		tx.AddError(err)
+		return tx
+	}
+	tx.Statement.ConnPool = pool
+	return tx
+}
+
+// shardingFields returns the fields of the schema tagged with the tag, in
+// their declaration order
+func shardingFields(sch *schema.Schema, tag string) []*schema.Field {
+	if sch == nil {
+		return nil
+	}
+	var fields []*schema.Field
+	for _, field := range sch.Fields {
+		if _, ok := field.TagSettings[tag]; ok && field.DBName != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// ShardingHandler routes the statements of models with sharding key fields
+// to the shard of the key. The key is read from the record, or from the
+// records when they share it, and for queries and records without one,
+// from equality conditions on all the key columns. Statements whose key is
+// not found run on the shard catalog. It runs before the transaction of the
+// statement is begun, and leaves the statements of transactions and of
+// sessions with a sharding key where they are.
+func ShardingHandler(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.DryRun {
+		return
+	}
+	switch stmt.ConnPool.(type) {
+	case gorm.TxCommitter, *shardConnPool:
+		return
+	}
+	sharding := shardingConfig(db)
+	if sharding == nil || sharding.pool == nil {
+		return
+	}
+
+	keyFields := shardingFields(stmt.Schema, "SHARDINGKEY")
+	superKeyFields := shardingFields(stmt.Schema, "SUPERSHARDINGKEY")
+	if len(keyFields) == 0 && len(superKeyFields) == 0 {
+		return
+	}
+
+	key, ok := shardingKeyOf(stmt, keyFields)
+	if !ok {
+		return
+	}
+	superKey, ok := shardingKeyOf(stmt, superKeyFields)
+	if !ok {
+		return
+	}
+	pool, err := newShardConnPool(sharding, key, superKey)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	stmt.ConnPool = pool
+}
+
+// shardingKeyOf returns the values of the key fields for the statement
+func shardingKeyOf(stmt *gorm.Statement, fields []*schema.Field) ([]interface{}, bool) {
+	if len(fields) == 0 {
+		return nil, true
+	}
+	if key, ok := recordShardingKey(stmt, fields); ok {
+		return key, true
+	}
+	return conditionShardingKey(stmt, fields)
+}
+
+// recordShardingKey returns the key of the record of the statement, or of
+// its records when they all have the same one
+func recordShardingKey(stmt *gorm.Statement, fields []*schema.Field) ([]interface{}, bool) {
+	value := reflect.Indirect(stmt.ReflectValue)
+	keyOf := func(record reflect.Value) ([]interface{}, bool) {
+		key := make([]interface{}, 0, len(fields))
+		for _, field := range fields {
+			v, isZero := field.ValueOf(stmt.Context, record)
+			if isZero {
+				return nil, false
+			}
+			key = append(key, v)
+		}
+		return key, true
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		return keyOf(value)
+	case reflect.Slice, reflect.Array:
+		var key []interface{}
+		for i := 0; i < value.Len(); i++ {
+			recordKey, ok := keyOf(reflect.Indirect(value.Index(i)))
+			if !ok || (key != nil && !reflect.DeepEqual(key, recordKey)) {
+				return nil, false
+			}
+			key = recordKey
+		}
+		return key, key != nil
+	}
+	return nil, false
+}
+
+// conditionShardingKey returns the key compared for equality by the WHERE
+// clause of the statement
+func conditionShardingKey(stmt *gorm.Statement, fields []*schema.Field) ([]interface{}, bool) {
+	c, ok := stmt.Clauses["WHERE"]
+	if !ok {
+		return nil, false
+	}
+	where, ok := c.Expression.(clause.Where)
+	if !ok {
+		return nil, false
+	}
+
+	values := map[string]interface{}{}
+	for _, expr := range where.Exprs {
+		eq, ok := expr.(clause.Eq)
+		if !ok {
+			continue
+		}
+		var column string
+		switch c := eq.Column.(type) {
+		case clause.Column:
+			if c.Table != "" && c.Table != clause.CurrentTable && c.Table != stmt.Table {
+				continue
+			}
+			column = c.Name
+		case string:
+			column = c
+		default:
+			continue
+		}
+		values[column] = eq.Value
+	}
+
+	key := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		value, ok := values[field.DBName]
+		if !ok || value == nil {
+			return nil, false
+		}
+		key = append(key, value)
+	}
+	return key, true
+}
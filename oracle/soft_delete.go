@@ -0,0 +1,257 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// DeletedFlag is a soft-delete field stored as a one-character flag, for
+// tables that mark deleted rows with a CHAR(1) column rather than the time
+// of deletion. Queries and updates only see the rows whose flag is active,
+// and Delete sets the flag instead of deleting the rows.
+//
+//	type Order struct {
+//		ID      uint
+//		Deleted oracle.DeletedFlag `gorm:"type:CHAR(1);default:'N';not null"`
+//	}
+//
+// The flag is 'N' for active rows and 'Y' for deleted ones. The DELETEDFLAG
+// tag setting changes both, as in `gorm:"deletedFlag:0,1"`. The zero value
+// is inserted as NULL, so the column should default to the active flag.
+type DeletedFlag string
+
+const (
+	// DeletedFlagActive is the default flag of rows that are not deleted
+	DeletedFlagActive DeletedFlag = "N"
+	// DeletedFlagDeleted is the default flag Delete sets
+	DeletedFlagDeleted DeletedFlag = "Y"
+)
+
+// Scan implements the sql.Scanner interface
+func (f *DeletedFlag) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*f = ""
+	case string:
+		*f = DeletedFlag(strings.TrimSpace(v))
+	case []byte:
+		*f = DeletedFlag(strings.TrimSpace(string(v)))
+	default:
+		return fmt.Errorf("unsupported deleted flag %T", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The zero value is NULL, so
+// that inserted rows get the default of the column.
+func (f DeletedFlag) Value() (driver.Value, error) {
+	if f == "" {
+		return nil, nil
+	}
+	return string(f), nil
+}
+
+// deletedFlagValues returns the active and deleted flags of the field
+func deletedFlagValues(f *schema.Field) (active, deleted DeletedFlag) {
+	active, deleted = DeletedFlagActive, DeletedFlagDeleted
+	if setting, ok := f.TagSettings["DELETEDFLAG"]; ok {
+		if values := strings.SplitN(setting, ",", 2); len(values) == 2 {
+			active = DeletedFlag(strings.TrimSpace(values[0]))
+			deleted = DeletedFlag(strings.TrimSpace(values[1]))
+		}
+	}
+	return active, deleted
+}
+
+// QueryClauses makes queries skip deleted rows, unless they are Unscoped
+func (DeletedFlag) QueryClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{deletedFlagQueryClause{Field: f}}
+}
+
+// UpdateClauses makes updates skip deleted rows, unless they are Unscoped
+func (DeletedFlag) UpdateClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{deletedFlagUpdateClause{Field: f}}
+}
+
+// DeleteClauses makes Delete flag rows as deleted, unless it is Unscoped
+func (DeletedFlag) DeleteClauses(f *schema.Field) []clause.Interface {
+	return []clause.Interface{deletedFlagDeleteClause{Field: f}}
+}
+
+// deletedFlagQueryClause restricts a statement to the active rows
+type deletedFlagQueryClause struct {
+	Field *schema.Field
+}
+
+func (deletedFlagQueryClause) Name() string {
+	return ""
+}
+
+func (deletedFlagQueryClause) Build(clause.Builder) {
+}
+
+func (deletedFlagQueryClause) MergeClause(*clause.Clause) {
+}
+
+func (c deletedFlagQueryClause) ModifyStatement(stmt *gorm.Statement) {
+	if _, ok := stmt.Clauses["soft_delete_enabled"]; ok || stmt.Unscoped {
+		return
+	}
+
+	// Group a single OR condition so that it does not bind the flag
+	if where, ok := stmt.Clauses["WHERE"].Expression.(clause.Where); ok {
+		for _, expr := range where.Exprs {
+			if orCond, ok := expr.(clause.OrConditions); ok && len(orCond.Exprs) == 1 {
+				where.Exprs = []clause.Expression{clause.And(where.Exprs...)}
+				stmt.Clauses["WHERE"] = clause.Clause{Name: "WHERE", Expression: where}
+				break
+			}
+		}
+	}
+
+	active, _ := deletedFlagValues(c.Field)
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: c.Field.DBName}, Value: string(active)},
+	}})
+	stmt.Clauses["soft_delete_enabled"] = clause.Clause{}
+}
+
+// deletedFlagUpdateClause restricts an UPDATE to the active rows
+type deletedFlagUpdateClause struct {
+	Field *schema.Field
+}
+
+func (deletedFlagUpdateClause) Name() string {
+	return ""
+}
+
+func (deletedFlagUpdateClause) Build(clause.Builder) {
+}
+
+func (deletedFlagUpdateClause) MergeClause(*clause.Clause) {
+}
+
+func (c deletedFlagUpdateClause) ModifyStatement(stmt *gorm.Statement) {
+	if stmt.SQL.Len() == 0 && !stmt.Unscoped {
+		deletedFlagQueryClause(c).ModifyStatement(stmt)
+	}
+}
+
+// deletedFlagDeleteClause turns a DELETE into an UPDATE flagging the rows
+type deletedFlagDeleteClause struct {
+	Field *schema.Field
+}
+
+func (deletedFlagDeleteClause) Name() string {
+	return ""
+}
+
+func (deletedFlagDeleteClause) Build(clause.Builder) {
+}
+
+func (deletedFlagDeleteClause) MergeClause(*clause.Clause) {
+}
+
+func (c deletedFlagDeleteClause) ModifyStatement(stmt *gorm.Statement) {
+	if stmt.SQL.Len() > 0 || stmt.Unscoped {
+		return
+	}
+
+	_, deleted := deletedFlagValues(c.Field)
+	stmt.AddClause(clause.Set{{Column: clause.Column{Name: c.Field.DBName}, Value: string(deleted)}})
+	stmt.SetColumn(c.Field.DBName, deleted, true)
+
+	// The Delete callback has already added the primary key conditions
+	deletedFlagQueryClause(c).ModifyStatement(stmt)
+	stmt.AddClauseIfNotExists(clause.Update{})
+	stmt.Build(stmt.DB.Callback().Update().Clauses...)
+}
+
+// isSoftDeleteField reports whether the field is one of the soft-delete
+// strategies: a gorm.DeletedAt time, a DeletedFlag or an ArchiveState
+func isSoftDeleteField(field *schema.Field) bool {
+	fieldType := field.FieldType
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType {
+	case reflect.TypeOf(gorm.DeletedAt{}), reflect.TypeOf(DeletedFlag("")), reflect.TypeOf(ArchiveState("")):
+		return true
+	}
+	return false
+}
+
+// softDeleteField returns the soft-delete field of the schema that maps the
+// column, if any
+func softDeleteField(sch *schema.Schema, column string) *schema.Field {
+	if sch == nil {
+		return nil
+	}
+	if field := sch.LookUpField(column); field != nil && isSoftDeleteField(field) {
+		return field
+	}
+	if strings.EqualFold(column, archiveStateColumn) {
+		if field, _ := archiveStateField(sch); field != nil {
+			return field
+		}
+	}
+	return nil
+}
+
+// softDeleteExprRegexp matches an expression comparing a single, possibly
+// quoted and qualified, column with a value
+var softDeleteExprRegexp = regexp.MustCompile(`(?i)^\s*(?:"?\w+"?\.)?"?(\w+)"?\s*(?:=|<>|!=|IS\s+NULL|IS\s+NOT\s+NULL)\s*(?:\?|'[^']*'|:\w+)?\s*$`)
+
+// softDeleteExprColumn returns the column of an expression that only
+// compares one column with a value
+func softDeleteExprColumn(sql string) (string, bool) {
+	matches := softDeleteExprRegexp.FindStringSubmatch(sql)
+	if matches == nil {
+		return "", false
+	}
+	return matches[1], true
+}
@@ -0,0 +1,177 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqlIDAlphabet is the base 32 alphabet of SQL_ID values
+const sqlIDAlphabet = "0123456789abcdfghjkmnpqrstuvwxyz"
+
+// SQLStatsMode selects the SQL statistics added to the statement context
+type SQLStatsMode int
+
+const (
+	// SQLStatsOff adds no statistics
+	SQLStatsOff SQLStatsMode = iota
+	// SQLStatsID adds the SQL_ID of the statement, computed from its text
+	SQLStatsID
+	// SQLStatsFetch adds the SQL_ID and the statistics read from V$SQL
+	// with an additional query after each statement
+	SQLStatsFetch
+)
+
+// SQLID returns the SQL_ID that Oracle assigns to the statement text, which
+// identifies the statement in V$SQL, AWR reports and SQL Monitor
+func SQLID(sql string) string {
+	hash := md5.Sum(append([]byte(sql), 0))
+	value := uint64(binary.LittleEndian.Uint32(hash[8:12]))<<32 | uint64(binary.LittleEndian.Uint32(hash[12:16]))
+
+	id := make([]byte, 13)
+	for i := range id {
+		id[i] = sqlIDAlphabet[(value>>(5*(12-i)))&31]
+	}
+	return string(id)
+}
+
+// SQLStats are the SQL_ID of a statement and the statistics of its cursors
+// in V$SQL, accumulated over all executions
+type SQLStats struct {
+	SQLID       string
+	Executions  int64
+	ElapsedTime time.Duration
+	CPUTime     time.Duration
+	BufferGets  int64
+	DiskReads   int64
+	// Err is the error raised while reading V$SQL, which requires the
+	// SELECT_CATALOG_ROLE role or SELECT privilege on V_$SQL
+	Err error
+}
+
+// String formats the statistics for the log
+func (s *SQLStats) String() string {
+	if s.Err != nil || s.Executions == 0 {
+		return fmt.Sprintf("sql_id=%s", s.SQLID)
+	}
+	return fmt.Sprintf("sql_id=%s executions=%d elapsed=%s cpu=%s buffer_gets=%d disk_reads=%d",
+		s.SQLID, s.Executions, s.ElapsedTime, s.CPUTime, s.BufferGets, s.DiskReads)
+}
+
+type sqlStatsKey struct{}
+
+// SQLStatsFromContext returns the statistics of the statement that ran with
+// the context, as passed to the Trace method of the logger
+func SQLStatsFromContext(ctx context.Context) (*SQLStats, bool) {
+	stats, ok := ctx.Value(sqlStatsKey{}).(*SQLStats)
+	return stats, ok
+}
+
+// SQLStatsHandler reads the SQL_ID and, when fetchStats is set, the V$SQL
+// statistics of the statement once it has run, and adds them to the
+// statement context for the logger. A statement reused for several
+// operations keeps a single context value, which holds the statistics of
+// the last one.
+func SQLStatsHandler(fetchStats bool) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		stmt := db.Statement
+		if stmt == nil || stmt.DryRun || stmt.SQL.Len() == 0 {
+			return
+		}
+
+		stats := &SQLStats{SQLID: SQLID(stmt.SQL.String())}
+		if fetchStats && db.Error == nil {
+			var elapsed, cpu int64
+			row := stmt.ConnPool.QueryRowContext(stmt.Context,
+				"SELECT NVL(SUM(executions), 0), NVL(SUM(elapsed_time), 0), NVL(SUM(cpu_time), 0), NVL(SUM(buffer_gets), 0), NVL(SUM(disk_reads), 0) FROM v$sql WHERE sql_id = :1",
+				stats.SQLID)
+			if stats.Err = row.Scan(&stats.Executions, &elapsed, &cpu, &stats.BufferGets, &stats.DiskReads); stats.Err == nil {
+				// V$SQL reports times in microseconds
+				stats.ElapsedTime = time.Duration(elapsed) * time.Microsecond
+				stats.CPUTime = time.Duration(cpu) * time.Microsecond
+			}
+		}
+		if existing, ok := SQLStatsFromContext(stmt.Context); ok {
+			*existing = *stats
+			return
+		}
+		stmt.Context = context.WithValue(stmt.Context, sqlStatsKey{}, stats)
+	}
+}
+
+// statsLogger appends the SQL statistics of the statement to the traced SQL
+type statsLogger struct {
+	logger.Interface
+}
+
+// NewStatsLogger returns a logger that appends the SQL_ID and statistics
+// read with the SQLStats option of Config to the traced statements:
+//
+//	db, err := gorm.Open(oracle.New(oracle.Config{DataSourceName: dsn, SQLStats: oracle.SQLStatsFetch}), &gorm.Config{
+//		Logger: oracle.NewStatsLogger(logger.Default.LogMode(logger.Info)),
+//	})
+//	// SELECT * FROM "users" /* sql_id=a5ks9fhw2v9s1 executions=3 elapsed=1.2ms ... */
+func NewStatsLogger(l logger.Interface) logger.Interface {
+	return statsLogger{Interface: l}
+}
+
+// LogMode returns a copy of the logger with the log level
+func (l statsLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return statsLogger{Interface: l.Interface.LogMode(level)}
+}
+
+// Trace traces the statement with its statistics
+func (l statsLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	stats, ok := SQLStatsFromContext(ctx)
+	if !ok {
+		l.Interface.Trace(ctx, begin, fc, err)
+		return
+	}
+	l.Interface.Trace(ctx, begin, func() (string, int64) {
+		sql, rowsAffected := fc()
+		return sql + " /* " + stats.String() + " */", rowsAffected
+	}, err)
+}
@@ -0,0 +1,113 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/godror/godror"
+	"gorm.io/gorm"
+)
+
+// FindEach finds the records matching db one at a time: each row is scanned
+// into dest, a pointer to a model, and fc is called before the next row is
+// fetched, so that exporting millions of rows does not hold them all in
+// memory. fetchSize is the number of rows the driver fetches in each round
+// trip, zero keeping the default of the driver; larger sizes take fewer
+// round trips for more memory.
+//
+//	var order Order
+//	err := oracle.FindEach(db.WithContext(ctx).Where(`"status" = ?`, "shipped"), &order, 1000,
+//		func(tx *gorm.DB) error {
+//			return w.Write(order.CSV())
+//		})
+//
+// dest is reset to its zero value before each row. Statements of fc do not
+// inherit the conditions of db, and run on their own connection unless db
+// is a transaction. An error of fc stops the query and is returned.
+// Preloads are not loaded.
+func FindEach(db *gorm.DB, dest interface{}, fetchSize int, fc func(tx *gorm.DB) error) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() || destValue.Elem().Kind() == reflect.Slice {
+		return errors.New("find each destination must be a pointer to a model")
+	}
+	if fetchSize < 0 {
+		return fmt.Errorf("invalid fetch size %d", fetchSize)
+	}
+
+	// Rows are streamed, so the row cap of QueryDefaults does not apply
+	if defaults, ok := queryDefaults(db); ok && defaults.MaxRows > 0 {
+		defaults.MaxRows = 0
+		db = db.Set(queryDefaultsKey, defaults)
+	}
+
+	record := destValue.Elem()
+	record.Set(reflect.Zero(record.Type()))
+	query := db.Session(&gorm.Session{DryRun: true}).Find(dest)
+	if query.Error != nil {
+		return query.Error
+	}
+
+	args := query.Statement.Vars
+	if fetchSize > 0 && driverFeatures(db).FetchOptions {
+		args = append(slices.Clone(args), godror.PrefetchCount(fetchSize), godror.FetchArraySize(fetchSize))
+	}
+	rows, err := db.Statement.ConnPool.QueryContext(db.Statement.Context, query.Statement.SQL.String(), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	session := db.Session(&gorm.Session{NewDB: true})
+	for rows.Next() {
+		record.Set(reflect.Zero(record.Type()))
+		if err := session.ScanRows(rows, dest); err != nil {
+			return err
+		}
+		if err := fc(session); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return rows.Close()
+}
@@ -0,0 +1,422 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// SessionTimeZone is the DateLocation of the time zone of the database
+// session, SESSIONTIMEZONE, which is resolved when the database is opened
+var SessionTimeZone = time.FixedZone("SESSIONTIMEZONE", 0)
+
+// wallClockLayout and wallClockMask format the wall clocks bound for DATE
+// and TIMESTAMP columns
+const (
+	wallClockLayout = "2006-01-02 15:04:05.000000000"
+	wallClockMask   = "YYYY-MM-DD HH24:MI:SS.FF9"
+)
+
+// wallClock is a time bound as its wall clock in the DateLocation, for a
+// DATE or TIMESTAMP column
+type wallClock string
+
+// wallClocks is an array of wall clocks bound for a DATE or TIMESTAMP
+// column, with empty strings for NULL
+type wallClocks []string
+
+// sessionTimeZoneSQL returns the time zone of the session, as a region name
+// or an offset
+const sessionTimeZoneSQL = "SELECT SESSIONTIMEZONE FROM DUAL"
+
+// resolveSessionTimeZone returns the location of the time zone of the
+// session
+func resolveSessionTimeZone(pool gorm.ConnPool) (*time.Location, error) {
+	var zone string
+	if err := pool.QueryRowContext(context.Background(), sessionTimeZoneSQL).Scan(&zone); err != nil {
+		return nil, fmt.Errorf("resolve the session time zone: %w", err)
+	}
+	return parseTimeZone(zone)
+}
+
+// parseTimeZone returns the location of an Oracle time zone, either an
+// offset such as +02:00 or a region name such as Europe/Paris
+func parseTimeZone(zone string) (*time.Location, error) {
+	zone = strings.TrimSpace(zone)
+	if len(zone) == 6 && (zone[0] == '+' || zone[0] == '-') && zone[3] == ':' {
+		offset, err := time.Parse("-07:00", zone)
+		if err == nil {
+			_, seconds := offset.Zone()
+			return time.FixedZone(zone, seconds), nil
+		}
+	}
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: %w", zone, err)
+	}
+	return location, nil
+}
+
+// dateLocation returns the DateLocation of the dialector, nil if not set
+func dateLocation(db *gorm.DB) *time.Location {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		if d.Config != nil {
+			return d.DateLocation
+		}
+	case Dialector:
+		if d.Config != nil {
+			return d.DateLocation
+		}
+	}
+	return nil
+}
+
+// isWallClockField reports whether the column of the field is a DATE or a
+// TIMESTAMP without time zone
+func isWallClockField(field *schema.Field) bool {
+	if field == nil {
+		return false
+	}
+	dataType := strings.ToUpper(string(field.DataType))
+	return (strings.HasPrefix(dataType, "DATE") || strings.HasPrefix(dataType, "TIMESTAMP")) &&
+		!strings.Contains(dataType, "TIME ZONE")
+}
+
+// wallClockValue returns the wall clock of a time value in the location,
+// or false for other values, NULL and zero times
+func wallClockValue(value interface{}, location *time.Location) (wallClock, bool) {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return "", false
+		}
+		t = *v
+	case sql.NullTime:
+		if !v.Valid {
+			return "", false
+		}
+		t = v.Time
+	case gorm.DeletedAt:
+		if !v.Valid {
+			return "", false
+		}
+		t = v.Time
+	default:
+		return "", false
+	}
+	if t.IsZero() {
+		return "", false
+	}
+	return wallClock(t.In(location).Format(wallClockLayout)), true
+}
+
+// wallClockCreateValues replaces the times of DATE and TIMESTAMP columns by
+// their wall clocks in the DateLocation
+func wallClockCreateValues(db *gorm.DB, createValues clause.Values) {
+	location := dateLocation(db)
+	if location == nil || db.Statement.Schema == nil {
+		return
+	}
+	for i, column := range createValues.Columns {
+		if !isWallClockField(db.Statement.Schema.LookUpField(column.Name)) {
+			continue
+		}
+		for _, row := range createValues.Values {
+			if value, ok := wallClockValue(row[i], location); ok {
+				row[i] = value
+			}
+		}
+	}
+}
+
+// wallClockAssignments replaces the times assigned to DATE and TIMESTAMP
+// columns by their wall clocks in the DateLocation
+func wallClockAssignments(db *gorm.DB, set clause.Set) {
+	location := dateLocation(db)
+	if location == nil || db.Statement.Schema == nil {
+		return
+	}
+	for i, assignment := range set {
+		if !isWallClockField(db.Statement.Schema.LookUpField(assignment.Column.Name)) {
+			continue
+		}
+		if value, ok := wallClockValue(assignment.Value, location); ok {
+			set[i].Value = value
+		}
+	}
+}
+
+// inLocation returns the time with the same wall clock in the location
+func inLocation(t time.Time, location *time.Location) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), location)
+}
+
+// DateLocationHandler sets the times read from DATE and TIMESTAMP columns
+// into the fields of the model in the DateLocation: the driver returns the
+// wall clock stored by the database in the time zone of the connection, and
+// the wall clock is kept in the location. It is registered by the
+// DateLocation option.
+func DateLocationHandler(db *gorm.DB) {
+	location := dateLocation(db)
+	if location == nil {
+		return
+	}
+	convertTimeFields(db, isWallClockField, func(t time.Time) time.Time {
+		return inLocation(t, location)
+	})
+}
+
+// convertTimeFields converts the times that are not zero in the fields of
+// the model that match
+func convertTimeFields(db *gorm.DB, match func(*schema.Field) bool, convert func(time.Time) time.Time) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || !stmt.ReflectValue.IsValid() {
+		return
+	}
+
+	var fields []*schema.Field
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName != "" && match(field) {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+
+	convertRow := func(rv reflect.Value) {
+		for rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return
+			}
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			return
+		}
+		for _, field := range fields {
+			value, zero := field.ValueOf(stmt.Context, rv)
+			if zero {
+				continue
+			}
+			switch v := value.(type) {
+			case time.Time:
+				value = convert(v)
+			case *time.Time:
+				t := convert(*v)
+				value = &t
+			case sql.NullTime:
+				v.Time = convert(v.Time)
+				value = v
+			case gorm.DeletedAt:
+				v.Time = convert(v.Time)
+				value = v
+			default:
+				continue
+			}
+			if err := field.Set(stmt.Context, rv, value); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	}
+
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			convertRow(stmt.ReflectValue.Index(i))
+		}
+	default:
+		convertRow(stmt.ReflectValue)
+	}
+}
+
+// ZeroTimeMode selects how the zero time.Time is written to the database,
+// see Config.ZeroTime
+type ZeroTimeMode int
+
+const (
+	// ZeroTimeDriver leaves zero times to the driver. godror binds them as
+	// NULL, which fails with ORA-01400 on NOT NULL columns.
+	ZeroTimeDriver ZeroTimeMode = iota
+	// ZeroTimeReject fails Create and Update with ErrZeroTime when they write
+	// a zero time
+	ZeroTimeReject
+	// ZeroTimeNull writes zero times as NULL, which pointer fields read back
+	// as nil and other fields as the zero time, and fails with ErrZeroTime
+	// when the column is NOT NULL
+	ZeroTimeNull
+	// ZeroTimeStore writes zero times as 0001-01-01 00:00:00, which is read
+	// back as the zero time
+	ZeroTimeStore
+)
+
+// ErrZeroTime is returned when a zero time is written with ZeroTimeReject,
+// or to a NOT NULL column with ZeroTimeNull
+var ErrZeroTime = errors.New("zero time cannot be written")
+
+// zeroTimeWallClock is the wall clock of the zero time stored with
+// ZeroTimeStore
+const zeroTimeWallClock wallClock = "0001-01-01 00:00:00.000000000"
+
+// zeroTimeMode returns the ZeroTime mode of the dialector
+func zeroTimeMode(db *gorm.DB) ZeroTimeMode {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		if d.Config != nil {
+			return d.ZeroTime
+		}
+	case Dialector:
+		if d.Config != nil {
+			return d.ZeroTime
+		}
+	}
+	return ZeroTimeDriver
+}
+
+// isZeroTime reports whether the value is a zero time that is not NULL
+func isZeroTime(value interface{}) bool {
+	switch v := value.(type) {
+	case time.Time:
+		return v.IsZero()
+	case *time.Time:
+		return v != nil && v.IsZero()
+	case sql.NullTime:
+		return v.Valid && v.Time.IsZero()
+	case gorm.DeletedAt:
+		return v.Valid && v.Time.IsZero()
+	}
+	return false
+}
+
+// zeroTimeValue returns the value written for a zero time to the column of
+// the field with the mode
+func zeroTimeValue(mode ZeroTimeMode, table string, field *schema.Field, column string) (interface{}, error) {
+	switch mode {
+	case ZeroTimeReject:
+		return nil, fmt.Errorf("%w: %s.%s", ErrZeroTime, table, column)
+	case ZeroTimeNull:
+		if field != nil && (field.NotNull || field.PrimaryKey) {
+			return nil, fmt.Errorf("%w: %s.%s is NOT NULL", ErrZeroTime, table, column)
+		}
+		return nil, nil
+	}
+	return zeroTimeWallClock, nil
+}
+
+// zeroTimeCreateValues replaces the zero times of the values as set by the
+// ZeroTime mode
+func zeroTimeCreateValues(db *gorm.DB, createValues clause.Values) error {
+	mode := zeroTimeMode(db)
+	if mode == ZeroTimeDriver {
+		return nil
+	}
+	stmt := db.Statement
+	for i, column := range createValues.Columns {
+		var field *schema.Field
+		if stmt.Schema != nil {
+			field = stmt.Schema.LookUpField(column.Name)
+		}
+		for _, row := range createValues.Values {
+			if !isZeroTime(row[i]) {
+				continue
+			}
+			value, err := zeroTimeValue(mode, stmt.Table, field, column.Name)
+			if err != nil {
+				return err
+			}
+			row[i] = value
+		}
+	}
+	return nil
+}
+
+// zeroTimeAssignments replaces the zero times assigned as set by the
+// ZeroTime mode
+func zeroTimeAssignments(db *gorm.DB, set clause.Set) error {
+	mode := zeroTimeMode(db)
+	if mode == ZeroTimeDriver {
+		return nil
+	}
+	stmt := db.Statement
+	for i, assignment := range set {
+		if !isZeroTime(assignment.Value) {
+			continue
+		}
+		var field *schema.Field
+		if stmt.Schema != nil {
+			field = stmt.Schema.LookUpField(assignment.Column.Name)
+		}
+		value, err := zeroTimeValue(mode, stmt.Table, field, assignment.Column.Name)
+		if err != nil {
+			return err
+		}
+		set[i].Value = value
+	}
+	return nil
+}
+
+// ZeroTimeHandler sets the times stored for zero times with ZeroTimeStore,
+// whose wall clock is 0001-01-01 00:00:00 in any location, to the zero time
+// in the fields of the model. It is registered by the ZeroTimeStore mode.
+func ZeroTimeHandler(db *gorm.DB) {
+	convertTimeFields(db, func(*schema.Field) bool { return true }, func(t time.Time) time.Time {
+		if t.Format(wallClockLayout) == string(zeroTimeWallClock) {
+			return time.Time{}
+		}
+		return t
+	})
+}
@@ -89,10 +89,19 @@ func Update(db *gorm.DB) {
 	if stmt.SQL.Len() == 0 {
 		stmt.SQL.Grow(180)
 		stmt.AddClauseIfNotExists(clause.Update{})
+		if _, ok := limitedRows(stmt); ok {
+			// The limited rows are selected in the WHERE clause
+			stmt.AddClauseIfNotExists(clause.Where{})
+		}
 
 		// Build SET clause if not exists
 		if _, ok := stmt.Clauses["SET"]; !ok {
 			if set := convertToUpdateAssignments(stmt); len(set) != 0 {
+				if err := zeroTimeAssignments(db, set); err != nil {
+					db.AddError(err)
+					return
+				}
+				wallClockAssignments(db, set)
 				defer delete(stmt.Clauses, "SET")
 				stmt.AddClause(set)
 			} else {
@@ -101,6 +110,15 @@ func Update(db *gorm.DB) {
 			}
 		}
 
+		// Fields tagged returning-always are read back into the updated model
+		if updatesModel(stmt) && (!db.DryRun || db.SkipDefaultTransaction) {
+			if _, ok := stmt.Clauses["RETURNING"]; !ok && addReturningAlways(stmt) {
+				defer delete(stmt.Clauses, "RETURNING")
+			} else if ok {
+				addReturningAlways(stmt)
+			}
+		}
+
 		// Check if we need RETURNING clause
 		_, hasReturning := stmt.Clauses["RETURNING"]
 		needsReturning := stmt.Schema != nil && hasReturning
@@ -162,7 +180,7 @@ func checkMissingWhereConditions(db *gorm.DB) {
 				switch e := expr.(type) {
 				case clause.Eq:
 					// Check if this is a soft delete condition
-					if isSoftDeleteCondition(e.Column, e.Value) {
+					if isSoftDeleteCondition(db.Statement.Schema, e.Column, e.Value) {
 						// This is a soft delete condition, skip it
 						continue
 					}
@@ -180,7 +198,7 @@ func checkMissingWhereConditions(db *gorm.DB) {
 					}
 				case clause.Expr:
 					// Check if this is just a soft delete condition
-					if isSoftDeleteExprCondition(e.SQL) {
+					if isSoftDeleteExprCondition(db.Statement.Schema, e.SQL) {
 						// This is just the soft delete condition, not a real filter
 						continue
 					}
@@ -224,8 +242,10 @@ func checkMissingWhereConditions(db *gorm.DB) {
 	db.AddError(gorm.ErrMissingWhereClause)
 }
 
-// Helper function to check if a condition is a soft delete condition
-func isSoftDeleteCondition(column interface{}, value interface{}) bool {
+// Helper function to check if a condition is a soft delete condition: a
+// condition on a DeletedFlag, ArchiveState or DeletedAt field of the schema,
+// or a NULL check of a deleted_at column
+func isSoftDeleteCondition(sch *schema.Schema, column interface{}, value interface{}) bool {
 	// Convert column to string
 	var columnStr string
 	switch c := column.(type) {
@@ -237,6 +257,10 @@ func isSoftDeleteCondition(column interface{}, value interface{}) bool {
 		return false
 	}
 
+	if softDeleteField(sch, columnStr) != nil {
+		return true
+	}
+
 	// Check if column name contains "deleted_at" and value is NULL-like
 	columnLower := strings.ToLower(columnStr)
 	if strings.Contains(columnLower, "deleted_at") {
@@ -248,7 +272,11 @@ func isSoftDeleteCondition(column interface{}, value interface{}) bool {
 }
 
 // Helper function to check if an expression is a soft delete condition
-func isSoftDeleteExprCondition(sql string) bool {
+func isSoftDeleteExprCondition(sch *schema.Schema, sql string) bool {
+	if column, ok := softDeleteExprColumn(sql); ok && softDeleteField(sch, column) != nil {
+		return true
+	}
+
 	sqlLower := strings.ToLower(sql)
 	// Check for common soft delete patterns
 	return strings.Contains(sqlLower, "deleted_at is null") ||
@@ -293,6 +321,8 @@ func convertToUpdateAssignments(stmt *gorm.Statement) (set clause.Set) {
 		addPrimaryKeyWhereClauseForUpdate(stmt)
 	}
 
+	addRowIDWhereClause(stmt)
+
 	switch value := updatingValue.Interface().(type) {
 	case map[string]interface{}:
 		set = make([]clause.Assignment, 0, len(value))
@@ -473,17 +503,22 @@ func buildUpdatePLSQL(db *gorm.DB) {
 		return
 	}
 
+	// Return the requested columns of the updated rows
+	allColumns := plsqlReturningColumns(stmt, getReturnableFields(sch))
+	fields := columnFields(sch, allColumns)
+
 	var plsqlBuilder strings.Builder
 
 	// Start PL/SQL block
 	plsqlBuilder.WriteString("DECLARE\n")
-	writeTableRecordCollectionDecl(db, &plsqlBuilder, getUpdatableFields(stmt.Schema), stmt.Table)
+	writeTableRecordCollectionDecl(db, &plsqlBuilder, allColumns, stmt.Table)
 	plsqlBuilder.WriteString("  l_updated_records t_records;\n")
 	plsqlBuilder.WriteString("BEGIN\n")
 
 	// Build UPDATE statement
 	plsqlBuilder.WriteString("  UPDATE ")
 	db.QuoteTo(&plsqlBuilder, stmt.Table)
+	writePartitionExtension(stmt, plsqlClauseBuilder{&plsqlBuilder, stmt})
 	plsqlBuilder.WriteString(" SET ")
 
 	// Add SET assignments - handle both regular values and expressions
@@ -497,25 +532,25 @@ func buildUpdatePLSQL(db *gorm.DB) {
 		// Check if the value is a clause.Expr (like gorm.Expr)
 		if expr, ok := assignment.Value.(clause.Expr); ok {
 			// Handle expressions directly by building them into SQL
-			exprSQL := expr.SQL
-			varIndex := 0
-
-			// Replace ? placeholders with proper parameter references
-			for strings.Contains(exprSQL, "?") && varIndex < len(expr.Vars) {
-				exprSQL = strings.Replace(exprSQL, "?", fmt.Sprintf(":%d", len(stmt.Vars)+1), 1)
-				stmt.Vars = append(stmt.Vars, convertValue(expr.Vars[varIndex]))
-				varIndex++
-			}
-			plsqlBuilder.WriteString(exprSQL)
+			buildExpressionClause(db, &plsqlBuilder, expr)
+		} else if expr, ok := assignment.Value.(clause.Expression); ok {
+			expr.Build(plsqlClauseBuilder{&plsqlBuilder, stmt})
 		} else {
 			// Handle regular values as parameters
-			plsqlBuilder.WriteString(fmt.Sprintf(":%d", len(stmt.Vars)+1))
 			stmt.Vars = append(stmt.Vars, convertValue(assignment.Value))
+			writeBindVar(&plsqlBuilder, stmt)
 		}
 	}
 
 	// Add WHERE clause if present
-	if hasWhere {
+	if limit, ok := limitedRows(stmt); ok {
+		var writeConditions func()
+		if where, ok := whereClause.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			writeConditions = func() { buildWhereClause(db, &plsqlBuilder, where.Exprs) }
+		}
+		plsqlBuilder.WriteString(" WHERE ")
+		writeLimitedRowIDs(plsqlClauseBuilder{&plsqlBuilder, stmt}, stmt, limit, writeConditions)
+	} else if hasWhere {
 		plsqlBuilder.WriteString(" WHERE ")
 		if where, ok := whereClause.Expression.(clause.Where); ok {
 			buildWhereClause(db, &plsqlBuilder, where.Exprs)
@@ -524,12 +559,11 @@ func buildUpdatePLSQL(db *gorm.DB) {
 
 	// Add RETURNING clause
 	plsqlBuilder.WriteString("\n  RETURNING ")
-	allColumns := getUpdatableFields(sch)
 	for i, column := range allColumns {
 		if i > 0 {
 			plsqlBuilder.WriteString(", ")
 		}
-		db.QuoteTo(&plsqlBuilder, column)
+		quoteTo(db, &plsqlBuilder, column)
 	}
 	plsqlBuilder.WriteString("\n  BULK COLLECT INTO l_updated_records;\n")
 
@@ -537,11 +571,15 @@ func buildUpdatePLSQL(db *gorm.DB) {
 	outParamStartIndex := len(stmt.Vars)
 	//TODO make it configurable
 	estimatedRows := 100 // Estimate maximum rows to update (same as DELETE)
+	if updatesModel(stmt) {
+		// Only the row of the model is read back
+		estimatedRows = 1
+	}
 
 	// First, create all OUT parameters
 	for rowIdx := 0; rowIdx < estimatedRows; rowIdx++ {
-		for _, column := range allColumns {
-			field := findFieldByDBName(sch, column)
+		for colIdx := range allColumns {
+			field := fields[colIdx]
 			if field != nil {
 				var dest interface{}
 				if isJSONField(field) {
@@ -563,7 +601,7 @@ func buildUpdatePLSQL(db *gorm.DB) {
 	// Then, generate PL/SQL assignments with correct parameter indices
 	for rowIdx := 0; rowIdx < estimatedRows; rowIdx++ {
 		for colIdx, column := range allColumns {
-			field := findFieldByDBName(sch, column)
+			field := fields[colIdx]
 			if field != nil {
 				paramIndex := outParamStartIndex + (rowIdx * len(allColumns)) + colIdx + 1
 
@@ -573,17 +611,17 @@ func buildUpdatePLSQL(db *gorm.DB) {
 				if isJSONField(field) {
 					if isRawMessageField(field) {
 						plsqlBuilder.WriteString(fmt.Sprintf("l_updated_records(%d).", rowIdx+1))
-						db.QuoteTo(&plsqlBuilder, column)
+						quoteTo(db, &plsqlBuilder, column)
 					} else {
 						// serialize JSON so it binds as text
 						plsqlBuilder.WriteString("JSON_SERIALIZE(")
 						plsqlBuilder.WriteString(fmt.Sprintf("l_updated_records(%d).", rowIdx+1))
-						db.QuoteTo(&plsqlBuilder, column)
+						quoteTo(db, &plsqlBuilder, column)
 						plsqlBuilder.WriteString(" RETURNING CLOB)")
 					}
 				} else {
 					plsqlBuilder.WriteString(fmt.Sprintf("l_updated_records(%d).", rowIdx+1))
-					db.QuoteTo(&plsqlBuilder, column)
+					quoteTo(db, &plsqlBuilder, column)
 				}
 
 				plsqlBuilder.WriteString("; END IF;\n")
@@ -609,8 +647,9 @@ func executeUpdate(db *gorm.DB) {
 	_, hasReturning := stmt.Clauses["RETURNING"]
 
 	if hasReturning {
-		// Always use ExecContext for PL/SQL blocks with RETURNING
-		result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+		// Always use ExecContext for PL/SQL blocks with RETURNING, which
+		// change with the number of updated rows
+		result, err := unpreparedConnPool(stmt.ConnPool).ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
 
 		if err == nil {
 			db.RowsAffected, _ = result.RowsAffected()
@@ -671,7 +710,8 @@ func getUpdateReturningValues(db *gorm.DB) {
 		return
 	}
 
-	allColumns := getUpdatableFields(db.Statement.Schema)
+	allColumns := plsqlReturningColumns(db.Statement, getReturnableFields(db.Statement.Schema))
+	fields := columnFields(db.Statement.Schema, allColumns)
 
 	if len(allColumns) == 0 {
 		return
@@ -680,7 +720,7 @@ func getUpdateReturningValues(db *gorm.DB) {
 		// For single struct, process row 0 only
 		rowIdx := 0
 
-		for colIdx, column := range allColumns {
+		for colIdx := range allColumns {
 			paramIndex := actualStartIndex + (rowIdx * len(allColumns)) + colIdx
 
 			if paramIndex >= len(db.Statement.Vars) {
@@ -700,7 +740,7 @@ func getUpdateReturningValues(db *gorm.DB) {
 			actualValue := destValue.Elem().Interface()
 
 			// Find the field and set it directly on the target struct
-			field := findFieldByDBName(db.Statement.Schema, column)
+			field := fields[colIdx]
 			if field == nil {
 				continue
 			}
@@ -750,7 +790,7 @@ func getUpdateReturningValues(db *gorm.DB) {
 			targetElement = reflect.New(elementType).Elem()
 		}
 
-		for colIdx, column := range allColumns {
+		for colIdx := range allColumns {
 			paramIndex := actualStartIndex + (rowIdx * len(allColumns)) + colIdx
 
 			if paramIndex >= len(db.Statement.Vars) {
@@ -778,7 +818,7 @@ func getUpdateReturningValues(db *gorm.DB) {
 				hasRealData = true
 			}
 			// Find the field in the schema
-			field := findFieldByDBName(db.Statement.Schema, column)
+			field := fields[colIdx]
 			if field == nil {
 				continue
 			}
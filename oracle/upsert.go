@@ -0,0 +1,148 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const ClauseUpsertOmit = "UPSERT_OMIT"
+
+// UpsertOmitClause lists columns that an upsert with
+// clause.OnConflict{UpdateAll: true} inserts but never updates, such as a
+// creation time or an immutable code. GORM already leaves out the primary
+// key, autoCreateTime fields and fields that are not updatable; these
+// columns are left out of the WHEN MATCHED THEN UPDATE branch of the MERGE
+// as well.
+//
+//	db.Clauses(clause.OnConflict{UpdateAll: true}, oracle.UpsertOmit("registered_at")).Create(&users)
+//
+// Config.UpsertOmitColumns omits columns from every upsert. Columns are
+// given by column or field name. Upserts with explicit DoUpdates are not
+// changed.
+type UpsertOmitClause struct {
+	Columns []string
+}
+
+// UpsertOmit returns a clause omitting the columns from the updates of an
+// upsert with UpdateAll
+func UpsertOmit(columns ...string) UpsertOmitClause {
+	return UpsertOmitClause{Columns: columns}
+}
+
+// Name returns the name of the clause
+func (UpsertOmitClause) Name() string {
+	return ClauseUpsertOmit
+}
+
+// Build writes nothing, the clause only changes the updates of the MERGE
+func (UpsertOmitClause) Build(clause.Builder) {}
+
+// MergeClause adds the columns to those of a previous UPSERT_OMIT clause
+func (u UpsertOmitClause) MergeClause(c *clause.Clause) {
+	if previous, ok := c.Expression.(UpsertOmitClause); ok {
+		u.Columns = append(append([]string(nil), previous.Columns...), u.Columns...)
+	}
+	c.Expression = u
+}
+
+// upsertOmittedColumns returns the column names omitted from the updates of
+// an upsert by the statement and the dialector
+func upsertOmittedColumns(db *gorm.DB) map[string]bool {
+	var columns []string
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		columns = append(columns, d.UpsertOmitColumns...)
+	case Dialector:
+		columns = append(columns, d.UpsertOmitColumns...)
+	}
+	if c, ok := db.Statement.Clauses[ClauseUpsertOmit]; ok {
+		if u, ok := c.Expression.(UpsertOmitClause); ok {
+			columns = append(columns, u.Columns...)
+		}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	omitted := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		if db.Statement.Schema != nil {
+			if field := db.Statement.Schema.LookUpField(column); field != nil && field.DBName != "" {
+				column = field.DBName
+			}
+		}
+		omitted[strings.ToLower(column)] = true
+	}
+	return omitted
+}
+
+// omitUpsertColumns removes the omitted columns from the updates GORM
+// derived from UpdateAll. When no update is left, the upsert only inserts
+// the rows that do not conflict, as GORM does.
+func omitUpsertColumns(db *gorm.DB) {
+	c, ok := db.Statement.Clauses["ON CONFLICT"]
+	if !ok {
+		return
+	}
+	onConflict, ok := c.Expression.(clause.OnConflict)
+	if !ok || !onConflict.UpdateAll || len(onConflict.DoUpdates) == 0 {
+		return
+	}
+	omitted := upsertOmittedColumns(db)
+	if len(omitted) == 0 {
+		return
+	}
+
+	updates := make(clause.Set, 0, len(onConflict.DoUpdates))
+	for _, assignment := range onConflict.DoUpdates {
+		if !omitted[strings.ToLower(assignment.Column.Name)] {
+			updates = append(updates, assignment)
+		}
+	}
+	onConflict.DoUpdates = updates
+	if len(updates) == 0 {
+		onConflict.DoNothing = true
+	}
+	c.Expression = onConflict
+	db.Statement.Clauses["ON CONFLICT"] = c
+}
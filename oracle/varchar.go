@@ -0,0 +1,91 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package oracle
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// The largest sizes of VARCHAR2 columns in bytes, set by the MAX_STRING_SIZE
+// parameter of the database
+const (
+	// StandardMaxStringSize is the largest VARCHAR2 with MAX_STRING_SIZE set
+	// to STANDARD, the default
+	StandardMaxStringSize uint = 4000
+	// ExtendedMaxStringSize is the largest VARCHAR2 with MAX_STRING_SIZE set
+	// to EXTENDED
+	ExtendedMaxStringSize uint = 32767
+)
+
+// standardMaxRawSize is the largest RAW with MAX_STRING_SIZE set to STANDARD.
+// With EXTENDED, RAW columns are as large as VARCHAR2 columns.
+const standardMaxRawSize uint = 2000
+
+// extendedStringSQL only parses with MAX_STRING_SIZE set to EXTENDED, and
+// fails with ORA-00910 otherwise. Unlike V$PARAMETER, it needs no privilege.
+const extendedStringSQL = "SELECT CAST(NULL AS VARCHAR2(32767)) FROM DUAL"
+
+// detectMaxStringSize returns the largest VARCHAR2 size of the database,
+// StandardMaxStringSize if it can't be told
+func detectMaxStringSize(pool gorm.ConnPool) uint {
+	var value *string
+	if err := pool.QueryRowContext(context.Background(), extendedStringSQL).Scan(&value); err != nil {
+		return StandardMaxStringSize
+	}
+	return ExtendedMaxStringSize
+}
+
+// maxStringSize returns the largest VARCHAR2 size of the dialector,
+// StandardMaxStringSize if it is not set or detected
+func (d Dialector) maxStringSize() uint {
+	if d.MaxStringSize == 0 {
+		return StandardMaxStringSize
+	}
+	return d.MaxStringSize
+}
+
+// maxRawSize returns the largest RAW size of the dialector
+func (d Dialector) maxRawSize() uint {
+	if size := d.maxStringSize(); size > StandardMaxStringSize {
+		return size
+	}
+	return standardMaxRawSize
+}
@@ -0,0 +1,315 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+package oracle
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// XID identifies a branch of a distributed transaction, as assigned by the
+// transaction manager coordinating it
+type XID struct {
+	// FormatID identifies the format of the other fields, 0 for the OSI CCR
+	// format
+	FormatID int
+	// GlobalTransactionID is shared by the branches of a transaction, at most
+	// 64 bytes
+	GlobalTransactionID []byte
+	// BranchQualifier identifies the branch, at most 64 bytes
+	BranchQualifier []byte
+}
+
+// String returns the XID as format.gtrid.bqual, with hex encoded ids
+func (x XID) String() string {
+	return fmt.Sprintf("%d.%s.%s", x.FormatID, hex.EncodeToString(x.GlobalTransactionID), hex.EncodeToString(x.BranchQualifier))
+}
+
+// XA return codes of DBMS_XA
+const (
+	xaOK       = 0
+	xaReadOnly = 3
+)
+
+// XATransaction is a branch of a distributed transaction, run with DBMS_XA on
+// a connection held until it is committed or rolled back. Like sql.Tx, it is
+// finished by Commit or Rollback, after which its methods return
+// sql.ErrTxDone.
+//
+//	xa, err := oracle.BeginXA(db, xid, 0)
+//	if err != nil {
+//		return err
+//	}
+//	if err := xa.DB().Create(&order).Error; err != nil {
+//		xa.Rollback()
+//		return err
+//	}
+//	if _, err := xa.Prepare(); err != nil {
+//		return err
+//	}
+//	// after every branch is prepared
+//	return xa.Commit()
+type XATransaction struct {
+	xid  XID
+	conn *sql.Conn
+	tx   *sql.Tx
+	db   *gorm.DB
+
+	ended    bool
+	prepared bool
+	readOnly bool
+	done     bool
+}
+
+// BeginXA starts the branch xid of a distributed transaction on a connection
+// of db. A branch left inactive for longer than timeout is rolled back by the
+// database, which applies its own default when timeout is 0.
+func BeginXA(db *gorm.DB, xid XID, timeout time.Duration) (*XATransaction, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	ctx := db.Statement.Context
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Statements of the branch must not commit, so they run in a local
+	// transaction, which the driver may open with SET TRANSACTION and which
+	// XA_START would refuse to replace
+	tx, err := conn.BeginTx(ctx, nil)
+	if err == nil {
+		if _, err = tx.ExecContext(ctx, "ROLLBACK"); err != nil {
+			tx.Rollback()
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	x := &XATransaction{xid: xid, conn: conn, tx: tx}
+	x.db = db.Session(&gorm.Session{NewDB: true, Context: ctx})
+	x.db.Statement.ConnPool = tx
+
+	if timeout > 0 {
+		_, err = xaCall(x.db, "XA_SETTIMEOUT", fmt.Sprintf("XA_SETTIMEOUT(%d)", int(timeout.Seconds())), xid, false)
+	}
+	if err == nil {
+		_, err = xaCall(x.db, "XA_START", "XA_START(?, DBMS_XA.TMNOFLAGS)", xid, true)
+	}
+	if err != nil {
+		x.release()
+		return nil, err
+	}
+	return x, nil
+}
+
+// XID returns the id of the branch
+func (x *XATransaction) XID() XID {
+	return x.xid
+}
+
+// DB returns a session running its statements in the branch. GORM does not
+// start transactions of its own in it, and Transaction uses savepoints.
+func (x *XATransaction) DB() *gorm.DB {
+	return x.db
+}
+
+// end detaches the session from the branch
+func (x *XATransaction) end() error {
+	if x.ended {
+		return nil
+	}
+	x.ended = true
+	_, err := xaCall(x.db, "XA_END", "XA_END(?, DBMS_XA.TMSUCCESS)", x.xid, true)
+	return err
+}
+
+// Prepare ends the branch and prepares it, the first phase of the commit. A
+// branch that wrote nothing is read-only: it is already complete, and Commit
+// only releases its connection.
+func (x *XATransaction) Prepare() (readOnly bool, err error) {
+	if x.done {
+		return false, sql.ErrTxDone
+	}
+	if x.prepared {
+		return x.readOnly, nil
+	}
+	if err := x.end(); err != nil {
+		return false, err
+	}
+	rc, err := xaCall(x.db, "XA_PREPARE", "XA_PREPARE(?)", x.xid, true)
+	if err != nil {
+		return false, err
+	}
+	x.prepared = true
+	x.readOnly = rc == xaReadOnly
+	return x.readOnly, nil
+}
+
+// Commit commits a prepared branch, or commits the branch in one phase when
+// it is the only participant and was not prepared, and releases its
+// connection
+func (x *XATransaction) Commit() error {
+	if x.done {
+		return sql.ErrTxDone
+	}
+	defer x.release()
+
+	if !x.prepared {
+		if err := x.end(); err != nil {
+			return err
+		}
+		_, err := xaCall(x.db, "XA_COMMIT", "XA_COMMIT(?, TRUE)", x.xid, true)
+		return err
+	}
+	if x.readOnly {
+		return nil
+	}
+	_, err := xaCall(x.db, "XA_COMMIT", "XA_COMMIT(?, FALSE)", x.xid, true)
+	return err
+}
+
+// Rollback rolls the branch back and releases its connection
+func (x *XATransaction) Rollback() error {
+	if x.done {
+		return sql.ErrTxDone
+	}
+	defer x.release()
+
+	if x.prepared && x.readOnly {
+		return nil
+	}
+	// The rollback is attempted even if the branch could not be ended
+	endErr := x.end()
+	if _, err := xaCall(x.db, "XA_ROLLBACK", "XA_ROLLBACK(?)", x.xid, true); err != nil {
+		return err
+	}
+	return endErr
+}
+
+// release ends the local transaction, which holds no changes, and returns
+// the connection to the pool
+func (x *XATransaction) release() {
+	x.done = true
+	x.tx.Rollback()
+	x.conn.Close()
+}
+
+// CommitXA commits the prepared branch xid from any session, such as a
+// branch found by RecoverXA after the process that prepared it failed. It
+// needs the FORCE ANY TRANSACTION privilege for branches of other users.
+func CommitXA(db *gorm.DB, xid XID) error {
+	_, err := xaCall(db, "XA_COMMIT", "XA_COMMIT(?, FALSE)", xid, true)
+	return err
+}
+
+// RollbackXA rolls back the prepared branch xid from any session
+func RollbackXA(db *gorm.DB, xid XID) error {
+	_, err := xaCall(db, "XA_ROLLBACK", "XA_ROLLBACK(?)", xid, true)
+	return err
+}
+
+// RecoverXA returns the branches that are prepared and wait for a commit or
+// rollback. It reads DBA_PENDING_TRANSACTIONS, which needs the SELECT ANY
+// DICTIONARY privilege or a grant on the view.
+func RecoverXA(db *gorm.DB) ([]XID, error) {
+	var rows []struct {
+		FormatID int
+		GlobalID []byte
+		BranchID []byte
+	}
+	err := db.Raw(`SELECT "FORMATID" AS "format_id", "GLOBALID" AS "global_id", "BRANCHID" AS "branch_id"
+FROM DBA_PENDING_TRANSACTIONS ORDER BY "GLOBALID", "BRANCHID"`).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	xids := make([]XID, len(rows))
+	for i, row := range rows {
+		xids[i] = XID{FormatID: row.FormatID, GlobalTransactionID: row.GlobalID, BranchQualifier: row.BranchID}
+	}
+	return xids, nil
+}
+
+// xaCall runs the DBMS_XA function call, whose ? placeholder is bound to
+// xid when withXID is set, and returns its XA return code. Return codes
+// other than XA_OK and XA_RDONLY are returned as an XAError, together with
+// the Oracle error reported by XA_GETLASTOER.
+func xaCall(db *gorm.DB, op string, call string, xid XID, withXID bool) (int, error) {
+	var rc, oer int64
+	var msg string
+	vars := []interface{}{sql.Out{Dest: &rc}}
+	if withXID {
+		call = strings.Replace(call, "?", "DBMS_XA_XID(?, ?, ?)", 1)
+		vars = append(vars, xid.FormatID, xid.GlobalTransactionID, xid.BranchQualifier)
+	}
+	vars = append(vars, sql.Out{Dest: &oer}, sql.Out{Dest: &msg})
+
+	block := `DECLARE
+  l_oer PLS_INTEGER;
+BEGIN
+  ? := DBMS_XA.` + call + `;
+  l_oer := DBMS_XA.XA_GETLASTOER;
+  ? := l_oer;
+  IF l_oer <> 0 THEN
+    ? := SQLERRM(-l_oer);
+  END IF;
+END;`
+	if err := db.Exec(block, vars...).Error; err != nil {
+		return 0, err
+	}
+	if rc != xaOK && rc != xaReadOnly {
+		xaErr := &XAError{Operation: op, XID: xid, Code: int(rc)}
+		if oer != 0 {
+			if e := asError(errors.New(msg)); e != nil {
+				xaErr.Err = e
+			} else {
+				xaErr.Err = &Error{Code: int(oer), Message: msg}
+			}
+		}
+		return int(rc), xaErr
+	}
+	return int(rc), nil
+}
@@ -0,0 +1,332 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+// Package oracletest runs integration tests against an Oracle Database. It
+// starts Oracle Database Free in a container, or connects to a database given
+// by a DSN, waits until it accepts connections, and creates a schema of its
+// own for each run, which is dropped when the run ends.
+//
+//	func TestMain(m *testing.M) {
+//		database, err := oracletest.Start(context.Background(), oracletest.Options{})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		DB = database.DB
+//		code := m.Run()
+//		database.Close()
+//		os.Exit(code)
+//	}
+//
+// A single test can use New, which closes the database with the test:
+//
+//	db := oracletest.New(t, oracletest.Options{})
+package oracletest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godror/godror"
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	// DefaultImage is the container image started when no DSN is given
+	DefaultImage = "container-registry.oracle.com/database/free:latest"
+	// DefaultStartupTimeout is how long Start waits for the database
+	DefaultStartupTimeout = 10 * time.Minute
+	// DSNEnv is the environment variable read for Options.DSN
+	DSNEnv = "GORM_ORACLE_TEST_DSN"
+	// LibDirEnv is the environment variable read for Options.LibDir
+	LibDirEnv = "GORM_ORACLE_TEST_LIBDIR"
+)
+
+// schemaPrivileges are granted to the schema of a run
+var schemaPrivileges = []string{
+	"CREATE SESSION", "CREATE TABLE", "CREATE VIEW", "CREATE SEQUENCE",
+	"CREATE PROCEDURE", "CREATE TRIGGER", "CREATE TYPE", "CREATE SYNONYM",
+}
+
+// Options configures Start
+type Options struct {
+	// DSN connects to an existing database as a user allowed to create and
+	// drop users, such as SYSTEM. It defaults to GORM_ORACLE_TEST_DSN. When
+	// both are empty, a container is started.
+	DSN string
+	// Image is the container image, DefaultImage if empty. It must accept
+	// the ORACLE_PWD variable and serve the FREEPDB1 service on port 1521.
+	Image string
+	// LibDir is the Oracle Instant Client directory passed to godror,
+	// GORM_ORACLE_TEST_LIBDIR if empty
+	LibDir string
+	// StartupTimeout limits the wait for the database, DefaultStartupTimeout
+	// if zero
+	StartupTimeout time.Duration
+	// Grants are privileges granted to the schema on top of those needed to
+	// create tables, views, sequences, procedures, triggers and types, such
+	// as EXECUTE ON DBMS_LOCK
+	Grants []string
+	// Config configures the dialector, whose DataSourceName is set by Start
+	Config oracle.Config
+	// GormConfig configures the connection of DB, with a silent logger if
+	// nil
+	GormConfig *gorm.Config
+}
+
+// Database is a schema created for a test run
+type Database struct {
+	// DB is connected as the owner of the schema
+	DB *gorm.DB
+	// Schema is the name of the schema and of its user
+	Schema string
+	// DSN connects as the owner of the schema
+	DSN string
+
+	admin     *gorm.DB
+	container string
+}
+
+// Start connects to the database of the options, or starts a container,
+// waits until the database accepts connections, and creates a schema for
+// the run
+func Start(ctx context.Context, opts Options) (_ *Database, err error) {
+	if opts.DSN == "" {
+		opts.DSN = os.Getenv(DSNEnv)
+	}
+	if opts.LibDir == "" {
+		opts.LibDir = os.Getenv(LibDirEnv)
+	}
+	if opts.StartupTimeout <= 0 {
+		opts.StartupTimeout = DefaultStartupTimeout
+	}
+
+	d := &Database{}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, d.Close())
+		}
+	}()
+
+	adminDSN := opts.DSN
+	if adminDSN == "" {
+		if adminDSN, err = d.startContainer(ctx, opts); err != nil {
+			return nil, err
+		}
+	}
+	params, err := godror.ParseDSN(adminDSN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DSN: %w", err)
+	}
+	if opts.LibDir != "" {
+		params.LibDir = opts.LibDir
+	}
+
+	silent := &gorm.Config{Logger: logger.Discard}
+	if d.admin, err = waitForDatabase(ctx, params.StringWithPassword(), opts.StartupTimeout, silent); err != nil {
+		return nil, err
+	}
+
+	d.Schema = "GORM_TEST_" + strings.ToUpper(randomHex(6))
+	password := "P" + randomHex(12)
+	if err = d.createSchema(password, opts.Grants); err != nil {
+		return nil, err
+	}
+
+	params.Username = d.Schema
+	params.Password = godror.NewPassword(password)
+	params.AdminRole = ""
+	d.DSN = params.StringWithPassword()
+
+	config := opts.Config
+	config.DataSourceName = d.DSN
+	gormConfig := opts.GormConfig
+	if gormConfig == nil {
+		gormConfig = silent
+	}
+	if d.DB, err = gorm.Open(oracle.New(config), gormConfig); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// New starts a database for the test, skipping it when neither a DSN nor
+// docker is available, and closes the database when the test ends
+func New(t testing.TB, opts Options) *gorm.DB {
+	t.Helper()
+	if opts.DSN == "" && os.Getenv(DSNEnv) == "" {
+		if _, err := exec.LookPath("docker"); err != nil {
+			t.Skipf("oracletest: no %s and no docker to start a database", DSNEnv)
+		}
+	}
+
+	d, err := Start(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("oracletest: failed to start database: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := d.Close(); err != nil {
+			t.Errorf("oracletest: failed to close database: %v", err)
+		}
+	})
+	return d.DB
+}
+
+// Close drops the schema of the run and stops the container, if one was
+// started
+func (d *Database) Close() error {
+	var errs []error
+	if d.DB != nil {
+		if sqlDB, err := d.DB.DB(); err == nil {
+			errs = append(errs, sqlDB.Close())
+		}
+		d.DB = nil
+	}
+	if d.admin != nil {
+		if d.Schema != "" {
+			errs = append(errs, d.admin.Exec(`DROP USER "`+d.Schema+`" CASCADE`).Error)
+		}
+		if sqlDB, err := d.admin.DB(); err == nil {
+			errs = append(errs, sqlDB.Close())
+		}
+		d.admin = nil
+	}
+	if d.container != "" {
+		if out, err := exec.Command("docker", "rm", "-f", d.container).CombinedOutput(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove container %s: %w: %s", d.container, err, out))
+		}
+		d.container = ""
+	}
+	return errors.Join(errs...)
+}
+
+// startContainer starts the image with a random password and returns the
+// DSN connecting to it as SYSTEM
+func (d *Database) startContainer(ctx context.Context, opts Options) (string, error) {
+	image := opts.Image
+	if image == "" {
+		image = DefaultImage
+	}
+	password := "P" + randomHex(12)
+
+	out, err := exec.CommandContext(ctx, "docker", "run", "--detach", "--publish", "127.0.0.1::1521",
+		"--env", "ORACLE_PWD="+password, image).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to start container %s: %w", image, commandError(err))
+	}
+	d.container = strings.TrimSpace(string(out))
+
+	out, err = exec.CommandContext(ctx, "docker", "port", d.container, "1521/tcp").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the port of container %s: %w", d.container, commandError(err))
+	}
+	// 127.0.0.1:49153, possibly followed by other bindings
+	address := strings.Fields(string(out))
+	if len(address) == 0 {
+		return "", fmt.Errorf("container %s publishes no port", d.container)
+	}
+
+	connect := "//" + address[0] + "/FREEPDB1"
+	return fmt.Sprintf("user=system password=%q connectString=%q", password, connect), nil
+}
+
+// waitForDatabase connects to the database until it answers a query or the
+// timeout expires
+func waitForDatabase(ctx context.Context, dsn string, timeout time.Duration, config *gorm.Config) (*gorm.DB, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		db, err := gorm.Open(oracle.Open(dsn), config)
+		if err == nil {
+			var one int
+			if err = db.WithContext(ctx).Raw("SELECT 1 FROM DUAL").Scan(&one).Error; err == nil {
+				return db, nil
+			}
+			if sqlDB, dbErr := db.DB(); dbErr == nil {
+				sqlDB.Close()
+			}
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("database not ready after %v: %w", timeout, errors.Join(ctx.Err(), lastErr))
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// createSchema creates the user owning the schema of the run
+func (d *Database) createSchema(password string, grants []string) error {
+	if err := d.admin.Exec(fmt.Sprintf(`CREATE USER "%s" IDENTIFIED BY "%s" QUOTA UNLIMITED ON USERS`, d.Schema, password)).Error; err != nil {
+		return fmt.Errorf("failed to create schema %s: %w", d.Schema, err)
+	}
+	for _, privilege := range slices.Concat(schemaPrivileges, grants) {
+		if err := d.admin.Exec(fmt.Sprintf(`GRANT %s TO "%s"`, privilege, d.Schema)).Error; err != nil {
+			return fmt.Errorf("failed to grant %s to %s: %w", privilege, d.Schema, err)
+		}
+	}
+	return nil
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// commandError adds the standard error of a failed command to its error
+func commandError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
@@ -0,0 +1,211 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	. "github.com/oracle-samples/gorm-oracle/tests/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestAggregateExpressions(t *testing.T) {
+	users := []User{
+		*GetUser("aggregate_1", Config{}),
+		*GetUser("aggregate_2", Config{}),
+		*GetUser("aggregate_3", Config{}),
+	}
+	users[0].Age, users[1].Age, users[2].Age = 71, 71, 72
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&User{}).Select("?, ?",
+			clause.Column{Name: "age"},
+			oracle.ListAgg("name", "', ").WithDistinct().WithinGroup("name").Truncate("...", true).As("names"),
+		).Group("age").Find(&[]map[string]interface{}{})
+	})
+	if !regexp.MustCompile(`SELECT "age", LISTAGG\(DISTINCT "name", ''', ' ON OVERFLOW TRUNCATE '...' WITH COUNT\) WITHIN GROUP \(ORDER BY "name"\) AS "names" FROM "users"`).MatchString(sql) {
+		t.Errorf("unexpected LISTAGG SQL, got %v", sql)
+	}
+
+	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&User{}).Select("?", oracle.KeepLast("max", "name", clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: true})).
+			Having("? > ?", oracle.ApproxCountDistinct("name"), 1).Group("age").Find(&[]map[string]interface{}{})
+	})
+	if !regexp.MustCompile(`SELECT MAX\("name"\) KEEP \(DENSE_RANK LAST ORDER BY "id" DESC\) FROM .* HAVING APPROX_COUNT_DISTINCT\("name"\) > 1`).MatchString(sql) {
+		t.Errorf("unexpected KEEP/APPROX_COUNT_DISTINCT SQL, got %v", sql)
+	}
+
+	if err := DB.Session(&gorm.Session{DryRun: true}).Model(&User{}).Select("?", oracle.KeepFirst("max(1); --", "name", "id")).Find(&[]User{}).Error; err == nil {
+		t.Errorf("expected error for invalid aggregate function")
+	}
+
+	type result struct {
+		Age    uint
+		Names  string
+		Oldest string
+		Count  int
+	}
+	var results []result
+	err := DB.Model(&User{}).
+		Select("?, ?, ?, ?",
+			clause.Column{Name: "age"},
+			oracle.ListAgg("name", ",").WithinGroup("name").As("names"),
+			oracle.KeepFirst("MIN", "name", "id").As("oldest"),
+			oracle.ApproxCountDistinct("name").As("count"),
+		).
+		Where("\"name\" LIKE ?", "aggregate_%").
+		Group("age").Order("\"age\"").Scan(&results).Error
+	if err != nil {
+		t.Fatalf("failed to query aggregates, got error %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 groups, got %v", results)
+	}
+	if results[0].Names != "aggregate_1,aggregate_2" || results[0].Oldest != "aggregate_1" || results[0].Count != 2 {
+		t.Errorf("unexpected aggregates for first group, got %+v", results[0])
+	}
+	if results[1].Names != "aggregate_3" || results[1].Count != 1 {
+		t.Errorf("unexpected aggregates for second group, got %+v", results[1])
+	}
+}
+
+func TestGroupingExtensions(t *testing.T) {
+	users := []User{
+		*GetUser("grouping_1", Config{}),
+		*GetUser("grouping_2", Config{}),
+		*GetUser("grouping_3", Config{}),
+	}
+	users[0].Age, users[1].Age, users[2].Age = 81, 81, 82
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&User{}).Clauses(oracle.Cube("age", "u.name")).Find(&[]map[string]interface{}{})
+	})
+	if !regexp.MustCompile(`GROUP BY CUBE\("age","u"."name"\)`).MatchString(sql) {
+		t.Errorf("unexpected CUBE SQL, got %v", sql)
+	}
+
+	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&User{}).Clauses(oracle.GroupingSets([]string{"age", "name"}, []string{"age"}, nil)).Find(&[]map[string]interface{}{})
+	})
+	if !regexp.MustCompile(`GROUP BY GROUPING SETS\(\("age","name"\),\("age"\),\(\)\)`).MatchString(sql) {
+		t.Errorf("unexpected GROUPING SETS SQL, got %v", sql)
+	}
+
+	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&User{}).Group("name").Clauses(oracle.Rollup("Age")).Having("COUNT(*) > ?", 1).Find(&[]map[string]interface{}{})
+	})
+	if !regexp.MustCompile(`GROUP BY "name",ROLLUP\("age"\) HAVING COUNT\(\*\) > 1`).MatchString(sql) {
+		t.Errorf("unexpected ROLLUP SQL with grouped columns, got %v", sql)
+	}
+
+	skipQuoteDB, err := openTestDBWithOptions(&oracle.Config{SkipQuoteIdentifiers: true}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open database, got error %v", err)
+	}
+	sql = skipQuoteDB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("users").Clauses(oracle.Rollup("age", "name")).Find(&[]map[string]interface{}{})
+	})
+	if !regexp.MustCompile(`GROUP BY ROLLUP\(age,name\)`).MatchString(sql) {
+		t.Errorf("expected unquoted ROLLUP columns, got %v", sql)
+	}
+
+	type result struct {
+		Age      *uint
+		Subtotal int
+		Count    int
+	}
+	var results []result
+	err = DB.Model(&User{}).
+		Select("?, ?, COUNT(*) AS \"count\"", clause.Column{Name: "age"}, oracle.Grouping("age").As("subtotal")).
+		Where("\"name\" LIKE ?", "grouping_%").
+		Clauses(oracle.Rollup("age")).
+		Order("\"subtotal\", \"age\"").
+		Scan(&results).Error
+	if err != nil {
+		t.Fatalf("failed to query with ROLLUP, got error %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 2 groups and a grand total, got %+v", results)
+	}
+	if results[0].Count != 2 || results[1].Count != 1 {
+		t.Errorf("unexpected group counts, got %+v", results)
+	}
+	if results[2].Subtotal != 1 || results[2].Age != nil || results[2].Count != 3 {
+		t.Errorf("expected grand total row, got %+v", results[2])
+	}
+
+	// Grouped columns are in every grouping set, so there is no grand total
+	type groupedResult struct {
+		Age      uint
+		Name     *string
+		Subtotal int
+		Count    int
+	}
+	var grouped []groupedResult
+	err = DB.Model(&User{}).
+		Select("\"age\", \"name\", ?, COUNT(*) AS \"count\"", oracle.Grouping("name").As("subtotal")).
+		Where("\"name\" LIKE ?", "grouping_%").
+		Group("age").
+		Clauses(oracle.Rollup("name")).
+		Order("\"age\", \"subtotal\", \"name\"").
+		Scan(&grouped).Error
+	if err != nil {
+		t.Fatalf("failed to query with Group and ROLLUP, got error %v", err)
+	}
+	if len(grouped) != 5 {
+		t.Fatalf("expected 3 groups and 2 subtotals by age, got %+v", grouped)
+	}
+	for i, expected := range []groupedResult{{81, &users[0].Name, 0, 1}, {81, &users[1].Name, 0, 1}, {81, nil, 1, 2}, {82, &users[2].Name, 0, 1}, {82, nil, 1, 1}} {
+		got := grouped[i]
+		if got.Age != expected.Age || got.Subtotal != expected.Subtotal || got.Count != expected.Count ||
+			(got.Name == nil) != (expected.Name == nil) || (got.Name != nil && *got.Name != *expected.Name) {
+			t.Errorf("unexpected row %d, expected %+v, got %+v", i, expected, got)
+		}
+	}
+}
@@ -39,9 +39,12 @@
 package tests
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
@@ -637,3 +640,75 @@ func TestMany2ManyConstraintViolations(t *testing.T) {
 		t.Errorf("Expected count 0 after clear, got %d", countAfterClear)
 	}
 }
+
+func TestMany2ManyArrayDML(t *testing.T) {
+	users := []User{*GetUser("many2many-array-dml-1", Config{}), *GetUser("many2many-array-dml-2", Config{})}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+	languages := []Language{
+		{Code: "m2m-array-1", Name: "m2m-array-1"},
+		{Code: "m2m-array-2", Name: "m2m-array-2"},
+		{Code: "m2m-array-3", Name: "m2m-array-3"},
+	}
+	if err := DB.Create(&languages).Error; err != nil {
+		t.Fatalf("failed to create languages, got error: %v", err)
+	}
+
+	var statements []string
+	sess := DB.Session(&gorm.Session{Logger: Tracer{
+		Logger: DB.Config.Logger,
+		Test: func(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+			sql, _ := fc()
+			statements = append(statements, sql)
+		},
+	}})
+	joinTableSQL := func(prefix string) (found []string) {
+		for _, sql := range statements {
+			if strings.HasPrefix(sql, prefix) && strings.Contains(sql, "language_code") {
+				found = append(found, sql)
+			}
+		}
+		return found
+	}
+
+	for i := range users {
+		if err := sess.Model(&users[i]).Association("Languages").Append(&languages); err != nil {
+			t.Fatalf("failed to append languages, got error: %v", err)
+		}
+		AssertAssociationCount(t, users[i], "Languages", 3, "after append")
+	}
+	merges := joinTableSQL("MERGE INTO")
+	if len(merges) != len(users) {
+		t.Fatalf("expected one MERGE per append, got %v", statements)
+	}
+	for _, sql := range merges {
+		if strings.Contains(sql, "UNION ALL") {
+			t.Errorf("expected a single row of array binds, got %s", sql)
+		}
+	}
+
+	// appending again leaves the existing rows of the join table alone
+	if err := sess.Model(&users[0]).Association("Languages").Append(&languages[0]); err != nil {
+		t.Fatalf("failed to append a language again, got error: %v", err)
+	}
+	AssertAssociationCount(t, users[0], "Languages", 3, "after appending again")
+
+	statements = nil
+	if err := sess.Model(&users).Association("Languages").Delete(&languages[0], &languages[1]); err != nil {
+		t.Fatalf("failed to delete languages, got error: %v", err)
+	}
+	deletes := joinTableSQL("DELETE FROM")
+	if len(deletes) != 1 || strings.Contains(deletes[0], " IN ") {
+		t.Errorf("expected a single DELETE with array binds, got %v", statements)
+	}
+	for _, user := range users {
+		AssertAssociationCount(t, user, "Languages", 1, "after delete")
+	}
+
+	if err := sess.Model(&users[0]).Association("Languages").Replace(&languages[1]); err != nil {
+		t.Fatalf("failed to replace languages, got error: %v", err)
+	}
+	AssertAssociationCount(t, users[0], "Languages", 1, "after replace")
+	AssertAssociationCount(t, users[1], "Languages", 1, "after replacing the languages of another user")
+}
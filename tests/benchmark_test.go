@@ -43,6 +43,8 @@ import (
 	"testing"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 )
 
@@ -134,6 +136,48 @@ func BenchmarkCreateInBatches(b *testing.B) {
 	}
 }
 
+func BenchmarkCreateInBatchesArrayDML(b *testing.B) {
+	benchmarkCreateInBatchesInsertOnly(b, DB)
+}
+
+func BenchmarkCreateInBatchesValuesList(b *testing.B) {
+	features := DB.Dialector.(*oracle.Dialector).Features()
+	features.ArrayDML = false
+	db, err := openTestDBWithOptions(&oracle.Config{DriverFeatures: &features}, &gorm.Config{})
+	if err != nil {
+		b.Fatalf("failed to connect database, got error %v", err)
+	}
+	benchmarkCreateInBatchesInsertOnly(b, db)
+}
+
+// benchmarkCreateInBatchesInsertOnly creates batches of users without
+// returning their IDs, which is the path array DML speeds up
+func benchmarkCreateInBatchesInsertOnly(b *testing.B, db *gorm.DB) {
+	users := make([]User, 1000)
+	for i := 0; i < len(users); i++ {
+		users[i] = *GetUser(fmt.Sprintf("batch-%d", i), Config{})
+	}
+
+	b.ResetTimer()
+	for x := 0; x < b.N; x++ {
+		db.Clauses(oracle.InsertOnly()).CreateInBatches(users, 500)
+	}
+}
+
+func BenchmarkBuildCreateInBatches(b *testing.B) {
+	users := make([]User, 100)
+	for i := 0; i < len(users); i++ {
+		users[i] = *GetUser(fmt.Sprintf("build-%d", i), Config{})
+	}
+	dryRun := DB.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for x := 0; x < b.N; x++ {
+		dryRun.Clauses(clause.Returning{}).Create(&users)
+	}
+}
+
 func BenchmarkFirst(b *testing.B) {
 	user := *GetUser("first", Config{})
 	DB.Create(&user)
@@ -39,13 +39,16 @@
 package tests
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"time"
@@ -182,6 +185,63 @@ func TestCreateFromMap(t *testing.T) {
 	}
 }
 
+func TestCreateFromMapsInBulk(t *testing.T) {
+	type MapBulkItem struct {
+		ID      uint
+		Name    string
+		Amount  float64
+		Notes   string `gorm:"type:CLOB"`
+		DueDate *time.Time
+	}
+
+	DB.Migrator().DropTable(&MapBulkItem{})
+	if err := DB.AutoMigrate(&MapBulkItem{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&MapBulkItem{})
+
+	due := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	withModel := []map[string]interface{}{
+		{"Name": "map-bulk-1", "Amount": 12, "DueDate": nil},
+		{"Name": "map-bulk-2", "Amount": "12.5", "DueDate": due},
+		{"Name": "map-bulk-3", "Amount": nil, "DueDate": nil},
+	}
+	if err := DB.Model(&MapBulkItem{}).Create(&withModel).Error; err != nil {
+		t.Fatalf("failed to create items from maps, got error: %v", err)
+	}
+	for _, row := range withModel {
+		if id, ok := row["ID"]; !ok || reflect.ValueOf(id).IsZero() {
+			t.Errorf("expected the ID to be returned into %v", row)
+		}
+	}
+
+	long := strings.Repeat("n", 5000)
+	withTable := []map[string]interface{}{
+		{"name": "map-bulk-4", "amount": 1, "notes": long},
+		{"name": "map-bulk-5", "amount": nil, "notes": nil},
+	}
+	if err := DB.Table("map_bulk_items").Create(&withTable).Error; err != nil {
+		t.Fatalf("failed to create items from maps with a table, got error: %v", err)
+	}
+
+	var items []MapBulkItem
+	if err := DB.Order("\"name\"").Find(&items).Error; err != nil {
+		t.Fatalf("failed to load items, got error: %v", err)
+	}
+	if len(items) != 5 {
+		t.Fatalf("expected 5 items, got %d", len(items))
+	}
+	if items[0].Amount != 12 || items[1].Amount != 12.5 || items[2].Amount != 0 || items[3].Amount != 1 {
+		t.Errorf("expected the amounts to be converted to the column type, got %+v", items)
+	}
+	if items[0].DueDate != nil || items[1].DueDate == nil || !items[1].DueDate.Equal(due) {
+		t.Errorf("expected the due dates to be inserted, got %v and %v", items[0].DueDate, items[1].DueDate)
+	}
+	if items[3].Notes != long || items[4].Notes != "" {
+		t.Errorf("expected the notes to be inserted as a CLOB, got %d and %d characters", len(items[3].Notes), len(items[4].Notes))
+	}
+}
+
 func TestCreateWithAssociations(t *testing.T) {
 	user := *GetUser("create_with_associations", Config{
 		Account:   true,
@@ -1048,3 +1108,417 @@ func TestCreateReadOnlyJson(t *testing.T) {
 		t.Fatalf("expected 1 parent, got %d", len(results))
 	}
 }
+
+func TestCreateInsertOnly(t *testing.T) {
+	users := []User{*GetUser("insert_only_1", Config{}), *GetUser("insert_only_2", Config{}), *GetUser("insert_only_3", Config{})}
+
+	stmt := DB.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true}).
+		Clauses(oracle.InsertOnly()).Create(&users).Statement
+	if sql := stmt.SQL.String(); strings.Contains(sql, "RETURNING") || strings.Contains(sql, "BEGIN") {
+		t.Fatalf("expected a plain INSERT in insert-only mode, got %s", sql)
+	}
+
+	if err := DB.Clauses(oracle.InsertOnly()).Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+	for _, user := range users {
+		if user.ID != 0 {
+			t.Errorf("expected ID to stay zero in insert-only mode, got %d", user.ID)
+		}
+	}
+
+	var count int64
+	DB.Model(&User{}).Where("\"name\" IN ?", []string{"insert_only_1", "insert_only_2", "insert_only_3"}).Count(&count)
+	if count != 3 {
+		t.Errorf("expected 3 users to be inserted, got %d", count)
+	}
+
+	db, err := openTestDBWithOptions(&oracle.Config{InsertOnly: true}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	user := *GetUser("insert_only_global", Config{})
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user, got error: %v", err)
+	}
+	if user.ID != 0 {
+		t.Errorf("expected ID to stay zero with Config.InsertOnly, got %d", user.ID)
+	}
+
+	user = *GetUser("insert_only_disabled", Config{})
+	if err := db.Clauses(oracle.InsertOnlyMode{Disable: true}).Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user, got error: %v", err)
+	}
+	if user.ID == 0 {
+		t.Errorf("expected ID to be returned when insert-only mode is disabled")
+	}
+}
+
+func TestCreateArrayDML(t *testing.T) {
+	users := []User{
+		*GetUser("array_dml_1", Config{}),
+		*GetUser("array_dml_2", Config{}),
+		*GetUser("array_dml_3", Config{}),
+	}
+	users[1].Birthday = nil
+	users[1].Active = true
+
+	stmt := DB.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true}).
+		Clauses(oracle.InsertOnly()).Create(&users).Statement
+	if sql := stmt.SQL.String(); strings.Count(sql, "(:1,") != 1 || strings.Contains(sql, "),(") {
+		t.Fatalf("expected a single row of array binds, got %s", sql)
+	}
+	for _, v := range stmt.Vars {
+		if reflect.ValueOf(v).Kind() != reflect.Slice || reflect.ValueOf(v).Len() != len(users) {
+			t.Fatalf("expected an array of %d values per column, got %#v", len(users), v)
+		}
+	}
+
+	if err := DB.Clauses(oracle.InsertOnly()).CreateInBatches(&users, 2).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+
+	var results []User
+	if err := DB.Where("\"name\" LIKE ?", "array_dml_%").Order("\"name\"").Find(&results).Error; err != nil {
+		t.Fatalf("failed to query users, got error: %v", err)
+	}
+	if len(results) != len(users) {
+		t.Fatalf("expected %d users, got %d", len(users), len(results))
+	}
+	for i, result := range results {
+		if result.Name != users[i].Name || result.Age != users[i].Age || result.Active != users[i].Active {
+			t.Errorf("expected %+v, got %+v", users[i], result)
+		}
+		if (result.Birthday == nil) != (users[i].Birthday == nil) {
+			t.Errorf("expected birthday %v for %s, got %v", users[i].Birthday, users[i].Name, result.Birthday)
+		}
+	}
+
+	stmt = DB.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true}).
+		Clauses(oracle.InsertOnly()).Model(&User{}).Create([]map[string]interface{}{
+		{"name": "array_dml_expr_1", "age": gorm.Expr("1 + 1")},
+		{"name": "array_dml_expr_2", "age": 3},
+	}).Statement
+	if sql := stmt.SQL.String(); !strings.Contains(sql, "),(") {
+		t.Errorf("expected a VALUES list when a value is an expression, got %s", sql)
+	}
+}
+
+func TestCreateBulkReturningColumns(t *testing.T) {
+	outBinds := func(stmt *gorm.Statement) (count int) {
+		for _, v := range stmt.Vars {
+			if _, ok := v.(sql.Out); ok {
+				count++
+			}
+		}
+		return count
+	}
+
+	users := []User{*GetUser("returning_columns_1", Config{}), *GetUser("returning_columns_2", Config{}), *GetUser("returning_columns_3", Config{})}
+
+	dryRun := DB.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true})
+	if stmt := dryRun.Create(&users).Statement; outBinds(stmt) != len(users) {
+		t.Errorf("expected only the primary key to be returned, got %d OUT binds in %s", outBinds(stmt), stmt.SQL.String())
+	}
+	stmt := dryRun.Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "name"}}}).Create(&users).Statement
+	if outBinds(stmt) != 2*len(users) {
+		t.Errorf("expected the columns of the RETURNING clause to be returned, got %d OUT binds in %s", outBinds(stmt), stmt.SQL.String())
+	}
+
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+	for _, user := range users {
+		if user.ID == 0 {
+			t.Errorf("expected the ID of %s to be returned", user.Name)
+		}
+	}
+
+	var deleted []User
+	if err := DB.Unscoped().Clauses(clause.Returning{Columns: []clause.Column{{Name: "name"}}}).
+		Where("\"name\" LIKE ?", "returning_columns_%").Delete(&deleted).Error; err != nil {
+		t.Fatalf("failed to delete users, got error: %v", err)
+	}
+	if len(deleted) != len(users) {
+		t.Fatalf("expected %d deleted users, got %d", len(users), len(deleted))
+	}
+	for _, user := range deleted {
+		if user.ID != 0 || !strings.HasPrefix(user.Name, "returning_columns_") {
+			t.Errorf("expected only the name of deleted users to be returned, got %+v", user)
+		}
+	}
+}
+
+func TestCreateFieldPermissions(t *testing.T) {
+	type PermissionItem struct {
+		ID        uint
+		Name      string
+		UpperName string `gorm:"->"`
+		Secret    string `gorm:"<-;->:false"`
+		Owner     string `gorm:"<-:create"`
+	}
+
+	DB.Exec(`DROP TABLE "permission_items" CASCADE CONSTRAINTS`)
+	if err := DB.Exec(`CREATE TABLE "permission_items" (
+		"id" NUMBER(20) GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+		"name" VARCHAR2(50 CHAR),
+		"upper_name" VARCHAR2(50 CHAR) GENERATED ALWAYS AS (UPPER("name")) VIRTUAL,
+		"secret" VARCHAR2(50 CHAR),
+		"owner" VARCHAR2(50 CHAR)
+	)`).Error; err != nil {
+		t.Fatalf("failed to create table, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable("permission_items")
+
+	items := []PermissionItem{{Name: "first", Secret: "s1", Owner: "alice"}, {Name: "second", Secret: "s2", Owner: "bob"}}
+	if err := DB.Clauses(clause.Returning{}).Create(&items).Error; err != nil {
+		t.Fatalf("failed to create items, got error: %v", err)
+	}
+	for _, item := range items {
+		if item.ID == 0 || item.UpperName != strings.ToUpper(item.Name) {
+			t.Errorf("expected the identity and the virtual column to be returned, got %+v", item)
+		}
+	}
+
+	var updated []PermissionItem
+	if err := DB.Model(&updated).Clauses(clause.Returning{}).Where("1 = 1").
+		Updates(map[string]interface{}{"name": "renamed", "owner": "mallory", "upper_name": "X"}).Error; err != nil {
+		t.Fatalf("failed to update items, got error: %v", err)
+	}
+	if len(updated) != len(items) {
+		t.Fatalf("expected %d updated items, got %d", len(items), len(updated))
+	}
+	for _, item := range updated {
+		if item.UpperName != "RENAMED" || item.Secret != "" {
+			t.Errorf("expected the virtual column and not the write-only field to be returned, got %+v", item)
+		}
+	}
+
+	upserted := []PermissionItem{{ID: items[0].ID, Name: "upserted", Secret: "s3", Owner: "mallory"}}
+	if err := DB.Clauses(clause.OnConflict{UpdateAll: true}).Create(&upserted).Error; err != nil {
+		t.Fatalf("failed to upsert items, got error: %v", err)
+	}
+
+	var loaded []PermissionItem
+	if err := DB.Order("\"id\"").Find(&loaded).Error; err != nil {
+		t.Fatalf("failed to load items, got error: %v", err)
+	}
+	for i, item := range loaded {
+		if item.Owner != items[i].Owner || item.Secret != "" {
+			t.Errorf("expected the create-only owner %q to be kept and the secret not to be read, got %+v", items[i].Owner, item)
+		}
+	}
+	if len(loaded) != 2 || loaded[0].Name != "upserted" || loaded[0].UpperName != "UPSERTED" {
+		t.Errorf("expected the upsert to update the name, got %+v", loaded)
+	}
+}
+
+func TestCreateWithExplicitIdentity(t *testing.T) {
+	type LegacyAccount struct {
+		ID   uint `gorm:"primaryKey;autoIncrement"`
+		Name string
+	}
+
+	DB.Migrator().DropTable(&LegacyAccount{})
+	if err := DB.AutoMigrate(&LegacyAccount{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+
+	accounts := []LegacyAccount{{ID: 100, Name: "legacy-100"}, {ID: 101, Name: "legacy-101"}}
+	if err := DB.Create(&accounts).Error; err != nil {
+		t.Fatalf("failed to create accounts with fixed IDs, got error: %v", err)
+	}
+
+	upserted := []LegacyAccount{{ID: 101, Name: "legacy-101-updated"}, {ID: 200, Name: "legacy-200"}}
+	if err := DB.Clauses(clause.OnConflict{UpdateAll: true}).Create(&upserted).Error; err != nil {
+		t.Fatalf("failed to upsert accounts with fixed IDs, got error: %v", err)
+	}
+
+	var loaded []LegacyAccount
+	if err := DB.Order("\"id\"").Find(&loaded).Error; err != nil {
+		t.Fatalf("failed to load accounts, got error: %v", err)
+	}
+	expected := []LegacyAccount{{ID: 100, Name: "legacy-100"}, {ID: 101, Name: "legacy-101-updated"}, {ID: 200, Name: "legacy-200"}}
+	tests.AssertEqual(t, loaded, expected)
+
+	if err := DB.Migrator().(oracle.Migrator).ResyncIdentity(&LegacyAccount{}); err != nil {
+		t.Fatalf("failed to resync identity, got error: %v", err)
+	}
+
+	account := LegacyAccount{Name: "generated"}
+	if err := DB.Create(&account).Error; err != nil {
+		t.Fatalf("failed to create account after resync, got error: %v", err)
+	}
+	if account.ID <= 200 {
+		t.Errorf("expected generated ID after the loaded IDs, got %d", account.ID)
+	}
+}
+
+func TestCreateReturningExpressions(t *testing.T) {
+	type ReturningExprItem struct {
+		ID        uint
+		Name      string
+		CreatedAt time.Time
+		NameUpper string `gorm:"column:name_upper;->;-:migration"`
+	}
+
+	DB.Migrator().DropTable(&ReturningExprItem{})
+	if err := DB.AutoMigrate(&ReturningExprItem{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&ReturningExprItem{})
+
+	item := ReturningExprItem{Name: "returning"}
+	values := oracle.ReturningValues{}
+	err := DB.Clauses(clause.Returning{Columns: []clause.Column{
+		{Name: "id"},
+		{Name: "ROWID", Raw: true, Alias: "row_id"},
+		{Name: `UPPER("name")`, Raw: true, Alias: "name_upper"},
+		{Name: `TO_CHAR(SYS_EXTRACT_UTC("created_at"), 'YYYY-MM-DD')`, Raw: true, Alias: "created_utc"},
+	}}, values).Create(&item).Error
+	if err != nil {
+		t.Fatalf("failed to create with returning expressions, got error: %v", err)
+	}
+
+	if item.ID == 0 {
+		t.Errorf("expected the id to be returned")
+	}
+	tests.AssertEqual(t, item.NameUpper, "RETURNING")
+
+	rowID, ok := values["row_id"].(string)
+	if !ok || rowID == "" {
+		t.Fatalf("expected the ROWID to be returned, got %#v", values["row_id"])
+	}
+	if _, ok := values["name_upper"]; ok {
+		t.Errorf("expected values mapped to a field not to be collected")
+	}
+	tests.AssertEqual(t, values["created_utc"], item.CreatedAt.UTC().Format("2006-01-02"))
+
+	var found ReturningExprItem
+	if err := DB.Where("ROWID = ?", rowID).Select("id", "name").First(&found).Error; err != nil {
+		t.Fatalf("failed to find the row by its ROWID, got error: %v", err)
+	}
+	tests.AssertEqual(t, found.ID, item.ID)
+}
+
+func TestCreateWithDatabaseTime(t *testing.T) {
+	// An application clock far off the database clock
+	skewed := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	db, err := openTestDBWithOptions(&oracle.Config{DatabaseTime: true}, &gorm.Config{
+		NowFunc: func() time.Time { return skewed },
+	})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	var dbNow time.Time
+	if err := DB.Raw("SELECT SYSTIMESTAMP FROM DUAL").Scan(&dbNow).Error; err != nil {
+		t.Fatalf("failed to read the database time, got error %v", err)
+	}
+
+	users := []User{*GetUser("database_time_1", Config{}), *GetUser("database_time_2", Config{})}
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+	for _, user := range users {
+		if user.CreatedAt.Year() == skewed.Year() || user.CreatedAt.Sub(dbNow).Abs() > time.Minute {
+			t.Errorf("expected the database time near %v, got %v", dbNow, user.CreatedAt)
+		}
+		if user.CreatedAt.Location() != time.UTC {
+			t.Errorf("expected the time in the location of NowFunc, got %v", user.CreatedAt.Location())
+		}
+	}
+	if !users[0].CreatedAt.Equal(users[1].CreatedAt) {
+		t.Errorf("expected the users of a batch to share the time, got %v and %v", users[0].CreatedAt, users[1].CreatedAt)
+	}
+
+	if err := db.Model(&users[0]).Update("age", 30).Error; err != nil {
+		t.Fatalf("failed to update user, got error: %v", err)
+	}
+	var updated User
+	db.First(&updated, users[0].ID)
+	if updated.UpdatedAt.Year() == skewed.Year() {
+		t.Errorf("expected the update time from the database, got %v", updated.UpdatedAt)
+	}
+}
+
+func TestCopyIn(t *testing.T) {
+	type CopyEvent struct {
+		ID   int64  `gorm:"primaryKey;autoIncrement:false"`
+		Code string `gorm:"size:20;uniqueIndex"`
+		At   time.Time
+	}
+	DB.Migrator().DropTable(&CopyEvent{})
+	if err := DB.AutoMigrate(&CopyEvent{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	columns := []string{"id", "code", "at"}
+	now := time.Now().Truncate(time.Second)
+
+	rows := make([][]interface{}, 2500)
+	for i := range rows {
+		rows[i] = []interface{}{int64(i + 1), fmt.Sprintf("copy-%d", i+1), now}
+	}
+	// a duplicate code in the second batch
+	rows[1500][1] = "copy-1"
+
+	result, err := oracle.CopyIn(DB, "copy_events", columns, oracle.CopyFromRows(rows), &oracle.CopyOptions{
+		BatchSize:  1000,
+		CommitRows: 2000,
+		MaxErrors:  5,
+	})
+	if err != nil {
+		t.Fatalf("failed to copy rows, got error: %v", err)
+	}
+	if result.Rows != int64(len(rows)-1) {
+		t.Errorf("expected %d rows inserted, got %d", len(rows)-1, result.Rows)
+	}
+	if len(result.Rejected) != 1 || result.Rejected[0].Row != 1501 || oracle.ErrorCode(result.Rejected[0].Err) != 1 {
+		t.Errorf("expected row 1501 to be rejected with ORA-00001, got %v", result.Rejected)
+	}
+	var count int64
+	DB.Model(&CopyEvent{}).Count(&count)
+	if count != result.Rows {
+		t.Errorf("expected %d rows in the table, got %d", result.Rows, count)
+	}
+
+	// without MaxErrors, the first rejected row fails the copy and the rows
+	// since the last commit are rolled back
+	more := [][]interface{}{
+		{int64(3001), "copy-3001", now},
+		{int64(3002), "copy-3002", now},
+		{int64(3003), "copy-3001", now},
+	}
+	result, err = oracle.CopyIn(DB, "copy_events", columns, oracle.CopyFromRows(more), &oracle.CopyOptions{BatchSize: 2})
+	if err == nil || result.Rows != 0 {
+		t.Errorf("expected the copy to fail without rows inserted, got %d rows and error %v", result.Rows, err)
+	}
+	DB.Model(&CopyEvent{}).Where("\"id\" > ?", 3000).Count(&count)
+	if count != 0 {
+		t.Errorf("expected the copied rows to be rolled back, got %d", count)
+	}
+
+	// rows copied in a transaction are rolled back with it
+	DB.Transaction(func(tx *gorm.DB) error {
+		result, err = oracle.CopyIn(tx, "copy_events", columns, oracle.CopyFromRows(more[:2]))
+		if err != nil || result.Rows != 2 {
+			t.Errorf("expected 2 rows copied in the transaction, got %d rows and error %v", result.Rows, err)
+		}
+		return errors.New("rollback")
+	})
+	DB.Model(&CopyEvent{}).Where("\"id\" > ?", 3000).Count(&count)
+	if count != 0 {
+		t.Errorf("expected the rows copied in the transaction to be rolled back, got %d", count)
+	}
+	tx := DB.Begin()
+	if _, err := oracle.CopyIn(tx, "copy_events", columns, oracle.CopyFromRows(more), &oracle.CopyOptions{CommitRows: 1}); err == nil {
+		t.Errorf("expected an error for periodic commits in a transaction")
+	}
+	tx.Rollback()
+
+	result, err = oracle.CopyIn(DB, "copy_events", columns, oracle.CopyFromRows(more[:2]), &oracle.CopyOptions{DirectPath: true})
+	if err != nil || result.Rows != 2 {
+		t.Errorf("expected 2 rows copied with direct-path inserts, got %d rows and error %v", result.Rows, err)
+	}
+}
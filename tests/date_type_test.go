@@ -39,10 +39,14 @@
 package tests
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DateModel struct {
@@ -212,3 +216,201 @@ func TestDate_ZeroDateHandling(t *testing.T) {
 		t.Errorf("Unexpected error inserting zero date: %v", err)
 	}
 }
+
+func TestDate_DateLocation(t *testing.T) {
+	setupDateTests(t)
+
+	db, err := openTestDBWithOptions(&oracle.Config{DateLocation: time.UTC}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	paris := time.FixedZone("CET", 3600)
+	eventDate := time.Date(2024, 3, 1, 10, 30, 15, 0, paris)
+	records := []DateModel{{EventName: "DateLocation", EventDate: eventDate}, {EventName: "DateLocation2", EventDate: eventDate}}
+	if err := db.Create(&records[0]).Error; err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	if err := db.Create(records[1:]).Error; err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	var stored []string
+	if err := db.Raw(`SELECT TO_CHAR("EVENT_DATE", 'YYYY-MM-DD HH24:MI:SS') FROM "date_models" WHERE "id" IN ? ORDER BY "id"`,
+		[]uint{records[0].ID, records[1].ID}).Scan(&stored).Error; err != nil {
+		t.Fatalf("failed to read the stored date, got error %v", err)
+	}
+	if len(stored) != 2 || stored[0] != "2024-03-01 09:30:15" || stored[1] != stored[0] {
+		t.Errorf("expected the wall clock in UTC to be stored, got %v", stored)
+	}
+
+	var fetched DateModel
+	if err := db.First(&fetched, records[0].ID).Error; err != nil {
+		t.Fatalf("failed to find the record, got error %v", err)
+	}
+	if fetched.EventDate.Location() != time.UTC || !fetched.EventDate.Equal(eventDate) {
+		t.Errorf("expected %v in UTC, got %v", eventDate, fetched.EventDate)
+	}
+
+	later := eventDate.Add(time.Hour)
+	if err := db.Model(&fetched).Update("EVENT_DATE", later).Error; err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := db.First(&fetched, records[0].ID).Error; err != nil {
+		t.Fatalf("failed to find the record, got error %v", err)
+	}
+	if !fetched.EventDate.Equal(later) {
+		t.Errorf("expected %v after the update, got %v", later, fetched.EventDate)
+	}
+}
+
+type PreciseEvent struct {
+	ID    uint
+	Name  string
+	At    time.Time  `gorm:"precision:9"`
+	Local *time.Time `gorm:"type:TIMESTAMP(9)"`
+	Day   datatypes.Date
+}
+
+func TestDate_SubSecondPrecisionReturning(t *testing.T) {
+	DB.Migrator().DropTable(&PreciseEvent{})
+	if err := DB.AutoMigrate(&PreciseEvent{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&PreciseEvent{})
+
+	var dataType string
+	DB.Raw(`SELECT DATA_TYPE FROM USER_TAB_COLUMNS WHERE TABLE_NAME = 'precise_events' AND COLUMN_NAME = 'at'`).Scan(&dataType)
+	if dataType != "TIMESTAMP(9) WITH TIME ZONE" {
+		t.Errorf("expected precision:9 to create TIMESTAMP(9) WITH TIME ZONE, got %q", dataType)
+	}
+
+	at := time.Date(2024, 3, 1, 10, 30, 15, 123456789, time.UTC)
+	local := at.Add(time.Nanosecond)
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	events := []PreciseEvent{
+		{Name: "precise-1", At: at, Local: &local, Day: datatypes.Date(day)},
+		{Name: "precise-2", At: at, Local: &local, Day: datatypes.Date(day)},
+	}
+	if err := DB.Create(&events).Error; err != nil {
+		t.Fatalf("failed to create, got error: %v", err)
+	}
+
+	var updated []PreciseEvent
+	later := at.Add(987654321 * time.Nanosecond)
+	if err := DB.Model(&updated).Clauses(clause.Returning{}).Where(`"name" LIKE ?`, "precise-%").Update("at", later).Error; err != nil {
+		t.Fatalf("failed to update, got error: %v", err)
+	}
+	if len(updated) != 2 {
+		t.Fatalf("expected 2 updated rows, got %d", len(updated))
+	}
+	for _, event := range updated {
+		if !event.At.Equal(later) {
+			t.Errorf("expected the returned time %v with its nanoseconds, got %v", later, event.At)
+		}
+		if event.Local == nil || !event.Local.Equal(local) {
+			t.Errorf("expected the returned TIMESTAMP(9) %v, got %v", local, event.Local)
+		}
+		if !time.Time(event.Day).Equal(day) {
+			t.Errorf("expected the returned date %v, got %v", day, time.Time(event.Day))
+		}
+	}
+
+	var deleted []PreciseEvent
+	if err := DB.Clauses(clause.Returning{}).Where(`"name" = ?`, "precise-1").Delete(&deleted).Error; err != nil {
+		t.Fatalf("failed to delete, got error: %v", err)
+	}
+	if len(deleted) != 1 || !deleted[0].At.Equal(later) || deleted[0].Local == nil || !deleted[0].Local.Equal(local) {
+		t.Errorf("expected the deleted row with its nanoseconds, got %+v", deleted)
+	}
+}
+
+type ZeroTimeEvent struct {
+	ID       uint
+	Required time.Time `gorm:"not null"`
+	Optional *time.Time
+}
+
+func TestDate_ZeroTimeModes(t *testing.T) {
+	DB.Migrator().DropTable(&ZeroTimeEvent{})
+	if err := DB.AutoMigrate(&ZeroTimeEvent{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&ZeroTimeEvent{})
+
+	now := time.Now().Truncate(time.Second)
+	zero := time.Time{}
+
+	t.Run("Reject", func(t *testing.T) {
+		db, err := openTestDBWithOptions(&oracle.Config{ZeroTime: oracle.ZeroTimeReject}, &gorm.Config{})
+		if err != nil {
+			t.Fatalf("failed to connect database, got error %v", err)
+		}
+		if err := db.Create(&ZeroTimeEvent{Optional: &now}).Error; !errors.Is(err, oracle.ErrZeroTime) {
+			t.Errorf("expected ErrZeroTime for the zero required time, got %v", err)
+		}
+		if err := db.Create(&ZeroTimeEvent{Required: now, Optional: &zero}).Error; !errors.Is(err, oracle.ErrZeroTime) {
+			t.Errorf("expected ErrZeroTime for the zero optional time, got %v", err)
+		}
+
+		event := ZeroTimeEvent{Required: now}
+		if err := db.Create(&event).Error; err != nil {
+			t.Fatalf("failed to create, got error %v", err)
+		}
+		if err := db.Model(&event).Update("Required", time.Time{}).Error; !errors.Is(err, oracle.ErrZeroTime) {
+			t.Errorf("expected ErrZeroTime for the update, got %v", err)
+		}
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		db, err := openTestDBWithOptions(&oracle.Config{ZeroTime: oracle.ZeroTimeNull}, &gorm.Config{})
+		if err != nil {
+			t.Fatalf("failed to connect database, got error %v", err)
+		}
+		if err := db.Create(&ZeroTimeEvent{}).Error; !errors.Is(err, oracle.ErrZeroTime) {
+			t.Errorf("expected ErrZeroTime for the NOT NULL column, got %v", err)
+		}
+
+		event := ZeroTimeEvent{Required: now, Optional: &zero}
+		if err := db.Create(&event).Error; err != nil {
+			t.Fatalf("failed to create, got error %v", err)
+		}
+		var fetched ZeroTimeEvent
+		if err := db.First(&fetched, event.ID).Error; err != nil {
+			t.Fatalf("failed to find the record, got error %v", err)
+		}
+		if fetched.Optional != nil {
+			t.Errorf("expected the zero optional time to be stored as NULL, got %v", fetched.Optional)
+		}
+	})
+
+	t.Run("Store", func(t *testing.T) {
+		db, err := openTestDBWithOptions(&oracle.Config{ZeroTime: oracle.ZeroTimeStore}, &gorm.Config{})
+		if err != nil {
+			t.Fatalf("failed to connect database, got error %v", err)
+		}
+		events := []ZeroTimeEvent{{}, {Required: now, Optional: &zero}}
+		if err := db.Create(&events).Error; err != nil {
+			t.Fatalf("failed to create, got error %v", err)
+		}
+
+		var stored []string
+		if err := db.Raw(`SELECT TO_CHAR("required", 'YYYY-MM-DD HH24:MI:SS') FROM "zero_time_events" WHERE "id" = ?`,
+			events[0].ID).Scan(&stored).Error; err != nil {
+			t.Fatalf("failed to read the stored time, got error %v", err)
+		}
+		if len(stored) != 1 || stored[0] != "0001-01-01 00:00:00" {
+			t.Errorf("expected the zero time to be stored as 0001-01-01, got %v", stored)
+		}
+
+		var fetched []ZeroTimeEvent
+		if err := db.Order("id").Find(&fetched, []uint{events[0].ID, events[1].ID}).Error; err != nil {
+			t.Fatalf("failed to find the records, got error %v", err)
+		}
+		if len(fetched) != 2 || !fetched[0].Required.IsZero() || fetched[0].Optional != nil {
+			t.Errorf("expected the zero required time back, got %+v", fetched)
+		} else if fetched[1].Optional == nil || !fetched[1].Optional.IsZero() || !fetched[1].Required.Equal(now) {
+			t.Errorf("expected the zero optional time back, got %+v", fetched[1])
+		}
+	})
+}
@@ -40,8 +40,11 @@ package tests
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"gorm.io/gorm"
@@ -289,6 +292,193 @@ func TestDeleteReturning(t *testing.T) {
 	}
 }
 
+func TestDeleteReturningManyRows(t *testing.T) {
+	companies := make([]Company, 250)
+	for i := range companies {
+		companies[i].Name = fmt.Sprintf("delete-returning-many-%03d", i)
+	}
+	if err := DB.CreateInBatches(&companies, 100).Error; err != nil {
+		t.Fatalf("failed to create companies, got error: %v", err)
+	}
+
+	var results []Company
+	result := DB.Where("\"name\" LIKE ?", "delete-returning-many-%").Clauses(clause.Returning{}).Delete(&results)
+	if result.Error != nil {
+		t.Fatalf("failed to delete companies, got error: %v", result.Error)
+	}
+	if result.RowsAffected != int64(len(companies)) {
+		t.Errorf("expected %d rows affected, got %d", len(companies), result.RowsAffected)
+	}
+	if len(results) != len(companies) {
+		t.Fatalf("expected %d returned companies, got %d", len(companies), len(results))
+	}
+
+	names := map[string]bool{}
+	for _, company := range results {
+		names[company.Name] = true
+	}
+	for _, company := range companies {
+		if !names[company.Name] {
+			t.Errorf("expected %s to be returned", company.Name)
+		}
+	}
+
+	var count int64
+	DB.Model(&Company{}).Where("\"name\" LIKE ?", "delete-returning-many-%").Count(&count)
+	if count != 0 {
+		t.Errorf("failed to delete data, current count %v", count)
+	}
+}
+
+func TestDeleteInChunks(t *testing.T) {
+	companies := make([]Company, 25)
+	for i := range companies {
+		companies[i].Name = fmt.Sprintf("delete-chunks-%02d", i)
+	}
+	if err := DB.Create(&companies).Error; err != nil {
+		t.Fatalf("failed to create companies, got error: %v", err)
+	}
+	keep := Company{Name: "delete-chunks-keep"}
+	if err := DB.Create(&keep).Error; err != nil {
+		t.Fatalf("failed to create company, got error: %v", err)
+	}
+
+	var progress []int64
+	chunks := oracle.DeleteInChunks(10)
+	chunks.Progress = func(deleted int64) {
+		progress = append(progress, deleted)
+	}
+	result := DB.Clauses(chunks).
+		Where("\"name\" LIKE ?", "delete-chunks-%").
+		Or("\"name\" = ?", "delete-chunks-none").
+		Where("\"name\" <> ?", keep.Name).
+		Delete(&Company{})
+	if result.Error != nil {
+		t.Fatalf("failed to delete in chunks, got error: %v", result.Error)
+	}
+	if result.RowsAffected != int64(len(companies)) {
+		t.Errorf("expected %d rows affected, got %d", len(companies), result.RowsAffected)
+	}
+	tests.AssertEqual(t, progress, []int64{10, 20, 25})
+
+	var count int64
+	DB.Model(&Company{}).Where("\"name\" LIKE ?", "delete-chunks-%").Count(&count)
+	if count != 1 {
+		t.Errorf("expected only %s to remain, current count %v", keep.Name, count)
+	}
+}
+
+func TestDeleteWithLimit(t *testing.T) {
+	companies := make([]Company, 5)
+	for i := range companies {
+		companies[i].Name = fmt.Sprintf("delete-limit-%d", i)
+	}
+	if err := DB.Create(&companies).Error; err != nil {
+		t.Fatalf("failed to create companies, got error: %v", err)
+	}
+
+	result := DB.Where("\"name\" LIKE ?", "delete-limit-%").Order("\"name\" DESC").Limit(2).Delete(&Company{})
+	if result.Error != nil {
+		t.Fatalf("failed to delete with limit, got error: %v", result.Error)
+	}
+	if result.RowsAffected != 2 {
+		t.Errorf("expected 2 rows affected, got %d", result.RowsAffected)
+	}
+
+	var deleted []Company
+	result = DB.Clauses(clause.Returning{}).Where("\"name\" LIKE ?", "delete-limit-%").
+		Order("\"name\"").Offset(1).Limit(1).Delete(&deleted)
+	if result.Error != nil {
+		t.Fatalf("failed to delete with offset and returning, got error: %v", result.Error)
+	}
+	if len(deleted) != 1 || deleted[0].Name != "delete-limit-1" {
+		t.Errorf("expected delete-limit-1 to be returned, got %+v", deleted)
+	}
+
+	var names []string
+	DB.Model(&Company{}).Where("\"name\" LIKE ?", "delete-limit-%").Order("\"name\"").Pluck("name", &names)
+	tests.AssertEqual(t, names, []string{"delete-limit-0", "delete-limit-2"})
+
+	if err := DB.Limit(1).Delete(&Company{}).Error; !errors.Is(err, gorm.ErrMissingWhereClause) {
+		t.Errorf("expected ErrMissingWhereClause for a delete with only a limit, got %v", err)
+	}
+}
+
+func TestDeleteReturningBeyondBindLimit(t *testing.T) {
+	type ReturningEvent struct {
+		ID     uint `gorm:"primaryKey"`
+		Source string
+		Kind   string
+	}
+
+	DB.Migrator().DropTable(&ReturningEvent{})
+	if err := DB.AutoMigrate(&ReturningEvent{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Migrator().DropTable(&ReturningEvent{})
+	})
+
+	// Returning 3 columns for every row would take more binds than Oracle allows
+	events := make([]ReturningEvent, 25000)
+	for i := range events {
+		events[i] = ReturningEvent{Source: "bind-limit", Kind: fmt.Sprintf("kind-%d", i%7)}
+	}
+	if err := DB.CreateInBatches(&events, 1000).Error; err != nil {
+		t.Fatalf("failed to create events, got error: %v", err)
+	}
+
+	var deleted []ReturningEvent
+	result := DB.Where("\"source\" = ?", "bind-limit").Clauses(clause.Returning{}).Delete(&deleted)
+	if result.Error != nil {
+		t.Fatalf("failed to delete events, got error: %v", result.Error)
+	}
+	if result.RowsAffected != int64(len(events)) || len(deleted) != len(events) {
+		t.Fatalf("expected %d deleted events, got %d rows affected and %d returned", len(events), result.RowsAffected, len(deleted))
+	}
+	for _, event := range deleted {
+		if event.ID == 0 || event.Source != "bind-limit" || !strings.HasPrefix(event.Kind, "kind-") {
+			t.Fatalf("expected the columns of the deleted event, got %+v", event)
+		}
+	}
+
+	var count int64
+	DB.Model(&ReturningEvent{}).Count(&count)
+	if count != 0 {
+		t.Errorf("failed to delete data, current count %v", count)
+	}
+}
+
+func TestDeleteReturningWithConditionExpressions(t *testing.T) {
+	companies := []Company{
+		{Name: "delete-returning-expr-1"},
+		{Name: "delete-returning-expr-2"},
+		{Name: "delete-returning-expr-3"},
+	}
+	if err := DB.Create(&companies).Error; err != nil {
+		t.Fatalf("failed to create companies, got error %v", err)
+	}
+	names := []string{companies[0].Name, companies[1].Name, companies[2].Name}
+
+	// Conditions other than equalities, IN lists and SQL strings restrict
+	// the deleted rows rather than being dropped
+	var results []Company
+	if err := DB.Where("\"name\" IN ?", names).
+		Where(clause.Gt{Column: clause.Column{Name: "id"}, Value: companies[0].ID}).
+		Clauses(clause.Returning{}).Delete(&results).Error; err != nil {
+		t.Fatalf("failed to delete, got error %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("expected 2 deleted companies, got %v", results)
+	}
+
+	var remaining []Company
+	DB.Where("\"name\" IN ?", names).Find(&remaining)
+	if len(remaining) != 1 || remaining[0].ID != companies[0].ID {
+		t.Errorf("expected only the first company to remain, got %v", remaining)
+	}
+}
+
 func TestDeleteWithOnDeleteCascade(t *testing.T) {
 	type Room struct {
 		ID      uint
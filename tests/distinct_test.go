@@ -108,7 +108,7 @@ func TestDistinct(t *testing.T) {
 
 	dryDB := DB.Session(&gorm.Session{DryRun: true})
 	r := dryDB.Distinct("u.id, u.*").Table("user_speaks s").Joins("inner join users u on u.id = s.user_id").Where("s.language_code ='US' or s.language_code ='ES'").Find(&User{})
-	if !regexp.MustCompile(`SELECT DISTINCT u\.id, u\.\* FROM user_speaks s inner join users u`).MatchString(r.Statement.SQL.String()) {
+	if !regexp.MustCompile(`SELECT DISTINCT u\.id, u\.\* FROM "user_speaks" "S" inner join users u`).MatchString(r.Statement.SQL.String()) {
 		t.Fatalf("Build Distinct with u.*, but got %v", r.Statement.SQL.String())
 	}
 }
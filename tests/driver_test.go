@@ -0,0 +1,78 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	. "github.com/oracle-samples/gorm-oracle/tests/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestDriverFeatures(t *testing.T) {
+	features := DB.Dialector.(*oracle.Dialector).Features()
+	if !features.LOBStreaming || !features.PLSQLArrays || !features.ArrayDML {
+		t.Errorf("expected all features for godror, got %+v", features)
+	}
+
+	db, err := openTestDBWithOptions(&oracle.Config{DriverFeatures: &oracle.DriverFeatures{}}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	user := GetUser("driver_features", Config{})
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("failed to create a single user without driver features, got error %v", err)
+	}
+
+	users := []User{*GetUser("driver_features_1", Config{}), *GetUser("driver_features_2", Config{})}
+	if err := db.Create(&users).Error; !errors.Is(err, oracle.ErrUnsupportedByDriver) {
+		t.Errorf("expected ErrUnsupportedByDriver for a bulk create with RETURNING, got %v", err)
+	}
+
+	var deleted []User
+	err = db.Unscoped().Where("\"name\" = ?", user.Name).Clauses(clause.Returning{}).Delete(&deleted).Error
+	if !errors.Is(err, oracle.ErrUnsupportedByDriver) {
+		t.Errorf("expected ErrUnsupportedByDriver for a delete with RETURNING, got %v", err)
+	}
+}
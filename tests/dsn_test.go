@@ -0,0 +1,140 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/godror/godror"
+	"github.com/oracle-samples/gorm-oracle/oracle"
+)
+
+func TestEZConnect(t *testing.T) {
+	connect := oracle.EZConnect{
+		Protocol: "tcps",
+		AddressLists: [][]oracle.Address{
+			{{Host: "rac-node1", Port: 1522}, {Host: "rac-node2", Port: 1522}},
+			{{Host: "standby.example.com"}},
+		},
+		ServiceName:             "sales.example.com",
+		Server:                  "dedicated",
+		RetryCount:              3,
+		RetryDelay:              2 * time.Second,
+		TransportConnectTimeout: 500 * time.Millisecond,
+		HTTPSProxy:              "proxy.example.com",
+		HTTPSProxyPort:          8080,
+		SSLServerDNMatch:        true,
+		Params:                  map[string]string{"load_balance": "on"},
+	}
+
+	expected := "tcps://rac-node1:1522,rac-node2:1522;standby.example.com/sales.example.com:dedicated" +
+		"?retry_count=3&retry_delay=2&transport_connect_timeout=500ms&https_proxy=proxy.example.com&https_proxy_port=8080&ssl_server_dn_match=true&load_balance=on"
+	if connect.String() != expected {
+		t.Errorf("expected %v, got %v", expected, connect.String())
+	}
+
+	dsn, err := connect.DSN("scott", `p@ss"word`)
+	if err != nil {
+		t.Fatalf("failed to build DSN, got error %v", err)
+	}
+	params, err := godror.ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("failed to parse DSN %v, got error %v", dsn, err)
+	}
+	if params.Username != "scott" || params.Password.Secret() != `p@ss"word` || params.ConnectString != expected {
+		t.Errorf("unexpected connection parameters %v", params)
+	}
+
+	invalid := []oracle.EZConnect{
+		{},
+		{AddressLists: [][]oracle.Address{{{Host: "db host"}}}},
+		{AddressLists: [][]oracle.Address{{{Host: "db", Port: 70000}}}},
+		{AddressLists: [][]oracle.Address{{{Host: "db"}}}, Server: "pooled"},
+		{AddressLists: [][]oracle.Address{{{Host: "db"}}}, HTTPSProxy: "proxy", HTTPSProxyPort: 80},
+		{AddressLists: [][]oracle.Address{{{Host: "db"}}}, Params: map[string]string{"retry_count": "1&x=2"}},
+	}
+	for _, c := range invalid {
+		if _, err := c.DSN("scott", "tiger"); err == nil {
+			t.Errorf("expected %+v to be invalid", c)
+		}
+	}
+}
+
+func TestEZConnectFailover(t *testing.T) {
+	connect := oracle.EZConnect{
+		AddressLists: [][]oracle.Address{{{Host: "rac-node1"}, {Host: "rac-node2", Port: 1522}}},
+		ServiceName:  "sales.example.com",
+		RetryCount:   3,
+		FailoverMode: &oracle.FailoverMode{Type: "select", Retries: 20, Delay: 3 * time.Second},
+		Events:       true,
+	}
+
+	expected := "(DESCRIPTION=(RETRY_COUNT=3)(ADDRESS_LIST=(LOAD_BALANCE=ON)" +
+		"(ADDRESS=(PROTOCOL=TCP)(HOST=rac-node1)(PORT=1521))(ADDRESS=(PROTOCOL=TCP)(HOST=rac-node2)(PORT=1522)))" +
+		"(CONNECT_DATA=(SERVICE_NAME=sales.example.com)(FAILOVER_MODE=(TYPE=SELECT)(METHOD=BASIC)(RETRIES=20)(DELAY=3))))"
+	if connect.String() != expected {
+		t.Errorf("expected %v, got %v", expected, connect.String())
+	}
+
+	dsn, err := connect.DSN("scott", "tiger")
+	if err != nil {
+		t.Fatalf("failed to build DSN, got error %v", err)
+	}
+	params, err := godror.ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("failed to parse DSN %v, got error %v", dsn, err)
+	}
+	if params.ConnectString != expected || !params.EnableEvents {
+		t.Errorf("unexpected connection parameters %v", params)
+	}
+
+	invalid := []*oracle.FailoverMode{
+		{Type: "transaction"},
+		{Type: "select", Method: "preconnect"},
+		{Type: "session", Backup: "sales_backup"},
+		{Type: "session", Retries: -1},
+	}
+	for _, mode := range invalid {
+		connect.FailoverMode = mode
+		if _, err := connect.DSN("scott", "tiger"); err == nil {
+			t.Errorf("expected failover mode %+v to be invalid", mode)
+		}
+	}
+}
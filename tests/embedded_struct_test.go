@@ -50,6 +50,7 @@ import (
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/utils/tests"
 )
 
@@ -383,3 +384,73 @@ func TestUpdateEmbeddedFields(t *testing.T) {
 		t.Errorf("embedded field not updated: expected Houston, got %s", loaded.Address.City)
 	}
 }
+
+func TestEmbeddedPrefixReturning(t *testing.T) {
+	type Revision struct {
+		Number int    `gorm:"default:1"`
+		State  string `gorm:"size:16;default:draft"`
+	}
+	type Document struct {
+		gorm.Model
+		Title    string
+		Current  Revision  `gorm:"embedded;embeddedPrefix:current_"`
+		Previous *Revision `gorm:"embedded;embeddedPrefix:previous_"`
+	}
+
+	DB.Migrator().DropTable(&Document{})
+	if err := DB.Migrator().AutoMigrate(&Document{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+
+	check := func(t *testing.T, doc Document) {
+		t.Helper()
+		if doc.ID == 0 {
+			t.Errorf("expected ID to be returned, got 0")
+		}
+		if doc.CreatedAt.IsZero() || doc.UpdatedAt.IsZero() {
+			t.Errorf("expected timestamps to be set, got %v/%v", doc.CreatedAt, doc.UpdatedAt)
+		}
+		if doc.Current.Number != 1 || doc.Current.State != "draft" {
+			t.Errorf("expected prefixed defaults to be returned, got %+v", doc.Current)
+		}
+		if doc.Previous == nil || doc.Previous.Number != 1 || doc.Previous.State != "draft" {
+			t.Errorf("expected pointer embedded defaults to be returned, got %+v", doc.Previous)
+		}
+
+		var loaded Document
+		if err := DB.First(&loaded, doc.ID).Error; err != nil {
+			t.Fatalf("failed to load document %d, got error: %v", doc.ID, err)
+		}
+		if loaded.Title != doc.Title || loaded.Current != doc.Current {
+			t.Errorf("returned values %+v do not match stored values %+v", doc, loaded)
+		}
+	}
+
+	t.Run("Single", func(t *testing.T) {
+		doc := Document{Title: "single"}
+		if err := DB.Create(&doc).Error; err != nil {
+			t.Fatalf("failed to create, got error: %v", err)
+		}
+		check(t, doc)
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		docs := []Document{{Title: "batch-1"}, {Title: "batch-2"}, {Title: "batch-3"}}
+		if err := DB.Create(&docs).Error; err != nil {
+			t.Fatalf("failed to create, got error: %v", err)
+		}
+		for _, doc := range docs {
+			check(t, doc)
+		}
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		docs := []Document{{Title: "upsert-1"}, {Title: "upsert-2"}}
+		if err := DB.Clauses(clause.OnConflict{UpdateAll: true}).Create(&docs).Error; err != nil {
+			t.Fatalf("failed to upsert, got error: %v", err)
+		}
+		for _, doc := range docs {
+			check(t, doc)
+		}
+	})
+}
@@ -40,8 +40,12 @@ package tests
 
 import (
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/utils/tests"
 )
@@ -77,7 +81,7 @@ func TestSupportedDialectorWithErrDuplicatedKey(t *testing.T) {
 		t.Fatalf("failed to connect database, got error %v", err)
 	}
 
-	dialectors := map[string]bool{"sqlite": true, "postgres": true, "mysql": true, "sqlserver": true}
+	dialectors := map[string]bool{"sqlite": true, "postgres": true, "mysql": true, "sqlserver": true, "oracle": true}
 	if supported, found := dialectors[db.Dialector.Name()]; !(found && supported) {
 		return
 	}
@@ -117,7 +121,7 @@ func TestSupportedDialectorWithErrForeignKeyViolated(t *testing.T) {
 		t.Fatalf("failed to connect database, got error %v", err)
 	}
 
-	dialectors := map[string]bool{"sqlite": true, "postgres": true, "mysql": true, "sqlserver": true}
+	dialectors := map[string]bool{"sqlite": true, "postgres": true, "mysql": true, "sqlserver": true, "oracle": true}
 	if supported, found := dialectors[db.Dialector.Name()]; !(found && supported) {
 		return
 	}
@@ -303,3 +307,103 @@ func TestSupportedDialectorWithErrForeignKeyViolatedNegative(t *testing.T) {
 		t.Fatalf("expected orphan museum insert to succeed, got: %v", err)
 	}
 }
+
+func TestOracleError(t *testing.T) {
+	type City struct {
+		gorm.Model
+		Name string `gorm:"unique"`
+	}
+
+	DB.Migrator().DropTable(&City{})
+	if err := DB.AutoMigrate(&City{}); err != nil {
+		t.Fatalf("failed to migrate cities table, got error: %v", err)
+	}
+
+	if err := DB.Create(&City{Name: "Lisbon"}).Error; err != nil {
+		t.Fatalf("failed to create record: %v", err)
+	}
+
+	err := DB.Create(&City{Name: "Lisbon"}).Error
+	var oraErr *oracle.Error
+	if !errors.As(err, &oraErr) {
+		t.Fatalf("expected oracle.Error, got %T: %v", err, err)
+	}
+	if oraErr.Code != 1 || oracle.ErrorCode(err) != 1 {
+		t.Errorf("expected ORA-00001, got code %v", oraErr.Code)
+	}
+	if !strings.Contains(oraErr.SQL, "INSERT INTO") || strings.Contains(oraErr.SQL, "Lisbon") {
+		t.Errorf("expected the statement without bound values, got %v", oraErr.SQL)
+	}
+	if !errors.Is(err, &oracle.Error{Code: 1}) || errors.Is(err, &oracle.Error{Code: 1400}) {
+		t.Errorf("expected errors.Is to match the error code, got %v", err)
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("expected no translation without TranslateError, got %v", err)
+	}
+
+	err = DB.Exec(`SELECT * FROM "no_such_table"`).Error
+	if !errors.As(err, &oraErr) || oraErr.Code != 942 {
+		t.Fatalf("expected ORA-00942, got %v", err)
+	}
+	if oraErr.Offset <= 0 {
+		t.Errorf("expected the offset of the table name, got %v", oraErr.Offset)
+	}
+}
+
+func TestUniqueViolationFields(t *testing.T) {
+	type Country struct {
+		ID     uint   `gorm:"primarykey"`
+		Name   string `gorm:"unique"`
+		Code   string `gorm:"uniqueIndex:idx_country_code_region"`
+		Region string `gorm:"uniqueIndex:idx_country_code_region"`
+	}
+
+	DB.Migrator().DropTable(&Country{})
+	if err := DB.AutoMigrate(&Country{}); err != nil {
+		t.Fatalf("failed to migrate countries table, got error: %v", err)
+	}
+
+	if err := DB.Create(&Country{ID: 1, Name: "Portugal", Code: "PT", Region: "EU"}).Error; err != nil {
+		t.Fatalf("failed to create record: %v", err)
+	}
+
+	cases := []struct {
+		country Country
+		fields  []string
+	}{
+		{Country{ID: 2, Name: "Portugal", Code: "PO", Region: "EU"}, []string{"Name"}},
+		{Country{ID: 3, Name: "Portuguese Republic", Code: "PT", Region: "EU"}, []string{"Code", "Region"}},
+		{Country{ID: 1, Name: "Spain", Code: "ES", Region: "EU"}, []string{"ID"}},
+	}
+	for _, c := range cases {
+		err := DB.Create(&c.country).Error
+		var oraErr *oracle.Error
+		if !errors.As(err, &oraErr) || oraErr.Code != 1 {
+			t.Fatalf("expected ORA-00001, got %v", err)
+		}
+		if oraErr.Constraint == "" {
+			t.Errorf("expected the constraint name, got %v", err)
+		}
+		if !reflect.DeepEqual(oraErr.Fields, c.fields) {
+			t.Errorf("expected fields %v for constraint %v, got %v", c.fields, oraErr.Constraint, oraErr.Fields)
+		}
+	}
+}
+
+func TestOracleErrorSentinelsNotAnnotated(t *testing.T) {
+	tx := DB.Table("users")
+	tx.Statement.SQL.WriteString(`SELECT * FROM "users" FOR UPDATE`)
+	tx.Error = oracle.ErrDeadlock
+	oracle.ErrorHandler(tx)
+
+	var oraErr *oracle.Error
+	if !errors.As(tx.Error, &oraErr) || oraErr == oracle.ErrDeadlock {
+		t.Fatalf("expected a copy of ErrDeadlock, got %v", tx.Error)
+	}
+	if oraErr.SQL != `SELECT * FROM "users" FOR UPDATE` || !errors.Is(tx.Error, oracle.ErrDeadlock) {
+		t.Errorf("expected the copy to record the statement, got %+v", oraErr)
+	}
+	if oracle.ErrDeadlock.SQL != "" {
+		t.Errorf("expected ErrDeadlock to be unchanged, got SQL %v", oracle.ErrDeadlock.SQL)
+	}
+}
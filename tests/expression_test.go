@@ -0,0 +1,161 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	. "github.com/oracle-samples/gorm-oracle/tests/utils"
+
+	"gorm.io/gorm"
+)
+
+func TestILike(t *testing.T) {
+	users := []User{*GetUser("ILike_Mixed", Config{}), *GetUser("ilike_lower", Config{})}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(oracle.ILike("name", "%x%")).Not(oracle.ILike("name", "y")).Find(&[]User{})
+	})
+	if !regexp.MustCompile(`WHERE UPPER\("name"\) LIKE UPPER\('%x%'\) AND UPPER\("name"\) NOT LIKE UPPER\('y'\)`).MatchString(sql) {
+		t.Errorf("unexpected ILIKE SQL, got %v", sql)
+	}
+
+	var found []User
+	if err := DB.Where(oracle.ILike("name", "ILIKE_%")).Order("\"name\"").Find(&found).Error; err != nil {
+		t.Fatalf("failed to query with ILike, got error %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("expected 2 users matching regardless of case, got %v", len(found))
+	}
+}
+
+func TestCaseInsensitiveConfig(t *testing.T) {
+	db, err := openTestDBWithOptions(&oracle.Config{CaseInsensitive: oracle.CaseInsensitiveUpper}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	user := GetUser("Case_Insensitive", Config{})
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user, got error %v", err)
+	}
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(&User{Name: "x"}).Or("name", []string{"a", "b"}).Find(&[]User{})
+	})
+	if !regexp.MustCompile(`UPPER\("users"."name"\) = UPPER\('x'\).* OR UPPER\("name"\) IN \(UPPER\('a'\),UPPER\('b'\)\)`).MatchString(sql) {
+		t.Errorf("expected case-insensitive comparisons, got %v", sql)
+	}
+
+	var found User
+	if err := db.Where(&User{Name: "case_insensitive"}).First(&found).Error; err != nil {
+		t.Fatalf("failed to find user regardless of case, got error %v", err)
+	}
+	if found.ID != user.ID {
+		t.Errorf("expected user %v, got %v", user.ID, found.ID)
+	}
+
+	result := db.Model(&User{}).Where("name", "CASE_INSENSITIVE").Update("age", 33)
+	if result.Error != nil || result.RowsAffected != 1 {
+		t.Errorf("expected 1 updated row, got %v, error %v", result.RowsAffected, result.Error)
+	}
+
+	var count int64
+	DB.Model(&User{}).Where("name", "case_insensitive").Count(&count)
+	if count != 0 {
+		t.Errorf("expected default connection to stay case-sensitive, got %v matches", count)
+	}
+}
+
+func TestRegexpExpressions(t *testing.T) {
+	users := []User{*GetUser("regexp_abc-123", Config{}), *GetUser("regexp_XYZ-456", Config{})}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&User{}).
+			Select("?", oracle.RegexpSubstr("name", "-(\\d+)$").Group(1).As("num")).
+			Where(oracle.RegexpLike("name", "^regexp_", "i")).
+			Find(&[]map[string]interface{}{})
+	})
+	if !regexp.MustCompile(`SELECT REGEXP_SUBSTR\("name", '-\(\\d\+\)\$', 1, 1, NULL, 1\) AS "num" FROM "users" WHERE REGEXP_LIKE\("name", '\^regexp_', 'i'\)`).MatchString(sql) {
+		t.Errorf("unexpected REGEXP SQL, got %v", sql)
+	}
+
+	if err := DB.Session(&gorm.Session{DryRun: true}).Where(oracle.RegexpLike("name", "x", "z")).Find(&[]User{}).Error; err == nil {
+		t.Errorf("expected error for invalid match parameter")
+	}
+
+	var found []User
+	if err := DB.Where(oracle.RegexpLike("name", "^REGEXP_[a-z]+-", "i")).Order("\"name\"").Find(&found).Error; err != nil {
+		t.Fatalf("failed to query with REGEXP_LIKE, got error %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("expected 2 users, got %v", len(found))
+	}
+
+	var numbers []string
+	err := DB.Model(&User{}).
+		Select("?", oracle.RegexpSubstr("name", "-(\\d+)$").Group(1)).
+		Where(oracle.RegexpLike("name", "^regexp_")).
+		Order("\"id\"").
+		Scan(&numbers).Error
+	if err != nil {
+		t.Fatalf("failed to query with REGEXP_SUBSTR, got error %v", err)
+	}
+	if len(numbers) != 2 || numbers[0] != "123" || numbers[1] != "456" {
+		t.Errorf("expected extracted numbers, got %v", numbers)
+	}
+
+	err = DB.Model(&User{}).Where("\"id\" = ?", users[1].ID).Update("name", oracle.RegexpReplace("name", "[0-9]", "#").WithFlags("i")).Error
+	if err != nil {
+		t.Fatalf("failed to update with REGEXP_REPLACE, got error %v", err)
+	}
+	var updated User
+	DB.First(&updated, users[1].ID)
+	if updated.Name != "regexp_XYZ-###" {
+		t.Errorf("expected replaced name, got %v", updated.Name)
+	}
+}
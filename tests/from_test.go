@@ -0,0 +1,282 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	. "github.com/oracle-samples/gorm-oracle/tests/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+func TestSampleClause(t *testing.T) {
+	users := []User{*GetUser("sample_clause_1", Config{}), *GetUser("sample_clause_2", Config{})}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	dryDB := DB.Session(&gorm.Session{DryRun: true})
+
+	result := dryDB.Clauses(oracle.Sample(10, 42)).Find(&[]User{})
+	if !regexp.MustCompile(`FROM "users" SAMPLE \(10\) SEED \(42\) WHERE`).MatchString(result.Statement.SQL.String()) {
+		t.Errorf("expected SAMPLE clause after table, got %v", result.Statement.SQL.String())
+	}
+
+	result = dryDB.Table("users u").Clauses(oracle.SampleBlock(0.5)).Find(&[]User{})
+	if !regexp.MustCompile(`FROM "users" SAMPLE BLOCK \(0.5\) "U"`).MatchString(result.Statement.SQL.String()) {
+		t.Errorf("expected SAMPLE BLOCK clause before table alias, got %v", result.Statement.SQL.String())
+	}
+
+	if err := dryDB.Clauses(oracle.Sample(100)).Find(&[]User{}).Error; err == nil {
+		t.Errorf("expected error for sample percent out of range")
+	}
+
+	var sampled []User
+	if err := DB.Clauses(oracle.Sample(99.999999, 1)).Where("\"name\" LIKE ?", "sample_clause_%").Find(&sampled).Error; err != nil {
+		t.Fatalf("failed to query with SAMPLE clause, got error %v", err)
+	}
+	if len(sampled) > len(users) {
+		t.Errorf("expected at most %d sampled users, got %d", len(users), len(sampled))
+	}
+}
+
+type PartitionedEvent struct {
+	ID        uint      `gorm:"primaryKey"`
+	Name      string    `gorm:"size:100"`
+	CreatedAt time.Time `gorm:"type:DATE"`
+}
+
+func TestPartitionClause(t *testing.T) {
+	DB.Migrator().DropTable(&PartitionedEvent{})
+	createTable := `CREATE TABLE "partitioned_events" (
+		"id" NUMBER PRIMARY KEY,
+		"name" VARCHAR2(100),
+		"created_at" DATE
+	) PARTITION BY RANGE ("created_at") (
+		PARTITION p2024_01 VALUES LESS THAN (DATE '2024-02-01'),
+		PARTITION p2024_02 VALUES LESS THAN (DATE '2024-03-01')
+	)`
+	if err := DB.Exec(createTable).Error; err != nil {
+		t.Fatalf("failed to create partitioned table, got error %v", err)
+	}
+	defer DB.Migrator().DropTable(&PartitionedEvent{})
+
+	events := []PartitionedEvent{
+		{ID: 1, Name: "january", CreatedAt: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Name: "february", CreatedAt: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	if err := DB.Create(&events).Error; err != nil {
+		t.Fatalf("failed to create events, got error %v", err)
+	}
+
+	var january []PartitionedEvent
+	if err := DB.Clauses(oracle.Partition("p2024_01")).Find(&january).Error; err != nil {
+		t.Fatalf("failed to query partition, got error %v", err)
+	}
+	if len(january) != 1 || january[0].Name != "january" {
+		t.Errorf("expected only the january event, got %v", january)
+	}
+
+	var february []PartitionedEvent
+	if err := DB.Clauses(oracle.PartitionFor(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC))).Find(&february).Error; err != nil {
+		t.Fatalf("failed to query partition by key, got error %v", err)
+	}
+	if len(february) != 1 || february[0].Name != "february" {
+		t.Errorf("expected only the february event, got %v", february)
+	}
+
+	// DML restricted to a partition must not touch rows in other partitions
+	result := DB.Clauses(oracle.Partition("p2024_01")).Model(&PartitionedEvent{}).Where("1 = 1").Update("name", "updated")
+	if result.Error != nil || result.RowsAffected != 1 {
+		t.Errorf("expected 1 updated row in partition, got %v, error %v", result.RowsAffected, result.Error)
+	}
+
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(oracle.Subpartition("sp1")).Table("orders o").Find(&[]PartitionedEvent{})
+	})
+	if !regexp.MustCompile(`FROM "orders" SUBPARTITION \(sp1\) "O"`).MatchString(sql) {
+		t.Errorf("expected SUBPARTITION clause before table alias, got %v", sql)
+	}
+
+	if err := DB.Session(&gorm.Session{DryRun: true}).Clauses(oracle.Partition("p1) UNION SELECT (1")).Find(&[]PartitionedEvent{}).Error; err == nil {
+		t.Errorf("expected error for invalid partition name")
+	}
+}
+
+func TestTableFunction(t *testing.T) {
+	if err := DB.Exec(`CREATE OR REPLACE TYPE tf_number_table AS TABLE OF NUMBER`).Error; err != nil {
+		t.Fatalf("failed to create collection type, got error %v", err)
+	}
+	createFunction := `CREATE OR REPLACE FUNCTION tf_numbers(n NUMBER) RETURN tf_number_table PIPELINED IS
+	BEGIN
+		FOR i IN 1..n LOOP
+			PIPE ROW(i);
+		END LOOP;
+		RETURN;
+	END;`
+	if err := DB.Exec(createFunction).Error; err != nil {
+		t.Fatalf("failed to create table function, got error %v", err)
+	}
+	defer func() {
+		DB.Exec(`DROP FUNCTION tf_numbers`)
+		DB.Exec(`DROP TYPE tf_number_table FORCE`)
+	}()
+
+	var values []int
+	if err := DB.Table("?", oracle.TableFunction("tf_numbers", 3)).Pluck("COLUMN_VALUE", &values).Error; err != nil {
+		t.Fatalf("failed to query table function, got error %v", err)
+	}
+	if len(values) != 3 || values[0] != 1 || values[2] != 3 {
+		t.Errorf("expected values [1 2 3], got %v", values)
+	}
+
+	var count int64
+	if err := DB.Clauses(oracle.TableFunction("tf_numbers", 5).As("n")).Where("\"n\".COLUMN_VALUE > ?", 2).Count(&count).Error; err != nil {
+		t.Fatalf("failed to count table function rows, got error %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 rows, got %v", count)
+	}
+
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(oracle.TableFunction("pkg.fn", 1, "a").As("t")).Find(&[]User{})
+	})
+	if !regexp.MustCompile(`FROM TABLE\(pkg\.fn\(1,'a'\)\) "t"`).MatchString(sql) {
+		t.Errorf("expected TABLE() expression in FROM clause, got %v", sql)
+	}
+
+	if err := DB.Session(&gorm.Session{DryRun: true}).Table("?", oracle.TableFunction("fn(); --")).Find(&[]User{}).Error; err == nil {
+		t.Errorf("expected error for invalid table function name")
+	}
+}
+
+func TestFlashbackClause(t *testing.T) {
+	dryDB := DB.Session(&gorm.Session{DryRun: true})
+
+	result := dryDB.Clauses(oracle.AsOfSCN(42)).Joins("Company").Find(&[]User{})
+	if !regexp.MustCompile(`FROM "users" AS OF SCN :1 LEFT JOIN "companies" AS OF SCN :2 "Company" ON`).MatchString(result.Statement.SQL.String()) {
+		t.Errorf("expected AS OF clause after the table and the joined table, got %v", result.Statement.SQL.String())
+	}
+
+	result = dryDB.Table("users u").Clauses(oracle.AsOfTimestamp(time.Now()), oracle.Sample(10)).Find(&[]User{})
+	if !regexp.MustCompile(`FROM "users" SAMPLE \(10\) AS OF TIMESTAMP :1 "U"`).MatchString(result.Statement.SQL.String()) {
+		t.Errorf("expected AS OF clause after SAMPLE and before the table alias, got %v", result.Statement.SQL.String())
+	}
+
+	if err := dryDB.Clauses(oracle.Flashback{}).Find(&[]User{}).Error; err == nil {
+		t.Errorf("expected error for a flashback clause without SCN or timestamp")
+	}
+}
+
+func TestWithSnapshot(t *testing.T) {
+	user := *GetUser("snapshot", Config{})
+	if err := DB.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user, got error %v", err)
+	}
+
+	var before, after []User
+	err := oracle.WithSnapshot(DB, func(tx *gorm.DB, scn uint64) error {
+		if scn == 0 {
+			t.Errorf("expected the current SCN")
+		}
+		if err := tx.Where("\"name\" LIKE ?", "snapshot%").Find(&before).Error; err != nil {
+			return err
+		}
+		if err := DB.Model(&user).Update("name", "snapshot_updated").Error; err != nil {
+			return err
+		}
+		return tx.Where("\"name\" LIKE ?", "snapshot%").Find(&after).Error
+	})
+	if err != nil {
+		t.Fatalf("failed to query snapshot, got error %v", err)
+	}
+
+	// The SCN may predate the user when it is derived from the current time
+	if len(before) != len(after) {
+		t.Fatalf("expected the same users in the snapshot, got %v and %v", before, after)
+	}
+	for i := range after {
+		if after[i].Name != before[i].Name || after[i].Name == "snapshot_updated" {
+			t.Errorf("expected the snapshot to hide the update, got %v and %v", before[i].Name, after[i].Name)
+		}
+	}
+}
+
+func TestTableAlias(t *testing.T) {
+	user := *GetUser("table_alias", Config{Account: true})
+	if err := DB.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user, got error %v", err)
+	}
+
+	dryDB := DB.Session(&gorm.Session{DryRun: true})
+	for _, table := range []string{"users u", `"users" u`, "users AS u", `"users" AS "U"`, `"users" "U"`} {
+		result := dryDB.Table(table).Where(&User{Name: user.Name}).Group("name").Find(&[]User{})
+		expected := `SELECT * FROM "users" "U" WHERE "U"."name" = :1 AND "U"."deleted_at" IS NULL GROUP BY "name"`
+		if sql := result.Statement.SQL.String(); sql != expected {
+			t.Errorf("expected %v for %v, got %v", expected, table, sql)
+		}
+	}
+
+	for _, table := range []string{"users u", `"users" u`, "users AS u"} {
+		var found []User
+		if err := DB.Table(table).Joins("Account").Where("u.\"name\" = ?", user.Name).Where(&User{Age: user.Age}).Find(&found).Error; err != nil {
+			t.Fatalf("failed to query %v, got error %v", table, err)
+		}
+		if len(found) != 1 || found[0].Name != user.Name || found[0].Account.AccountNumber != user.Account.AccountNumber {
+			t.Errorf("expected %v with its account for %v, got %+v", user.Name, table, found)
+		}
+	}
+
+	var count int64
+	subquery := DB.Table("users")
+	if err := DB.Table("(?) AS sub", subquery).Where("sub.\"name\" = ?", user.Name).Count(&count).Error; err != nil || count != 1 {
+		t.Errorf("expected to count 1 user through the aliased subquery, got %v, error %v", count, err)
+	}
+
+	var names []string
+	if err := DB.Model(&User{}).Joins("JOIN \"accounts\" AS a ON a.\"user_id\" = \"users\".\"id\"").Where("a.\"account_number\" = ?", user.Account.AccountNumber).Pluck("name", &names).Error; err != nil {
+		t.Fatalf("failed to join with AS alias, got error %v", err)
+	}
+	tests.AssertEqual(t, names, []string{user.Name})
+}
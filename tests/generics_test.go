@@ -165,10 +165,7 @@ func TestGenericsExecAndUpdate(t *testing.T) {
 	if err := gorm.G[User](DB).Exec(ctx, "INSERT INTO \"users\"(\"name\") VALUES(?)", name); err != nil {
 		t.Fatalf("Exec insert failed: %v", err)
 	}
-	// todo: uncomment the below line, once the alias quoting issue is resolved.
-	// Gorm issue track: https://github.com/oracle-samples/gorm-oracle/issues/36
-	// u, err := gorm.G[User](DB).Table("\"users\" u").Where("u.\"name\" = ?", name).First(ctx)
-	u, err := gorm.G[User](DB).Table("users").Where("\"name\" = ?", name).First(ctx)
+	u, err := gorm.G[User](DB).Table("\"users\" u").Where("u.\"name\" = ?", name).First(ctx)
 	if err != nil {
 		t.Fatalf("failed to find user, got error: %v", err)
 	} else if u.Name != name || u.ID == 0 {
@@ -425,9 +422,8 @@ func TestGenericsJoins(t *testing.T) {
 		t.Fatalf("Joins expected %s, got %+v", u.Name, result)
 	}
 
-	// TODO: Temporarily disabled due to issue with As("t")
 	// Raw Subquery JOIN + WHERE
-	/*result, err = db.Joins(clause.LeftJoin.AssociationFrom("Company", gorm.G[Company](DB)).As("t"),
+	result, err = db.Joins(clause.LeftJoin.AssociationFrom("Company", gorm.G[Company](DB)).As("t"),
 		func(db gorm.JoinBuilder, joinTable clause.Table, curTable clause.Table) error {
 			if joinTable.Name != "t" {
 				t.Fatalf("Join table should be t, but got %v", joinTable.Name)
@@ -441,10 +437,10 @@ func TestGenericsJoins(t *testing.T) {
 	}
 	if result.Name != u2.Name || result.Company.Name != u.Company.Name || result.Company.ID == 0 {
 		t.Fatalf("Joins expected %s, got %+v", u.Name, result)
-	}*/
+	}
 
 	// Raw Subquery JOIN + WHERE + Select
-	/*result, err = db.Joins(clause.LeftJoin.AssociationFrom("Company", gorm.G[Company](DB).Select("Name")).As("t"),
+	result, err = db.Joins(clause.LeftJoin.AssociationFrom("Company", gorm.G[Company](DB).Select("Name")).As("t"),
 		func(db gorm.JoinBuilder, joinTable clause.Table, curTable clause.Table) error {
 			if joinTable.Name != "t" {
 				t.Fatalf("Join table should be t, but got %v", joinTable.Name)
@@ -458,7 +454,7 @@ func TestGenericsJoins(t *testing.T) {
 	}
 	if result.Name != u2.Name || result.Company.Name != u.Company.Name || result.Company.ID != 0 {
 		t.Fatalf("Joins expected %s, got %+v", u.Name, result)
-	}*/
+	}
 
 	_, err = db.Joins(clause.Has("Company"), func(db gorm.JoinBuilder, joinTable clause.Table, curTable clause.Table) error {
 		return errors.New("join error")
@@ -0,0 +1,75 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+
+	"gorm.io/gorm"
+)
+
+func TestHealthCheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := openTestDBWithOptions(&oracle.Config{HealthCheck: &oracle.HealthCheck{
+		PingInterval:       time.Second,
+		ValidationInterval: 50 * time.Millisecond,
+		Context:            ctx,
+	}}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database with a health check, got error %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		var one int
+		if err := db.Raw("SELECT 1 FROM DUAL").Scan(&one).Error; err != nil || one != 1 {
+			t.Fatalf("failed to query with a health check, got %v, %v", one, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if _, err := openTestDBWithOptions(&oracle.Config{DriverName: "other", HealthCheck: &oracle.HealthCheck{}}, &gorm.Config{}); err == nil {
+		t.Errorf("expected an error for a health check with another driver")
+	}
+}
@@ -46,8 +46,10 @@ import (
 
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/utils/tests"
 )
 
@@ -603,3 +605,62 @@ func TestJoinInvalidSQL(t *testing.T) {
 		t.Errorf("expected SQL error for invalid join, got nil")
 	}
 }
+
+func TestJoinsForUpdateOf(t *testing.T) {
+	user := *GetUser("joins-for-update-of", Config{Company: true})
+	DB.Create(&user)
+
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Joins("Company").Clauses(clause.Locking{
+			Strength: clause.LockingStrengthUpdate,
+			Table:    clause.Table{Name: clause.CurrentTable},
+		}).Find(&[]User{})
+	})
+	if !regexp.MustCompile(`FOR UPDATE OF "users"."id"$`).MatchString(sql) {
+		t.Errorf("expected the users table to be locked through its primary key, got %s", sql)
+	}
+
+	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Joins("Company").Clauses(clause.Locking{
+			Strength: clause.LockingStrengthUpdate,
+			Table:    clause.Table{Name: "companies"},
+			Options:  clause.LockingOptionsNoWait,
+		}).Find(&[]User{})
+	})
+	if !regexp.MustCompile(`FOR UPDATE OF "Company"."id" NOWAIT$`).MatchString(sql) {
+		t.Errorf("expected the joined table to be locked through its alias, got %s", sql)
+	}
+
+	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Joins("Company").Clauses(oracle.Locking{
+			Columns: []clause.Column{{Table: clause.CurrentTable, Name: "name"}},
+			Options: clause.LockingOptionsSkipLocked,
+		}).Find(&[]User{})
+	})
+	if !regexp.MustCompile(`FOR UPDATE OF "users"."name" SKIP LOCKED$`).MatchString(sql) {
+		t.Errorf("expected the columns of oracle.Locking, got %s", sql)
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var users []User
+		if err := tx.Joins("Company").Clauses(clause.Locking{
+			Strength: clause.LockingStrengthUpdate,
+			Table:    clause.Table{Name: clause.CurrentTable},
+		}).Where("\"users\".\"id\" = ?", user.ID).Find(&users).Error; err != nil {
+			return err
+		}
+		if len(users) != 1 || users[0].Company.Name != user.Company.Name {
+			t.Errorf("expected the user and its company, got %+v", users)
+		}
+
+		// Only the users row is locked, so the company can be locked elsewhere
+		return DB.Transaction(func(other *gorm.DB) error {
+			var company Company
+			return other.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsNoWait}).
+				First(&company, user.Company.ID).Error
+		})
+	})
+	if err != nil {
+		t.Fatalf("failed to lock the joined rows, got error: %v", err)
+	}
+}
@@ -39,13 +39,16 @@
 package tests
 
 import (
+	"database/sql"
 	"encoding/json"
 	"strconv"
 	"testing"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
 )
 
 func TestJSONKeys(t *testing.T) {
@@ -609,3 +612,239 @@ func TestJSONNegative(t *testing.T) {
 		t.Fatalf("expected 1 row after failed update, got %d", cnt)
 	}
 }
+
+func TestJSONTransformBuilder(t *testing.T) {
+	type TransformRecord struct {
+		ID  uint           `gorm:"primaryKey;autoIncrement;column:record_id"`
+		Doc datatypes.JSON `gorm:"column:doc"`
+	}
+	DB.Migrator().DropTable(&TransformRecord{})
+	if err := DB.AutoMigrate(&TransformRecord{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	defer DB.Migrator().DropTable(&TransformRecord{})
+
+	transform := oracle.JSONTransform("doc").
+		SetJSON("$.address", map[string]string{"city": "Lisbon"}).
+		Set("$.name", "it's").
+		Remove("$.draft").
+		Append("$.tags", "go").
+		Rename("$.old", "new")
+
+	stmt := DB.Session(&gorm.Session{DryRun: true}).Model(&TransformRecord{}).Where(`"record_id" = ?`, 1).Update("doc", transform).Statement
+	expected := `UPDATE "transform_records" SET "doc"=JSON_TRANSFORM("doc", SET '$.address' = :1 FORMAT JSON, SET '$.name' = :2, REMOVE '$.draft', APPEND '$.tags' = :3, RENAME '$.old' = 'new') WHERE "record_id" = :4`
+	if got := stmt.SQL.String(); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+
+	rec := TransformRecord{Doc: datatypes.JSON([]byte(`{"draft":true,"tags":["sql"],"old":1}`))}
+	if err := DB.Create(&rec).Error; err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+	if err := DB.Model(&TransformRecord{}).Where(`"record_id" = ?`, rec.ID).Update("doc", transform).Error; err != nil {
+		t.Fatalf("JSON_TRANSFORM builder update failed: %v", err)
+	}
+
+	var got TransformRecord
+	if err := DB.First(&got, rec.ID).Error; err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(got.Doc, &doc); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if address, ok := doc["address"].(map[string]interface{}); !ok || address["city"] != "Lisbon" {
+		t.Errorf("expected the address to be set as a JSON object, got %v", doc["address"])
+	}
+	if doc["name"] != "it's" {
+		t.Errorf("expected the name to be set, got %v", doc["name"])
+	}
+	if _, ok := doc["draft"]; ok {
+		t.Errorf("expected draft to be removed, got %v", doc)
+	}
+	if tags, ok := doc["tags"].([]interface{}); !ok || len(tags) != 2 || tags[1] != "go" {
+		t.Errorf("expected go to be appended to the tags, got %v", doc["tags"])
+	}
+	if _, ok := doc["old"]; ok || doc["new"] != float64(1) {
+		t.Errorf("expected old to be renamed to new, got %v", doc)
+	}
+
+	if err := DB.Model(&TransformRecord{}).Where(`"record_id" = ?`, rec.ID).
+		Update("doc", oracle.JSONTransform("doc").Set("name", "x")).Error; err == nil {
+		t.Errorf("expected an error for a path not starting with $")
+	}
+}
+
+func TestJSONTransformBuilderTxRollback(t *testing.T) {
+	type BuilderCounterRecord struct {
+		ID  uint           `gorm:"primaryKey;autoIncrement;column:record_id"`
+		Doc datatypes.JSON `gorm:"column:doc"`
+	}
+	DB.Migrator().DropTable(&BuilderCounterRecord{})
+	if err := DB.AutoMigrate(&BuilderCounterRecord{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	defer DB.Migrator().DropTable(&BuilderCounterRecord{})
+
+	record := BuilderCounterRecord{Doc: datatypes.JSON([]byte(`{"cnt":1}`))}
+	if err := DB.Create(&record).Error; err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+
+	tx := DB.Session(&gorm.Session{PrepareStmt: true}).Begin()
+	if err := tx.Error; err != nil {
+		t.Fatalf("begin tx failed: %v", err)
+	}
+
+	if err := tx.Model(&BuilderCounterRecord{}).
+		Where(`"record_id" = ?`, record.ID).
+		Update("doc", oracle.JSONTransform("doc").Set("$.cnt", 2)).Error; err != nil {
+		tx.Rollback()
+		t.Fatalf("tx update set failed: %v", err)
+	}
+
+	// Force an error to trigger rollback (invalid path syntax)
+	if err := tx.Model(&BuilderCounterRecord{}).
+		Where(`"record_id" = ?`, record.ID).
+		Update("doc", oracle.JSONTransform("doc").Set("$.[bad", 0)).Error; err == nil {
+		tx.Rollback()
+		t.Fatalf("expected JSON path syntax error inside tx, got nil")
+	}
+	_ = tx.Rollback()
+
+	var cntAfter int
+	if err := DB.Model(&BuilderCounterRecord{}).
+		Select(`JSON_VALUE("doc",'$.cnt' RETURNING NUMBER)`).
+		Where(`"record_id" = ?`, record.ID).
+		Scan(&cntAfter).Error; err != nil {
+		t.Fatalf("verify after rollback failed: %v", err)
+	}
+	if cntAfter != 1 {
+		t.Fatalf("expected cnt=1 after rollback, got %d", cntAfter)
+	}
+}
+
+func TestJSONValueAndExists(t *testing.T) {
+	type PathRecord struct {
+		ID  uint           `gorm:"primaryKey;autoIncrement;column:record_id"`
+		Doc datatypes.JSON `gorm:"column:doc"`
+	}
+	DB.Migrator().DropTable(&PathRecord{})
+	if err := DB.AutoMigrate(&PathRecord{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	defer DB.Migrator().DropTable(&PathRecord{})
+
+	records := []PathRecord{
+		{Doc: datatypes.JSON(`{"name":"pen","price":3,"tags":["office"]}`)},
+		{Doc: datatypes.JSON(`{"name":"desk","price":250,"tags":["office","furniture"]}`)},
+	}
+	if err := DB.Create(&records).Error; err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+
+	var names []string
+	if err := DB.Model(&PathRecord{}).
+		Select("?", oracle.JSONValue("doc", "$.name", "VARCHAR2(100)").As("name")).
+		Where("? > ?", oracle.JSONValue("doc", "$.price", "NUMBER"), 10).
+		Scan(&names).Error; err != nil {
+		t.Fatalf("JSON_VALUE query failed: %v", err)
+	}
+	tests.AssertEqual(t, names, []string{"desk"})
+
+	var count int64
+	if err := DB.Model(&PathRecord{}).
+		Where(oracle.JSONExists("doc", `$.tags?(@ == $tag)`, sql.Named("tag", "furniture"))).
+		Count(&count).Error; err != nil {
+		t.Fatalf("JSON_EXISTS with a named variable failed: %v", err)
+	}
+	tests.AssertEqual(t, count, int64(1))
+
+	if err := DB.Model(&PathRecord{}).
+		Where(oracle.JSONExists("doc", `$?(@.price < $p1 && @.name == $p2)`, 100, "pen")).
+		Count(&count).Error; err != nil {
+		t.Fatalf("JSON_EXISTS with positional variables failed: %v", err)
+	}
+	tests.AssertEqual(t, count, int64(1))
+
+	// A value that would break out of an interpolated path is only compared
+	if err := DB.Model(&PathRecord{}).
+		Where(oracle.JSONExists("doc", `$?(@.name == $p1)`, `pen") || exists(@.price`)).
+		Count(&count).Error; err != nil {
+		t.Fatalf("JSON_EXISTS with a quoted variable failed: %v", err)
+	}
+	tests.AssertEqual(t, count, int64(0))
+
+	if err := DB.Model(&PathRecord{}).
+		Not(oracle.JSONExists("doc", `$.tags?(@ == "furniture")`)).
+		Count(&count).Error; err != nil {
+		t.Fatalf("NOT JSON_EXISTS failed: %v", err)
+	}
+	tests.AssertEqual(t, count, int64(1))
+
+	if err := DB.Model(&PathRecord{}).
+		Where("? = 1", oracle.JSONValue("doc", "$.price", "NUMBER) FROM DUAL --")).
+		Count(&count).Error; err == nil {
+		t.Errorf("expected an error for an invalid returning type")
+	}
+}
+
+func TestJSONScanNestedStructs(t *testing.T) {
+	type Address struct {
+		City    string `json:"city"`
+		Zip     string `json:"zip"`
+		Primary bool   `json:"primary"`
+	}
+	type Profile struct {
+		Age       int64     `json:"age"`
+		Addresses []Address `json:"addresses"`
+	}
+	type NestedRecord struct {
+		ID      uint     `gorm:"primaryKey;autoIncrement;column:record_id"`
+		Profile Profile  `gorm:"serializer:json;type:json"`
+		Tags    []string `gorm:"serializer:json;type:json"`
+		Extra   *Profile `gorm:"serializer:json;type:json"`
+	}
+	DB.Migrator().DropTable(&NestedRecord{})
+	if err := DB.AutoMigrate(&NestedRecord{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	defer DB.Migrator().DropTable(&NestedRecord{})
+
+	record := NestedRecord{
+		Profile: Profile{Age: 9007199254740993, Addresses: []Address{{City: "Lisbon", Zip: "1000", Primary: true}, {City: "Porto"}}},
+		Tags:    []string{"a", "b"},
+	}
+	if err := DB.Create(&record).Error; err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+
+	var got NestedRecord
+	if err := DB.First(&got, record.ID).Error; err != nil {
+		t.Fatalf("failed to scan native JSON into nested structs: %v", err)
+	}
+	tests.AssertEqual(t, got.Profile, record.Profile)
+	tests.AssertEqual(t, got.Tags, record.Tags)
+	if got.Extra != nil {
+		t.Errorf("expected a NULL document to leave the pointer nil, got %+v", got.Extra)
+	}
+
+	// Selected JSON columns are scanned into plain fields as well
+	type ProfileOnly struct {
+		Profile Profile `gorm:"type:json"`
+		Doc     json.RawMessage
+	}
+	var profiles []ProfileOnly
+	if err := DB.Model(&NestedRecord{}).Select(`"profile", "profile" AS "doc"`).Find(&profiles).Error; err != nil {
+		t.Fatalf("failed to scan native JSON into a nested struct: %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(profiles))
+	}
+	tests.AssertEqual(t, profiles[0].Profile, record.Profile)
+	var decoded Profile
+	if err := json.Unmarshal(profiles[0].Doc, &decoded); err != nil {
+		t.Fatalf("expected the JSON text of the document, got %s: %v", profiles[0].Doc, err)
+	}
+	tests.AssertEqual(t, decoded, record.Profile)
+}
@@ -46,9 +46,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/utils/tests"
 )
 
 func TestBasicCRUD_JSONText(t *testing.T) {
@@ -964,6 +966,88 @@ func TestJSONRootArray(t *testing.T) {
 	}
 }
 
+func TestJSONTransformBuilderMatchesExpr(t *testing.T) {
+	type BuilderRec struct {
+		ID  uint           `gorm:"primaryKey;autoIncrement;column:record_id"`
+		Doc datatypes.JSON `gorm:"column:doc"`
+		Obj datatypes.JSON `gorm:"column:obj"`
+		Arr datatypes.JSON `gorm:"column:arr"`
+	}
+
+	cases := []struct {
+		name    string
+		column  string
+		builder oracle.JSONTransformExpr
+		expr    clause.Expr
+	}{
+		{"Set", "doc", oracle.JSONTransform("doc").Set("$.s", "b"), gorm.Expr(`JSON_TRANSFORM("doc", SET '$.s' = ?)`, "b")},
+		{"SetObject", "obj", oracle.JSONTransform("obj").Set("$.count", 8), gorm.Expr(`JSON_TRANSFORM("obj", SET '$.count' = ?)`, 8)},
+		{"SetMany", "doc", oracle.JSONTransform("doc").Set("$.k1001", "v1001").Set("$.k050", "v050-upd"), gorm.Expr(`JSON_TRANSFORM("doc", SET '$.k1001' = ?, SET '$.k050' = ?)`, "v1001", "v050-upd")},
+		{"Append", "arr", oracle.JSONTransform("arr").Append("$", "o"), gorm.Expr(`JSON_TRANSFORM("arr", APPEND '$' = ?)`, "o")},
+		{"AppendMany", "doc", oracle.JSONTransform("doc").Append("$", 1).Append("$", 2), gorm.Expr(`JSON_TRANSFORM("doc", APPEND '$' = ?, APPEND '$' = ?)`, 1, 2)},
+		{"RemoveAndSet", "doc", oracle.JSONTransform("doc").Remove(`$."weird.key"."sp ace"`).Set("$.arr[1]", 99), gorm.Expr(`JSON_TRANSFORM("doc", REMOVE '$."weird.key"."sp ace"', SET '$.arr[1]' = ?)`, 99)},
+	}
+
+	dryRunDB := DB.Session(&gorm.Session{DryRun: true})
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			want := dryRunDB.Model(&BuilderRec{}).Where(`"record_id" = ?`, 1).Update(tt.column, tt.expr).Statement
+			got := dryRunDB.Model(&BuilderRec{}).Where(`"record_id" = ?`, 1).Update(tt.column, tt.builder).Statement
+			tests.AssertEqual(t, got.SQL.String(), want.SQL.String())
+			tests.AssertEqual(t, got.Vars, want.Vars)
+		})
+	}
+}
+
+func TestJSONTransformBuilderRootArray(t *testing.T) {
+	type BuilderTransRec struct {
+		ID  uint           `gorm:"primaryKey;autoIncrement;column:record_id"`
+		Doc datatypes.JSON `gorm:"column:doc"`
+	}
+
+	DB.Migrator().DropTable(&BuilderTransRec{})
+	if err := DB.AutoMigrate(&BuilderTransRec{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	defer DB.Migrator().DropTable(&BuilderTransRec{})
+
+	rec := BuilderTransRec{Doc: datatypes.JSON([]byte(`{}`))}
+	if err := DB.Create(&rec).Error; err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+
+	// APPEND to non-array root should fail with ORA-40769: value not a JSON array
+	if err := DB.Model(&BuilderTransRec{}).
+		Where(`"record_id" = ?`, rec.ID).
+		Update("doc", oracle.JSONTransform("doc").Append("$", 1)).Error; err == nil {
+		t.Fatalf("expected error appending to non-array root JSON, got nil")
+	}
+
+	if err := DB.Model(&BuilderTransRec{}).
+		Where(`"record_id" = ?`, rec.ID).
+		Update("doc", gorm.Expr(`JSON_SERIALIZE(JSON_ARRAY() RETURNING CLOB)`)).Error; err != nil {
+		t.Fatalf("set root to empty array failed: %v", err)
+	}
+
+	if err := DB.Model(&BuilderTransRec{}).
+		Where(`"record_id" = ?`, rec.ID).
+		Update("doc", oracle.JSONTransform("doc").Append("$", 1).Append("$", 2)).Error; err != nil {
+		t.Fatalf("append after fixing root failed: %v", err)
+	}
+
+	var out BuilderTransRec
+	if err := DB.First(&out, `"record_id" = ?`, rec.ID).Error; err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	var arr []int
+	if err := json.Unmarshal(out.Doc, &arr); err != nil {
+		t.Fatalf("unmarshal as array failed: %v", err)
+	}
+	if len(arr) != 2 || arr[0] != 1 || arr[1] != 2 {
+		t.Fatalf("unexpected array content after appends: %#v", arr)
+	}
+}
+
 func TestCustomJSON(t *testing.T) {
 	type CustomJSONModel struct {
 		Blah string       `gorm:"primaryKey"`
@@ -39,9 +39,11 @@
 package tests
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 
+	"github.com/godror/godror"
 	"gorm.io/gorm/clause"
 )
 
@@ -369,3 +371,226 @@ func TestClobUpdateOnConflict(t *testing.T) {
 		t.Fatalf("Failed to update CLOB record with ON CONFLICT: %v", err)
 	}
 }
+
+type LobScanModel struct {
+	ID    uint   `gorm:"primaryKey"`
+	Text  string `gorm:"type:clob"`
+	NText string `gorm:"type:nclob"`
+	Data  []byte `gorm:"type:blob"`
+}
+
+func TestLobScanning(t *testing.T) {
+	DB.Migrator().DropTable(&LobScanModel{})
+	if err := DB.AutoMigrate(&LobScanModel{}); err != nil {
+		t.Fatalf("failed to migrate LOB scan table, got error %v", err)
+	}
+	defer DB.Migrator().DropTable(&LobScanModel{})
+
+	text := strings.Repeat("clob data ", 200000)
+	ntext := strings.Repeat("nclob ü ", 1000)
+	data := bytes.Repeat([]byte{0, 1, 2, 255}, 100000)
+	if err := DB.Create(&LobScanModel{ID: 1, Text: text, NText: ntext, Data: data}).Error; err != nil {
+		t.Fatalf("failed to create LOB row, got error %v", err)
+	}
+
+	query := `SELECT "id", "text", "n_text", "data" FROM "lob_scan_models"`
+	check := func(name string, row map[string]interface{}) {
+		t.Helper()
+		if v, ok := row["text"].(string); !ok || v != text {
+			t.Errorf("%s: expected CLOB as string of length %d, got %T", name, len(text), row["text"])
+		}
+		if v, ok := row["n_text"].(string); !ok || v != ntext {
+			t.Errorf("%s: expected NCLOB as string, got %T", name, row["n_text"])
+		}
+		if v, ok := row["data"].([]byte); !ok || !bytes.Equal(v, data) {
+			t.Errorf("%s: expected BLOB as []byte of length %d, got %T", name, len(data), row["data"])
+		}
+	}
+
+	var scanned []map[string]interface{}
+	if err := DB.Raw(query).Scan(&scanned).Error; err != nil {
+		t.Fatalf("failed to scan LOBs into maps, got error %v", err)
+	}
+	if len(scanned) != 1 {
+		t.Fatalf("expected 1 row, got %v", len(scanned))
+	}
+	check("Scan", scanned[0])
+
+	// Ask the driver to return LOB readers instead of values
+	var found []map[string]interface{}
+	if err := DB.Raw(query, godror.LobAsReader()).Find(&found).Error; err != nil {
+		t.Fatalf("failed to find LOBs into maps with LOB readers, got error %v", err)
+	}
+	if len(found) != 1 {
+		t.Fatalf("expected 1 row, got %v", len(found))
+	}
+	check("Find", found[0])
+
+	var readers []map[string]interface{}
+	if err := DB.Raw(query, godror.LobAsReader()).Scan(&readers).Error; err != nil {
+		t.Fatalf("failed to scan LOBs into maps with LOB readers, got error %v", err)
+	}
+	if len(readers) != 1 {
+		t.Fatalf("expected 1 row, got %v", len(readers))
+	}
+	check("Scan", readers[0])
+
+	var model LobScanModel
+	if err := DB.Raw(query, godror.LobAsReader()).Find(&model).Error; err != nil {
+		t.Fatalf("failed to find LOBs into struct with LOB readers, got error %v", err)
+	}
+	if model.Text != text || model.NText != ntext || !bytes.Equal(model.Data, data) {
+		t.Errorf("unexpected LOB values in struct, got lengths %d, %d, %d", len(model.Text), len(model.NText), len(model.Data))
+	}
+
+	var scannedModel LobScanModel
+	if err := DB.Raw(query, godror.LobAsReader()).Scan(&scannedModel).Error; err != nil {
+		t.Fatalf("failed to scan LOBs into struct with LOB readers, got error %v", err)
+	}
+	if scannedModel.Text != text || scannedModel.NText != ntext || !bytes.Equal(scannedModel.Data, data) {
+		t.Errorf("unexpected LOB values in scanned struct, got lengths %d, %d, %d", len(scannedModel.Text), len(scannedModel.NText), len(scannedModel.Data))
+	}
+
+	readerRows, err := DB.Raw(query, godror.LobAsReader()).Rows()
+	if err != nil {
+		t.Fatalf("failed to query LOB rows with LOB readers, got error %v", err)
+	}
+	defer readerRows.Close()
+	for readerRows.Next() {
+		var (
+			id    uint
+			clob  string
+			nclob []byte
+			blob  []byte
+		)
+		if err := readerRows.Scan(&id, &clob, &nclob, &blob); err != nil {
+			t.Fatalf("failed to scan LOB row with LOB readers, got error %v", err)
+		}
+		if clob != text || string(nclob) != ntext || !bytes.Equal(blob, data) {
+			t.Errorf("unexpected LOB values from Rows with LOB readers")
+		}
+	}
+
+	rows, err := DB.Raw(query).Rows()
+	if err != nil {
+		t.Fatalf("failed to query LOB rows, got error %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id        uint
+			clobBytes []byte
+			nclob     string
+			blob      []byte
+		)
+		if err := rows.Scan(&id, &clobBytes, &nclob, &blob); err != nil {
+			t.Fatalf("failed to scan LOB row, got error %v", err)
+		}
+		if string(clobBytes) != text || nclob != ntext || !bytes.Equal(blob, data) {
+			t.Errorf("unexpected LOB values from Rows")
+		}
+	}
+}
+
+func TestLongColumns(t *testing.T) {
+	type LongItem struct {
+		ID    uint
+		Name  string
+		Notes string `gorm:"type:LONG;->"`
+	}
+	type LongRawItem struct {
+		ID      uint
+		Payload []byte `gorm:"type:LONG RAW;->"`
+	}
+
+	// A table can have a single LONG or LONG RAW column
+	DB.Exec(`DROP TABLE "long_items" CASCADE CONSTRAINTS`)
+	DB.Exec(`DROP TABLE "long_raw_items" CASCADE CONSTRAINTS`)
+	if err := DB.Exec(`CREATE TABLE "long_items" (
+		"id" NUMBER(20) GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+		"name" VARCHAR2(50 CHAR),
+		"notes" LONG
+	)`).Error; err != nil {
+		t.Fatalf("failed to create LONG table, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable("long_items")
+	if err := DB.Exec(`CREATE TABLE "long_raw_items" (
+		"id" NUMBER(20) PRIMARY KEY,
+		"payload" LONG RAW
+	)`).Error; err != nil {
+		t.Fatalf("failed to create LONG RAW table, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable("long_raw_items")
+
+	notes := strings.Repeat("legacy notes ", 1500)
+	payload := bytes.Repeat([]byte{0, 1, 2, 255}, 2500)
+	if err := DB.Exec(`INSERT INTO "long_items" ("name", "notes") VALUES (?, ?)`, "first", notes).Error; err != nil {
+		t.Fatalf("failed to insert LONG value, got error: %v", err)
+	}
+	if err := DB.Exec(`INSERT INTO "long_raw_items" ("id", "payload") VALUES (?, ?)`, 1, payload).Error; err != nil {
+		t.Fatalf("failed to insert LONG RAW value, got error: %v", err)
+	}
+
+	var items []LongItem
+	if err := DB.Find(&items).Error; err != nil {
+		t.Fatalf("failed to find LONG rows, got error: %v", err)
+	}
+	if len(items) != 1 || items[0].Notes != notes {
+		t.Fatalf("expected the LONG value to be scanned into a string, got %d rows", len(items))
+	}
+
+	var rawItem LongRawItem
+	if err := DB.First(&rawItem, 1).Error; err != nil {
+		t.Fatalf("failed to find LONG RAW row, got error: %v", err)
+	}
+	if !bytes.Equal(rawItem.Payload, payload) {
+		t.Errorf("expected the LONG RAW value to be scanned into a []byte, got length %d", len(rawItem.Payload))
+	}
+
+	var rows []map[string]interface{}
+	if err := DB.Raw(`SELECT "id", "notes" FROM "long_items"`).Scan(&rows).Error; err != nil {
+		t.Fatalf("failed to scan LONG rows into maps, got error: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["notes"] != notes {
+		t.Errorf("expected the LONG value to be scanned into a map as string, got %T", rows[0]["notes"])
+	}
+
+	var pointers struct {
+		Notes   *string
+		Payload *[]byte
+	}
+	if err := DB.Raw(`SELECT i."notes", r."payload" FROM "long_items" i, "long_raw_items" r`).Scan(&pointers).Error; err != nil {
+		t.Fatalf("failed to scan LONG values into pointers, got error: %v", err)
+	}
+	if pointers.Notes == nil || *pointers.Notes != notes || pointers.Payload == nil || !bytes.Equal(*pointers.Payload, payload) {
+		t.Errorf("expected the LONG and LONG RAW values to be scanned into pointers")
+	}
+
+	// The read-only LONG field is neither inserted nor returned
+	created := LongItem{Name: "second", Notes: "ignored"}
+	if err := DB.Clauses(clause.Returning{}).Create(&created).Error; err != nil {
+		t.Fatalf("failed to create row of LONG table, got error: %v", err)
+	}
+	if created.ID == 0 || created.Name != "second" {
+		t.Errorf("expected the identity to be returned, got %+v", created)
+	}
+
+	// Migrating a model that maps the LONG column as a plain string keeps it
+	type LegacyItem struct {
+		ID    uint
+		Name  string `gorm:"size:50"`
+		Notes string
+	}
+	if err := DB.Table("long_items").AutoMigrate(&LegacyItem{}); err != nil {
+		t.Fatalf("failed to migrate model with LONG column, got error: %v", err)
+	}
+	columnTypes, err := DB.Migrator().ColumnTypes("long_items")
+	if err != nil {
+		t.Fatalf("failed to read column types, got error: %v", err)
+	}
+	for _, columnType := range columnTypes {
+		if strings.EqualFold(columnType.Name(), "notes") && columnType.DatabaseTypeName() != "LONG" {
+			t.Errorf("expected the LONG column to be kept, got %v", columnType.DatabaseTypeName())
+		}
+	}
+}
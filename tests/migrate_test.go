@@ -39,11 +39,14 @@
 package tests
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"math/rand"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -1410,8 +1413,6 @@ func TestMigrateExistingBoolColumnPG(t *testing.T) {
 }
 
 func TestMigrateWithUniqueIndexAndUnique(t *testing.T) {
-	t.Skip()
-
 	const table = "unique_struct"
 
 	checkField := func(model interface{}, fieldName string, unique bool, uniqueIndex string) {
@@ -2376,3 +2377,1296 @@ func TestMigrateOnUpdateConstraint(t *testing.T) {
 		panic(fmt.Errorf("company id is not equal: expects: %v, got: %v", 0, updatedPen3.OwnerID))
 	}
 }
+
+func TestMigrateGatherTableStats(t *testing.T) {
+	type StatsSample struct {
+		ID   uint
+		Name string `gorm:"index"`
+	}
+
+	DB.Migrator().DropTable(&StatsSample{})
+	if err := DB.AutoMigrate(&StatsSample{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+
+	samples := make([]StatsSample, 50)
+	for i := range samples {
+		samples[i].Name = "sample_" + strconv.Itoa(i)
+	}
+	if err := DB.Create(&samples).Error; err != nil {
+		t.Fatalf("failed to create samples, got error: %v", err)
+	}
+
+	m := DB.Migrator().(oracle.Migrator)
+	if err := m.GatherTableStats(&StatsSample{}, oracle.GatherStatsOptions{EstimatePercent: 100, Degree: 2, Cascade: true}); err != nil {
+		t.Fatalf("failed to gather table statistics, got error: %v", err)
+	}
+
+	var numRows int64
+	if err := DB.Raw("SELECT NUM_ROWS FROM USER_TABLES WHERE TABLE_NAME = ?", "stats_samples").Row().Scan(&numRows); err != nil {
+		t.Fatalf("failed to read table statistics, got error: %v", err)
+	}
+	if numRows != int64(len(samples)) {
+		t.Errorf("expected statistics for %d rows, got %d", len(samples), numRows)
+	}
+
+	var analyzed int64
+	DB.Raw("SELECT COUNT(*) FROM USER_INDEXES WHERE TABLE_NAME = ? AND LAST_ANALYZED IS NULL", "stats_samples").Row().Scan(&analyzed)
+	if analyzed != 0 {
+		t.Errorf("expected the indexes to be analyzed, got %d without statistics", analyzed)
+	}
+
+	if err := m.GatherTableStats(&StatsSample{}, oracle.GatherStatsOptions{EstimatePercent: 150}); err == nil {
+		t.Errorf("expected an error for an invalid estimate percent")
+	}
+}
+
+func TestMigrateRebuildIndex(t *testing.T) {
+	type RebuildSample struct {
+		ID   uint
+		Code string `gorm:"size:32;index:idx_rebuild_samples_code"`
+	}
+
+	DB.Migrator().DropTable(&RebuildSample{}, "rebuild_partitioned")
+	if err := DB.AutoMigrate(&RebuildSample{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	if err := DB.Exec(`CREATE TABLE "rebuild_partitioned" ("id" NUMBER, "code" VARCHAR2(32))
+PARTITION BY RANGE ("id") (PARTITION "p1" VALUES LESS THAN (100), PARTITION "p2" VALUES LESS THAN (MAXVALUE))`).Error; err != nil {
+		t.Fatalf("failed to create partitioned table, got error: %v", err)
+	}
+	if err := DB.Exec(`CREATE INDEX "idx_rebuild_partitioned_code" ON "rebuild_partitioned" ("code") LOCAL`).Error; err != nil {
+		t.Fatalf("failed to create local index, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable("rebuild_partitioned")
+
+	DB.Exec(`ALTER INDEX "idx_rebuild_samples_code" UNUSABLE`)
+	DB.Exec(`ALTER INDEX "idx_rebuild_partitioned_code" MODIFY PARTITION "p2" UNUSABLE`)
+
+	m := DB.Migrator().(oracle.Migrator)
+	indexes, err := m.UnusableIndexes(nil)
+	if err != nil {
+		t.Fatalf("failed to list unusable indexes, got error: %v", err)
+	}
+	expected := []oracle.UnusableIndex{
+		{Table: "rebuild_partitioned", Index: "idx_rebuild_partitioned_code", Partition: "p2"},
+		{Table: "rebuild_samples", Index: "idx_rebuild_samples_code"},
+	}
+	for _, index := range expected {
+		if !slices.Contains(indexes, index) {
+			t.Errorf("expected %+v to be listed as unusable, got %+v", index, indexes)
+		}
+	}
+
+	indexes, err = m.UnusableIndexes(&RebuildSample{})
+	if err != nil {
+		t.Fatalf("failed to list unusable indexes, got error: %v", err)
+	}
+	tests.AssertEqual(t, indexes, expected[1:])
+
+	if err := m.RebuildIndex("idx_rebuild_samples_code", false); err != nil {
+		t.Fatalf("failed to rebuild index, got error: %v", err)
+	}
+	if err := m.RebuildIndex("idx_rebuild_partitioned_code", true); err != nil {
+		t.Fatalf("failed to rebuild partitioned index online, got error: %v", err)
+	}
+
+	indexes, err = m.UnusableIndexes("rebuild_partitioned")
+	if err != nil {
+		t.Fatalf("failed to list unusable indexes, got error: %v", err)
+	}
+	if len(indexes) != 0 {
+		t.Errorf("expected no unusable indexes after the rebuild, got %+v", indexes)
+	}
+	indexes, _ = m.UnusableIndexes(&RebuildSample{})
+	if len(indexes) != 0 {
+		t.Errorf("expected no unusable indexes after the rebuild, got %+v", indexes)
+	}
+}
+
+func TestMigrateIndexReport(t *testing.T) {
+	type ReportSample struct {
+		ID    uint
+		Name  string `gorm:"size:64;index:idx_report_samples_name"`
+		Email string `gorm:"size:64;index:idx_report_samples_email,expression:LOWER(email)"`
+		Code  string `gorm:"size:32;index:idx_report_samples_code"`
+		Age   int    `gorm:"check:chk_report_samples_age,age >= 0"`
+	}
+
+	DB.Migrator().DropTable(&ReportSample{})
+	if err := DB.AutoMigrate(&ReportSample{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&ReportSample{})
+	DB.Exec(`DROP INDEX "idx_report_samples_code"`)
+	DB.Exec(`ALTER INDEX "idx_report_samples_name" UNUSABLE`)
+	DB.Exec(`ALTER INDEX "idx_report_samples_email" INVISIBLE`)
+
+	recorder := oracle.NewWorkloadRecorder(DB.Logger, 0)
+	session := DB.Session(&gorm.Session{Logger: recorder})
+	var samples []ReportSample
+	session.Where("name", "report").Find(&samples)
+	session.Where(`UPPER("name") = ?`, "REPORT").Find(&samples)
+	session.Where(`LOWER("email") = ?`, "report@example.com").Find(&samples)
+	session.Where("email", "report@example.com").Find(&samples)
+	if len(recorder.Statements()) != 4 {
+		t.Fatalf("expected 4 recorded statements, got %v", recorder.Statements())
+	}
+
+	report, err := DB.Migrator().(oracle.Migrator).IndexReport(&ReportSample{}, recorder.Statements()...)
+	if err != nil {
+		t.Fatalf("failed to report indexes, got error: %v", err)
+	}
+
+	statuses := map[string]oracle.ObjectStatus{}
+	for _, object := range report.Objects {
+		if object.Declared {
+			statuses[object.Kind+" "+object.Name] = object.Status
+		}
+	}
+	expected := map[string]oracle.ObjectStatus{
+		"INDEX idx_report_samples_name":  oracle.StatusUnusable,
+		"INDEX idx_report_samples_email": oracle.StatusInvisible,
+		"INDEX idx_report_samples_code":  oracle.StatusMissing,
+		"PRIMARY KEY ":                   oracle.StatusValid,
+		"CHECK chk_report_samples_age":   oracle.StatusValid,
+	}
+	tests.AssertEqual(t, statuses, expected)
+
+	issues := map[string]string{}
+	for _, issue := range report.Queries {
+		issues[issue.SQL] = issue.Index
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 statements unable to use an index, got %+v", report.Queries)
+	}
+	for sql, index := range issues {
+		switch {
+		case strings.Contains(sql, `UPPER("name")`):
+			tests.AssertEqual(t, index, "idx_report_samples_name")
+		case strings.Contains(sql, `WHERE "email" =`):
+			tests.AssertEqual(t, index, "idx_report_samples_email")
+		default:
+			t.Errorf("unexpected issue with %s on %s", sql, index)
+		}
+	}
+}
+
+type CheckDriftV1 struct {
+	ID  uint
+	Age int `gorm:"check:chk_check_drifts_age,age >= 13"`
+}
+
+func (CheckDriftV1) TableName() string { return "check_drifts" }
+
+type CheckDriftV2 struct {
+	ID  uint
+	Age int `gorm:"check:chk_check_drifts_age,age >= 18"`
+}
+
+func (CheckDriftV2) TableName() string { return "check_drifts" }
+
+func TestMigrateCheckConstraintDrift(t *testing.T) {
+	DB.Migrator().DropTable("check_drifts")
+	if err := DB.AutoMigrate(&CheckDriftV1{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable("check_drifts")
+
+	condition := func() string {
+		var condition string
+		if err := DB.Raw(`SELECT SEARCH_CONDITION_VC FROM USER_CONSTRAINTS WHERE TABLE_NAME = 'check_drifts' AND CONSTRAINT_NAME = 'chk_check_drifts_age'`).
+			Row().Scan(&condition); err != nil {
+			t.Fatalf("failed to read the check constraint, got error: %v", err)
+		}
+		return condition
+	}
+
+	// An unchanged predicate is left as it is
+	if err := DB.AutoMigrate(&CheckDriftV1{}); err != nil {
+		t.Fatalf("failed to migrate again, got error: %v", err)
+	}
+	if got := condition(); got != `"age" >= 13` {
+		t.Fatalf("expected the declared condition, got %q", got)
+	}
+	if err := DB.Create(&CheckDriftV1{Age: 20}).Error; err != nil {
+		t.Fatalf("expected age 20 to be accepted, got error: %v", err)
+	}
+
+	if err := DB.AutoMigrate(&CheckDriftV2{}); err != nil {
+		t.Fatalf("failed to migrate the changed check, got error: %v", err)
+	}
+	if got := condition(); got != `"age" >= 18` {
+		t.Errorf("expected the check to be re-created with the new condition, got %q", got)
+	}
+	if err := DB.Create(&CheckDriftV2{Age: 16}).Error; !errors.Is(err, gorm.ErrCheckConstraintViolated) {
+		t.Errorf("expected the new check to reject age 16, got %v", err)
+	}
+}
+
+func TestMigrateJSONCheckConstraints(t *testing.T) {
+	type JSONCheck struct {
+		ID       uint
+		Document string `gorm:"size:4000;check:json"`
+		Settings string `gorm:"size:4000;check:chk_json_checks_settings,json strict"`
+	}
+
+	DB.Migrator().DropTable(&JSONCheck{})
+	if err := DB.AutoMigrate(&JSONCheck{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&JSONCheck{})
+
+	var condition string
+	if err := DB.Raw(`SELECT SEARCH_CONDITION_VC FROM USER_CONSTRAINTS WHERE TABLE_NAME = 'json_checks' AND CONSTRAINT_NAME = 'chk_json_checks_settings'`).
+		Row().Scan(&condition); err != nil {
+		t.Fatalf("failed to read the check constraint, got error: %v", err)
+	}
+	if condition != `"settings" IS JSON (STRICT)` {
+		t.Errorf("expected an IS JSON (STRICT) condition, got %q", condition)
+	}
+
+	// The migration is idempotent
+	if err := DB.AutoMigrate(&JSONCheck{}); err != nil {
+		t.Fatalf("failed to migrate again, got error: %v", err)
+	}
+
+	if err := DB.Create(&JSONCheck{Document: `{"a": 1}`, Settings: `{"a": 1}`}).Error; err != nil {
+		t.Fatalf("expected valid JSON to be accepted, got error: %v", err)
+	}
+	if err := DB.Create(&JSONCheck{Document: `{"a": `, Settings: `{"a": 1}`}).Error; !errors.Is(err, gorm.ErrCheckConstraintViolated) {
+		t.Errorf("expected malformed JSON to be rejected, got %v", err)
+	}
+	// Unquoted keys are lax JSON, rejected by the strict check only
+	if err := DB.Create(&JSONCheck{Document: `{a: 1}`, Settings: `{"a": 1}`}).Error; err != nil {
+		t.Errorf("expected lax JSON to be accepted, got error: %v", err)
+	}
+	if err := DB.Create(&JSONCheck{Document: `{"a": 1}`, Settings: `{a: 1}`}).Error; !errors.Is(err, gorm.ErrCheckConstraintViolated) {
+		t.Errorf("expected lax JSON to be rejected by the strict check, got %v", err)
+	}
+}
+
+func TestMigrateJSONSchemaCheckConstraint(t *testing.T) {
+	var version int
+	if err := DB.Raw(`SELECT TO_NUMBER(REGEXP_SUBSTR(VERSION, '^[0-9]+')) FROM PRODUCT_COMPONENT_VERSION WHERE PRODUCT LIKE 'Oracle%' AND ROWNUM = 1`).
+		Row().Scan(&version); err != nil {
+		t.Fatalf("failed to read the database version, got error: %v", err)
+	}
+	if version < 23 {
+		t.Skip("JSON schema validation requires Oracle Database 23ai")
+	}
+
+	type JSONSchemaCheck struct {
+		ID      uint
+		Profile string `gorm:"size:4000;check:json validate '{\"type\": \"object\", \"required\": [\"name\"]}'"`
+	}
+
+	DB.Migrator().DropTable(&JSONSchemaCheck{})
+	if err := DB.AutoMigrate(&JSONSchemaCheck{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&JSONSchemaCheck{})
+
+	if err := DB.AutoMigrate(&JSONSchemaCheck{}); err != nil {
+		t.Fatalf("failed to migrate again, got error: %v", err)
+	}
+	if err := DB.Create(&JSONSchemaCheck{Profile: `{"name": "jinzhu"}`}).Error; err != nil {
+		t.Fatalf("expected a document matching the schema to be accepted, got error: %v", err)
+	}
+	if err := DB.Create(&JSONSchemaCheck{Profile: `{"age": 18}`}).Error; err == nil {
+		t.Errorf("expected a document not matching the schema to be rejected")
+	}
+}
+
+func TestMigrateStringSizes(t *testing.T) {
+	type StringSize struct {
+		ID    uint
+		Code  string
+		Notes string `gorm:"size:10000"`
+	}
+
+	extended := DB.Exec(`SELECT CAST(NULL AS VARCHAR2(32767)) FROM DUAL`).Error == nil
+
+	columnTypes := func(db *gorm.DB) map[string]gorm.ColumnType {
+		db.Migrator().DropTable(&StringSize{})
+		if err := db.AutoMigrate(&StringSize{}); err != nil {
+			t.Fatalf("failed to migrate, got error: %v", err)
+		}
+		columns, err := db.Migrator().ColumnTypes(&StringSize{})
+		if err != nil {
+			t.Fatalf("failed to read the column types, got error: %v", err)
+		}
+		types := map[string]gorm.ColumnType{}
+		for _, column := range columns {
+			types[column.Name()] = column
+		}
+		return types
+	}
+	defer DB.Migrator().DropTable(&StringSize{})
+
+	db, err := openTestDBWithOptions(&oracle.Config{DefaultStringSize: 255}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	types := columnTypes(db)
+	if length, _ := types["code"].Length(); types["code"].DatabaseTypeName() != "VARCHAR2" || length != 255 {
+		t.Errorf("expected VARCHAR2(255) for a string without size, got %s(%d)", types["code"].DatabaseTypeName(), length)
+	}
+	if extended {
+		if length, _ := types["notes"].Length(); types["notes"].DatabaseTypeName() != "VARCHAR2" || length != 10000 {
+			t.Errorf("expected VARCHAR2(10000) with MAX_STRING_SIZE EXTENDED, got %s(%d)", types["notes"].DatabaseTypeName(), length)
+		}
+	} else if types["notes"].DatabaseTypeName() != "CLOB" {
+		t.Errorf("expected CLOB with MAX_STRING_SIZE STANDARD, got %s", types["notes"].DatabaseTypeName())
+	}
+
+	db, err = openTestDBWithOptions(&oracle.Config{MaxStringSize: oracle.StandardMaxStringSize}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	types = columnTypes(db)
+	if types["notes"].DatabaseTypeName() != "CLOB" {
+		t.Errorf("expected CLOB above MaxStringSize, got %s", types["notes"].DatabaseTypeName())
+	}
+	if length, _ := types["code"].Length(); length != 4000 {
+		t.Errorf("expected VARCHAR2(4000) for a string without size by default, got %d", length)
+	}
+
+	// A CLOB column is kept when the field now fits a VARCHAR2
+	if extended {
+		if err := DB.AutoMigrate(&StringSize{}); err != nil {
+			t.Fatalf("failed to migrate the CLOB column, got error: %v", err)
+		}
+	}
+}
+
+type RawColumnV1 struct {
+	ID       uint
+	Checksum []byte `gorm:"size:32"`
+	Token    []byte `gorm:"type:raw(100)"`
+	Payload  []byte
+}
+
+func (RawColumnV1) TableName() string { return "raw_columns" }
+
+type RawColumnV2 struct {
+	ID       uint
+	Checksum []byte `gorm:"size:64"`
+	Token    []byte `gorm:"type:raw(100)"`
+	Payload  []byte
+}
+
+func (RawColumnV2) TableName() string { return "raw_columns" }
+
+func TestMigrateRawColumns(t *testing.T) {
+	DB.Migrator().DropTable("raw_columns")
+	if err := DB.AutoMigrate(&RawColumnV1{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable("raw_columns")
+
+	columnType := func(name string) (string, int64) {
+		columns, err := DB.Migrator().ColumnTypes("raw_columns")
+		if err != nil {
+			t.Fatalf("failed to read the column types, got error: %v", err)
+		}
+		for _, column := range columns {
+			if column.Name() == name {
+				length, _ := column.Length()
+				return column.DatabaseTypeName(), length
+			}
+		}
+		t.Fatalf("column %s not found", name)
+		return "", 0
+	}
+
+	if typeName, length := columnType("checksum"); typeName != "RAW" || length != 32 {
+		t.Errorf("expected RAW(32) for a byte slice of size 32, got %s(%d)", typeName, length)
+	}
+	if typeName, length := columnType("token"); typeName != "RAW" || length != 100 {
+		t.Errorf("expected RAW(100) for type:raw(100), got %s(%d)", typeName, length)
+	}
+	if typeName, _ := columnType("payload"); typeName != "BLOB" {
+		t.Errorf("expected BLOB for a byte slice without size, got %s", typeName)
+	}
+
+	record := RawColumnV1{Checksum: bytes.Repeat([]byte{0xab}, 32), Token: []byte{0, 1, 2}, Payload: []byte("payload")}
+	if err := DB.Create(&record).Error; err != nil {
+		t.Fatalf("failed to create, got error: %v", err)
+	}
+	var got RawColumnV1
+	if err := DB.First(&got, record.ID).Error; err != nil {
+		t.Fatalf("failed to query, got error: %v", err)
+	}
+	tests.AssertEqual(t, got.Checksum, record.Checksum)
+	tests.AssertEqual(t, got.Token, record.Token)
+	var found int64
+	if err := DB.Model(&RawColumnV1{}).Where(`"checksum" = ?`, record.Checksum).Count(&found).Error; err != nil || found != 1 {
+		t.Errorf("expected to find the record by its RAW checksum, got %d, %v", found, err)
+	}
+
+	// Unchanged sizes are left as they are, changed ones are altered
+	if err := DB.AutoMigrate(&RawColumnV1{}); err != nil {
+		t.Fatalf("failed to migrate again, got error: %v", err)
+	}
+	if err := DB.AutoMigrate(&RawColumnV2{}); err != nil {
+		t.Fatalf("failed to migrate the new size, got error: %v", err)
+	}
+	if typeName, length := columnType("checksum"); typeName != "RAW" || length != 64 {
+		t.Errorf("expected the column to be altered to RAW(64), got %s(%d)", typeName, length)
+	}
+	if typeName, length := columnType("token"); typeName != "RAW" || length != 100 {
+		t.Errorf("expected RAW(100) to be kept, got %s(%d)", typeName, length)
+	}
+	if err := DB.First(&got, record.ID).Error; err != nil {
+		t.Fatalf("failed to query, got error: %v", err)
+	}
+	tests.AssertEqual(t, got.Checksum, record.Checksum)
+}
+
+func TestMigrateUniqueIndexes(t *testing.T) {
+	type UniqueStrategy struct {
+		ID    uint
+		Email string `gorm:"size:100;unique"`
+	}
+
+	db, err := openTestDBWithOptions(&oracle.Config{UniqueIndexes: true}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	DB.Migrator().DropTable(&UniqueStrategy{})
+	if err := db.AutoMigrate(&UniqueStrategy{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&UniqueStrategy{})
+
+	strategy := func() (constraints, indexes int64) {
+		if err := DB.Raw(`SELECT COUNT(*) FROM USER_CONSTRAINTS WHERE TABLE_NAME = 'unique_strategies' AND CONSTRAINT_NAME = 'uni_unique_strategies_email' AND CONSTRAINT_TYPE = 'U'`).
+			Row().Scan(&constraints); err != nil {
+			t.Fatalf("failed to count the constraints, got error: %v", err)
+		}
+		if err := DB.Raw(`SELECT COUNT(*) FROM USER_INDEXES WHERE TABLE_NAME = 'unique_strategies' AND INDEX_NAME = 'uni_unique_strategies_email' AND UNIQUENESS = 'UNIQUE'`).
+			Row().Scan(&indexes); err != nil {
+			t.Fatalf("failed to count the indexes, got error: %v", err)
+		}
+		return
+	}
+
+	if constraints, indexes := strategy(); constraints != 0 || indexes != 1 {
+		t.Fatalf("expected a unique index and no constraint, got %d constraints and %d indexes", constraints, indexes)
+	}
+	if err := db.Create(&UniqueStrategy{Email: "a@example.com"}).Error; err != nil {
+		t.Fatalf("failed to create, got error: %v", err)
+	}
+	if err := db.Create(&UniqueStrategy{Email: "a@example.com"}).Error; err == nil {
+		t.Errorf("expected the unique index to reject a duplicate")
+	}
+
+	// Migrating without the option converts the index to a constraint
+	if err := DB.AutoMigrate(&UniqueStrategy{}); err != nil {
+		t.Fatalf("failed to migrate to a constraint, got error: %v", err)
+	}
+	if constraints, indexes := strategy(); constraints != 1 || indexes != 1 {
+		t.Errorf("expected a constraint with its index, got %d constraints and %d indexes", constraints, indexes)
+	}
+	if columnTypes, err := DB.Migrator().ColumnTypes(&UniqueStrategy{}); err != nil {
+		t.Fatalf("failed to get the column types, got error: %v", err)
+	} else {
+		for _, columnType := range columnTypes {
+			if unique, _ := columnType.Unique(); columnType.Name() == "email" && !unique {
+				t.Errorf("expected the email column to be unique")
+			}
+		}
+	}
+
+	// And back to an index
+	if err := db.AutoMigrate(&UniqueStrategy{}); err != nil {
+		t.Fatalf("failed to migrate to an index, got error: %v", err)
+	}
+	if constraints, indexes := strategy(); constraints != 0 || indexes != 1 {
+		t.Errorf("expected a unique index and no constraint, got %d constraints and %d indexes", constraints, indexes)
+	}
+
+	report, err := db.Migrator().(oracle.Migrator).IndexReport(&UniqueStrategy{})
+	if err != nil {
+		t.Fatalf("failed to report, got error: %v", err)
+	}
+	for _, object := range report.Objects {
+		if object.Status != oracle.StatusValid {
+			t.Errorf("expected every object to be valid, got %+v", object)
+		}
+	}
+}
+
+func TestMigrateDropTableOrder(t *testing.T) {
+	db, err := openTestDBWithOptions(&oracle.Config{DropTableOrder: true}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	tables := []interface{}{"drop_order_outside", "drop_order_child", "drop_order_parent", "drop_order_cycle_a", "drop_order_cycle_b"}
+	DB.Migrator().DropTable(tables...)
+	defer DB.Migrator().DropTable(tables...)
+	for _, ddl := range []string{
+		`CREATE TABLE "drop_order_parent" ("id" NUMBER PRIMARY KEY)`,
+		`CREATE TABLE "drop_order_child" ("id" NUMBER PRIMARY KEY, "parent_id" NUMBER REFERENCES "drop_order_parent")`,
+		`CREATE TABLE "drop_order_outside" ("id" NUMBER PRIMARY KEY, "parent_id" NUMBER CONSTRAINT "fk_drop_order_outside" REFERENCES "drop_order_parent")`,
+		`CREATE TABLE "drop_order_cycle_a" ("id" NUMBER PRIMARY KEY, "b_id" NUMBER)`,
+		`CREATE TABLE "drop_order_cycle_b" ("id" NUMBER PRIMARY KEY, "a_id" NUMBER REFERENCES "drop_order_cycle_a")`,
+		`ALTER TABLE "drop_order_cycle_a" ADD FOREIGN KEY ("b_id") REFERENCES "drop_order_cycle_b"`,
+	} {
+		if err := DB.Exec(ddl).Error; err != nil {
+			t.Fatalf("failed to create the tables, got error: %v", err)
+		}
+	}
+
+	// Parents listed first, and tables referencing each other
+	if err := db.Migrator().DropTable("drop_order_parent", "drop_order_cycle_a", "drop_order_child", "drop_order_cycle_b"); err == nil {
+		t.Errorf("expected the parent referenced by another table to be kept")
+	} else if !strings.Contains(err.Error(), "ORA-02449") {
+		t.Errorf("expected ORA-02449 for the parent, got %v", err)
+	}
+	for _, table := range []string{"drop_order_child", "drop_order_cycle_a", "drop_order_cycle_b"} {
+		if DB.Migrator().HasTable(table) {
+			t.Errorf("expected %s to be dropped", table)
+		}
+	}
+	if !DB.Migrator().HasTable("drop_order_parent") || !DB.Migrator().HasConstraint("drop_order_outside", "fk_drop_order_outside") {
+		t.Errorf("expected the parent and the foreign key of the other table to be kept")
+	}
+
+	if err := db.Migrator().DropTable("drop_order_parent", "drop_order_outside"); err != nil {
+		t.Errorf("failed to drop the parent with the table referencing it, got error: %v", err)
+	}
+	if DB.Migrator().HasTable("drop_order_parent") || DB.Migrator().HasTable("drop_order_outside") {
+		t.Errorf("expected the tables to be dropped")
+	}
+}
+
+func TestMigrateCaseInsensitiveNames(t *testing.T) {
+	db, err := openTestDBWithOptions(&oracle.Config{CaseInsensitiveNames: true}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	DB.Exec(`DROP TABLE CI_LEGACY_ACCOUNTS`)
+	if err := DB.Exec(`CREATE TABLE CI_LEGACY_ACCOUNTS (ID NUMBER PRIMARY KEY, EMAIL VARCHAR2(100))`).Error; err != nil {
+		t.Fatalf("failed to create the table, got error: %v", err)
+	}
+	defer DB.Exec(`DROP TABLE CI_LEGACY_ACCOUNTS`)
+
+	if DB.Migrator().HasTable("ci_legacy_accounts") || DB.Migrator().HasColumn("CI_LEGACY_ACCOUNTS", "email") {
+		t.Errorf("expected the names to be compared exactly without the option")
+	}
+	if !db.Migrator().HasTable("ci_legacy_accounts") {
+		t.Errorf("expected the unquoted table to be found")
+	}
+	if !db.Migrator().HasColumn("ci_legacy_accounts", "email") {
+		t.Errorf("expected the unquoted column to be found")
+	}
+	if db.Migrator().HasColumn("ci_legacy_accounts", "name") {
+		t.Errorf("expected a missing column not to be found")
+	}
+}
+
+func TestMigrateColumnTypesMetadata(t *testing.T) {
+	DB.Exec(`DROP TABLE "column_metadata" CASCADE CONSTRAINTS`)
+	if err := DB.Exec(`CREATE TABLE "column_metadata" (
+		"id" NUMBER(20) GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+		"name" VARCHAR2(50 CHAR),
+		"code" VARCHAR2(10 BYTE) DEFAULT 'new' NOT NULL,
+		"upper_name" VARCHAR2(50 CHAR) GENERATED ALWAYS AS (UPPER("name")) VIRTUAL
+	)`).Error; err != nil {
+		t.Fatalf("failed to create table, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable("column_metadata")
+
+	if err := DB.Exec(`COMMENT ON COLUMN "column_metadata"."name" IS 'display name'`).Error; err != nil {
+		t.Fatalf("failed to comment column, got error: %v", err)
+	}
+
+	columnTypes, err := DB.Migrator().ColumnTypes("column_metadata")
+	if err != nil {
+		t.Fatalf("failed to get column types, got error: %v", err)
+	}
+
+	columns := map[string]oracle.ColumnType{}
+	for _, columnType := range columnTypes {
+		ct, ok := columnType.(oracle.ColumnType)
+		if !ok {
+			t.Fatalf("expected oracle.ColumnType, got %T", columnType)
+		}
+		columns[ct.Name()] = ct
+	}
+
+	if id := columns["id"]; !id.Identity() || id.Virtual() {
+		t.Errorf("expected id to be an identity column, got identity=%v virtual=%v", id.Identity(), id.Virtual())
+	}
+	if autoIncrement, ok := columns["id"].AutoIncrement(); !ok || !autoIncrement {
+		t.Errorf("expected id to report auto increment")
+	}
+
+	if semantics, ok := columns["name"].CharSemantics(); !ok || semantics != "CHAR" {
+		t.Errorf("expected CHAR semantics for name, got %q", semantics)
+	}
+	if comment, ok := columns["name"].ColumnComment(); !ok || comment != "display name" {
+		t.Errorf("expected comment for name, got %q", comment)
+	}
+	if _, ok := columns["name"].DefaultExpression(); ok {
+		t.Errorf("expected no default for name")
+	}
+
+	if semantics, ok := columns["code"].CharSemantics(); !ok || semantics != "BYTE" {
+		t.Errorf("expected BYTE semantics for code, got %q", semantics)
+	}
+	if expr, ok := columns["code"].DefaultExpression(); !ok || expr != "'new'" {
+		t.Errorf("expected default expression 'new' for code, got %q", expr)
+	}
+	if _, ok := columns["code"].ColumnComment(); ok {
+		t.Errorf("expected no comment for code")
+	}
+
+	upper := columns["upper_name"]
+	if !upper.Virtual() || upper.Identity() {
+		t.Errorf("expected upper_name to be a virtual column, got identity=%v virtual=%v", upper.Identity(), upper.Virtual())
+	}
+	if expr, ok := upper.DefaultExpression(); !ok || !strings.Contains(expr, "UPPER") {
+		t.Errorf("expected the virtual expression for upper_name, got %q", expr)
+	}
+	if _, ok := columns["id"].CharSemantics(); ok {
+		t.Errorf("expected no char semantics for a numeric column")
+	}
+}
+
+func TestMigrateAddNotNullColumnWithDefault(t *testing.T) {
+	type BackfillItem struct {
+		ID   uint
+		Name string
+	}
+	type BackfillItemWithStatus struct {
+		ID     uint
+		Name   string
+		Status string `gorm:"size:20;not null;default:'active'"`
+		Rank   int    `gorm:"not null;default:5"`
+	}
+
+	DB.Migrator().DropTable("backfill_items")
+	if err := DB.Table("backfill_items").AutoMigrate(&BackfillItem{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable("backfill_items")
+
+	items := []BackfillItem{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	if err := DB.Table("backfill_items").Create(&items).Error; err != nil {
+		t.Fatalf("failed to create items, got error: %v", err)
+	}
+
+	if err := DB.Table("backfill_items").AutoMigrate(&BackfillItemWithStatus{}); err != nil {
+		t.Fatalf("failed to add NOT NULL columns to a populated table, got error: %v", err)
+	}
+
+	var result []BackfillItemWithStatus
+	if err := DB.Table("backfill_items").Order("\"id\"").Find(&result).Error; err != nil {
+		t.Fatalf("failed to query items, got error: %v", err)
+	}
+	if len(result) != len(items) {
+		t.Fatalf("expected %d items, got %d", len(items), len(result))
+	}
+	for _, item := range result {
+		if item.Status != "active" || item.Rank != 5 {
+			t.Errorf("expected the defaults on existing rows, got %+v", item)
+		}
+	}
+
+	columnTypes, err := DB.Migrator().ColumnTypes("backfill_items")
+	if err != nil {
+		t.Fatalf("failed to get column types, got error: %v", err)
+	}
+	for _, columnType := range columnTypes {
+		if columnType.Name() == "status" || columnType.Name() == "rank" {
+			if nullable, ok := columnType.Nullable(); !ok || nullable {
+				t.Errorf("expected %s to be NOT NULL", columnType.Name())
+			}
+		}
+	}
+}
+
+func TestMigrateDropColumnWithOptions(t *testing.T) {
+	type DropColumnSample struct {
+		ID       uint
+		Code     string `gorm:"size:20"`
+		Region   string `gorm:"size:20"`
+		Obsolete string `gorm:"size:20"`
+	}
+
+	DB.Migrator().DropTable(&DropColumnSample{})
+	if err := DB.AutoMigrate(&DropColumnSample{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&DropColumnSample{})
+
+	if err := DB.Exec(`ALTER TABLE "drop_column_samples" ADD CONSTRAINT "uni_dcs_code_region" UNIQUE ("code", "region")`).Error; err != nil {
+		t.Fatalf("failed to add constraint, got error: %v", err)
+	}
+
+	m := DB.Migrator().(oracle.Migrator)
+	if err := m.DropColumnWithOptions(&DropColumnSample{}, "Obsolete", oracle.DropColumnOptions{SetUnused: true}); err != nil {
+		t.Fatalf("failed to set column unused, got error: %v", err)
+	}
+	if m.HasColumn(&DropColumnSample{}, "Obsolete") {
+		t.Errorf("expected the unused column to be hidden")
+	}
+
+	var unused int64
+	DB.Raw(`SELECT COUNT(*) FROM USER_UNUSED_COL_TABS WHERE TABLE_NAME = 'drop_column_samples'`).Scan(&unused)
+	if unused != 1 {
+		t.Errorf("expected the table to have unused columns")
+	}
+	if err := m.DropUnusedColumns(&DropColumnSample{}, 1000); err != nil {
+		t.Fatalf("failed to drop unused columns, got error: %v", err)
+	}
+	DB.Raw(`SELECT COUNT(*) FROM USER_UNUSED_COL_TABS WHERE TABLE_NAME = 'drop_column_samples'`).Scan(&unused)
+	if unused != 0 {
+		t.Errorf("expected no unused columns after DropUnusedColumns")
+	}
+
+	if err := m.DropColumn(&DropColumnSample{}, "Region"); err == nil {
+		t.Errorf("expected an error dropping a column of a multi-column constraint")
+	}
+	if err := m.DropColumnWithOptions(&DropColumnSample{}, "Region", oracle.DropColumnOptions{CascadeConstraints: true}); err != nil {
+		t.Fatalf("failed to drop column with cascade constraints, got error: %v", err)
+	}
+	if m.HasColumn(&DropColumnSample{}, "Region") {
+		t.Errorf("expected the column to be dropped")
+	}
+	if m.HasConstraint(&DropColumnSample{}, "uni_dcs_code_region") {
+		t.Errorf("expected the dependent constraint to be dropped")
+	}
+}
+
+func TestMigrateOnlineDDL(t *testing.T) {
+	type OnlineDDLItem struct {
+		ID   uint
+		Code string `gorm:"size:20;index:idx_online_ddl_items_code"`
+	}
+
+	var statements []string
+	db, err := openTestDBWithOptions(&oracle.Config{OnlineDDL: true, DDLLockTimeout: 5 * time.Second}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	db.Logger = Tracer{
+		Logger: db.Logger,
+		Test: func(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+			sql, _ := fc()
+			statements = append(statements, sql)
+		},
+	}
+
+	db.Migrator().DropTable(&OnlineDDLItem{})
+	if err := db.AutoMigrate(&OnlineDDLItem{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer db.Migrator().DropTable(&OnlineDDLItem{})
+
+	expected := []string{
+		"ALTER SESSION SET DDL_LOCK_TIMEOUT = 5",
+		`CREATE INDEX "idx_online_ddl_items_code" ON "online_ddl_items"("code") ONLINE`,
+		"ALTER SESSION SET DDL_LOCK_TIMEOUT = 0",
+	}
+	for _, sql := range expected {
+		if !slices.Contains(statements, sql) {
+			t.Errorf("expected %q to be executed, got %v", sql, statements)
+		}
+	}
+
+	if err := db.Migrator().DropIndex(&OnlineDDLItem{}, "idx_online_ddl_items_code"); err != nil {
+		t.Fatalf("failed to drop index online, got error: %v", err)
+	}
+	if !slices.Contains(statements, `DROP INDEX "idx_online_ddl_items_code" ONLINE`) {
+		t.Errorf("expected the index to be dropped online, got %v", statements)
+	}
+}
+
+func TestMigrateWithMigrationLock(t *testing.T) {
+	type MigrationLockItem struct {
+		ID    uint
+		Code  string `gorm:"size:20;index"`
+		Label string `gorm:"size:50;not null;default:'none'"`
+	}
+
+	DB.Migrator().DropTable(&MigrationLockItem{})
+	defer DB.Migrator().DropTable(&MigrationLockItem{})
+
+	lock := &oracle.MigrationLock{Name: "gorm_oracle_test_migration", Timeout: time.Minute}
+	db, err := openTestDBWithOptions(&oracle.Config{MigrationLock: lock}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	if err := db.AutoMigrate(&MigrationLockItem{}); err != nil {
+		if strings.Contains(err.Error(), "PLS-00201") {
+			t.Skip("EXECUTE on DBMS_LOCK is not granted to the test user")
+		}
+		t.Fatalf("failed to migrate with the migration lock, got error: %v", err)
+	}
+	DB.Migrator().DropTable(&MigrationLockItem{})
+
+	// Instances starting together must not race on the same DDL
+	errs := make(chan error, 4)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			db, err := openTestDBWithOptions(&oracle.Config{MigrationLock: lock}, &gorm.Config{})
+			if err == nil {
+				err = db.AutoMigrate(&MigrationLockItem{})
+			}
+			errs <- err
+		}()
+	}
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("concurrent migration failed, got error: %v", err)
+		}
+	}
+
+	if !DB.Migrator().HasTable(&MigrationLockItem{}) || !DB.Migrator().HasIndex(&MigrationLockItem{}, "Code") {
+		t.Errorf("expected the table and its index to be migrated")
+	}
+}
+
+func TestMigrateRenameConstraint(t *testing.T) {
+	type RenameConstraintItem struct {
+		ID   uint
+		Code string `gorm:"size:20;unique"`
+	}
+
+	DB.Migrator().DropTable(&RenameConstraintItem{})
+	if err := DB.AutoMigrate(&RenameConstraintItem{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&RenameConstraintItem{})
+
+	m := DB.Migrator().(oracle.Migrator)
+	oldName := DB.NamingStrategy.UniqueName("rename_constraint_items", "code")
+	if !m.HasConstraint(&RenameConstraintItem{}, oldName) || !m.HasIndex(&RenameConstraintItem{}, oldName) {
+		t.Fatalf("expected constraint %s and its index", oldName)
+	}
+
+	if err := m.RenameConstraint(&RenameConstraintItem{}, oldName, "uq_rci_code"); err != nil {
+		t.Fatalf("failed to rename constraint, got error: %v", err)
+	}
+	if m.HasConstraint(&RenameConstraintItem{}, oldName) || !m.HasConstraint(&RenameConstraintItem{}, "uq_rci_code") {
+		t.Errorf("expected the constraint to be renamed")
+	}
+	if m.HasIndex(&RenameConstraintItem{}, oldName) || !m.HasIndex(&RenameConstraintItem{}, "uq_rci_code") {
+		t.Errorf("expected the index of the constraint to be renamed with it")
+	}
+
+	if err := m.RenameIndex(&RenameConstraintItem{}, "uq_rci_code", oldName); err != nil {
+		t.Fatalf("failed to rename index, got error: %v", err)
+	}
+	if !m.HasIndex(&RenameConstraintItem{}, oldName) || !m.HasConstraint(&RenameConstraintItem{}, oldName) {
+		t.Errorf("expected the constraint to be renamed with its index")
+	}
+
+	if err := DB.Create(&RenameConstraintItem{Code: "a"}).Error; err != nil {
+		t.Fatalf("failed to create, got error: %v", err)
+	}
+	if err := DB.Create(&RenameConstraintItem{Code: "a"}).Error; err == nil {
+		t.Errorf("expected the renamed constraint to be enforced")
+	}
+}
+
+func TestMigrateAlterType(t *testing.T) {
+	const (
+		objectTypeName = "contact_t"
+		varrayTypeName = "tag_list_t"
+		tableName      = "contacts_with_types"
+	)
+
+	m := DB.Migrator().(oracle.Migrator)
+	DB.Migrator().DropTable(tableName)
+	m.DropType(objectTypeName)
+	m.DropType(varrayTypeName)
+	defer func() {
+		DB.Migrator().DropTable(tableName)
+		m.DropType(objectTypeName)
+		m.DropType(varrayTypeName)
+	}()
+
+	if err := m.CreateType(objectTypeName, "OBJECT", "name VARCHAR2(50), age NUMBER(3)"); err != nil {
+		t.Fatalf("failed to create object type, got error: %v", err)
+	}
+	if err := m.CreateType(varrayTypeName, "VARRAY(5)", "VARCHAR2(20)"); err != nil {
+		t.Fatalf("failed to create varray type, got error: %v", err)
+	}
+	if err := DB.Exec(fmt.Sprintf(`CREATE TABLE "%s" ("id" NUMBER PRIMARY KEY, "contact" "%s", "tags" "%s")`,
+		tableName, objectTypeName, varrayTypeName)).Error; err != nil {
+		t.Fatalf("failed to create table, got error: %v", err)
+	}
+
+	findType := func(name string) oracle.UserType {
+		types, err := m.GetTypes()
+		if err != nil {
+			t.Fatalf("failed to get types, got error: %v", err)
+		}
+		for _, userType := range types {
+			if userType.Name == name {
+				return userType
+			}
+		}
+		t.Fatalf("expected type %s to be listed", name)
+		return oracle.UserType{}
+	}
+
+	contact := findType(objectTypeName)
+	tests.AssertEqual(t, contact.Kind, "OBJECT")
+	tests.AssertEqual(t, contact.Attributes, []oracle.TypeAttribute{
+		{Name: "NAME", Type: "VARCHAR2", Length: 50},
+		{Name: "AGE", Type: "NUMBER", Precision: 3, Scale: 0},
+	})
+
+	tags := findType(varrayTypeName)
+	tests.AssertEqual(t, tags.Kind, "COLLECTION")
+	tests.AssertEqual(t, tags.CollectionKind, "VARYING ARRAY")
+	tests.AssertEqual(t, tags.Limit, 5)
+	if tags.Element == nil || tags.Element.Type != "VARCHAR2" || tags.Element.Length != 20 {
+		t.Errorf("expected a VARCHAR2(20) element, got %+v", tags.Element)
+	}
+
+	if err := m.AlterType(objectTypeName, "ADD ATTRIBUTE (email VARCHAR2(100))", false); err == nil {
+		t.Errorf("expected an error altering a type with dependent tables without cascade")
+	}
+	if err := m.AlterType(objectTypeName, "ADD ATTRIBUTE (email VARCHAR2(100))", true); err != nil {
+		t.Fatalf("failed to add attribute, got error: %v", err)
+	}
+	if err := m.AlterType(varrayTypeName, "MODIFY LIMIT 10", true); err != nil {
+		t.Fatalf("failed to modify varray limit, got error: %v", err)
+	}
+
+	altered := findType(objectTypeName)
+	if len(altered.Attributes) != 3 || altered.Attributes[2].Name != "EMAIL" {
+		t.Errorf("expected the email attribute to be added, got %+v", altered.Attributes)
+	}
+	if altered.Version <= contact.Version {
+		t.Errorf("expected the type version to increase, got %d after %d", altered.Version, contact.Version)
+	}
+	tests.AssertEqual(t, findType(varrayTypeName).Limit, 10)
+
+	if err := DB.Exec(fmt.Sprintf(`INSERT INTO "%s" VALUES (1, "%s"('a', 30, 'a@example.com'), "%s"('x', 'y'))`,
+		tableName, objectTypeName, varrayTypeName)).Error; err != nil {
+		t.Errorf("failed to insert with the altered types, got error: %v", err)
+	}
+}
+
+type ExternalStagedOrder struct {
+	ID     uint
+	Code   string `gorm:"size:20;not null"`
+	Amount float64
+}
+
+func (ExternalStagedOrder) ExternalTable() oracle.ExternalTable {
+	return oracle.ExternalTable{
+		DefaultDirectory: "GORM_TEST_DIR",
+		AccessParameters: "RECORDS DELIMITED BY NEWLINE FIELDS TERMINATED BY ',' MISSING FIELD VALUES ARE NULL",
+		Location:         []string{"orders.csv"},
+		RejectLimit:      "UNLIMITED",
+	}
+}
+
+func TestMigrateExternalTable(t *testing.T) {
+	m := DB.Migrator().(oracle.Migrator)
+	if err := m.CreateDirectory("GORM_TEST_DIR", "/tmp"); err != nil {
+		t.Skipf("cannot create a directory object: %v", err)
+	}
+	defer m.DropDirectory("GORM_TEST_DIR")
+
+	DB.Migrator().DropTable(&ExternalStagedOrder{})
+	if err := DB.AutoMigrate(&ExternalStagedOrder{}); err != nil {
+		t.Fatalf("failed to create the external table, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&ExternalStagedOrder{})
+
+	var tableName string
+	if err := DB.Raw(`SELECT TABLE_NAME FROM USER_EXTERNAL_TABLES WHERE TABLE_NAME = 'external_staged_orders'`).Scan(&tableName).Error; err != nil || tableName == "" {
+		t.Fatalf("expected an external table, got %q, error: %v", tableName, err)
+	}
+
+	// Migrating again leaves the external table as it is
+	if err := DB.AutoMigrate(&ExternalStagedOrder{}); err != nil {
+		t.Fatalf("failed to migrate the external table again, got error: %v", err)
+	}
+
+	if err := m.SetExternalLocation(&ExternalStagedOrder{}, "orders_2.csv", "GORM_TEST_DIR:orders_3.csv"); err != nil {
+		t.Fatalf("failed to set the location, got error: %v", err)
+	}
+	var locations []string
+	if err := DB.Raw(`SELECT LOCATION FROM USER_EXTERNAL_LOCATIONS WHERE TABLE_NAME = 'external_staged_orders' ORDER BY LOCATION`).Scan(&locations).Error; err != nil {
+		t.Fatalf("failed to read the locations, got error: %v", err)
+	}
+	tests.AssertEqual(t, locations, []string{"orders_2.csv", "orders_3.csv"})
+
+	if err := m.SetExternalLocation(&ExternalStagedOrder{}, "BAD DIR:orders.csv"); err == nil {
+		t.Errorf("expected an error for an invalid directory")
+	}
+}
+
+func TestMigrateDirectory(t *testing.T) {
+	m := DB.Migrator().(oracle.Migrator)
+
+	err := m.CreateDirectory("gorm_test_dir_2", "/tmp/gorm")
+	var privErr *oracle.PrivilegeError
+	if errors.As(err, &privErr) {
+		if !errors.Is(err, oracle.ErrInsufficientPrivileges) || privErr.Privilege != "CREATE ANY DIRECTORY" {
+			t.Errorf("unexpected privilege error %v", err)
+		}
+		t.Skipf("cannot create a directory object: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("failed to create the directory, got error: %v", err)
+	}
+
+	if !m.HasDirectory("GORM_TEST_DIR_2") {
+		t.Errorf("expected the directory to exist")
+	}
+	var path string
+	DB.Raw(`SELECT DIRECTORY_PATH FROM ALL_DIRECTORIES WHERE DIRECTORY_NAME = 'GORM_TEST_DIR_2'`).Scan(&path)
+	tests.AssertEqual(t, path, "/tmp/gorm")
+
+	// Creating it again points it at the new path
+	if err := m.CreateDirectory("gorm_test_dir_2", "/tmp/gorm2"); err != nil {
+		t.Fatalf("failed to replace the directory, got error: %v", err)
+	}
+	DB.Raw(`SELECT DIRECTORY_PATH FROM ALL_DIRECTORIES WHERE DIRECTORY_NAME = 'GORM_TEST_DIR_2'`).Scan(&path)
+	tests.AssertEqual(t, path, "/tmp/gorm2")
+
+	if err := m.DropDirectory("gorm_test_dir_2"); err != nil {
+		t.Fatalf("failed to drop the directory, got error: %v", err)
+	}
+	if m.HasDirectory("GORM_TEST_DIR_2") {
+		t.Errorf("expected the directory to be dropped")
+	}
+
+	if err := m.CreateDirectory("bad dir", "/tmp"); err == nil {
+		t.Errorf("expected an error for an invalid directory name")
+	}
+}
+
+func TestGenDataTypes(t *testing.T) {
+	DB.Exec(`DROP TABLE GEN_EMPLOYEES CASCADE CONSTRAINTS`)
+	if err := DB.Exec(`CREATE TABLE GEN_EMPLOYEES (
+		EMPLOYEE_ID NUMBER(20) PRIMARY KEY,
+		ACTIVE NUMBER(1),
+		GRADE NUMBER(3),
+		DEPARTMENT_ID NUMBER(9),
+		SALARY NUMBER(10, 2),
+		RATING NUMBER,
+		FULL_NAME VARCHAR2(100),
+		NOTES CLOB,
+		HIRED DATE,
+		PHOTO BLOB
+	)`).Error; err != nil {
+		t.Fatalf("failed to create table, got error: %v", err)
+	}
+	defer DB.Exec(`DROP TABLE GEN_EMPLOYEES CASCADE CONSTRAINTS`)
+
+	columnTypes, err := DB.Migrator().ColumnTypes("GEN_EMPLOYEES")
+	if err != nil {
+		t.Fatalf("failed to get column types, got error: %v", err)
+	}
+
+	expected := map[string]string{
+		"EMPLOYEE_ID":   "int64",
+		"ACTIVE":        "bool",
+		"GRADE":         "int16",
+		"DEPARTMENT_ID": "int32",
+		"SALARY":        "float64",
+		"RATING":        "float64",
+		"FULL_NAME":     "string",
+		"NOTES":         "string",
+		"HIRED":         "time.Time",
+		"PHOTO":         "[]byte",
+	}
+	dataTypeMap := oracle.GenDataTypeMap()
+	for _, columnType := range columnTypes {
+		mapping, ok := dataTypeMap[columnType.DatabaseTypeName()]
+		if !ok {
+			t.Errorf("no data type mapping for %s of %s", columnType.DatabaseTypeName(), columnType.Name())
+			continue
+		}
+		if dataType := mapping(columnType); dataType != expected[columnType.Name()] {
+			t.Errorf("expected %s for %s, got %s", expected[columnType.Name()], columnType.Name(), dataType)
+		}
+		primaryKey, ok := columnType.PrimaryKey()
+		if !ok || primaryKey != (columnType.Name() == "EMPLOYEE_ID") {
+			t.Errorf("unexpected primary key %v for %s", primaryKey, columnType.Name())
+		}
+	}
+
+	opts := oracle.GenConfig()
+	if name := opts.ModelNameStrategy("GEN_EMPLOYEES"); name != "GenEmployee" {
+		t.Errorf("expected model name GenEmployee, got %s", name)
+	}
+	if name := (oracle.GenNamingStrategy{}).SchemaName("EMPLOYEE_ID"); name != "EmployeeID" {
+		t.Errorf("expected field name EmployeeID, got %s", name)
+	}
+	if tag := opts.JSONTagNameStrategy("EMPLOYEE_ID"); tag != "employee_id" {
+		t.Errorf("expected json tag employee_id, got %s", tag)
+	}
+}
+
+func TestGenerateModels(t *testing.T) {
+	DB.Exec(`DROP TABLE GEN_EMPLOYEES CASCADE CONSTRAINTS`)
+	DB.Exec(`DROP TABLE GEN_DEPARTMENTS CASCADE CONSTRAINTS`)
+	if err := DB.Exec(`CREATE TABLE GEN_DEPARTMENTS (
+		DEPARTMENT_ID NUMBER(9) GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+		NAME VARCHAR2(100 CHAR) NOT NULL UNIQUE
+	)`).Error; err != nil {
+		t.Fatalf("failed to create table, got error: %v", err)
+	}
+	defer DB.Exec(`DROP TABLE GEN_DEPARTMENTS CASCADE CONSTRAINTS`)
+	if err := DB.Exec(`CREATE TABLE GEN_EMPLOYEES (
+		EMPLOYEE_ID NUMBER(20) PRIMARY KEY,
+		SALARY NUMBER(10, 2) DEFAULT 0,
+		HIRED TIMESTAMP,
+		DEPARTMENT_ID NUMBER(9) REFERENCES GEN_DEPARTMENTS ON DELETE CASCADE
+	)`).Error; err != nil {
+		t.Fatalf("failed to create table, got error: %v", err)
+	}
+	defer DB.Exec(`DROP TABLE GEN_EMPLOYEES CASCADE CONSTRAINTS`)
+
+	var src strings.Builder
+	err := DB.Migrator().(oracle.Migrator).GenerateModels(&src, oracle.ModelOptions{
+		Package:          "hr",
+		Tables:           []string{"GEN_DEPARTMENTS", "GEN_EMPLOYEES"},
+		NullablePointers: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to generate models, got error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"package hr",
+		`"time"`,
+		"type GenDepartment struct",
+		"DepartmentID int32 `gorm:\"column:DEPARTMENT_ID;primaryKey;type:NUMBER(9);autoIncrement\"`",
+		"Name string `gorm:\"column:NAME;unique;type:VARCHAR2(100 CHAR);size:100;not null\"`",
+		"type GenEmployee struct",
+		"EmployeeID int64 `gorm:\"column:EMPLOYEE_ID;primaryKey;type:NUMBER(20)\"`",
+		"Salary *float64 `gorm:\"column:SALARY;type:NUMBER(10,2);precision:10;scale:2;default:0\"`",
+		"Hired *time.Time `gorm:\"column:HIRED;type:TIMESTAMP(6)\"`",
+		"Department *GenDepartment `gorm:\"foreignKey:DepartmentID;references:DepartmentID;constraint:OnDelete:CASCADE\"`",
+		`return "GEN_EMPLOYEES"`,
+	} {
+		if !strings.Contains(strings.Join(strings.Fields(src.String()), " "), expected) {
+			t.Errorf("expected generated models to contain %s, got:\n%s", expected, src.String())
+		}
+	}
+
+	if err := DB.Migrator().(oracle.Migrator).GenerateModels(&src, oracle.ModelOptions{Tables: []string{"GEN_MISSING"}}); err == nil {
+		t.Errorf("expected an error for a missing table")
+	}
+}
+
+func TestMigrationRunner(t *testing.T) {
+	type RunnerOrder struct {
+		ID   uint
+		Code string `gorm:"size:20"`
+	}
+
+	DB.Migrator().DropTable(&RunnerOrder{}, "runner_migrations")
+	defer DB.Migrator().DropTable(&RunnerOrder{}, "runner_migrations")
+
+	var ran []string
+	runner := oracle.MigrationRunner{
+		Table: "runner_migrations",
+		Lock:  &oracle.MigrationLock{Name: "gorm_oracle_test_runner", Timeout: time.Minute},
+		Migrations: []oracle.Migration{{
+			ID: "001_create_orders",
+			Migrate: func(tx *gorm.DB) error {
+				ran = append(ran, "001")
+				return tx.Migrator().CreateTable(&RunnerOrder{})
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Migrator().DropTable(&RunnerOrder{})
+			},
+		}, {
+			ID: "002_seed_orders",
+			Migrate: func(tx *gorm.DB) error {
+				ran = append(ran, "002")
+				return tx.Create(&RunnerOrder{Code: "seed"}).Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Where("\"code\" = ?", "seed").Delete(&RunnerOrder{}).Error
+			},
+		}},
+	}
+
+	if err := runner.Migrate(DB); err != nil {
+		if strings.Contains(err.Error(), "PLS-00201") {
+			t.Skip("EXECUTE on DBMS_LOCK is not granted to the test user")
+		}
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	if err := runner.Migrate(DB); err != nil {
+		t.Fatalf("failed to migrate again, got error: %v", err)
+	}
+	if !slices.Equal(ran, []string{"001", "002"}) {
+		t.Errorf("expected each migration to run once in order, got %v", ran)
+	}
+
+	applied, err := runner.Applied(DB)
+	if err != nil || len(applied) != 2 || applied[0].ID != "001_create_orders" || applied[1].Dirty {
+		t.Fatalf("expected two applied migrations, got %+v, error %v", applied, err)
+	}
+
+	// A failing migration without DDL is rolled back entirely
+	errFailed := errors.New("failed")
+	runner.Migrations = append(runner.Migrations, oracle.Migration{
+		ID: "003_failing",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Create(&RunnerOrder{Code: "partial"}).Error; err != nil {
+				return err
+			}
+			return errFailed
+		},
+	})
+	if err := runner.Migrate(DB); !errors.Is(err, errFailed) {
+		t.Fatalf("expected the migration error, got %v", err)
+	}
+	var count int64
+	DB.Model(&RunnerOrder{}).Where("\"code\" = ?", "partial").Count(&count)
+	if applied, _ := runner.Applied(DB); len(applied) != 2 || count != 0 {
+		t.Errorf("expected the failed migration to be rolled back, got %+v and %d rows", applied, count)
+	}
+	runner.Migrations = runner.Migrations[:2]
+
+	// A failing migration after DDL is left dirty
+	runner.Migrations = append(runner.Migrations, oracle.Migration{
+		ID: "003_dirty",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE "runner_orders" ADD "extra" NUMBER`).Error; err != nil {
+				return err
+			}
+			return errFailed
+		},
+	})
+	runner.Migrate(DB)
+	var dirty *oracle.DirtyMigrationError
+	if err := runner.Migrate(DB); !errors.As(err, &dirty) || dirty.ID != "003_dirty" {
+		t.Fatalf("expected a dirty migration error, got %v", err)
+	}
+	DB.Exec(`DELETE FROM "runner_migrations" WHERE "id" = ?`, "003_dirty")
+	runner.Migrations = runner.Migrations[:2]
+
+	if err := runner.Rollback(DB); err != nil {
+		t.Fatalf("failed to roll back, got error: %v", err)
+	}
+	DB.Model(&RunnerOrder{}).Where("\"code\" = ?", "seed").Count(&count)
+	if applied, _ := runner.Applied(DB); len(applied) != 1 || count != 0 {
+		t.Errorf("expected the last migration to be rolled back, got %+v and %d rows", applied, count)
+	}
+	if err := runner.Rollback(DB); err != nil || DB.Migrator().HasTable(&RunnerOrder{}) {
+		t.Errorf("expected the first migration to be rolled back, got error %v", err)
+	}
+}
@@ -257,3 +257,44 @@ func TestNamedArgReservedWordParam(t *testing.T) {
 	}
 	tests.AssertEqual(t, result, user)
 }
+
+func TestNamedArgOracleBinds(t *testing.T) {
+	type NamedUser struct {
+		gorm.Model
+		Name1 string
+		Name2 string
+	}
+	DB.Migrator().DropTable(&NamedUser{})
+	DB.AutoMigrate(&NamedUser{})
+
+	user := NamedUser{Name1: "bind1", Name2: "bind2"}
+	DB.Create(&user)
+
+	var result NamedUser
+	if err := DB.Raw(`SELECT * FROM "named_users" WHERE "name1" = :name1 AND :name1 IS NOT NULL AND "name2" = :NAME2`,
+		map[string]interface{}{"name1": "bind1", "name2": "bind2"}).Scan(&result).Error; err != nil {
+		t.Errorf("failed map bind test: %v", err)
+	}
+	tests.AssertEqual(t, result, user)
+
+	type filter struct {
+		Name1 string
+		Name2 string
+	}
+	result = NamedUser{}
+	if err := DB.Raw(`SELECT * FROM "named_users" WHERE "name1" = :name1 AND "name2" = :name2 AND '--:x' = '--:x' /* :y */`,
+		filter{Name1: "bind1", Name2: "bind2"}).Scan(&result).Error; err != nil {
+		t.Errorf("failed struct bind test: %v", err)
+	}
+	tests.AssertEqual(t, result, user)
+
+	if err := DB.Exec(`UPDATE "named_users" SET "name2" = :name2 WHERE "name1" = :name1`,
+		map[string]interface{}{"name1": "bind1", "name2": "updated"}).Error; err != nil {
+		t.Errorf("failed exec bind test: %v", err)
+	}
+	var name2 string
+	DB.Raw(`SELECT "name2" FROM "named_users" WHERE "id" = :id`, sql.Named("id", user.ID)).Scan(&name2)
+	if name2 != "updated" {
+		t.Errorf("expected updated name2, got %q", name2)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/oracle-samples/gorm-oracle/oracletest"
+)
+
+func TestOracletestNew(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the start of a database in short mode")
+	}
+
+	var schema string
+	t.Run("New", func(t *testing.T) {
+		db := oracletest.New(t, oracletest.Options{Grants: []string{"CREATE MATERIALIZED VIEW"}})
+
+		if err := db.Raw("SELECT USER FROM DUAL").Scan(&schema).Error; err != nil {
+			t.Fatalf("failed to query the schema, got error: %v", err)
+		}
+		if schema == "" {
+			t.Fatalf("expected the user of the schema")
+		}
+
+		type OracletestItem struct {
+			ID   uint
+			Name string
+		}
+		if err := db.AutoMigrate(&OracletestItem{}); err != nil {
+			t.Fatalf("failed to create table in the schema, got error: %v", err)
+		}
+		if err := db.Create(&OracletestItem{Name: "item"}).Error; err != nil {
+			t.Fatalf("failed to insert into the schema, got error: %v", err)
+		}
+
+		var privileges int64
+		db.Raw(`SELECT COUNT(*) FROM USER_SYS_PRIVS WHERE PRIVILEGE = 'CREATE MATERIALIZED VIEW'`).Scan(&privileges)
+		if privileges != 1 {
+			t.Errorf("expected the extra grant, got %d", privileges)
+		}
+	})
+	if schema == "" {
+		return
+	}
+
+	// The schema is dropped when the test ends
+	var users int64
+	if err := DB.Raw("SELECT COUNT(*) FROM ALL_USERS WHERE USERNAME = ?", schema).Scan(&users).Error; err != nil {
+		t.Fatalf("failed to query users, got error: %v", err)
+	}
+	if users != 0 {
+		t.Errorf("expected schema %s to be dropped", schema)
+	}
+}
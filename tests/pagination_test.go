@@ -0,0 +1,106 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	. "github.com/oracle-samples/gorm-oracle/tests/utils"
+
+	"gorm.io/gorm"
+)
+
+func TestTotalCount(t *testing.T) {
+	users := []User{
+		*GetUser("total_count_1", Config{}),
+		*GetUser("total_count_2", Config{}),
+		*GetUser("total_count_3", Config{}),
+		*GetUser("total_count_4", Config{}),
+		*GetUser("total_count_5", Config{}),
+	}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	var total int64
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(oracle.WithTotalCount(&total)).Limit(2).Find(&[]User{})
+	})
+	if !regexp.MustCompile(`SELECT "users".\*, COUNT\(\*\) OVER \(\) AS "total_rows" FROM "users"`).MatchString(sql) {
+		t.Errorf("expected total count column, got %v", sql)
+	}
+
+	var page []User
+	err := DB.Clauses(oracle.WithTotalCount(&total)).
+		Where("\"name\" LIKE ?", "total_count_%").
+		Order("\"name\"").Limit(2).Offset(2).
+		Find(&page).Error
+	if err != nil {
+		t.Fatalf("failed to query page with total count, got error %v", err)
+	}
+	if total != 5 {
+		t.Errorf("expected total count 5, got %v", total)
+	}
+	if len(page) != 2 || page[0].Name != "total_count_3" || page[1].Name != "total_count_4" {
+		t.Errorf("unexpected page, got %v", page)
+	}
+
+	var names []map[string]interface{}
+	err = DB.Model(&User{}).Clauses(oracle.WithTotalCount(&total)).
+		Select("name").
+		Where("\"name\" LIKE ?", "total_count_%").
+		Limit(1).Find(&names).Error
+	if err != nil {
+		t.Fatalf("failed to query map page with total count, got error %v", err)
+	}
+	if total != 5 || len(names) != 1 || len(names[0]) != 1 {
+		t.Errorf("expected a single column row and total count 5, got %v and %v", names, total)
+	}
+
+	err = DB.Clauses(oracle.WithTotalCount(&total)).Where("\"name\" LIKE ?", "total_count_%").Offset(10).Find(&page).Error
+	if err != nil || total != 0 || len(page) != 0 {
+		t.Errorf("expected empty page with total count 0, got %v, %v, error %v", page, total, err)
+	}
+
+	if err := DB.Clauses(oracle.WithTotalCount(&total)).Distinct("name").Find(&[]User{}).Error; err == nil {
+		t.Errorf("expected error when combining total count with DISTINCT")
+	}
+}
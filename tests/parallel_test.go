@@ -0,0 +1,163 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	. "github.com/oracle-samples/gorm-oracle/tests/utils"
+
+	"gorm.io/gorm"
+)
+
+func TestParallelHint(t *testing.T) {
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(oracle.Parallel(4)).Where("\"age\" > ?", 10).Find(&[]User{})
+	})
+	if !regexp.MustCompile(`^SELECT /\*\+ PARALLEL\(4\) \*/ \* FROM "users"`).MatchString(sql) {
+		t.Errorf("expected PARALLEL hint after SELECT, got %v", sql)
+	}
+
+	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(oracle.Parallel(0).WithDML()).Model(&User{}).Where("\"age\" > ?", 10).Update("name", "parallel")
+	})
+	if !regexp.MustCompile(`^UPDATE /\*\+ ENABLE_PARALLEL_DML PARALLEL \*/ "users" SET`).MatchString(sql) {
+		t.Errorf("expected PARALLEL hint after UPDATE, got %v", sql)
+	}
+
+	if err := DB.Session(&gorm.Session{DryRun: true}).Clauses(oracle.Parallel(-1)).Find(&[]User{}).Error; err == nil {
+		t.Errorf("expected error for negative degree of parallelism")
+	}
+
+	users := []User{*GetUser("parallel_1", Config{}), *GetUser("parallel_2", Config{})}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	var found []User
+	if err := DB.Clauses(oracle.Parallel(2)).Where("\"name\" LIKE ?", "parallel_%").Find(&found).Error; err != nil {
+		t.Fatalf("failed to query with PARALLEL hint, got error %v", err)
+	}
+	if len(found) != 2 {
+		t.Errorf("expected 2 users, got %v", len(found))
+	}
+
+	err := oracle.ParallelDML(DB, func(tx *gorm.DB) error {
+		return tx.Clauses(oracle.Parallel(2)).Model(&User{}).Where("\"name\" LIKE ?", "parallel_%").Update("age", 42).Error
+	})
+	if err != nil {
+		t.Fatalf("failed to update with parallel DML, got error %v", err)
+	}
+
+	var count int64
+	DB.Model(&User{}).Where("\"name\" LIKE ? AND \"age\" = ?", "parallel_%", 42).Count(&count)
+	if count != 2 {
+		t.Errorf("expected 2 updated users, got %v", count)
+	}
+}
+
+func TestFindInRowIDRanges(t *testing.T) {
+	users := make([]User, 50)
+	for i := range users {
+		users[i] = *GetUser(fmt.Sprintf("rowid_range_%02d", i), Config{})
+	}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+
+	ranges, err := oracle.RowIDRanges(DB.Model(&User{}), 4)
+	if err != nil {
+		t.Fatalf("failed to split users into ROWID ranges, got error: %v", err)
+	}
+	if len(ranges) == 0 || len(ranges) > 4 {
+		t.Fatalf("expected 1 to 4 ROWID ranges, got %v", ranges)
+	}
+	var count int64
+	for _, r := range ranges {
+		var n int64
+		if err := DB.Model(&User{}).Where(r).Where("\"name\" LIKE ?", "rowid_range_%").Count(&n).Error; err != nil {
+			t.Fatalf("failed to count users in %v, got error: %v", r, err)
+		}
+		count += n
+	}
+	if count != int64(len(users)) {
+		t.Errorf("expected the ranges to cover %d users, got %d", len(users), count)
+	}
+
+	var (
+		mu      sync.Mutex
+		found   = map[string]int{}
+		batches = map[int]bool{}
+	)
+	err = oracle.FindInRowIDRanges(DB.Where("\"name\" LIKE ?", "rowid_range_%"), &[]User{}, 4, 2,
+		func(tx *gorm.DB, batch int, records interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			batches[batch] = true
+			for _, user := range *records.(*[]User) {
+				found[user.Name]++
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("failed to find users in ROWID ranges, got error: %v", err)
+	}
+	if len(batches) != len(ranges) {
+		t.Errorf("expected %d batches, got %v", len(ranges), batches)
+	}
+	for _, user := range users {
+		if found[user.Name] != 1 {
+			t.Errorf("expected %s to be found once, got %d", user.Name, found[user.Name])
+		}
+	}
+
+	errStop := errors.New("stop")
+	err = oracle.FindInRowIDRanges(DB.Where("\"name\" LIKE ?", "rowid_range_%"), &[]User{}, 4, 1,
+		func(tx *gorm.DB, batch int, records interface{}) error {
+			return errStop
+		})
+	if !errors.Is(err, errStop) {
+		t.Errorf("expected the error of the batch to be returned, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	. "github.com/oracle-samples/gorm-oracle/tests/utils"
+
+	"gorm.io/gorm"
+)
+
+func TestQuestionMarksInLiterals(t *testing.T) {
+	user := GetUser("question_mark?", Config{})
+	if err := DB.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user, got error %v", err)
+	}
+
+	var found User
+	if err := DB.Where(`"name" = 'question_mark?' /* ? */ AND "age" = ?`, user.Age).First(&found).Error; err != nil {
+		t.Fatalf("failed to query with a question mark literal, got error %v", err)
+	}
+	if found.ID != user.ID {
+		t.Errorf("expected user %v, got %v", user.ID, found.ID)
+	}
+
+	var count int64
+	if err := DB.Model(&User{}).Where(`"name" = q'[question_mark?]' OR "id" = ?`, 0).Where("id", user.ID).Count(&count).Error; err != nil {
+		t.Fatalf("failed to query with a q-quoted literal, got error %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 user, got %v", count)
+	}
+
+	if err := DB.Model(&found).Update("name", gorm.Expr(`? || '?'`, "renamed")).Error; err != nil {
+		t.Fatalf("failed to update with a question mark literal, got error %v", err)
+	}
+	var name string
+	if err := DB.Raw("?", oracle.Expr(`SELECT "name" FROM "users" WHERE "name" LIKE '%?' AND "id" = ?`, user.ID)).Scan(&name).Error; err != nil {
+		t.Fatalf("failed to run raw SQL with a question mark literal, got error %v", err)
+	}
+	if name != "renamed?" {
+		t.Errorf("expected name renamed?, got %v", name)
+	}
+}
+
+func TestQuestionMarksInRawLiterals(t *testing.T) {
+	user := GetUser("raw_question_mark", Config{})
+	if err := DB.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user, got error %v", err)
+	}
+
+	var name string
+	if err := DB.Raw(`SELECT "name" || '?' FROM "users" WHERE "id" = ?`, user.ID).Scan(&name).Error; err != nil {
+		t.Fatalf("failed to scan raw SQL with a question mark literal, got error %v", err)
+	}
+	if name != "raw_question_mark?" {
+		t.Errorf("expected name raw_question_mark?, got %v", name)
+	}
+
+	var names []string
+	if err := DB.Raw(`SELECT "name" FROM "users" /* why? */ WHERE "name" <> q'[why?]' -- ?
+		AND "id" IN ? AND "age" = ?`, []uint{user.ID}, user.Age).Scan(&names).Error; err != nil {
+		t.Fatalf("failed to scan raw SQL with a q-quoted literal and comments, got error %v", err)
+	}
+	if len(names) != 1 || names[0] != user.Name {
+		t.Errorf("expected [%v], got %v", user.Name, names)
+	}
+
+	var value string
+	if err := DB.Raw(`SELECT JSON_VALUE('{"a":[1,2]}', '$.a?(@ == 2)') FROM DUAL WHERE 1 = ?`, 1).Row().Scan(&value); err != nil {
+		t.Fatalf("failed to scan raw SQL with a JSON path filter, got error %v", err)
+	}
+	if value != "2" {
+		t.Errorf("expected 2, got %v", value)
+	}
+
+	if err := DB.Exec(`UPDATE "users" SET "name" = 'updated?' /* ? */ WHERE "id" = ? AND "age" = ?`, user.ID, user.Age).Error; err != nil {
+		t.Fatalf("failed to exec SQL with a question mark literal, got error %v", err)
+	}
+	if err := DB.Exec(`UPDATE "users" SET "name" = q'{ask?}' || ? -- ?
+		WHERE "id" = ?`, "!", user.ID).Error; err != nil {
+		t.Fatalf("failed to exec SQL with a q-quoted literal, got error %v", err)
+	}
+	var found User
+	if err := DB.First(&found, user.ID).Error; err != nil {
+		t.Fatalf("failed to find user, got error %v", err)
+	}
+	if found.Name != "ask?!" {
+		t.Errorf("expected name ask?!, got %v", found.Name)
+	}
+
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Raw(`SELECT '?' FROM "users" WHERE "id" = ?`, 5).Find(&names)
+	})
+	if sql != `SELECT '?' FROM "users" WHERE "id" = 5` {
+		t.Errorf("unexpected SQL %v", sql)
+	}
+}
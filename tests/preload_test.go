@@ -41,6 +41,7 @@ package tests
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"regexp"
 	"sort"
 	"strconv"
@@ -49,6 +50,7 @@ import (
 
 	"time"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"gorm.io/gorm"
@@ -179,10 +181,7 @@ func TestPreloadWithConds(t *testing.T) {
 
 	var users3 []User
 	if err := DB.Preload("Account", func(tx *gorm.DB) *gorm.DB {
-		// todo: uncomment the below line, once the alias quoting issue is resolved.
-		// Gorm issue track: https://github.com/oracle-samples/gorm-oracle/issues/36
-		// return tx.Table("\"accounts\" a").Select("a.*")
-		return tx.Table("accounts").Select("*")
+		return tx.Table("\"accounts\" a").Select("a.*")
 	}).Find(&users3, "\"id\" IN ?", userIDs).Error; err != nil {
 		t.Errorf("failed to query, got error %v", err)
 	}
@@ -672,3 +671,103 @@ func TestEmbedPreload(t *testing.T) {
 		})
 	}
 }
+
+func TestPreloadTempTable(t *testing.T) {
+	db, err := openTestDBWithOptions(&oracle.Config{PreloadTempTable: &oracle.PreloadTempTable{Threshold: 100}}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	users := make([]User, 1200)
+	for i := range users {
+		users[i] = *GetUser("preload_temp_table_"+strconv.Itoa(i), Config{Pets: 1})
+	}
+	if err := db.CreateInBatches(&users, 200).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+
+	check := func(t *testing.T, tx *gorm.DB) {
+		t.Helper()
+		var loaded []User
+		if err := tx.Preload("Pets").Where("\"name\" LIKE ?", "preload_temp_table_%").Find(&loaded).Error; err != nil {
+			t.Fatalf("failed to preload, got error: %v", err)
+		}
+		if len(loaded) != len(users) {
+			t.Fatalf("expected %d users, got %d", len(users), len(loaded))
+		}
+		for _, user := range loaded {
+			if len(user.Pets) != 1 || user.Pets[0].Name != user.Name+"_pet_1" {
+				t.Fatalf("expected user %s to have its pet preloaded, got %+v", user.Name, user.Pets)
+			}
+		}
+
+		var count int64
+		tx.Table("gorm_preload_keys").Count(&count)
+		if count != 0 {
+			t.Errorf("expected the preload keys to be deleted, got %d", count)
+		}
+	}
+
+	t.Run("Pool", func(t *testing.T) {
+		check(t, db)
+		if !db.Migrator().HasTable("gorm_preload_keys") {
+			t.Fatalf("expected the preload temporary table to be created")
+		}
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		db.Transaction(func(tx *gorm.DB) error {
+			check(t, tx)
+			return nil
+		})
+	})
+}
+
+func TestPreloadTempTableCreatedLazily(t *testing.T) {
+	DB.Migrator().DropTable("gorm_preload_keys_lazy")
+	defer DB.Migrator().DropTable("gorm_preload_keys_lazy")
+
+	db, err := openTestDBWithOptions(&oracle.Config{
+		PreloadTempTable: &oracle.PreloadTempTable{Threshold: 10, Table: "gorm_preload_keys_lazy"},
+	}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	if db.Migrator().HasTable("gorm_preload_keys_lazy") {
+		t.Fatalf("expected the preload temporary table not to be created when opening the database")
+	}
+
+	users := make([]User, 20)
+	for i := range users {
+		users[i] = *GetUser("preload_lazy_"+strconv.Itoa(i), Config{Pets: 1})
+	}
+
+	// Creating the table must not commit the transaction
+	db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&users).Error; err != nil {
+			t.Fatalf("failed to create users, got error: %v", err)
+		}
+		var loaded []User
+		if err := tx.Preload("Pets").Where("\"name\" LIKE ?", "preload_lazy_%").Find(&loaded).Error; err != nil {
+			t.Fatalf("failed to preload, got error: %v", err)
+		}
+		if len(loaded) != len(users) {
+			t.Fatalf("expected %d users, got %d", len(users), len(loaded))
+		}
+		for _, user := range loaded {
+			if len(user.Pets) != 1 {
+				t.Fatalf("expected user %s to have its pet preloaded, got %+v", user.Name, user.Pets)
+			}
+		}
+		return errors.New("rollback")
+	})
+
+	if !db.Migrator().HasTable("gorm_preload_keys_lazy") {
+		t.Fatalf("expected the preload temporary table to be created by the preload")
+	}
+	var count int64
+	db.Model(&User{}).Where("\"name\" LIKE ?", "preload_lazy_%").Count(&count)
+	if count != 0 {
+		t.Errorf("expected the transaction to be rolled back, got %d users", count)
+	}
+}
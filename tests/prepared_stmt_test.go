@@ -41,6 +41,7 @@ package tests
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -48,6 +49,7 @@ import (
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/utils/tests"
 )
 
@@ -327,3 +329,73 @@ func TestPreparedStmtConcurrentClose(t *testing.T) {
 		t.Fatalf("should is a unexpected error")
 	}
 }
+
+func TestPreparedStmtBulkCreate(t *testing.T) {
+	db, err := OpenTestConnection(&gorm.Config{PrepareStmt: true})
+	if err != nil {
+		t.Fatalf("failed to open connection, got error: %v", err)
+	}
+	pdb, ok := db.ConnPool.(*gorm.PreparedStmtDB)
+	if !ok {
+		t.Fatalf("should assign PreparedStatement Manager back to database when using PrepareStmt mode")
+	}
+
+	var before, after int64
+	db.Model(&User{}).Where("\"name\" = ?", "prepared_stmt_bulk").Count(&before)
+
+	// Batches of every size build a different PL/SQL block
+	for size := 2; size <= 20; size++ {
+		users := make([]User, size)
+		for i := range users {
+			users[i] = *GetUser("prepared_stmt_bulk", Config{})
+		}
+		if err := db.Create(&users).Error; err != nil {
+			t.Fatalf("failed to create %d users, got error: %v", size, err)
+		}
+		for _, user := range users {
+			if user.ID == 0 {
+				t.Fatalf("expected the ids of the %d users to be returned", size)
+			}
+		}
+	}
+
+	for _, key := range pdb.Stmts.Keys() {
+		if strings.HasPrefix(strings.TrimSpace(key), "DECLARE") {
+			t.Errorf("bulk PL/SQL blocks should not be cached as prepared statements, got %q", key)
+		}
+	}
+
+	db.Model(&User{}).Where("\"name\" = ?", "prepared_stmt_bulk").Count(&after)
+	tests.AssertEqual(t, after-before, int64(209))
+}
+
+func TestPreparedStmtUpdateReturning(t *testing.T) {
+	db, err := OpenTestConnection(&gorm.Config{PrepareStmt: true})
+	if err != nil {
+		t.Fatalf("failed to open connection, got error: %v", err)
+	}
+	pdb, ok := db.ConnPool.(*gorm.PreparedStmtDB)
+	if !ok {
+		t.Fatalf("should assign PreparedStatement Manager back to database when using PrepareStmt mode")
+	}
+
+	users := []User{*GetUser("prepared_stmt_update", Config{}), *GetUser("prepared_stmt_update", Config{})}
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+
+	var updated []User
+	err = db.Model(&updated).Clauses(clause.Returning{}).
+		Where("\"name\" = ?", "prepared_stmt_update").
+		Update("age", 30).Error
+	if err != nil {
+		t.Fatalf("failed to update users, got error: %v", err)
+	}
+	tests.AssertEqual(t, len(updated), len(users))
+
+	for _, key := range pdb.Stmts.Keys() {
+		if strings.HasPrefix(strings.TrimSpace(key), "DECLARE") {
+			t.Errorf("UPDATE RETURNING blocks should not be cached as prepared statements, got %q", key)
+		}
+	}
+}
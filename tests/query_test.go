@@ -41,6 +41,7 @@ package tests
 import (
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -52,6 +53,7 @@ import (
 	"time"
 
 	"github.com/godror/godror"
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"gorm.io/gorm"
@@ -1714,6 +1716,108 @@ func TestLargeResultSet(t *testing.T) {
 	}
 }
 
+func TestFindEach(t *testing.T) {
+	var row struct {
+		RowNum int    `gorm:"column:ROW_NUM"`
+		Value  string `gorm:"column:VALUE"`
+	}
+	count := 0
+	err := oracle.FindEach(DB.Raw(`SELECT LEVEL AS row_num, 'row_' || LEVEL AS value FROM dual CONNECT BY LEVEL <= 5000`), &row, 500,
+		func(tx *gorm.DB) error {
+			count++
+			if row.RowNum != count || row.Value != fmt.Sprintf("row_%d", count) {
+				return fmt.Errorf("unexpected row %d: %+v", count, row)
+			}
+			return nil
+		})
+	if err != nil || count != 5000 {
+		t.Fatalf("expected 5000 rows to be streamed, got %d, error: %v", count, err)
+	}
+
+	users := []User{*GetUser("find_each_1", Config{}), *GetUser("find_each_2", Config{}), *GetUser("find_each_3", Config{})}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+
+	var user User
+	var names []string
+	err = oracle.FindEach(DB.Where("\"name\" LIKE ?", "find_each_%").Order("\"name\""), &user, 0, func(tx *gorm.DB) error {
+		names = append(names, user.Name)
+		return tx.Model(&User{}).Where("\"id\" = ?", user.ID).Update("age", 42).Error
+	})
+	if err != nil {
+		t.Fatalf("failed to stream users, got error: %v", err)
+	}
+	tests.AssertEqual(t, names, []string{"find_each_1", "find_each_2", "find_each_3"})
+
+	var updated int64
+	DB.Model(&User{}).Where("\"name\" LIKE ? AND \"age\" = ?", "find_each_%", 42).Count(&updated)
+	if updated != 3 {
+		t.Errorf("expected the callback to update 3 users, got %d", updated)
+	}
+
+	stop := errors.New("stop")
+	count = 0
+	err = oracle.FindEach(DB.Where("\"name\" LIKE ?", "find_each_%"), &user, 0, func(tx *gorm.DB) error {
+		count++
+		return stop
+	})
+	if !errors.Is(err, stop) || count != 1 {
+		t.Errorf("expected the callback error to stop the query, got %v after %d rows", err, count)
+	}
+
+	if err := oracle.FindEach(DB, &[]User{}, 0, func(tx *gorm.DB) error { return nil }); err == nil {
+		t.Errorf("expected an error for a slice destination")
+	}
+}
+
+func TestQueryDefaults(t *testing.T) {
+	users := make([]User, 5)
+	for i := range users {
+		users[i] = *GetUser(fmt.Sprintf("query_defaults_%d", i+1), Config{})
+	}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+
+	session := oracle.WithQueryDefaults(DB, oracle.QueryDefaults{Hints: []string{"FIRST_ROWS(100)"}, MaxRows: 3})
+	sql := session.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("\"name\" LIKE ?", "query_defaults_%").Find(&[]User{})
+	})
+	if !strings.Contains(sql, "SELECT /*+ FIRST_ROWS(100) */") || !strings.Contains(sql, "FETCH NEXT 4 ROWS ONLY") {
+		t.Errorf("expected the default hints and row cap, got %s", sql)
+	}
+
+	var found []User
+	err := session.Where("\"name\" LIKE ?", "query_defaults_%").Order("\"name\"").Find(&found).Error
+	if !errors.Is(err, oracle.ErrMaxRowsExceeded) {
+		t.Errorf("expected ErrMaxRowsExceeded, got %v", err)
+	}
+	if len(found) != 3 || found[0].Name != "query_defaults_1" {
+		t.Errorf("expected the first 3 users to be found, got %d", len(found))
+	}
+
+	found = nil
+	if err := session.Where("\"name\" LIKE ?", "query_defaults_%").Limit(5).Find(&found).Error; err != nil || len(found) != 5 {
+		t.Errorf("expected a query with a limit not to be capped, got %d users, error: %v", len(found), err)
+	}
+
+	var count int64
+	if err := session.Model(&User{}).Where("\"name\" LIKE ?", "query_defaults_%").Count(&count).Error; err != nil || count != 5 {
+		t.Errorf("expected a count not to be capped, got %d, error: %v", count, err)
+	}
+
+	found = nil
+	if err := oracle.WithQueryDefaults(DB, oracle.QueryDefaults{MaxRows: 5}).Where("\"name\" LIKE ?", "query_defaults_%").Find(&found).Error; err != nil || len(found) != 5 {
+		t.Errorf("expected the users to be found under the cap, got %d users, error: %v", len(found), err)
+	}
+
+	found = nil
+	if err := DB.Where("\"name\" LIKE ?", "query_defaults_%").Find(&found).Error; err != nil || len(found) != 5 {
+		t.Errorf("expected other sessions not to be capped, got %d users, error: %v", len(found), err)
+	}
+}
+
 func TestBeforeQueryTableUnscoped(t *testing.T) {
 	beforequeryuserunscoped := []User{
 		{Name: "beforequery_user_unscoped", Age: 1},
@@ -1804,7 +1908,6 @@ func TestBeforeQueryTable(t *testing.T) {
 	}
 	DB.Save(&beforequeryuser)
 
-	// https://github.com/oracle-samples/gorm-oracle/issues/36
 	var fetched1 []User
 	err1 := DB.Table("\"users\" u").Where("\"name\" = ?", "beforequery_user").Find(&fetched1).Error
 	if err1 != nil {
@@ -1860,7 +1963,6 @@ func TestBeforeQueryTable(t *testing.T) {
 		t.Errorf("Expected to fetch 'beforequery_user6' with subquery table, got: %+v", fetched6)
 	}
 
-	// https://github.com/oracle-samples/gorm-oracle/issues/36
 	var fetched7 []User
 	err7 := DB.Table(`"users" u`).Where("\"name\" = ?", "beforequery_user7").Find(&fetched7).Error
 	if err7 != nil {
@@ -1870,3 +1972,160 @@ func TestBeforeQueryTable(t *testing.T) {
 		t.Errorf("Expected to fetch 'beforequery_user7' with subquery table, got: %+v", fetched7)
 	}
 }
+
+func TestQuoteColumnsConfig(t *testing.T) {
+	db, err := openTestDBWithOptions(&oracle.Config{QuoteColumns: true}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	users := []User{*GetUser("quote_columns_1", Config{}), *GetUser("quote_columns_2", Config{})}
+	users[0].Age, users[1].Age = 31, 32
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	query := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Select("name, age").Where("id IN ? AND users.age > ?", []uint{1, 2}, 18).
+			Where("UPPER(name) LIKE 'name%'").Order("age desc, name").Find(&[]User{})
+	})
+	expected := `SELECT "name", "age" FROM "users" WHERE ("id" IN (1,2) AND "users"."age" > 18) AND UPPER("name") LIKE 'name%' AND "users"."deleted_at" IS NULL ORDER BY "age" desc, "name"`
+	if query != expected {
+		t.Errorf("expected %v, got %v", expected, query)
+	}
+
+	var found []User
+	if err := db.Where("name LIKE ? AND Age > ?", "quote_columns_%", 31).Order("id").Find(&found).Error; err != nil {
+		t.Fatalf("failed to query with bare column names, got error %v", err)
+	}
+	if len(found) != 1 || found[0].Name != "quote_columns_2" {
+		t.Errorf("expected quote_columns_2, got %+v", found)
+	}
+
+	var results []struct {
+		Age   uint
+		Count int
+	}
+	if err := db.Model(&User{}).Select("age, COUNT(id) AS \"count\"").Where("name LIKE ?", "quote_columns_%").
+		Group("age, name").Having("COUNT(id) = ?", 1).Order("age").Scan(&results).Error; err != nil {
+		t.Fatalf("failed to group by bare column names, got error %v", err)
+	}
+	if len(results) != 2 || results[0].Age != 31 || results[1].Age != 32 {
+		t.Errorf("expected a group per age, got %+v", results)
+	}
+
+	if err := db.Model(&User{}).Where("name = ?", "quote_columns_1").Update("age", 41).Error; err != nil {
+		t.Fatalf("failed to update with a bare column name, got error %v", err)
+	}
+	if err := db.Where("name = ?", "quote_columns_2").Delete(&User{}).Error; err != nil {
+		t.Fatalf("failed to delete with a bare column name, got error %v", err)
+	}
+}
+
+func TestOrderGroupColumnHelpers(t *testing.T) {
+	users := []User{*GetUser("order_helpers_1", Config{}), *GetUser("order_helpers_2", Config{}), *GetUser("order_helpers_3", Config{})}
+	users[0].Age, users[1].Age, users[2].Age = 20, 30, 30
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	query := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Order(oracle.Desc("Age")).Order(oracle.Asc("users.name")).Find(&[]User{})
+	})
+	if !strings.Contains(query, `ORDER BY "age" DESC,"users"."name"`) {
+		t.Errorf("expected field names resolved to quoted columns, got %v", query)
+	}
+
+	query = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("users u").Select("u.age").Group("u.age").Order(oracle.Asc("u.age")).Find(&[]User{})
+	})
+	if !strings.Contains(query, `GROUP BY "U"."age" ORDER BY "U"."age"`) {
+		t.Errorf("expected columns qualified with the folded alias, got %v", query)
+	}
+
+	var found []User
+	if err := DB.Where("\"name\" LIKE ?", "order_helpers_%").Order(oracle.Desc("age")).Order(oracle.Asc("Name")).Find(&found).Error; err != nil {
+		t.Fatalf("failed to order with column helpers, got error %v", err)
+	}
+	if len(found) != 3 || found[0].Name != "order_helpers_2" || found[1].Name != "order_helpers_3" || found[2].Name != "order_helpers_1" {
+		t.Errorf("unexpected order, got %+v", found)
+	}
+
+	var results []struct {
+		Age   uint
+		Count int
+	}
+	if err := DB.Model(&User{}).Select("\"age\", COUNT(*) AS \"count\"").Where("\"name\" LIKE ?", "order_helpers_%").
+		Group("Age").Having(clause.Gt{Column: oracle.Col("age"), Value: 25}).Order(oracle.Asc("age")).Scan(&results).Error; err != nil {
+		t.Fatalf("failed to group with column helpers, got error %v", err)
+	}
+	if len(results) != 1 || results[0].Age != 30 || results[0].Count != 2 {
+		t.Errorf("expected one group of age 30, got %+v", results)
+	}
+}
+
+func TestImplicitResults(t *testing.T) {
+	users := []User{*GetUser("implicit_1", Config{}), *GetUser("implicit_2", Config{}), *GetUser("implicit_3", Config{})}
+	users[0].Age, users[1].Age, users[2].Age = 20, 30, 40
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+
+	if err := DB.Exec(`CREATE OR REPLACE PROCEDURE "implicit_users"(p_age NUMBER) AS
+		c1 SYS_REFCURSOR;
+		c2 SYS_REFCURSOR;
+	BEGIN
+		OPEN c1 FOR SELECT "id", "name", "age" FROM "users"
+			WHERE "name" LIKE 'implicit_%' AND "age" >= p_age ORDER BY "name";
+		DBMS_SQL.RETURN_RESULT(c1);
+		OPEN c2 FOR SELECT COUNT(*) AS "total" FROM "users" WHERE "name" LIKE 'implicit_%';
+		DBMS_SQL.RETURN_RESULT(c2);
+	END;`).Error; err != nil {
+		t.Fatalf("failed to create procedure, got error: %v", err)
+	}
+	defer DB.Exec(`DROP PROCEDURE "implicit_users"`)
+
+	var found []User
+	if err := DB.Raw(`BEGIN "implicit_users"(?); END;`, 30).Scan(&found).Error; err != nil {
+		t.Fatalf("failed to scan implicit result, got error: %v", err)
+	}
+	if len(found) != 2 || found[0].Name != "implicit_2" || found[1].Name != "implicit_3" || found[1].Age != 40 {
+		t.Errorf("expected the users of the first implicit result, got %+v", found)
+	}
+
+	var rows []map[string]interface{}
+	if err := DB.Raw(`BEGIN "implicit_users"(?); END;`, 40).Find(&rows).Error; err != nil {
+		t.Fatalf("failed to find implicit result, got error: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["name"] != "implicit_3" {
+		t.Errorf("expected one row of the first implicit result, got %+v", rows)
+	}
+
+	sqlRows, err := DB.Raw(`BEGIN "implicit_users"(?); END;`, 0).Rows()
+	if err != nil {
+		t.Fatalf("failed to query implicit results, got error: %v", err)
+	}
+	defer sqlRows.Close()
+	count := 0
+	for sqlRows.Next() {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("expected 3 rows in the first implicit result, got %d", count)
+	}
+	if !sqlRows.NextResultSet() {
+		t.Fatalf("expected a second implicit result, got error: %v", sqlRows.Err())
+	}
+	var total int
+	if !sqlRows.Next() {
+		t.Fatalf("expected a row in the second implicit result, got error: %v", sqlRows.Err())
+	}
+	if err := sqlRows.Scan(&total); err != nil || total != 3 {
+		t.Errorf("expected a total of 3, got %d, error: %v", total, err)
+	}
+
+	err = DB.Raw(`BEGIN NULL; END;`).Scan(&found).Error
+	if !errors.Is(err, oracle.ErrNoImplicitResult) {
+		t.Errorf("expected ErrNoImplicitResult for a block without results, got %v", err)
+	}
+}
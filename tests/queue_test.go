@@ -0,0 +1,177 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+
+	"gorm.io/gorm"
+)
+
+type QueueJob struct {
+	ID      uint
+	Queue   string
+	Payload string
+}
+
+func TestDequeue(t *testing.T) {
+	DB.Migrator().DropTable(&QueueJob{})
+	if err := DB.AutoMigrate(&QueueJob{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&QueueJob{})
+
+	jobs := []QueueJob{
+		{Queue: "mail", Payload: "a"},
+		{Queue: "mail", Payload: "b"},
+		{Queue: "mail", Payload: "c"},
+		{Queue: "sms", Payload: "d"},
+	}
+	if err := DB.Create(&jobs).Error; err != nil {
+		t.Fatalf("failed to create jobs, got error: %v", err)
+	}
+	mail := func() *gorm.DB {
+		return DB.Where("\"queue\" = ?", "mail").Order("\"id\"")
+	}
+
+	var failed []QueueJob
+	errHandler := errors.New("handler failed")
+	if _, err := oracle.Dequeue(mail(), &failed, 2, func(tx *gorm.DB) error {
+		return errHandler
+	}); !errors.Is(err, errHandler) {
+		t.Fatalf("expected the handler error, got %v", err)
+	}
+	var count int64
+	DB.Model(&QueueJob{}).Count(&count)
+	if count != 4 {
+		t.Fatalf("expected the jobs of a failed handler to be kept, got %d jobs", count)
+	}
+
+	var taken []QueueJob
+	n, err := oracle.Dequeue(mail(), &taken, 2, func(tx *gorm.DB) error {
+		// a concurrent consumer skips the rows locked by this one
+		var others []QueueJob
+		m, err := oracle.Dequeue(mail(), &others, 2, func(tx *gorm.DB) error { return nil })
+		if err != nil {
+			return err
+		}
+		if m != 1 || others[0].Payload != "c" {
+			t.Errorf("expected the concurrent consumer to take job c, got %d jobs %+v", m, others)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to dequeue, got error: %v", err)
+	}
+	if n != 2 || len(taken) != 2 || taken[0].Payload != "a" || taken[1].Payload != "b" {
+		t.Fatalf("expected jobs a and b, got %d jobs %+v", n, taken)
+	}
+
+	var remaining []QueueJob
+	DB.Order("\"id\"").Find(&remaining)
+	if len(remaining) != 1 || remaining[0].Payload != "d" {
+		t.Fatalf("expected only job d to remain, got %+v", remaining)
+	}
+
+	n, err = oracle.Dequeue(mail(), &taken, 2, func(tx *gorm.DB) error {
+		t.Errorf("handler should not be called for an empty queue")
+		return nil
+	})
+	if err != nil || n != 0 || len(taken) != 0 {
+		t.Fatalf("expected an empty queue, got %d jobs %+v, error %v", n, taken, err)
+	}
+}
+
+func TestDequeueConcurrentConsumers(t *testing.T) {
+	DB.Migrator().DropTable(&QueueJob{})
+	if err := DB.AutoMigrate(&QueueJob{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&QueueJob{})
+
+	jobs := []QueueJob{
+		{Queue: "mail", Payload: "a"},
+		{Queue: "mail", Payload: "b"},
+		{Queue: "mail", Payload: "c"},
+		{Queue: "mail", Payload: "d"},
+		{Queue: "mail", Payload: "e"},
+	}
+	if err := DB.Create(&jobs).Error; err != nil {
+		t.Fatalf("failed to create jobs, got error: %v", err)
+	}
+	mail := func() *gorm.DB {
+		return DB.Where("\"queue\" = ?", "mail").Order("\"id\"")
+	}
+
+	locked, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		var first []QueueJob
+		_, err := oracle.Dequeue(mail(), &first, 2, func(tx *gorm.DB) error {
+			close(locked)
+			<-release
+			return nil
+		})
+		done <- err
+	}()
+
+	select {
+	case <-locked:
+	case err := <-done:
+		t.Fatalf("the first consumer returned before taking its jobs, got error: %v", err)
+	}
+
+	// The first consumer holds the locks of jobs a and b only, so the
+	// second one takes the job right after its batch
+	var second []QueueJob
+	n, err := oracle.Dequeue(mail(), &second, 2, func(tx *gorm.DB) error { return nil })
+	close(release)
+	if err != nil {
+		t.Fatalf("failed to dequeue, got error: %v", err)
+	}
+	if n != 2 || second[0].Payload != "c" || second[1].Payload != "d" {
+		t.Errorf("expected the second consumer to take jobs c and d, got %d jobs %+v", n, second)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("the first consumer failed, got error: %v", err)
+	}
+}
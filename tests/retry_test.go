@@ -0,0 +1,223 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	. "github.com/oracle-samples/gorm-oracle/tests/utils"
+
+	"gorm.io/gorm"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := &oracle.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	transient := &oracle.Error{Code: 3113, Err: errors.New("ORA-03113: end-of-file on communication channel")}
+
+	attempts := 0
+	err := policy.Do(context.Background(), func() error {
+		if attempts++; attempts < 3 {
+			return transient
+		}
+		return nil
+	})
+	if err != nil || attempts != 3 {
+		t.Errorf("expected success after 3 attempts, got %v after %v attempts", err, attempts)
+	}
+
+	attempts = 0
+	err = policy.Do(context.Background(), func() error {
+		attempts++
+		return &oracle.Error{Code: 1, Err: errors.New("ORA-00001: unique constraint violated")}
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("expected no retry for ORA-00001, got %v after %v attempts", err, attempts)
+	}
+
+	db, err := openTestDBWithOptions(&oracle.Config{RetryPolicy: policy}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	user := GetUser("retry_policy", Config{})
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user, got error %v", err)
+	}
+
+	var found User
+	if err := db.First(&found, user.ID).Error; err != nil || found.Name != user.Name {
+		t.Errorf("failed to query with a retry policy, got %v, %v", found.Name, err)
+	}
+
+	rows, err := db.Model(&User{}).Where("\"id\" = ?", user.ID).Rows()
+	if err != nil {
+		t.Fatalf("failed to query rows with a retry policy, got error %v", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		t.Errorf("expected a row")
+	}
+}
+
+func TestRetryTransactionOnDeadlock(t *testing.T) {
+	deadlock := &oracle.Error{Code: 60, Err: errors.New("ORA-00060: deadlock detected while waiting for resource")}
+	if !errors.Is(deadlock, oracle.ErrDeadlock) || errors.Is(deadlock, oracle.ErrLockTimeout) {
+		t.Errorf("expected the error to match ErrDeadlock only")
+	}
+
+	users := []User{*GetUser("deadlock_a", Config{}), *GetUser("deadlock_b", Config{})}
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error %v", err)
+	}
+
+	policy := &oracle.RetryPolicy{MaxAttempts: 3, InitialBackoff: 10 * time.Millisecond}
+	var (
+		wg       sync.WaitGroup
+		locked   sync.WaitGroup
+		attempts [2]int
+		errs     [2]error
+	)
+	locked.Add(2)
+	for i := range users {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			first, second := users[i], users[1-i]
+			errs[i] = oracle.RetryTransaction(DB, policy, func(tx *gorm.DB) error {
+				attempts[i]++
+				if err := tx.Model(&User{}).Where("\"id\" = ?", first.ID).Update("age", gorm.Expr("\"age\" + 1")).Error; err != nil {
+					return err
+				}
+				if attempts[i] == 1 {
+					// Wait until both transactions hold their first lock
+					locked.Done()
+					locked.Wait()
+				}
+				return tx.Model(&User{}).Where("\"id\" = ?", second.ID).Update("age", gorm.Expr("\"age\" + 1")).Error
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("expected transaction %v to succeed after retrying, got error %v", i, err)
+		}
+	}
+	if attempts[0]+attempts[1] < 3 {
+		t.Errorf("expected one transaction to be retried after the deadlock, got attempts %v", attempts)
+	}
+
+	var updated []User
+	DB.Order("\"id\"").Find(&updated, []uint{users[0].ID, users[1].ID})
+	for i, user := range updated {
+		if user.Age != users[i].Age+2 {
+			t.Errorf("expected age %v, got %v", users[i].Age+2, user.Age)
+		}
+	}
+}
+
+func TestFailoverErrors(t *testing.T) {
+	var reported []*oracle.FailoverError
+	db, err := openTestDBWithOptions(&oracle.Config{Failover: &oracle.Failover{
+		OnFailover: func(ctx context.Context, err *oracle.FailoverError) {
+			reported = append(reported, err)
+		},
+	}}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	// Statements fail with the injected error, as if their session failed over
+	var inject error
+	injectFailover := func(tx *gorm.DB) {
+		if inject != nil {
+			tx.AddError(inject)
+			inject = nil
+		}
+	}
+	db.Callback().Query().Before("gorm:query").Register("test:failover", injectFailover)
+	db.Callback().Update().Before("gorm:update").Register("test:failover", injectFailover)
+
+	user := GetUser("failover", Config{})
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user, got error %v", err)
+	}
+
+	inject = &oracle.Error{Code: 25401, Err: errors.New("ORA-25401: can not continue fetches")}
+	err = db.First(&User{}, user.ID).Error
+	var failoverErr *oracle.FailoverError
+	if !errors.As(err, &failoverErr) || !errors.Is(err, oracle.ErrFailover) || oracle.ErrorCode(err) != 25401 {
+		t.Fatalf("expected a failover error for ORA-25401, got %v", err)
+	}
+	if !failoverErr.Retryable || failoverErr.InTransaction {
+		t.Errorf("expected a failed query to be retryable, got %+v", failoverErr)
+	}
+
+	inject = &oracle.Error{Code: 3113, Err: errors.New("ORA-03113: end-of-file on communication channel")}
+	err = db.Session(&gorm.Session{SkipDefaultTransaction: true}).Model(user).Update("age", 30).Error
+	if !errors.As(err, &failoverErr) || failoverErr.Retryable {
+		t.Errorf("expected a write committed on its own to have an unknown outcome, got %v", err)
+	}
+
+	attempts := 0
+	err = oracle.RetryTransaction(db, &oracle.RetryPolicy{InitialBackoff: time.Millisecond}, func(tx *gorm.DB) error {
+		if attempts++; attempts == 1 {
+			inject = &oracle.Error{Code: 25402, Err: errors.New("ORA-25402: transaction must roll back")}
+		}
+		return tx.Model(user).Update("age", 40).Error
+	})
+	if err != nil || attempts != 2 {
+		t.Errorf("expected the failed over transaction to run again, got %v after %d attempts", err, attempts)
+	}
+
+	if len(reported) != 3 || !reported[2].InTransaction || !reported[2].Retryable {
+		t.Errorf("expected 3 failovers to be reported, got %+v", reported)
+	}
+
+	var found User
+	if err := db.First(&found, user.ID).Error; err != nil || found.Age != 40 {
+		t.Errorf("expected the retried transaction to update the user, got age %v, error %v", found.Age, err)
+	}
+}
@@ -371,3 +371,78 @@ func TestScanToEmbedded(t *testing.T) {
 	err := DB.Raw("SELECT * FROM \"users\" INNER JOIN \"users\" \"manager\" ON \"users\".\"manager_id\" = \"manager\".\"id\" WHERE \"users\".\"id\" = ?", user.ID).Scan(&user2).Error
 	tests.AssertEqual(t, err, nil)
 }
+
+func TestScanNumbersIntoMap(t *testing.T) {
+	query := `SELECT CAST(9007199254740993 AS NUMBER) AS "big_id",
+		COUNT(*) AS "total",
+		CAST(12.5 AS NUMBER(10,2)) AS "price",
+		CAST(123456789012345678901234567890 AS NUMBER) AS "huge",
+		CAST(NULL AS NUMBER) AS "missing"
+		FROM DUAL`
+
+	scans := map[string]func(dest interface{}) error{
+		"Find": func(dest interface{}) error { return DB.Raw(query).Find(dest).Error },
+		"Scan": func(dest interface{}) error { return DB.Raw(query).Scan(dest).Error },
+		"ScanRows": func(dest interface{}) error {
+			rows, err := DB.Raw(query).Rows()
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			var results []map[string]interface{}
+			for rows.Next() {
+				row := map[string]interface{}{}
+				if err := DB.ScanRows(rows, &row); err != nil {
+					return err
+				}
+				results = append(results, row)
+			}
+			*dest.(*[]map[string]interface{}) = results
+			return rows.Err()
+		},
+	}
+	for name, scan := range scans {
+		t.Run(name, func(t *testing.T) {
+			var results []map[string]interface{}
+			if err := scan(&results); err != nil {
+				t.Fatalf("failed to query numbers, got error %v", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("expected 1 row, got %v", len(results))
+			}
+
+			row := results[0]
+			if v, ok := row["big_id"].(int64); !ok || v != 9007199254740993 {
+				t.Errorf("expected big_id to be int64 without precision loss, got %#v", row["big_id"])
+			}
+			if v, ok := row["total"].(int64); !ok || v != 1 {
+				t.Errorf("expected total to be int64, got %#v", row["total"])
+			}
+			if v, ok := row["price"].(string); !ok || v != "12.5" {
+				t.Errorf("expected price to keep its decimal digits, got %#v", row["price"])
+			}
+			if v, ok := row["huge"].(string); !ok || v != "123456789012345678901234567890" {
+				t.Errorf("expected huge to be kept as string, got %#v", row["huge"])
+			}
+			if row["missing"] != nil {
+				t.Errorf("expected missing to be nil, got %#v", row["missing"])
+			}
+		})
+	}
+
+	single := map[string]interface{}{}
+	if err := DB.Raw(`SELECT COUNT(*) AS "total" FROM DUAL`).Take(single).Error; err != nil {
+		t.Fatalf("failed to query number into map, got error %v", err)
+	}
+	if _, ok := single["total"].(int64); !ok {
+		t.Errorf("expected total to be int64, got %#v", single["total"])
+	}
+
+	scanned := map[string]interface{}{}
+	if err := DB.Raw(`SELECT COUNT(*) AS "total" FROM DUAL`).Scan(&scanned).Error; err != nil {
+		t.Fatalf("failed to scan number into map, got error %v", err)
+	}
+	if _, ok := scanned["total"].(int64); !ok {
+		t.Errorf("expected total to be int64, got %#v", scanned["total"])
+	}
+}
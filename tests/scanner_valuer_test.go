@@ -53,6 +53,7 @@ import (
 	"math"
 
 	"github.com/godror/godror"
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"gorm.io/gorm"
@@ -787,3 +788,108 @@ func TestStringsSliceWrongType(t *testing.T) {
 		t.Errorf("expected error for wrong type, got nil")
 	}
 }
+
+// PointerCode implements driver.Valuer with a pointer receiver
+type PointerCode struct {
+	Code string
+}
+
+func (c *PointerCode) Value() (driver.Value, error) {
+	return c.Code, nil
+}
+
+func (c *PointerCode) Scan(value interface{}) error {
+	var code sql.NullString
+	if err := code.Scan(value); err != nil {
+		return err
+	}
+	c.Code = code.String
+	return nil
+}
+
+type Priority int
+
+type NullKinds struct {
+	ID       uint
+	Name     string
+	String   sql.NullString
+	Int64    sql.NullInt64
+	Int32    sql.NullInt32
+	Int16    sql.NullInt16
+	Byte     sql.NullByte
+	Float64  sql.NullFloat64
+	Bool     sql.NullBool
+	Time     sql.NullTime
+	Generic  sql.Null[string] `gorm:"type:VARCHAR2(100)"`
+	Code     *PointerCode     `gorm:"type:VARCHAR2(20)"`
+	Priority Priority
+}
+
+func TestBulkCreateNullKinds(t *testing.T) {
+	DB.Migrator().DropTable(&NullKinds{})
+	if err := DB.AutoMigrate(&NullKinds{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&NullKinds{})
+
+	now := time.Now().Truncate(time.Second)
+	newRows := func(prefix string) []NullKinds {
+		return []NullKinds{
+			{
+				Name:     prefix + "valid",
+				String:   sql.NullString{String: "string", Valid: true},
+				Int64:    sql.NullInt64{Int64: 1 << 40, Valid: true},
+				Int32:    sql.NullInt32{Int32: 32, Valid: true},
+				Int16:    sql.NullInt16{Int16: 16, Valid: true},
+				Byte:     sql.NullByte{Byte: 8, Valid: true},
+				Float64:  sql.NullFloat64{Float64: 1.5, Valid: true},
+				Bool:     sql.NullBool{Bool: true, Valid: true},
+				Time:     sql.NullTime{Time: now, Valid: true},
+				Generic:  sql.Null[string]{V: "generic", Valid: true},
+				Code:     &PointerCode{Code: "code"},
+				Priority: 3,
+			},
+			{Name: prefix + "null"},
+		}
+	}
+
+	stmt := DB.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true}).
+		Clauses(oracle.InsertOnly()).Create(newRows("dry_")).Statement
+	for _, v := range stmt.Vars {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			t.Fatalf("expected an array per column, got %#v", v)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			elem := rv.Index(i).Interface()
+			if _, ok := elem.(driver.Valuer); ok && reflect.TypeOf(elem).PkgPath() != "database/sql" {
+				t.Errorf("expected the arrays to bind the values of the Valuers, got %#v", elem)
+			}
+		}
+	}
+
+	for i, tx := range []*gorm.DB{DB, DB.Clauses(oracle.InsertOnly())} {
+		prefix := fmt.Sprintf("bulk%d_", i)
+		rows := newRows(prefix)
+		if err := tx.Create(&rows).Error; err != nil {
+			t.Fatalf("failed to create, got error: %v", err)
+		}
+
+		var results []NullKinds
+		if err := DB.Where("\"name\" LIKE ?", prefix+"%").Order("\"name\" DESC").Find(&results).Error; err != nil {
+			t.Fatalf("failed to query, got error: %v", err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("expected 2 rows, got %d", len(results))
+		}
+		valid, null := results[0], results[1]
+		valid.ID, valid.Time.Time = rows[0].ID, valid.Time.Time.In(now.Location())
+		if !reflect.DeepEqual(valid, rows[0]) {
+			t.Errorf("expected %+v, got %+v", rows[0], valid)
+		}
+		if null.String.Valid || null.Int64.Valid || null.Int32.Valid || null.Int16.Valid || null.Byte.Valid ||
+			null.Float64.Valid || null.Bool.Valid || null.Time.Valid || null.Generic.Valid || null.Priority != 0 {
+			t.Errorf("expected NULL columns, got %+v", null)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	"gorm.io/gorm"
+)
+
+func TestSequenceAllocator(t *testing.T) {
+	type Shipment struct {
+		ID   int64 `gorm:"primaryKey;autoIncrement:false"`
+		Code string
+	}
+
+	DB.Migrator().DropTable(&Shipment{})
+	if err := DB.AutoMigrate(&Shipment{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+
+	DB.Exec("DROP SEQUENCE shipments_seq")
+	DB.Exec("DROP SEQUENCE shipments_block_seq")
+	if err := DB.Exec("CREATE SEQUENCE shipments_seq CACHE 100").Error; err != nil {
+		t.Fatalf("failed to create sequence, got error: %v", err)
+	}
+	if err := DB.Exec("CREATE SEQUENCE shipments_block_seq INCREMENT BY 10").Error; err != nil {
+		t.Fatalf("failed to create sequence, got error: %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Exec("DROP SEQUENCE shipments_seq")
+		DB.Exec("DROP SEQUENCE shipments_block_seq")
+	})
+
+	allocators := map[string]*oracle.SequenceAllocator{
+		"Query":            {Sequence: "shipments_seq", BlockSize: 16},
+		"IncrementByBlock": {Sequence: "shipments_block_seq", BlockSize: 10, IncrementByBlock: true},
+	}
+
+	for name, allocator := range allocators {
+		t.Run(name, func(t *testing.T) {
+			shipments := make([]Shipment, 25)
+			for i := range shipments {
+				shipments[i].Code = name
+			}
+			if err := allocator.Assign(DB, &shipments); err != nil {
+				t.Fatalf("failed to assign IDs, got error: %v", err)
+			}
+
+			seen := map[int64]bool{}
+			for _, shipment := range shipments {
+				if shipment.ID == 0 || seen[shipment.ID] {
+					t.Fatalf("expected unique non-zero IDs, got %d", shipment.ID)
+				}
+				seen[shipment.ID] = true
+			}
+
+			stmt := DB.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true}).Create(&shipments).Statement
+			if sql := stmt.SQL.String(); strings.Contains(sql, "RETURNING") {
+				t.Errorf("expected no RETURNING clause with assigned IDs, got %s", sql)
+			}
+
+			if err := DB.Create(&shipments).Error; err != nil {
+				t.Fatalf("failed to create shipments, got error: %v", err)
+			}
+
+			var count int64
+			DB.Model(&Shipment{}).Where("\"code\" = ?", name).Count(&count)
+			if count != int64(len(shipments)) {
+				t.Errorf("expected %d shipments, got %d", len(shipments), count)
+			}
+
+			var wg sync.WaitGroup
+			ids := make([][]int64, 4)
+			for i := range ids {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					values, err := allocator.Allocate(DB, 7)
+					if err != nil {
+						t.Errorf("failed to allocate IDs, got error: %v", err)
+					}
+					ids[i] = values
+				}(i)
+			}
+			wg.Wait()
+
+			for _, values := range ids {
+				for _, id := range values {
+					if seen[id] {
+						t.Errorf("ID %d was allocated twice", id)
+					}
+					seen[id] = true
+				}
+			}
+		})
+	}
+}
+
+func TestSequenceAllocatorValidation(t *testing.T) {
+	allocator := &oracle.SequenceAllocator{Sequence: "dual; DROP TABLE users"}
+	if _, err := allocator.Next(DB); err == nil || !strings.Contains(err.Error(), "invalid sequence name") {
+		t.Errorf("expected an invalid sequence name error, got %v", err)
+	}
+
+	DB.Exec("DROP SEQUENCE shipments_step_seq")
+	if err := DB.Exec("CREATE SEQUENCE shipments_step_seq INCREMENT BY 5").Error; err != nil {
+		t.Fatalf("failed to create sequence, got error: %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Exec("DROP SEQUENCE shipments_step_seq")
+	})
+
+	allocator = &oracle.SequenceAllocator{Sequence: "shipments_step_seq", BlockSize: 10, IncrementByBlock: true}
+	if _, err := allocator.Next(DB); err == nil || !strings.Contains(err.Error(), "INCREMENT BY 5") {
+		t.Errorf("expected an INCREMENT BY mismatch error, got %v", err)
+	}
+
+	allocator = &oracle.SequenceAllocator{Sequence: "shipments_step_seq", BlockSize: 5, IncrementByBlock: true}
+	first, err := allocator.Next(DB)
+	if err != nil {
+		t.Fatalf("failed to allocate an ID, got error: %v", err)
+	}
+	if second, err := allocator.Next(DB); err != nil || second != first+1 {
+		t.Errorf("expected the next value of the block, got %d, %v", second, err)
+	}
+}
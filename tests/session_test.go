@@ -0,0 +1,252 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+
+	"gorm.io/gorm"
+)
+
+func TestWithSessionContext(t *testing.T) {
+	clientIdentifier := func(tx *gorm.DB) string {
+		var identifier *string
+		if err := tx.Raw("SELECT SYS_CONTEXT('USERENV', 'CLIENT_IDENTIFIER') FROM DUAL").Scan(&identifier).Error; err != nil {
+			t.Fatalf("failed to read the client identifier, got error: %v", err)
+		}
+		if identifier == nil {
+			return ""
+		}
+		return *identifier
+	}
+
+	err := oracle.WithSessionContext(DB, oracle.SessionContext{ClientIdentifier: "session-context-user"}, func(tx *gorm.DB) error {
+		if identifier := clientIdentifier(tx); identifier != "session-context-user" {
+			t.Errorf("expected the client identifier to be set, got %q", identifier)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to run with the session context, got error: %v", err)
+	}
+
+	// The connection is back in the pool without the identifier
+	DB.Connection(func(tx *gorm.DB) error {
+		if identifier := clientIdentifier(tx); identifier != "" {
+			t.Errorf("expected the client identifier to be reset, got %q", identifier)
+		}
+		return nil
+	})
+
+	err = oracle.WithSessionContext(DB, oracle.SessionContext{
+		Setter:     "pkg.set_attribute; DROP TABLE users",
+		Attributes: map[string]string{"TENANT_ID": "1"},
+	}, func(tx *gorm.DB) error {
+		t.Errorf("expected an invalid setter to be rejected")
+		return nil
+	})
+	if err == nil {
+		t.Errorf("expected an error for an invalid setter")
+	}
+}
+
+func TestWithSessionContextAttributes(t *testing.T) {
+	if err := DB.Exec("CREATE OR REPLACE CONTEXT gorm_test_ctx USING gorm_test_ctx_pkg").Error; err != nil {
+		t.Skipf("cannot create an application context: %v", err)
+	}
+	defer DB.Exec("DROP CONTEXT gorm_test_ctx")
+
+	err := DB.Exec(`CREATE OR REPLACE PACKAGE gorm_test_ctx_pkg AS
+  PROCEDURE set_attribute(p_name VARCHAR2, p_value VARCHAR2);
+END;`).Error
+	if err == nil {
+		err = DB.Exec(`CREATE OR REPLACE PACKAGE BODY gorm_test_ctx_pkg AS
+  PROCEDURE set_attribute(p_name VARCHAR2, p_value VARCHAR2) IS
+  BEGIN
+    DBMS_SESSION.SET_CONTEXT('GORM_TEST_CTX', p_name, p_value);
+  END;
+END;`).Error
+	}
+	if err != nil {
+		t.Fatalf("failed to create the context package, got error: %v", err)
+	}
+	defer DB.Exec("DROP PACKAGE gorm_test_ctx_pkg")
+
+	tenant := func(tx *gorm.DB) string {
+		var value *string
+		if err := tx.Raw("SELECT SYS_CONTEXT('GORM_TEST_CTX', 'TENANT_ID') FROM DUAL").Scan(&value).Error; err != nil {
+			t.Fatalf("failed to read the context, got error: %v", err)
+		}
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+
+	sc := oracle.SessionContext{
+		Setter:     "gorm_test_ctx_pkg.set_attribute",
+		Attributes: map[string]string{"TENANT_ID": "42"},
+	}
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		return oracle.WithSessionContext(tx, sc, func(tx *gorm.DB) error {
+			if value := tenant(tx); value != "42" {
+				t.Errorf("expected the tenant to be set, got %q", value)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("failed to run with the session context, got error: %v", err)
+	}
+
+	DB.Connection(func(tx *gorm.DB) error {
+		if value := tenant(tx); value != "" {
+			t.Errorf("expected the tenant to be reset, got %q", value)
+		}
+		return nil
+	})
+}
+
+func TestWithPinnedSession(t *testing.T) {
+	var user string
+	if err := DB.Raw("SELECT USER FROM DUAL").Scan(&user).Error; err != nil {
+		t.Fatalf("failed to read the user, got error: %v", err)
+	}
+
+	sessionState := func(tx *gorm.DB) (format, schema string) {
+		if err := tx.Raw("SELECT VALUE FROM NLS_SESSION_PARAMETERS WHERE PARAMETER = 'NLS_DATE_FORMAT'").Scan(&format).Error; err != nil {
+			t.Fatalf("failed to read the date format, got error: %v", err)
+		}
+		if err := tx.Raw("SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM DUAL").Scan(&schema).Error; err != nil {
+			t.Fatalf("failed to read the current schema, got error: %v", err)
+		}
+		return format, schema
+	}
+
+	var before string
+	err := DB.Connection(func(tx *gorm.DB) error {
+		before, _ = sessionState(tx)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read the session state, got error: %v", err)
+	}
+
+	state := oracle.SessionState{
+		Context:       oracle.SessionContext{ClientIdentifier: "pinned-session-user"},
+		NLS:           map[string]string{"nls_date_format": "YYYY-MM-DD\"T\"HH24:MI"},
+		CurrentSchema: user,
+	}
+	err = oracle.WithPinnedSession(DB, state, func(tx *gorm.DB) error {
+		format, schema := sessionState(tx)
+		if format != "YYYY-MM-DD\"T\"HH24:MI" {
+			t.Errorf("expected the date format to be set, got %q", format)
+		}
+		if schema != user {
+			t.Errorf("expected the current schema %q, got %q", user, schema)
+		}
+
+		var formatted string
+		if err := tx.Raw("SELECT TO_CHAR(DATE '2024-05-06') FROM DUAL").Scan(&formatted).Error; err != nil {
+			return err
+		}
+		if formatted != "2024-05-06T00:00" {
+			t.Errorf("expected the statements of the session to use the date format, got %q", formatted)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to run with the pinned session, got error: %v", err)
+	}
+
+	DB.Connection(func(tx *gorm.DB) error {
+		if format, _ := sessionState(tx); format != before {
+			t.Errorf("expected the date format %q to be restored, got %q", before, format)
+		}
+		return nil
+	})
+
+	err = oracle.WithPinnedSession(DB, oracle.SessionState{NLS: map[string]string{"NLS_SORT = BINARY, NLS_COMP": "x"}}, func(tx *gorm.DB) error {
+		t.Errorf("expected an invalid NLS parameter to be rejected")
+		return nil
+	})
+	if err == nil {
+		t.Errorf("expected an error for an invalid NLS parameter")
+	}
+}
+
+func TestWithPinnedSessionPanic(t *testing.T) {
+	db, err := openTestDBWithOptions(nil, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get the pool, got error %v", err)
+	}
+	defer sqlDB.Close()
+	// The next checkout gets the connection of the panicking session
+	sqlDB.SetMaxOpenConns(1)
+
+	func() {
+		defer func() {
+			if r := recover(); r != "tenant failure" {
+				t.Errorf("expected the panic to go on, got %v", r)
+			}
+		}()
+		oracle.WithPinnedSession(db, oracle.SessionState{
+			Context: oracle.SessionContext{ClientIdentifier: "panicking-tenant"},
+		}, func(tx *gorm.DB) error {
+			panic("tenant failure")
+		})
+	}()
+
+	db.Connection(func(tx *gorm.DB) error {
+		var identifier *string
+		if err := tx.Raw("SELECT SYS_CONTEXT('USERENV', 'CLIENT_IDENTIFIER') FROM DUAL").Scan(&identifier).Error; err != nil {
+			t.Fatalf("failed to read the client identifier, got error: %v", err)
+		}
+		if identifier != nil {
+			t.Errorf("expected the client identifier to be reset after the panic, got %q", *identifier)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,98 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+
+	"gorm.io/gorm"
+)
+
+type ShardedOrder struct {
+	CustomerID int64 `gorm:"primaryKey;autoIncrement:false;shardingkey"`
+	OrderID    int64 `gorm:"primaryKey;autoIncrement:false"`
+	Amount     int
+}
+
+func TestShardingKey(t *testing.T) {
+	if err := oracle.WithShardingKey(DB, 42).Exec("SELECT 1 FROM DUAL").Error; err == nil {
+		t.Errorf("expected a sharding key to require the Sharding option")
+	}
+
+	db, err := openTestDBWithOptions(&oracle.Config{Sharding: &oracle.Sharding{}}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	if err := oracle.WithShardingKey(db, time.Now()).Exec("SELECT 1 FROM DUAL").Error; err == nil {
+		t.Errorf("expected a time sharding key to be rejected")
+	}
+	if err := oracle.WithShardingKey(db).Exec("SELECT 1 FROM DUAL").Error; err == nil {
+		t.Errorf("expected an empty sharding key to be rejected")
+	}
+
+	var sharded int64
+	db.Raw("SELECT COUNT(*) FROM USER_TABLES WHERE SHARDED = 'Y' AND TABLE_NAME = 'sharded_orders'").Scan(&sharded)
+	if sharded == 0 {
+		t.Skip("the sharded_orders table is not sharded")
+	}
+
+	orders := []ShardedOrder{{CustomerID: 42, OrderID: 1, Amount: 10}, {CustomerID: 42, OrderID: 2, Amount: 20}}
+	if err := db.Create(&orders).Error; err != nil {
+		t.Fatalf("failed to create orders on their shard, got error %v", err)
+	}
+	defer db.Where(&ShardedOrder{CustomerID: 42}).Delete(&ShardedOrder{})
+
+	var found []ShardedOrder
+	if err := db.Where(&ShardedOrder{CustomerID: 42}).Order("\"order_id\"").Find(&found).Error; err != nil || len(found) != 2 {
+		t.Errorf("expected 2 orders on the shard of the customer, got %d, error %v", len(found), err)
+	}
+
+	var total int64
+	if err := oracle.WithShardingKey(db, 42).Model(&ShardedOrder{}).Select("SUM(\"amount\")").Scan(&total).Error; err != nil || total != 30 {
+		t.Errorf("expected a total of 30 on the shard of the customer, got %d, error %v", total, err)
+	}
+
+	var count int64
+	if err := db.Model(&ShardedOrder{}).Where("\"amount\" > ?", 0).Count(&count).Error; err != nil || count < 2 {
+		t.Errorf("expected the cross-shard query to count the orders, got %d, error %v", count, err)
+	}
+}
@@ -46,6 +46,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"gorm.io/gorm/clause"
@@ -82,7 +83,7 @@ func TestSoftDelete(t *testing.T) {
 	}
 
 	sql = DB.Session(&gorm.Session{DryRun: true}).Table("user u").Select("name").Find(&User{}).Statement.SQL.String()
-	if !regexp.MustCompile(`SELECT .name. FROM user u WHERE .u.\..deleted_at. IS NULL`).MatchString(sql) {
+	if !regexp.MustCompile(`SELECT "name" FROM "user" "U" WHERE "U"."deleted_at" IS NULL`).MatchString(sql) {
 		t.Errorf("Table with escape character, got %v", sql)
 	}
 
@@ -321,3 +322,136 @@ func TestSoftDeletedRecordReinsert(t *testing.T) {
 		t.Errorf("should allow inserting new record after soft delete, got err %v", err)
 	}
 }
+
+func TestRowArchival(t *testing.T) {
+	type ArchivedOrder struct {
+		ID    uint
+		Code  string
+		State oracle.ArchiveState `gorm:"column:ORA_ARCHIVE_STATE"`
+	}
+
+	DB.Migrator().DropTable(&ArchivedOrder{})
+	if err := DB.AutoMigrate(&ArchivedOrder{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	if !DB.Migrator().HasColumn(&ArchivedOrder{}, "ORA_ARCHIVE_STATE") {
+		t.Fatalf("expected the table to be created with ROW ARCHIVAL")
+	}
+	// Migrating again must not try to enable ROW ARCHIVAL twice
+	if err := DB.AutoMigrate(&ArchivedOrder{}); err != nil {
+		t.Fatalf("failed to migrate again, got error: %v", err)
+	}
+
+	orders := []ArchivedOrder{{Code: "a"}, {Code: "b"}, {Code: "c"}}
+	if err := DB.Create(&orders).Error; err != nil {
+		t.Fatalf("failed to create orders, got error: %v", err)
+	}
+
+	if err := DB.Delete(&orders[0]).Error; err != nil {
+		t.Fatalf("failed to archive order, got error: %v", err)
+	}
+
+	var active []ArchivedOrder
+	DB.Order("\"id\"").Find(&active)
+	if len(active) != 2 || active[0].Code != "b" {
+		t.Errorf("expected the archived order to be hidden, got %+v", active)
+	}
+
+	err := oracle.WithArchivedRows(DB, func(tx *gorm.DB) error {
+		var all []ArchivedOrder
+		if err := tx.Select("id", "code", "ORA_ARCHIVE_STATE").Order("\"id\"").Find(&all).Error; err != nil {
+			return err
+		}
+		if len(all) != 3 {
+			t.Fatalf("expected archived orders to be visible, got %+v", all)
+		}
+		if !all[0].State.Archived() || all[1].State.Archived() {
+			t.Errorf("unexpected archive states %q and %q", all[0].State, all[1].State)
+		}
+
+		// Restore the archived order
+		return tx.Model(&all[0]).Update("ORA_ARCHIVE_STATE", oracle.ArchiveStateActive).Error
+	})
+	if err != nil {
+		t.Fatalf("failed to read archived orders, got error: %v", err)
+	}
+
+	var count int64
+	DB.Model(&ArchivedOrder{}).Count(&count)
+	if count != 3 {
+		t.Errorf("expected the restored order to be visible, got %d orders", count)
+	}
+
+	if err := DB.Unscoped().Delete(&orders[1]).Error; err != nil {
+		t.Fatalf("failed to delete order, got error: %v", err)
+	}
+	oracle.WithArchivedRows(DB, func(tx *gorm.DB) error {
+		return tx.Model(&ArchivedOrder{}).Count(&count).Error
+	})
+	if count != 2 {
+		t.Errorf("expected the unscoped delete to remove the row, got %d orders", count)
+	}
+}
+
+func TestSoftDeleteWithFlag(t *testing.T) {
+	type FlaggedOrder struct {
+		ID      uint
+		Code    string
+		Deleted oracle.DeletedFlag `gorm:"type:CHAR(1);default:'N';not null"`
+	}
+
+	DB.Migrator().DropTable(&FlaggedOrder{})
+	if err := DB.AutoMigrate(&FlaggedOrder{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+
+	orders := []FlaggedOrder{{Code: "a"}, {Code: "b"}, {Code: "c"}}
+	if err := DB.Create(&orders).Error; err != nil {
+		t.Fatalf("failed to create orders, got error: %v", err)
+	}
+
+	if err := DB.Delete(&orders[0]).Error; err != nil {
+		t.Fatalf("failed to soft delete order, got error: %v", err)
+	}
+
+	var active []FlaggedOrder
+	DB.Order("\"id\"").Find(&active)
+	if len(active) != 2 || active[0].Code != "b" {
+		t.Errorf("expected the deleted order to be hidden, got %+v", active)
+	}
+
+	var deleted FlaggedOrder
+	if err := DB.Unscoped().First(&deleted, orders[0].ID).Error; err != nil {
+		t.Fatalf("failed to find deleted order, got error: %v", err)
+	}
+	if deleted.Deleted != oracle.DeletedFlagDeleted {
+		t.Errorf("expected the order to be flagged as deleted, got %q", deleted.Deleted)
+	}
+
+	// The flag condition is not enough to update every row
+	err := DB.Model(&FlaggedOrder{}).Update("code", "x").Error
+	if !errors.Is(err, gorm.ErrMissingWhereClause) {
+		t.Errorf("expected ErrMissingWhereClause, got %v", err)
+	}
+	err = DB.Model(&FlaggedOrder{}).Where("\"deleted\" = ?", "N").Update("code", "x").Error
+	if !errors.Is(err, gorm.ErrMissingWhereClause) {
+		t.Errorf("expected ErrMissingWhereClause, got %v", err)
+	}
+
+	if err := DB.Model(&FlaggedOrder{}).Where("\"code\" IN ?", []string{"a", "b"}).Update("code", "y").Error; err != nil {
+		t.Fatalf("failed to update orders, got error: %v", err)
+	}
+	DB.Unscoped().First(&deleted, orders[0].ID)
+	if deleted.Code != "a" {
+		t.Errorf("expected the deleted order not to be updated, got %q", deleted.Code)
+	}
+
+	if err := DB.Unscoped().Delete(&orders[1]).Error; err != nil {
+		t.Fatalf("failed to delete order, got error: %v", err)
+	}
+	var count int64
+	DB.Unscoped().Model(&FlaggedOrder{}).Count(&count)
+	if count != 2 {
+		t.Errorf("expected the unscoped delete to remove the row, got %d orders", count)
+	}
+}
@@ -39,7 +39,11 @@
 package tests
 
 import (
+	"bytes"
+	gosql "database/sql"
 	"fmt"
+	"log"
+	"math"
 	"regexp"
 	"strings"
 	"testing"
@@ -50,6 +54,7 @@ import (
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
 )
 
 func TestRow(t *testing.T) {
@@ -453,11 +458,83 @@ func TestExplainSQL(t *testing.T) {
 
 	stmt = dryRunDB.Model(&user).Where("id = ?", 1).Updates(map[string]interface{}{"age": ageFloat(0.12345678)}).Statement
 	sql = DB.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)
-	if !regexp.MustCompile(`.*age.*=0.123457,`).MatchString(sql) {
+	if !regexp.MustCompile(`.*age.*=0.12345678,`).MatchString(sql) {
 		t.Errorf("Failed to generate sql, got %v", sql)
 	}
 }
 
+func TestExplainPLSQLBinds(t *testing.T) {
+	name := "returned"
+	sql := DB.Dialector.Explain(
+		`BEGIN FORALL i IN 1..:1 INSERT INTO "t" VALUES (:2(i), ':3') RETURNING "name" INTO :3; :result := 1; END;`,
+		2, []string{"a", "b"}, gosql.Out{Dest: &name}, gosql.Named("result", 7),
+	)
+	expected := `BEGIN FORALL i IN 1..2 INSERT INTO "t" VALUES (['a', 'b'](i), ':3') RETURNING "name" INTO 'returned'; 7 := 1; END;`
+	if sql != expected {
+		t.Errorf("expected %v, got %v", expected, sql)
+	}
+}
+
+func TestExplainOracleLiterals(t *testing.T) {
+	ts := time.Date(2021, 10, 18, 19, 50, 9, 438000000, time.FixedZone("", 2*60*60))
+	sql := DB.Dialector.Explain(`SELECT :1 AS "ts", :2 AS "num" FROM dual WHERE :3 IS NULL`, ts, 0.12345678, gosql.NullTime{})
+	expected := `SELECT TO_TIMESTAMP_TZ('2021-10-18 19:50:09.438000000 +02:00', 'YYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM') AS "ts", 0.12345678 AS "num" FROM dual WHERE NULL IS NULL`
+	if sql != expected {
+		t.Fatalf("expected %v, got %v", expected, sql)
+	}
+
+	var (
+		got time.Time
+		num float64
+	)
+	if err := DB.Raw(sql).Row().Scan(&got, &num); err != nil {
+		t.Fatalf("failed to run explained SQL, got error %v", err)
+	}
+	if !got.Equal(ts) || num != 0.12345678 {
+		t.Errorf("expected %v and %v, got %v and %v", ts, 0.12345678, got, num)
+	}
+
+	if sql := DB.Dialector.Explain(":1 :2 :3", math.NaN(), math.Inf(-1), float32(0.1)); sql != "BINARY_DOUBLE_NAN -BINARY_DOUBLE_INFINITY 0.1" {
+		t.Errorf("unexpected float literals, got %v", sql)
+	}
+}
+
+func TestMaskedFieldLogging(t *testing.T) {
+	type MaskedAccount struct {
+		ID       uint
+		Name     string
+		Password string `gorm:"mask"`
+	}
+
+	var buf bytes.Buffer
+	db := DB.Session(&gorm.Session{Logger: logger.New(log.New(&buf, "", 0), logger.Config{LogLevel: logger.Info})})
+	db.Migrator().DropTable(&MaskedAccount{})
+	if err := db.AutoMigrate(&MaskedAccount{}); err != nil {
+		t.Fatalf("failed to migrate, got error %v", err)
+	}
+
+	buf.Reset()
+	account := MaskedAccount{Name: "masked", Password: "s3cr3t-value"}
+	if err := db.Create(&account).Error; err != nil {
+		t.Fatalf("failed to create, got error %v", err)
+	}
+	if err := db.Model(&account).Update("password", "n3w-s3cr3t").Error; err != nil {
+		t.Fatalf("failed to update, got error %v", err)
+	}
+	var found MaskedAccount
+	if err := db.Where(&MaskedAccount{Password: "n3w-s3cr3t"}).First(&found).Error; err != nil {
+		t.Fatalf("failed to query, got error %v", err)
+	}
+
+	logged := buf.String()
+	if strings.Contains(logged, "s3cr3t") {
+		t.Errorf("expected masked values not to be logged, got %v", logged)
+	}
+	if !strings.Contains(logged, "'***'") || !strings.Contains(logged, "'masked'") {
+		t.Errorf("expected masked placeholders and unmasked values in the log, got %v", logged)
+	}
+}
+
 func TestGroupConditions(t *testing.T) {
 	type Pizza struct {
 		ID   uint
@@ -710,6 +787,7 @@ func TestToSQL(t *testing.T) {
 	}
 
 	date, _ := time.ParseInLocation("2006-01-02", "2021-10-18", time.Local)
+	dateLiteral := `TO_TIMESTAMP_TZ('2021-10-18 00:00:00 ` + date.Format("-07:00") + `', 'YYYY-MM-DD HH24:MI:SS TZH:TZM')`
 
 	// find
 	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
@@ -818,7 +896,7 @@ func TestToSQL(t *testing.T) {
 	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
 		return tx.Model(&User{}).Create(user)
 	})
-	assertEqualSQL(t, `INSERT INTO "users" ("created_at","updated_at","deleted_at","name","age","birthday","company_id","manager_id","active") VALUES ('2021-10-18 00:00:00','2021-10-18 00:00:00',NULL,'foo',20,NULL,NULL,NULL,false) RETURNING "id" INTO .*`, sql)
+	assertEqualSQL(t, `INSERT INTO "users" ("created_at","updated_at","deleted_at","name","age","birthday","company_id","manager_id","active") VALUES (`+dateLiteral+`,`+dateLiteral+`,NULL,'foo',20,NULL,NULL,NULL,false) RETURNING "id" INTO .*`, sql)
 
 	// save
 	user = &User{Name: "foo", Age: 20}
@@ -827,7 +905,7 @@ func TestToSQL(t *testing.T) {
 	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
 		return tx.Model(&User{}).Save(user)
 	})
-	assertEqualSQL(t, `INSERT INTO "users" ("created_at","updated_at","deleted_at","name","age","birthday","company_id","manager_id","active") VALUES ('2021-10-18 00:00:00','2021-10-18 00:00:00',NULL,'foo',20,NULL,NULL,NULL,false) RETURNING "id" INTO .*`, sql)
+	assertEqualSQL(t, `INSERT INTO "users" ("created_at","updated_at","deleted_at","name","age","birthday","company_id","manager_id","active") VALUES (`+dateLiteral+`,`+dateLiteral+`,NULL,'foo',20,NULL,NULL,NULL,false) RETURNING "id" INTO .*`, sql)
 
 	// insert with explicit Table via clause.Insert
 	user = &User{Name: "bar", Age: 42}
@@ -836,11 +914,11 @@ func TestToSQL(t *testing.T) {
 	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
 		return tx.Clauses(clause.Insert{Table: clause.Table{Name: "custom_table"}}).Create(user)
 	})
-	assertEqualSQL(t, `INSERT INTO "custom_table" ("created_at","updated_at","deleted_at","name","age","birthday","company_id","manager_id","active") VALUES ('2021-10-18 00:00:00','2021-10-18 00:00:00',NULL,'bar',42,NULL,NULL,NULL,false) RETURNING "id" INTO .*`, sql)
+	assertEqualSQL(t, `INSERT INTO "custom_table" ("created_at","updated_at","deleted_at","name","age","birthday","company_id","manager_id","active") VALUES (`+dateLiteral+`,`+dateLiteral+`,NULL,'bar',42,NULL,NULL,NULL,false) RETURNING "id" INTO .*`, sql)
 	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
 		return tx.Clauses(clause.Insert{Table: clause.Table{Name: "custom_table"}}).Unscoped().Create(user)
 	})
-	assertEqualSQL(t, `INSERT INTO "custom_table" ("created_at","updated_at","deleted_at","name","age","birthday","company_id","manager_id","active") VALUES ('2021-10-18 00:00:00','2021-10-18 00:00:00',NULL,'bar',42,NULL,NULL,NULL,false) RETURNING "id" INTO .*`, sql)
+	assertEqualSQL(t, `INSERT INTO "custom_table" ("created_at","updated_at","deleted_at","name","age","birthday","company_id","manager_id","active") VALUES (`+dateLiteral+`,`+dateLiteral+`,NULL,'bar',42,NULL,NULL,NULL,false) RETURNING "id" INTO .*`, sql)
 
 	// updates
 	user = &User{Name: "bar", Age: 22}
@@ -849,7 +927,7 @@ func TestToSQL(t *testing.T) {
 	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
 		return tx.Model(&User{}).Where("id = ?", 100).Updates(user)
 	})
-	assertEqualSQL(t, `UPDATE "users" SET "created_at"='2021-10-18 00:00:00',"updated_at"='2021-10-18 19:50:09.438',"name"='bar',"age"=22 WHERE id = 100 AND "users"."deleted_at" IS NULL`, sql)
+	assertEqualSQL(t, `UPDATE "users" SET "created_at"=`+dateLiteral+`,"updated_at"='2021-10-18 19:50:09.438',"name"='bar',"age"=22 WHERE id = 100 AND "users"."deleted_at" IS NULL`, sql)
 
 	// UPDATE with explicit Table via clause.Update
 	sql = DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
@@ -907,7 +985,7 @@ func assertEqualSQL(t *testing.T, expected string, actually string) {
 	actually = replaceQuoteInSQL(actually)
 
 	// ignore updated_at value, because it's generated in Gorm internal, can't to mock value on update.
-	updatedAtRe := regexp.MustCompile(`(?i)"updated_at"=".+?"`)
+	updatedAtRe := regexp.MustCompile(`(?i)"updated_at"=(TO_TIMESTAMP_TZ\(".+?", ".+?"\)|".+?")`)
 	actually = updatedAtRe.ReplaceAllString(actually, `"updated_at"=?`)
 	expected = updatedAtRe.ReplaceAllString(expected, `"updated_at"=?`)
 
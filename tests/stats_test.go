@@ -0,0 +1,202 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	. "github.com/oracle-samples/gorm-oracle/tests/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSQLID(t *testing.T) {
+	if id := oracle.SQLID("select * from dual"); id != "a5ks9fhw2v9s1" {
+		t.Errorf("expected SQL_ID a5ks9fhw2v9s1, got %v", id)
+	}
+
+}
+
+func TestSQLStatsLogger(t *testing.T) {
+	var buf bytes.Buffer
+	db, err := openTestDBWithOptions(&oracle.Config{SQLStats: oracle.SQLStatsFetch}, &gorm.Config{
+		Logger: oracle.NewStatsLogger(logger.New(log.New(&buf, "", 0), logger.Config{LogLevel: logger.Info})),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	var users []User
+	stmt := db.Where("name", "sql_stats").Find(&users).Statement
+	stats, ok := oracle.SQLStatsFromContext(stmt.Context)
+	if !ok {
+		t.Fatalf("expected SQL statistics in the statement context")
+	}
+	if expected := oracle.SQLID(`SELECT * FROM "users" WHERE "name" = :1 AND "users"."deleted_at" IS NULL`); stats.SQLID != expected {
+		t.Errorf("expected SQL_ID %v, got %v", expected, stats.SQLID)
+	}
+	if stats.Err == nil && stats.Executions == 0 {
+		t.Errorf("expected executions in V$SQL, got %+v", stats)
+	}
+	if !strings.Contains(buf.String(), "/* sql_id="+stats.SQLID) {
+		t.Errorf("expected the SQL_ID in the log, got %v", buf.String())
+	}
+}
+
+func TestSQLStatsReusedStatement(t *testing.T) {
+	db, err := openTestDBWithOptions(&oracle.Config{SQLStats: oracle.SQLStatsID}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	var users []User
+	var count int64
+	tx := db.Model(&User{}).Where("name", "sql_stats")
+	if err := tx.Find(&users).Error; err != nil {
+		t.Fatalf("failed to find users, got error %v", err)
+	}
+	ctx := tx.Statement.Context
+	stats, ok := oracle.SQLStatsFromContext(ctx)
+	if !ok {
+		t.Fatalf("expected SQL statistics in the statement context")
+	}
+	findID := stats.SQLID
+
+	if err := tx.Count(&count).Error; err != nil {
+		t.Fatalf("failed to count users, got error %v", err)
+	}
+	if tx.Statement.Context != ctx {
+		t.Errorf("expected the statement context to be reused")
+	}
+	if stats.SQLID == "" || stats.SQLID == findID {
+		t.Errorf("expected the statistics of the COUNT statement, got %+v", stats)
+	}
+}
+
+func TestLongOpsWatcher(t *testing.T) {
+	var buf bytes.Buffer
+	threshold := 100 * time.Millisecond
+	db, err := openTestDBWithOptions(&oracle.Config{LongOps: &oracle.LongOpsWatcher{Threshold: threshold}}, &gorm.Config{
+		Logger: logger.New(log.New(&buf, "", 0), logger.Config{LogLevel: logger.Warn}),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	var count int64
+	query := "SELECT COUNT(*) FROM (SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= 5000000)"
+	started := time.Now()
+	if err := db.Raw(query).Scan(&count).Error; err != nil {
+		t.Fatalf("failed to run the query, got error %v", err)
+	}
+	if count != 5000000 {
+		t.Errorf("expected 5000000 rows, got %d", count)
+	}
+
+	// The watcher logs the progress, or why it cannot read it, once the
+	// query runs longer than the threshold
+	if time.Since(started) > 3*threshold && !strings.Contains(buf.String(), "(sql_id="+oracle.SQLID(query)+")") {
+		t.Errorf("expected the progress of the query in the log, got %v", buf.String())
+	}
+
+	// Rows are watched until they are closed, not only until the query
+	// returns its first rows
+	buf.Reset()
+	rowsQuery := "SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= 1000"
+	rows, err := db.Raw(rowsQuery).Rows()
+	if err != nil {
+		t.Fatalf("failed to query rows, got error %v", err)
+	}
+	time.Sleep(3 * threshold)
+	for rows.Next() {
+	}
+	if err := rows.Close(); err != nil {
+		t.Fatalf("failed to close rows, got error %v", err)
+	}
+	if !strings.Contains(buf.String(), "(sql_id="+oracle.SQLID(rowsQuery)+")") {
+		t.Errorf("expected the progress of the rows in the log, got %v", buf.String())
+	}
+
+	buf.Reset()
+	var users []User
+	if err := db.Where("name", "long_ops").Find(&users).Error; err != nil {
+		t.Fatalf("failed to find users, got error %v", err)
+	}
+	if strings.Contains(buf.String(), "sql_id=") {
+		t.Errorf("expected no progress for a short query, got %v", buf.String())
+	}
+}
+
+func TestConversionWarnings(t *testing.T) {
+	var buf bytes.Buffer
+	db, err := openTestDBWithOptions(&oracle.Config{ConversionWarnings: true}, &gorm.Config{
+		Logger: logger.New(log.New(&buf, "", 0), logger.Config{LogLevel: logger.Warn}),
+	})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+
+	var users []User
+	if err := db.Where("name", "conversion").Where(`"age" IN ?`, []int{18, 20}).Find(&users).Error; err != nil {
+		t.Fatalf("failed to find users, got error %v", err)
+	}
+	if strings.Contains(buf.String(), "implicit conversion") {
+		t.Errorf("expected no warning when the binds match the columns, got %v", buf.String())
+	}
+
+	// The query may fail with ORA-01722 on names that are not numbers
+	db.Where(`"name" = ?`, 42).Find(&users)
+	if !strings.Contains(buf.String(), `"users"."name" VARCHAR2(`) || !strings.Contains(buf.String(), "converts the column with TO_NUMBER") {
+		t.Errorf("expected a warning for a number compared to the name, got %v", buf.String())
+	}
+
+	buf.Reset()
+	if err := db.Model(&User{}).Where("age", "18").Update("active", true).Error; err != nil {
+		t.Fatalf("failed to update users, got error %v", err)
+	}
+	if !strings.Contains(buf.String(), `"users"."age" NUMBER`) || !strings.Contains(buf.String(), "converts the bind with TO_NUMBER") {
+		t.Errorf("expected a warning for a string compared to the age, got %v", buf.String())
+	}
+}
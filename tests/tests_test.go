@@ -39,13 +39,16 @@
 package tests
 
 import (
+	"context"
 	"log"
 	"math/rand"
 	"os"
 	"strings"
+	"testing"
 	"time"
 
 	"github.com/oracle-samples/gorm-oracle/oracle"
+	"github.com/oracle-samples/gorm-oracle/oracletest"
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"gorm.io/gorm"
@@ -65,7 +68,22 @@ var newLogger = logger.New(
 	},
 )
 
+// testDatabase is the schema started by oracletest when GORM_ORACLE_TEST_DSN
+// is set, in place of the database of the GORM_ORACLEDB_* variables
+var testDatabase *oracletest.Database
+
 var oracleDSN = func() string {
+	if os.Getenv(oracletest.DSNEnv) != "" {
+		database, err := oracletest.Start(context.Background(), oracletest.Options{
+			Grants: []string{"EXECUTE ON DBMS_LOCK", "SELECT_CATALOG_ROLE", "CREATE JOB"},
+		})
+		if err != nil {
+			log.Fatalf("failed to start the test database: %v", err)
+		}
+		testDatabase = database
+		return database.DSN
+	}
+
 	user := os.Getenv("GORM_ORACLEDB_USER")
 	password := os.Getenv("GORM_ORACLEDB_PASSWORD")
 	connectString := os.Getenv("GORM_ORACLEDB_CONNECTSTRING")
@@ -117,6 +135,16 @@ func init() {
 	}
 }
 
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if testDatabase != nil {
+		if err := testDatabase.Close(); err != nil {
+			log.Printf("failed to drop the test database, got error %v", err)
+		}
+	}
+	os.Exit(code)
+}
+
 func OpenTestConnection(cfg *gorm.Config) (db *gorm.DB, err error) {
 	return openTestDBWithOptions(nil, cfg)
 }
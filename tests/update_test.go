@@ -42,6 +42,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"sort"
 	"strings"
@@ -49,6 +50,7 @@ import (
 
 	"time"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"gorm.io/gorm"
@@ -808,6 +810,98 @@ func TestSaveWithPrimaryValue(t *testing.T) {
 	}
 }
 
+func TestSaveMixedSlice(t *testing.T) {
+	type MixedSaveItem struct {
+		ID   uint
+		Name string
+	}
+
+	DB.Migrator().DropTable(&MixedSaveItem{})
+	if err := DB.AutoMigrate(&MixedSaveItem{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&MixedSaveItem{})
+
+	loaded := MixedSaveItem{Name: "loaded"}
+	if err := DB.Create(&loaded).Error; err != nil {
+		t.Fatalf("failed to create item, got error: %v", err)
+	}
+
+	items := []MixedSaveItem{
+		{ID: loaded.ID, Name: "loaded-updated"},
+		{Name: "new-1"},
+		{ID: loaded.ID + 1000, Name: "keyed"},
+		{Name: "new-2"},
+	}
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Save(&items)
+	})
+	if strings.Count(sql, "MERGE INTO") != 1 || strings.Contains(sql, "INSERT INTO") {
+		t.Errorf("expected a single MERGE for the batch, got %s", sql)
+	}
+
+	if err := DB.Save(&items).Error; err != nil {
+		t.Fatalf("failed to save items, got error: %v", err)
+	}
+	if items[0].ID != loaded.ID || items[2].ID != loaded.ID+1000 {
+		t.Errorf("expected the keys of the saved items to be kept, got %+v", items)
+	}
+	if items[1].ID == 0 || items[3].ID == 0 || items[1].ID == items[3].ID {
+		t.Errorf("expected new keys for the items without keys, got %+v", items)
+	}
+
+	var found []MixedSaveItem
+	if err := DB.Order("\"name\"").Find(&found).Error; err != nil {
+		t.Fatalf("failed to load items, got error: %v", err)
+	}
+	expected := []MixedSaveItem{items[2], items[0], items[1], items[3]}
+	tests.AssertEqual(t, found, expected)
+}
+
+func TestSaveMixedSliceTableNames(t *testing.T) {
+	type MixedSaveName struct {
+		ID   uint
+		Name string
+	}
+
+	var owner string
+	if err := DB.Raw("SELECT USER FROM DUAL").Scan(&owner).Error; err != nil {
+		t.Fatalf("failed to read the current user, got error: %v", err)
+	}
+	skipQuoteDB, err := openTestDBWithOptions(&oracle.Config{SkipQuoteIdentifiers: true}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open database, got error: %v", err)
+	}
+
+	cases := map[string]*gorm.DB{
+		"Owner":                DB.Table(owner + ".mixed_save_names"),
+		"SkipQuoteIdentifiers": skipQuoteDB.Table("mixed_save_names"),
+	}
+	for name, db := range cases {
+		t.Run(name, func(t *testing.T) {
+			migrator := db.Session(&gorm.Session{}).Migrator()
+			migrator.DropTable(&MixedSaveName{})
+			if err := migrator.AutoMigrate(&MixedSaveName{}); err != nil {
+				t.Fatalf("failed to migrate, got error: %v", err)
+			}
+			defer migrator.DropTable(&MixedSaveName{})
+
+			loaded := MixedSaveName{Name: "loaded"}
+			if err := db.Session(&gorm.Session{}).Create(&loaded).Error; err != nil {
+				t.Fatalf("failed to create item, got error: %v", err)
+			}
+
+			items := []MixedSaveName{{ID: loaded.ID, Name: "loaded-updated"}, {Name: "new-1"}, {Name: "new-2"}}
+			if err := db.Session(&gorm.Session{}).Save(&items).Error; err != nil {
+				t.Fatalf("failed to save items, got error: %v", err)
+			}
+			if items[1].ID == 0 || items[2].ID == 0 || items[1].ID == items[2].ID || items[1].ID == loaded.ID {
+				t.Errorf("expected new identity keys for the items without keys, got %+v", items)
+			}
+		})
+	}
+}
+
 // only sqlite, postgres, sqlserver support returning
 func TestUpdateReturning(t *testing.T) {
 
@@ -1163,3 +1257,168 @@ func TestUpdateCustomDataType(t *testing.T) {
 		t.Errorf("failed to update custom data type field: %v", err)
 	}
 }
+
+type RowIDItem struct {
+	ID    uint
+	RowID string `gorm:"column:rowid;->"`
+	Name  string
+}
+
+func TestUpdateAndDeleteByRowID(t *testing.T) {
+	DB.Migrator().DropTable(&RowIDItem{})
+	if err := DB.AutoMigrate(&RowIDItem{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	if DB.Migrator().HasColumn(&RowIDItem{}, "rowid") {
+		t.Fatalf("rowid should not be created as a column")
+	}
+
+	items := []RowIDItem{{Name: "rowid-1"}, {Name: "rowid-2"}}
+	if err := DB.Create(&items).Error; err != nil {
+		t.Fatalf("failed to create items, got error: %v", err)
+	}
+
+	var item RowIDItem
+	if err := DB.First(&item, items[0].ID).Error; err != nil {
+		t.Fatalf("failed to find item, got error: %v", err)
+	}
+	if item.RowID == "" {
+		t.Fatalf("expected the ROWID to be loaded")
+	}
+
+	var statements []string
+	session := DB.Session(&gorm.Session{Logger: Tracer{
+		Logger: DB.Config.Logger,
+		Test: func(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+			sql, _ := fc()
+			statements = append(statements, sql)
+		},
+	}})
+
+	item.Name = "rowid-1-updated"
+	if err := session.Save(&item).Error; err != nil {
+		t.Fatalf("failed to save item, got error: %v", err)
+	}
+	if err := session.Model(&item).Update("name", "rowid-1-renamed").Error; err != nil {
+		t.Fatalf("failed to update item, got error: %v", err)
+	}
+	if err := session.Delete(&item).Error; err != nil {
+		t.Fatalf("failed to delete item, got error: %v", err)
+	}
+
+	if len(statements) != 3 {
+		t.Fatalf("expected 3 statements, got %d: %v", len(statements), statements)
+	}
+	for _, sql := range statements {
+		if !strings.Contains(sql, "ROWID = CHARTOROWID(") {
+			t.Errorf("expected the row to be addressed by ROWID, got %s", sql)
+		}
+	}
+
+	var remaining []RowIDItem
+	if err := DB.Order("\"id\"").Find(&remaining).Error; err != nil {
+		t.Fatalf("failed to find items, got error: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].Name != "rowid-2" || remaining[0].RowID == "" {
+		t.Errorf("expected only the untouched item to remain, got %+v", remaining)
+	}
+}
+
+func TestUpdateWithLimit(t *testing.T) {
+	users := []User{*GetUser("update-limit-1", Config{}), *GetUser("update-limit-2", Config{}), *GetUser("update-limit-3", Config{})}
+	users[0].Age, users[1].Age, users[2].Age = 30, 20, 10
+	if err := DB.Create(&users).Error; err != nil {
+		t.Fatalf("failed to create users, got error: %v", err)
+	}
+
+	result := DB.Model(&User{}).Where("\"name\" LIKE ?", "update-limit-%").Order("\"age\"").Limit(2).Update("active", true)
+	if result.Error != nil {
+		t.Fatalf("failed to update with limit, got error: %v", result.Error)
+	}
+	if result.RowsAffected != 2 {
+		t.Errorf("expected 2 rows affected, got %d", result.RowsAffected)
+	}
+
+	var updated []User
+	if err := DB.Where("\"name\" LIKE ? AND \"active\" = ?", "update-limit-%", true).Order("\"age\"").Find(&updated).Error; err != nil {
+		t.Fatalf("failed to find users, got error: %v", err)
+	}
+	if len(updated) != 2 || updated[0].Name != "update-limit-3" || updated[1].Name != "update-limit-2" {
+		t.Errorf("expected the two youngest users to be updated, got %+v", updated)
+	}
+
+	var returned []User
+	result = DB.Model(&returned).Clauses(clause.Returning{}).Where("\"name\" LIKE ?", "update-limit-%").
+		Order("\"age\" DESC").Offset(1).Limit(1).Update("age", 21)
+	if result.Error != nil {
+		t.Fatalf("failed to update with offset and returning, got error: %v", result.Error)
+	}
+	if len(returned) != 1 || returned[0].Name != "update-limit-2" || returned[0].Age != 21 {
+		t.Errorf("expected the second oldest user to be returned, got %+v", returned)
+	}
+
+	if err := DB.Model(&User{}).Limit(1).Update("age", 1).Error; !errors.Is(err, gorm.ErrMissingWhereClause) {
+		t.Errorf("expected ErrMissingWhereClause for an update with only a limit, got %v", err)
+	}
+}
+
+type TriggeredContact struct {
+	ID    uint
+	Name  string
+	Email string `gorm:"size:100;returning-always"`
+}
+
+func TestReturningAlwaysTriggerColumns(t *testing.T) {
+	DB.Migrator().DropTable(&TriggeredContact{})
+	if err := DB.AutoMigrate(&TriggeredContact{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&TriggeredContact{})
+
+	if err := DB.Exec(`CREATE OR REPLACE TRIGGER "triggered_contacts_email"
+BEFORE INSERT OR UPDATE ON "triggered_contacts" FOR EACH ROW
+BEGIN
+  :NEW."email" := LOWER(TRIM(:NEW."email"));
+END;`).Error; err != nil {
+		t.Fatalf("failed to create the trigger, got error: %v", err)
+	}
+
+	contact := TriggeredContact{Name: "single", Email: " Single@Example.COM "}
+	if err := DB.Create(&contact).Error; err != nil {
+		t.Fatalf("failed to create, got error: %v", err)
+	}
+	if contact.ID == 0 || contact.Email != "single@example.com" {
+		t.Errorf("expected the id and the email set by the trigger, got %+v", contact)
+	}
+
+	contacts := []TriggeredContact{{Name: "bulk1", Email: "Bulk1@Example.COM"}, {Name: "bulk2", Email: "BULK2@example.com "}}
+	if err := DB.Create(&contacts).Error; err != nil {
+		t.Fatalf("failed to create, got error: %v", err)
+	}
+	if contacts[0].Email != "bulk1@example.com" || contacts[1].Email != "bulk2@example.com" {
+		t.Errorf("expected the emails set by the trigger, got %+v", contacts)
+	}
+
+	if err := DB.Model(&contact).Update("name", "renamed").Error; err != nil {
+		t.Fatalf("failed to update, got error: %v", err)
+	}
+	if contact.Name != "renamed" || contact.Email != "single@example.com" {
+		t.Errorf("expected the email to be returned by the update, got %+v", contact)
+	}
+
+	contact.Email = "  Changed@Example.COM"
+	if err := DB.Save(&contact).Error; err != nil {
+		t.Fatalf("failed to save, got error: %v", err)
+	}
+	if contact.Email != "changed@example.com" {
+		t.Errorf("expected the email set by the trigger after the save, got %+v", contact)
+	}
+
+	var fetched TriggeredContact
+	if err := DB.First(&fetched, contact.ID).Error; err != nil {
+		t.Fatalf("failed to find, got error: %v", err)
+	}
+	if !reflect.DeepEqual(fetched, contact) {
+		t.Errorf("expected %+v, got %+v", contact, fetched)
+	}
+}
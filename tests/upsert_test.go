@@ -39,12 +39,14 @@
 package tests
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
 
 	"time"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	. "github.com/oracle-samples/gorm-oracle/tests/utils"
 
 	"gorm.io/gorm"
@@ -592,3 +594,254 @@ func TestUpsertFromSubquery(t *testing.T) {
 		}
 	}
 }
+
+func TestUpsertCompositeKeysBulk(t *testing.T) {
+	type StockLevel struct {
+		Warehouse string `gorm:"primaryKey;size:32"`
+		SKU       string `gorm:"primaryKey;size:32"`
+		Quantity  int
+		Revision  int `gorm:"default:1"`
+	}
+	type RegionalPrice struct {
+		ID     uint
+		Region string `gorm:"size:8;uniqueIndex:idx_regional_price"`
+		SKU    string `gorm:"size:32;uniqueIndex:idx_regional_price"`
+		Amount int
+	}
+
+	DB.Migrator().DropTable(&StockLevel{}, &RegionalPrice{})
+	if err := DB.AutoMigrate(&StockLevel{}, &RegionalPrice{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+
+	t.Run("CompositePrimaryKey", func(t *testing.T) {
+		levels := []StockLevel{{Warehouse: "w1", SKU: "a", Quantity: 1}, {Warehouse: "w1", SKU: "b", Quantity: 2}}
+		if err := DB.Create(&levels).Error; err != nil {
+			t.Fatalf("failed to create, got error: %v", err)
+		}
+
+		levels = []StockLevel{{Warehouse: "w1", SKU: "a", Quantity: 10}, {Warehouse: "w2", SKU: "a", Quantity: 20}}
+		if err := DB.Clauses(clause.OnConflict{UpdateAll: true}).Create(&levels).Error; err != nil {
+			t.Fatalf("failed to upsert, got error: %v", err)
+		}
+
+		var results []StockLevel
+		DB.Order("\"warehouse\", \"sku\"").Find(&results)
+		expected := []StockLevel{
+			{Warehouse: "w1", SKU: "a", Quantity: 10, Revision: 1},
+			{Warehouse: "w1", SKU: "b", Quantity: 2, Revision: 1},
+			{Warehouse: "w2", SKU: "a", Quantity: 20, Revision: 1},
+		}
+		tests.AssertEqual(t, results, expected)
+	})
+
+	t.Run("CompositeUniqueIndex", func(t *testing.T) {
+		prices := []RegionalPrice{{Region: "eu", SKU: "a", Amount: 1}, {Region: "us", SKU: "a", Amount: 2}}
+		if err := DB.Create(&prices).Error; err != nil {
+			t.Fatalf("failed to create, got error: %v", err)
+		}
+
+		prices = []RegionalPrice{{Region: "eu", SKU: "a", Amount: 10}, {Region: "eu", SKU: "b", Amount: 30}}
+		if err := DB.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "region"}, {Name: "sku"}},
+			DoUpdates: clause.AssignmentColumns([]string{"amount"}),
+		}).Create(&prices).Error; err != nil {
+			t.Fatalf("failed to upsert, got error: %v", err)
+		}
+
+		var results []RegionalPrice
+		DB.Order("\"region\", \"sku\"").Find(&results)
+		if len(results) != 3 {
+			t.Fatalf("expected 3 prices, got %d: %+v", len(results), results)
+		}
+		for i, expected := range []RegionalPrice{{Region: "eu", SKU: "a", Amount: 10}, {Region: "eu", SKU: "b", Amount: 30}, {Region: "us", SKU: "a", Amount: 2}} {
+			if results[i].Region != expected.Region || results[i].SKU != expected.SKU || results[i].Amount != expected.Amount {
+				t.Errorf("expected %+v, got %+v", expected, results[i])
+			}
+		}
+	})
+}
+
+func TestUpsertOmitColumns(t *testing.T) {
+	type UpsertAccount struct {
+		ID        uint
+		Code      string `gorm:"size:20;uniqueIndex"`
+		Name      string
+		Origin    string `gorm:"<-:create"`
+		OpenedAt  time.Time
+		UpdatedAt time.Time
+	}
+
+	DB.Migrator().DropTable(&UpsertAccount{})
+	if err := DB.AutoMigrate(&UpsertAccount{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&UpsertAccount{})
+
+	opened := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	accounts := []UpsertAccount{
+		{Code: "a", Name: "first", Origin: "import", OpenedAt: opened},
+		{Code: "b", Name: "second", Origin: "import", OpenedAt: opened},
+	}
+	if err := DB.Create(&accounts).Error; err != nil {
+		t.Fatalf("failed to create, got error: %v", err)
+	}
+
+	assertAccount := func(t *testing.T, code, name string) {
+		t.Helper()
+		var account UpsertAccount
+		if err := DB.Where("\"code\" = ?", code).First(&account).Error; err != nil {
+			t.Fatalf("failed to find account %v, got error: %v", code, err)
+		}
+		if account.Name != name || account.Origin != "import" || !account.OpenedAt.Equal(opened) {
+			t.Errorf("expected account %v named %v with its original origin and opening time, got %+v", code, name, account)
+		}
+	}
+
+	t.Run("Bulk", func(t *testing.T) {
+		later := opened.Add(24 * time.Hour)
+		upserted := []UpsertAccount{
+			{Code: "a", Name: "first updated", Origin: "api", OpenedAt: later},
+			{Code: "b", Name: "second updated", Origin: "api", OpenedAt: later},
+		}
+		if err := DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, UpdateAll: true}, oracle.UpsertOmit("OpenedAt")).
+			Create(&upserted).Error; err != nil {
+			t.Fatalf("failed to upsert, got error: %v", err)
+		}
+		assertAccount(t, "a", "first updated")
+		assertAccount(t, "b", "second updated")
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		db, err := openTestDBWithOptions(&oracle.Config{UpsertOmitColumns: []string{"opened_at"}}, &gorm.Config{})
+		if err != nil {
+			t.Fatalf("failed to connect database, got error %v", err)
+		}
+
+		upserted := UpsertAccount{Code: "a", Name: "first again", Origin: "api", OpenedAt: time.Now()}
+		if err := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, UpdateAll: true}).Create(&upserted).Error; err != nil {
+			t.Fatalf("failed to upsert, got error: %v", err)
+		}
+		assertAccount(t, "a", "first again")
+	})
+
+	t.Run("AllColumnsOmitted", func(t *testing.T) {
+		upserted := []UpsertAccount{{Code: "b", Name: "ignored"}, {Code: "c", Name: "third", Origin: "import", OpenedAt: opened}}
+		if err := DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, UpdateAll: true},
+			oracle.UpsertOmit("name", "opened_at", "updated_at")).Create(&upserted).Error; err != nil {
+			t.Fatalf("failed to upsert, got error: %v", err)
+		}
+		assertAccount(t, "b", "second updated")
+		assertAccount(t, "c", "third")
+	})
+}
+
+func TestUpsertMergeCounts(t *testing.T) {
+	type MergeCountItem struct {
+		ID   uint
+		Code string `gorm:"size:20;uniqueIndex"`
+		Name string
+	}
+
+	DB.Migrator().DropTable(&MergeCountItem{})
+	if err := DB.AutoMigrate(&MergeCountItem{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&MergeCountItem{})
+
+	if err := DB.Create(&[]MergeCountItem{{Code: "a", Name: "a"}, {Code: "b", Name: "b"}}).Error; err != nil {
+		t.Fatalf("failed to create, got error: %v", err)
+	}
+	onCode := clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, UpdateAll: true}
+
+	items := []MergeCountItem{{Code: "a", Name: "a2"}, {Code: "b", Name: "b2"}, {Code: "c", Name: "c"}}
+	tx := DB.Clauses(onCode, oracle.MergeCounts()).Create(&items)
+	if tx.Error != nil {
+		t.Fatalf("failed to upsert, got error: %v", tx.Error)
+	}
+	result, ok := oracle.MergeResultOf(tx)
+	if !ok || result.Inserted != 1 || result.Updated != 2 {
+		t.Errorf("expected 1 inserted and 2 updated rows, got %+v (%v)", result, ok)
+	}
+
+	tx = DB.Clauses(onCode, oracle.MergeCounts()).Create(&MergeCountItem{Code: "d", Name: "d"})
+	if result, ok := oracle.MergeResultOf(tx); tx.Error != nil || !ok || result.Inserted != 1 || result.Updated != 0 {
+		t.Errorf("expected 1 inserted row, got %+v (%v, %v)", result, ok, tx.Error)
+	}
+
+	tx = DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "code"}}, DoNothing: true}, oracle.MergeCounts()).
+		Create(&[]MergeCountItem{{Code: "a", Name: "ignored"}, {Code: "e", Name: "e"}})
+	if result, ok := oracle.MergeResultOf(tx); tx.Error != nil || !ok || result.Inserted != 1 || result.Updated != 0 {
+		t.Errorf("expected 1 inserted row and no update, got %+v (%v, %v)", result, ok, tx.Error)
+	}
+
+	if _, ok := oracle.MergeResultOf(DB.Clauses(onCode).Create(&MergeCountItem{Code: "f", Name: "f"})); ok {
+		t.Errorf("expected no counts without MergeCounts")
+	}
+
+	res := gorm.WithResult()
+	if err := gorm.G[MergeCountItem](DB, res, onCode).CreateInBatches(context.Background(), &[]MergeCountItem{{Code: "a", Name: "a3"}, {Code: "g", Name: "g"}}, 10); err != nil {
+		t.Fatalf("failed to upsert with generics, got error: %v", err)
+	}
+	merged, ok := res.Result.(oracle.MergeResult)
+	if !ok || merged.Inserted != 1 || merged.Updated != 1 || res.RowsAffected != 2 {
+		t.Errorf("expected a merge result of 1 inserted and 1 updated rows, got %+v (%+v)", res.Result, res)
+	}
+}
+
+type SeedCurrency struct {
+	ID     uint
+	Code   string `gorm:"size:3"`
+	Name   string `gorm:"size:50"`
+	Symbol string `gorm:"size:5"`
+}
+
+func TestSeed(t *testing.T) {
+	DB.Migrator().DropTable(&SeedCurrency{})
+	if err := DB.AutoMigrate(&SeedCurrency{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&SeedCurrency{})
+
+	currencies := []SeedCurrency{{Code: "EUR", Name: "Euro", Symbol: "E"}, {Code: "USD", Name: "US Dollar", Symbol: "$"}}
+	if err := oracle.Seed(DB, &currencies, "Code"); err != nil {
+		t.Fatalf("failed to seed, got error: %v", err)
+	}
+	if currencies[0].ID == 0 || currencies[1].ID == 0 {
+		t.Fatalf("expected the primary keys of seeded records, got %+v", currencies)
+	}
+	ids := []uint{currencies[0].ID, currencies[1].ID}
+
+	// Seeding again updates the rows in place
+	reseeded := []SeedCurrency{{Code: "EUR", Name: "Euro", Symbol: "€"}, {Code: "USD", Name: "US Dollar", Symbol: "$"}, {Code: "GBP", Name: "Pound", Symbol: "£"}}
+	if err := oracle.Seed(DB, &reseeded, "Code"); err != nil {
+		t.Fatalf("failed to seed again, got error: %v", err)
+	}
+	if reseeded[0].ID != ids[0] || reseeded[1].ID != ids[1] || reseeded[2].ID == 0 {
+		t.Errorf("expected existing rows to keep their primary keys, got %+v", reseeded)
+	}
+
+	var all []SeedCurrency
+	DB.Order("\"code\"").Find(&all)
+	if len(all) != 3 || all[1].Code != "EUR" || all[1].Symbol != "€" {
+		t.Fatalf("expected 3 currencies with the updated symbol, got %+v", all)
+	}
+
+	// SeedMissing keeps existing rows
+	missing := SeedCurrency{Code: "EUR", Name: "Euro", Symbol: "EUR"}
+	if err := oracle.SeedMissing(DB, &missing, "Code"); err != nil {
+		t.Fatalf("failed to seed missing rows, got error: %v", err)
+	}
+	if missing.ID != ids[0] {
+		t.Errorf("expected the primary key of the existing row, got %d", missing.ID)
+	}
+	var euro SeedCurrency
+	DB.First(&euro, ids[0])
+	if euro.Symbol != "€" {
+		t.Errorf("expected the existing row to be kept, got %+v", euro)
+	}
+
+	if err := oracle.Seed(DB, &currencies, "Unknown"); err == nil {
+		t.Errorf("expected an error for an unknown key")
+	}
+}
@@ -39,24 +39,26 @@
 package tests
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/oracle-samples/gorm-oracle/oracle"
 	"gorm.io/gorm"
 )
 
 type VarcharTestModel struct {
-	ID               uint      `gorm:"primaryKey;autoIncrement"`
-	SmallText        string    `gorm:"size:50"`
-	MediumText       string    `gorm:"size:500"`
-	LargeText        string    `gorm:"size:4000"`
-	DefaultSizeText  string
-	OptionalText     *string   `gorm:"size:100"`
-	NotNullText      string    `gorm:"size:200;not null"`
-	UniqueText       string    `gorm:"size:100;uniqueIndex"`
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	ID              uint   `gorm:"primaryKey;autoIncrement"`
+	SmallText       string `gorm:"size:50"`
+	MediumText      string `gorm:"size:500"`
+	LargeText       string `gorm:"size:4000"`
+	DefaultSizeText string
+	OptionalText    *string `gorm:"size:100"`
+	NotNullText     string  `gorm:"size:200;not null"`
+	UniqueText      string  `gorm:"size:100;uniqueIndex"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 func setupVarcharTestTables(t *testing.T) {
@@ -146,7 +148,7 @@ func TestVarcharMaximumSize(t *testing.T) {
 
 	// Test at 4000 bytes
 	text4000 := strings.Repeat("X", 4000)
-	
+
 	model := &VarcharTestModel{
 		LargeText:   text4000,
 		NotNullText: "Required",
@@ -174,8 +176,8 @@ func TestVarcharMaximumSize(t *testing.T) {
 
 	// Test at boundary: 3999, 4000, 4001 bytes
 	testCases := []struct {
-		name     string
-		size     int
+		name      string
+		size      int
 		shouldFit bool
 	}{
 		{"3999 bytes", 3999, true},
@@ -430,4 +432,71 @@ func TestVarcharBulkAndConstraints(t *testing.T) {
 	if len(updatedRecords) != 5 {
 		t.Errorf("Expected 5 batch updated records, got %d", len(updatedRecords))
 	}
-}
\ No newline at end of file
+}
+
+type NationalTextModel struct {
+	ID       uint `gorm:"primaryKey;autoIncrement"`
+	Code     string
+	Name     oracle.NString  `gorm:"size:100"`
+	Note     *oracle.NString `gorm:"size:100"`
+	Greeting oracle.NString  `gorm:"size:20;default:'hola'"`
+}
+
+func TestNationalCharacterStrings(t *testing.T) {
+	DB.Migrator().DropTable(&NationalTextModel{})
+	if err := DB.AutoMigrate(&NationalTextModel{}); err != nil {
+		t.Fatalf("failed to migrate, got error: %v", err)
+	}
+	defer DB.Migrator().DropTable(&NationalTextModel{})
+
+	sql := DB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("name", oracle.NString("Żółw")).Where("code", "ascii").Find(&[]NationalTextModel{})
+	})
+	if !strings.Contains(sql, `"name" = UNISTR('\017B\00F3\0142w')`) || !strings.Contains(sql, `"code" = 'ascii'`) {
+		t.Errorf("expected the national bind through UNISTR, got %s", sql)
+	}
+
+	note := oracle.NString("Ωmega \\ 𝄞")
+	record := NationalTextModel{Code: "one", Name: "日本語のテキスト", Note: &note}
+	if err := DB.Create(&record).Error; err != nil {
+		t.Fatalf("failed to create, got error: %v", err)
+	}
+	if record.Greeting != "hola" {
+		t.Errorf("expected the default returned into the NString field, got %q", record.Greeting)
+	}
+
+	records := []NationalTextModel{{Code: "two", Name: "Ελληνικά"}, {Code: "three", Name: "plain"}}
+	if err := DB.Create(&records).Error; err != nil {
+		t.Fatalf("failed to create in bulk, got error: %v", err)
+	}
+
+	var found NationalTextModel
+	if err := DB.Where("name", oracle.NString("日本語のテキスト")).First(&found).Error; err != nil {
+		t.Fatalf("failed to find by national string, got error: %v", err)
+	}
+	if found.ID != record.ID || found.Note == nil || *found.Note != note {
+		t.Errorf("expected %+v, got %+v", record, found)
+	}
+
+	if err := DB.Model(&found).Update("name", oracle.NString("Ελληνικά 2")).Error; err != nil {
+		t.Fatalf("failed to update, got error: %v", err)
+	}
+	var names []oracle.NString
+	DB.Model(&NationalTextModel{}).Order("id").Pluck("name", &names)
+	if expected := []oracle.NString{"Ελληνικά 2", "Ελληνικά", "plain"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected names %q, got %q", expected, names)
+	}
+
+	db, err := openTestDBWithOptions(&oracle.Config{NationalCharacterBinds: true}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database, got error %v", err)
+	}
+	if err := db.Model(&NationalTextModel{}).Where("code", "three").Update("name", "Ünïcödé").Error; err != nil {
+		t.Fatalf("failed to update with national binds, got error: %v", err)
+	}
+	var count int64
+	db.Model(&NationalTextModel{}).Where(`"name" = ?`, "Ünïcödé").Count(&count)
+	if count != 1 {
+		t.Errorf("expected the string bound with national binds to match, got %d rows", count)
+	}
+}
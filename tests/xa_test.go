@@ -0,0 +1,141 @@
+/*
+** Copyright (c) 2025 Oracle and/or its affiliates.
+**
+** The Universal Permissive License (UPL), Version 1.0
+**
+** Subject to the condition set forth below, permission is hereby granted to any
+** person obtaining a copy of this software, associated documentation and/or data
+** (collectively the "Software"), free of charge and under any and all copyright
+** rights in the Software, and any and all patent rights owned or freely
+** licensable by each licensor hereunder covering either (i) the unmodified
+** Software as contributed to or provided by such licensor, or (ii) the Larger
+** Works (as defined below), to deal in both
+**
+** (a) the Software, and
+** (b) any piece of software and/or hardware listed in the lrgrwrks.txt file if
+** one is included with the Software (each a "Larger Work" to which the Software
+** is contributed by such licensors),
+**
+** without restriction, including without limitation the rights to copy, create
+** derivative works of, display, perform, and distribute the Software and make,
+** use, sell, offer for sale, import, export, have made, and have sold the
+** Software and the Larger Work(s), and to sublicense the foregoing rights on
+** either these or other terms.
+**
+** This license is subject to the following condition:
+** The above copyright notice and either this complete permission notice or at
+** a minimum a reference to the UPL must be included in all copies or
+** substantial portions of the Software.
+**
+** THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+** IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+** FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+** AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+** LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+** OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+** SOFTWARE.
+ */
+
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/oracle-samples/gorm-oracle/oracle"
+	. "github.com/oracle-samples/gorm-oracle/tests/utils"
+
+	"gorm.io/gorm"
+)
+
+func newXID(branch string) oracle.XID {
+	return oracle.XID{
+		FormatID:            1,
+		GlobalTransactionID: []byte(fmt.Sprintf("gorm-xa-%d", time.Now().UnixNano())),
+		BranchQualifier:     []byte(branch),
+	}
+}
+
+func TestXATransactionTwoPhaseCommit(t *testing.T) {
+	xa, err := oracle.BeginXA(DB, newXID("b1"), time.Minute)
+	if err != nil {
+		t.Skipf("XA transactions are not available, got error: %v", err)
+	}
+
+	user := *GetUser("xa_commit", Config{})
+	if err := xa.DB().Create(&user).Error; err != nil {
+		xa.Rollback()
+		t.Fatalf("failed to create user in XA transaction, got error: %v", err)
+	}
+
+	if err := DB.First(&User{}, "\"name\" = ?", "xa_commit").Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("user of an uncommitted XA transaction should not be visible, got %v", err)
+	}
+
+	readOnly, err := xa.Prepare()
+	if err != nil {
+		xa.Rollback()
+		t.Fatalf("failed to prepare XA transaction, got error: %v", err)
+	}
+	if readOnly {
+		t.Errorf("XA transaction with an insert should not be read-only")
+	}
+	if err := xa.Commit(); err != nil {
+		t.Fatalf("failed to commit XA transaction, got error: %v", err)
+	}
+	if err := xa.Commit(); !errors.Is(err, sql.ErrTxDone) {
+		t.Errorf("expected sql.ErrTxDone after commit, got %v", err)
+	}
+
+	if err := DB.First(&User{}, "\"name\" = ?", "xa_commit").Error; err != nil {
+		t.Fatalf("user of a committed XA transaction should be visible, got error: %v", err)
+	}
+}
+
+func TestXATransactionOnePhaseCommitAndRollback(t *testing.T) {
+	xa, err := oracle.BeginXA(DB, newXID("b1"), 0)
+	if err != nil {
+		t.Skipf("XA transactions are not available, got error: %v", err)
+	}
+	if err := xa.DB().Create(GetUser("xa_one_phase", Config{})).Error; err != nil {
+		xa.Rollback()
+		t.Fatalf("failed to create user in XA transaction, got error: %v", err)
+	}
+	if err := xa.Commit(); err != nil {
+		t.Fatalf("failed to commit XA transaction in one phase, got error: %v", err)
+	}
+	if err := DB.First(&User{}, "\"name\" = ?", "xa_one_phase").Error; err != nil {
+		t.Fatalf("user of a committed XA transaction should be visible, got error: %v", err)
+	}
+
+	xa, err = oracle.BeginXA(DB, newXID("b1"), 0)
+	if err != nil {
+		t.Fatalf("failed to begin XA transaction, got error: %v", err)
+	}
+	if err := xa.DB().Create(GetUser("xa_rollback", Config{})).Error; err != nil {
+		xa.Rollback()
+		t.Fatalf("failed to create user in XA transaction, got error: %v", err)
+	}
+	if _, err := xa.Prepare(); err != nil {
+		xa.Rollback()
+		t.Fatalf("failed to prepare XA transaction, got error: %v", err)
+	}
+	if err := xa.Rollback(); err != nil {
+		t.Fatalf("failed to roll back XA transaction, got error: %v", err)
+	}
+	if err := DB.First(&User{}, "\"name\" = ?", "xa_rollback").Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("user of a rolled back XA transaction should not be visible, got %v", err)
+	}
+
+	if err := oracle.CommitXA(DB, newXID("missing")); err == nil {
+		t.Errorf("expected an error committing an unknown XA transaction")
+	} else {
+		var xaErr *oracle.XAError
+		if !errors.As(err, &xaErr) || xaErr.Operation != "XA_COMMIT" {
+			t.Errorf("expected an XAError, got %v", err)
+		}
+	}
+}